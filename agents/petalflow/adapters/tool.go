@@ -127,6 +127,16 @@ func NewToolRegistry() *ToolRegistry {
 	}
 }
 
+// NewToolRegistryFrom creates a tool registry holding an adapter for each
+// tool in reg, such as tools registered from an MCP server.
+func NewToolRegistryFrom(reg *tools.Registry) *ToolRegistry {
+	r := NewToolRegistry()
+	for _, t := range reg.List() {
+		r.Register(NewToolAdapter(t))
+	}
+	return r
+}
+
 // Register adds a tool to the registry.
 func (r *ToolRegistry) Register(tool PetalTool) {
 	r.tools[tool.Name()] = tool
@@ -259,6 +259,29 @@ func TestToolRegistry_Get(t *testing.T) {
 	}
 }
 
+func TestNewToolRegistryFrom(t *testing.T) {
+	reg := tools.NewRegistry()
+	reg.Register(&mockTool{name: "search", callResult: "found"})
+	reg.Register(&mockTool{name: "lookup", callResult: map[string]any{"id": 1}})
+
+	registry := NewToolRegistryFrom(reg)
+	if len(registry.List()) != 2 {
+		t.Fatalf("expected 2 tools, got %d", len(registry.List()))
+	}
+
+	tool, ok := registry.Get("search")
+	if !ok {
+		t.Fatal("expected search to be found")
+	}
+	result, err := tool.Invoke(context.Background(), map[string]any{"q": "iris"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["result"] != "found" {
+		t.Errorf("expected result 'found', got %v", result)
+	}
+}
+
 func TestToolRegistry_Register_Override(t *testing.T) {
 	registry := NewToolRegistry()
 
@@ -0,0 +1,111 @@
+package petalflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/erikhoward/iris/tools"
+)
+
+// GraphToolConfig configures a GraphTool.
+type GraphToolConfig struct {
+	// Schema is the JSON Schema of the tool's arguments.
+	// Default: an object with any properties.
+	Schema json.RawMessage
+
+	// OutputVars lists the envelope variables returned as the tool's result.
+	// If empty, every variable is returned.
+	OutputVars []string
+
+	// Runtime executes the graph. Default: NewRuntime().
+	Runtime Runtime
+
+	// RunOptions controls execution. Default: DefaultRunOptions().
+	RunOptions *RunOptions
+}
+
+// GraphTool exposes a graph as a tools.Tool, so that models, tool
+// registries, and MCP servers can run a whole workflow as one tool call.
+//
+// Each call runs the graph on a new envelope whose Vars hold the call's
+// arguments by name, and whose Input holds the arguments as a map. The
+// result is a map of the envelope's variables after the run.
+type GraphTool struct {
+	name        string
+	description string
+	graph       Graph
+	config      GraphToolConfig
+}
+
+// NewGraphTool creates a tool that runs graph.
+func NewGraphTool(name, description string, graph Graph, config GraphToolConfig) *GraphTool {
+	// Apply defaults
+	if len(config.Schema) == 0 {
+		config.Schema = json.RawMessage(`{"type":"object","additionalProperties":true}`)
+	}
+	if config.Runtime == nil {
+		config.Runtime = NewRuntime()
+	}
+	if config.RunOptions == nil {
+		opts := DefaultRunOptions()
+		config.RunOptions = &opts
+	}
+
+	return &GraphTool{
+		name:        name,
+		description: description,
+		graph:       graph,
+		config:      config,
+	}
+}
+
+// Name returns the tool's name.
+func (t *GraphTool) Name() string {
+	return t.name
+}
+
+// Description returns the tool's description.
+func (t *GraphTool) Description() string {
+	return t.description
+}
+
+// Schema returns the JSON Schema of the tool's arguments.
+func (t *GraphTool) Schema() tools.ToolSchema {
+	return tools.ToolSchema{JSONSchema: t.config.Schema}
+}
+
+// Call runs the graph with args and returns the output variables as a
+// map[string]any.
+func (t *GraphTool) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	vars := make(map[string]any)
+	if len(args) > 0 && string(args) != "null" {
+		if err := json.Unmarshal(args, &vars); err != nil {
+			return nil, fmt.Errorf("graph tool %s: arguments must be a JSON object: %w", t.name, err)
+		}
+	}
+
+	env := NewEnvelope().WithInput(vars)
+	for name, value := range vars {
+		env.SetVar(name, value)
+	}
+
+	result, err := t.config.Runtime.Run(ctx, t.graph, env, *t.config.RunOptions)
+	if err != nil {
+		return nil, fmt.Errorf("graph tool %s: %w", t.name, err)
+	}
+
+	if len(t.config.OutputVars) == 0 {
+		return result.Vars, nil
+	}
+	output := make(map[string]any, len(t.config.OutputVars))
+	for _, name := range t.config.OutputVars {
+		if value, ok := result.GetVar(name); ok {
+			output[name] = value
+		}
+	}
+	return output, nil
+}
+
+// Compile-time check that GraphTool implements tools.Tool.
+var _ tools.Tool = (*GraphTool)(nil)
@@ -0,0 +1,103 @@
+package petalflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/tools"
+	"github.com/erikhoward/iris/tools/mcp"
+)
+
+// newGreetGraph returns a graph that sets "greeting" from the "name"
+// variable, and fails when the name is empty.
+func newGreetGraph() Graph {
+	g := NewGraph("greet")
+	g.AddNode(NewFuncNode("greet", func(ctx context.Context, env *Envelope) (*Envelope, error) {
+		name := env.GetVarString("name")
+		if name == "" {
+			return nil, errors.New("name required")
+		}
+		env.SetVar("greeting", "Hello, "+name)
+		return env, nil
+	}))
+	g.SetEntry("greet")
+	return g
+}
+
+func TestGraphTool(t *testing.T) {
+	tool := NewGraphTool("greet", "Greets someone", newGreetGraph(), GraphToolConfig{})
+
+	if tool.Name() != "greet" || tool.Description() != "Greets someone" {
+		t.Errorf("Name(), Description() = %q, %q", tool.Name(), tool.Description())
+	}
+	if !strings.Contains(string(tool.Schema().JSONSchema), `"object"`) {
+		t.Errorf("Schema() = %s", tool.Schema().JSONSchema)
+	}
+
+	got, err := tool.Call(context.Background(), json.RawMessage(`{"name":"Ada"}`))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	vars := got.(map[string]any)
+	if vars["greeting"] != "Hello, Ada" || vars["name"] != "Ada" {
+		t.Errorf("Call() = %v", vars)
+	}
+
+	if _, err := tool.Call(context.Background(), json.RawMessage(`{}`)); err == nil || !strings.Contains(err.Error(), "name required") {
+		t.Errorf("Call() without name error = %v", err)
+	}
+	if _, err := tool.Call(context.Background(), json.RawMessage(`[1]`)); err == nil {
+		t.Error("Call() with array arguments should fail")
+	}
+}
+
+func TestGraphToolOutputVars(t *testing.T) {
+	tool := NewGraphTool("greet", "Greets someone", newGreetGraph(), GraphToolConfig{
+		Schema:     json.RawMessage(`{"type":"object","properties":{"name":{"type":"string"}},"required":["name"]}`),
+		OutputVars: []string{"greeting", "missing"},
+	})
+
+	got, err := tool.Call(context.Background(), json.RawMessage(`{"name":"Ada"}`))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	vars := got.(map[string]any)
+	if len(vars) != 1 || vars["greeting"] != "Hello, Ada" {
+		t.Errorf("Call() = %v, want only greeting", vars)
+	}
+}
+
+func TestGraphToolOverMCP(t *testing.T) {
+	reg := tools.NewRegistry()
+	reg.Register(NewGraphTool("greet", "Greets someone", newGreetGraph(), GraphToolConfig{
+		OutputVars: []string{"greeting"},
+	}))
+
+	srv := mcp.NewServer(reg)
+	defer srv.Close()
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	client, err := mcp.ConnectHTTP(context.Background(), ts.URL)
+	if err != nil {
+		t.Fatalf("ConnectHTTP() error = %v", err)
+	}
+	defer client.Close()
+
+	result, err := client.CallTool(context.Background(), "greet", json.RawMessage(`{"name":"Ada"}`))
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if string(result.StructuredContent) != `{"greeting":"Hello, Ada"}` {
+		t.Errorf("StructuredContent = %s", result.StructuredContent)
+	}
+
+	result, err = client.CallTool(context.Background(), "greet", nil)
+	if err != nil || !result.IsError {
+		t.Errorf("CallTool() without name = %+v, %v; want an error result", result, err)
+	}
+}
@@ -0,0 +1,269 @@
+package catalog
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed models.yaml
+var embedded []byte
+
+// Catalog holds model metadata keyed by provider and model ID.
+// Catalog is safe for concurrent use.
+type Catalog struct {
+	mu        sync.RWMutex
+	providers map[string]*providerModels
+}
+
+// providerModels keeps one provider's entries in load order.
+type providerModels struct {
+	order  []core.ModelID
+	models map[core.ModelID]core.ModelInfo
+}
+
+// New returns an empty catalog.
+func New() *Catalog {
+	return &Catalog{providers: make(map[string]*providerModels)}
+}
+
+var (
+	defaultOnce    sync.Once
+	defaultCatalog *Catalog
+)
+
+func init() {
+	core.SetDefaultModelCatalog(defaultLookup{})
+}
+
+// defaultLookup looks models up in the default catalog, which it loads on
+// first use.
+type defaultLookup struct{}
+
+// Lookup implements core.ModelCatalog.
+func (defaultLookup) Lookup(provider string, id core.ModelID) (core.ModelInfo, bool) {
+	return Default().Lookup(provider, id)
+}
+
+// Default returns the shared catalog loaded from the embedded data.
+// Changes made through LoadFile, Load, or Add are visible to every user of
+// the shared catalog.
+func Default() *Catalog {
+	defaultOnce.Do(func() {
+		defaultCatalog = New()
+		if err := defaultCatalog.Load(bytes.NewReader(embedded)); err != nil {
+			panic("catalog: invalid embedded catalog: " + err.Error())
+		}
+	})
+	return defaultCatalog
+}
+
+// LoadFile merges the YAML catalog file at path into c.
+func (c *Catalog) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := c.Load(f); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// Load merges a YAML catalog into c. Unknown fields and modalities are
+// rejected so that typos are not silently ignored. Nothing is merged if
+// the catalog is invalid.
+func (c *Catalog) Load(r io.Reader) error {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	var f file
+	if err := dec.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid catalog: %w", err)
+	}
+
+	parsed := make(map[string][]core.ModelInfo, len(f.Providers))
+	for provider, entries := range f.Providers {
+		for i, e := range entries {
+			info, err := e.modelInfo()
+			if err != nil {
+				return fmt.Errorf("invalid catalog: %s model %d: %w", provider, i+1, err)
+			}
+			parsed[provider] = append(parsed[provider], info)
+		}
+	}
+
+	for provider, infos := range parsed {
+		c.Add(provider, infos...)
+	}
+	return nil
+}
+
+// Add merges models into the provider's entries. Fields set on a model
+// replace those of an existing entry with the same ID; new IDs are appended.
+func (c *Catalog) Add(provider string, models ...core.ModelInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pm := c.providers[provider]
+	if pm == nil {
+		pm = &providerModels{models: make(map[core.ModelID]core.ModelInfo)}
+		c.providers[provider] = pm
+	}
+	for _, m := range models {
+		existing, ok := pm.models[m.ID]
+		if !ok {
+			pm.order = append(pm.order, m.ID)
+		}
+		pm.models[m.ID] = existing.WithOverrides(m)
+	}
+}
+
+// Lookup returns the entry for a provider's model.
+// It implements core.ModelCatalog.
+func (c *Catalog) Lookup(provider string, id core.ModelID) (core.ModelInfo, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	pm := c.providers[provider]
+	if pm == nil {
+		return core.ModelInfo{}, false
+	}
+	m, ok := pm.models[id]
+	return m, ok
+}
+
+// Models returns the provider's entries in the order they were loaded.
+func (c *Catalog) Models(provider string) []core.ModelInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	pm := c.providers[provider]
+	if pm == nil {
+		return nil
+	}
+	result := make([]core.ModelInfo, len(pm.order))
+	for i, id := range pm.order {
+		result[i] = pm.models[id]
+	}
+	return result
+}
+
+// Providers returns the IDs of the providers with entries, sorted.
+func (c *Catalog) Providers() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ids := make([]string, 0, len(c.providers))
+	for id := range c.providers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Enrich layers the catalog's metadata over models, typically a provider's
+// Models or ListModels result, and appends catalog entries for the provider
+// that models does not include.
+func (c *Catalog) Enrich(provider string, models []core.ModelInfo) []core.ModelInfo {
+	result := make([]core.ModelInfo, 0, len(models))
+	seen := make(map[core.ModelID]bool, len(models))
+	for _, m := range models {
+		if entry, ok := c.Lookup(provider, m.ID); ok {
+			m = m.WithOverrides(entry)
+		}
+		seen[m.ID] = true
+		result = append(result, m)
+	}
+
+	for _, entry := range c.Models(provider) {
+		if !seen[entry.ID] {
+			if entry.DisplayName == "" {
+				entry.DisplayName = string(entry.ID)
+			}
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// Query describes the models wanted by Select. Zero fields match any model.
+type Query struct {
+	// Features the model must support.
+	Features []core.Feature
+
+	// InputModalities the model must accept.
+	InputModalities []core.Modality
+
+	// MinContextWindow is the smallest acceptable context window.
+	// Models with an unknown context window do not match.
+	MinContextWindow int
+
+	// MaxInputPrice is the highest acceptable input price per million
+	// tokens. Models with unknown pricing do not match.
+	MaxInputPrice float64
+
+	// IncludeDeprecated includes models whose deprecation date has passed.
+	IncludeDeprecated bool
+}
+
+// Matches reports whether m satisfies q.
+func (q Query) Matches(m core.ModelInfo) bool {
+	for _, f := range q.Features {
+		if !m.HasCapability(f) {
+			return false
+		}
+	}
+	for _, mod := range q.InputModalities {
+		if !m.AcceptsInput(mod) {
+			return false
+		}
+	}
+	if q.MinContextWindow > 0 && m.ContextWindow < q.MinContextWindow {
+		return false
+	}
+	if q.MaxInputPrice > 0 && (m.Pricing == nil || m.Pricing.Input > q.MaxInputPrice) {
+		return false
+	}
+	if !q.IncludeDeprecated && m.IsDeprecated(time.Now()) {
+		return false
+	}
+	return true
+}
+
+// Select returns the models that match q, cheapest input price first.
+// Models without pricing follow, in their original order.
+func Select(models []core.ModelInfo, q Query) []core.ModelInfo {
+	var matched []core.ModelInfo
+	for _, m := range models {
+		if q.Matches(m) {
+			matched = append(matched, m)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		pi, pj := matched[i].Pricing, matched[j].Pricing
+		switch {
+		case pi == nil:
+			return false
+		case pj == nil:
+			return true
+		default:
+			return pi.Input < pj.Input
+		}
+	})
+	return matched
+}
+
+// Compile-time check that Catalog implements ModelCatalog.
+var _ core.ModelCatalog = (*Catalog)(nil)
@@ -0,0 +1,231 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers/providertest"
+)
+
+func TestDefaultLookup(t *testing.T) {
+	info, ok := Default().Lookup("openai", "gpt-4o")
+	if !ok {
+		t.Fatal("Lookup(openai, gpt-4o) not found")
+	}
+	if info.ContextWindow != 128000 || !info.AcceptsInput(core.ModalityImage) || info.Pricing == nil {
+		t.Errorf("gpt-4o = %+v", info)
+	}
+
+	if _, ok := Default().Lookup("openai", "missing"); ok {
+		t.Error("Lookup(openai, missing) found")
+	}
+	if _, ok := Default().Lookup("missing", "gpt-4o"); ok {
+		t.Error("Lookup(missing, gpt-4o) found")
+	}
+}
+
+func TestLoadOverridesAndExtends(t *testing.T) {
+	cat := New()
+	cat.Add("openai", core.ModelInfo{
+		ID:            "gpt-4o",
+		DisplayName:   "GPT-4o",
+		ContextWindow: 128000,
+		Pricing:       &core.ModelPricing{Input: 2.5, Output: 10},
+	})
+
+	err := cat.Load(strings.NewReader(`
+providers:
+  openai:
+    - id: gpt-4o
+      pricing: {input: 2, output: 8}
+    - id: gpt-6
+      display_name: GPT-6
+      capabilities: [chat, tool_calling]
+      api_endpoint: responses
+      context_window: 1000000
+      input_modalities: [text, image, audio]
+      knowledge_cutoff: 2026-03
+      deprecation_date: 2030-01-01
+`))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	gpt4o, _ := cat.Lookup("openai", "gpt-4o")
+	if gpt4o.DisplayName != "GPT-4o" || gpt4o.ContextWindow != 128000 || gpt4o.Pricing.Input != 2 {
+		t.Errorf("gpt-4o = %+v, want pricing replaced and other fields kept", gpt4o)
+	}
+
+	gpt6, ok := cat.Lookup("openai", "gpt-6")
+	if !ok {
+		t.Fatal("gpt-6 not added")
+	}
+	if gpt6.GetAPIEndpoint() != core.APIEndpointResponses || !gpt6.HasCapability(core.FeatureToolCalling) ||
+		!gpt6.AcceptsInput(core.ModalityAudio) || gpt6.KnowledgeCutoff != "2026-03" || gpt6.DeprecationDate != "2030-01-01" {
+		t.Errorf("gpt-6 = %+v", gpt6)
+	}
+
+	models := cat.Models("openai")
+	if len(models) != 2 || models[0].ID != "gpt-4o" || models[1].ID != "gpt-6" {
+		t.Errorf("Models() = %+v, want load order", models)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"unknown field", "providers:\n  openai:\n    - id: x\n      context: 1\n", "context"},
+		{"missing id", "providers:\n  openai:\n    - display_name: X\n", "missing id"},
+		{"unknown modality", "providers:\n  openai:\n    - id: x\n      input_modalities: [smell]\n", "smell"},
+		{"unknown endpoint", "providers:\n  openai:\n    - id: x\n      api_endpoint: assistants\n", "assistants"},
+		{"bad date", "providers:\n  openai:\n    - id: x\n      deprecation_date: soon\n", "YYYY-MM-DD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cat := New()
+			err := cat.Load(strings.NewReader(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Load() error = %v, want mention of %q", err, tt.want)
+			}
+			if len(cat.Providers()) != 0 {
+				t.Error("invalid catalog was partially merged")
+			}
+		})
+	}
+
+	if err := New().Load(strings.NewReader("")); err != nil {
+		t.Errorf("Load(empty) error = %v", err)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "models.yaml")
+	if err := os.WriteFile(path, []byte("providers:\n  ollama:\n    - id: qwen3:8b\n      context_window: 40960\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cat := New()
+	if err := cat.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if info, _ := cat.Lookup("ollama", "qwen3:8b"); info.ContextWindow != 40960 {
+		t.Errorf("qwen3:8b = %+v", info)
+	}
+
+	if err := cat.LoadFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("LoadFile(missing) should fail")
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	cat := New()
+	cat.Add("openai",
+		core.ModelInfo{ID: "gpt-4o", ContextWindow: 128000},
+		core.ModelInfo{ID: "gpt-6", Capabilities: []core.Feature{core.FeatureChat}},
+	)
+
+	got := cat.Enrich("openai", []core.ModelInfo{
+		{ID: "gpt-4o", DisplayName: "GPT-4o", Capabilities: []core.Feature{core.FeatureChat, core.FeatureToolCalling}},
+		{ID: "gpt-4"},
+	})
+	if len(got) != 3 {
+		t.Fatalf("Enrich() returned %d models, want 3", len(got))
+	}
+	if got[0].ContextWindow != 128000 || !got[0].HasCapability(core.FeatureToolCalling) || got[0].DisplayName != "GPT-4o" {
+		t.Errorf("got[0] = %+v, want provider fields with catalog metadata", got[0])
+	}
+	if got[1].ID != "gpt-4" {
+		t.Errorf("got[1] = %+v", got[1])
+	}
+	if got[2].ID != "gpt-6" || got[2].DisplayName != "gpt-6" {
+		t.Errorf("got[2] = %+v, want catalog-only model appended", got[2])
+	}
+}
+
+func TestSelect(t *testing.T) {
+	models := []core.ModelInfo{
+		{ID: "pricey", Capabilities: []core.Feature{core.FeatureChat}, ContextWindow: 200000,
+			InputModalities: []core.Modality{core.ModalityText, core.ModalityImage}, Pricing: &core.ModelPricing{Input: 3}},
+		{ID: "cheap", Capabilities: []core.Feature{core.FeatureChat}, ContextWindow: 200000,
+			InputModalities: []core.Modality{core.ModalityText, core.ModalityImage}, Pricing: &core.ModelPricing{Input: 0.1}},
+		{ID: "unpriced", Capabilities: []core.Feature{core.FeatureChat}, ContextWindow: 200000,
+			InputModalities: []core.Modality{core.ModalityText, core.ModalityImage}},
+		{ID: "text-only", Capabilities: []core.Feature{core.FeatureChat}, ContextWindow: 200000},
+		{ID: "small", Capabilities: []core.Feature{core.FeatureChat}, ContextWindow: 8192,
+			InputModalities: []core.Modality{core.ModalityText, core.ModalityImage}},
+		{ID: "retired", Capabilities: []core.Feature{core.FeatureChat}, ContextWindow: 200000,
+			InputModalities: []core.Modality{core.ModalityText, core.ModalityImage}, DeprecationDate: "2020-01-01"},
+		{ID: "embedder", Capabilities: []core.Feature{core.FeatureEmbeddings}, ContextWindow: 200000},
+	}
+
+	got := Select(models, Query{
+		Features:         []core.Feature{core.FeatureChat},
+		InputModalities:  []core.Modality{core.ModalityImage},
+		MinContextWindow: 100000,
+	})
+	var ids []string
+	for _, m := range got {
+		ids = append(ids, string(m.ID))
+	}
+	if strings.Join(ids, ",") != "cheap,pricey,unpriced" {
+		t.Errorf("Select() = %v, want cheap,pricey,unpriced", ids)
+	}
+
+	got = Select(models, Query{MaxInputPrice: 1, IncludeDeprecated: true})
+	if len(got) != 1 || got[0].ID != "cheap" {
+		t.Errorf("Select(MaxInputPrice) = %+v", got)
+	}
+}
+
+func TestClientModelInfo(t *testing.T) {
+	provider := &providertest.Fake{
+		ProviderID: "openai",
+		ModelList: []core.ModelInfo{
+			{ID: "gpt-4o", DisplayName: "GPT-4o", Capabilities: []core.Feature{core.FeatureChat}},
+		},
+	}
+
+	client := core.NewClient(provider, core.WithModelCatalog(Default()))
+	info, ok := client.ModelInfo("gpt-4o")
+	if !ok {
+		t.Fatal("ModelInfo(gpt-4o) not found")
+	}
+	if info.DisplayName != "GPT-4o" || !info.HasCapability(core.FeatureChat) || info.ContextWindow == 0 {
+		t.Errorf("ModelInfo(gpt-4o) = %+v, want provider and catalog fields", info)
+	}
+
+	// Catalog entries make models known that the provider does not list
+	info, ok = client.ModelInfo("o3")
+	if !ok || info.ContextWindow == 0 {
+		t.Errorf("ModelInfo(o3) = %+v, %v", info, ok)
+	}
+
+	// Clients use the default catalog unless told otherwise
+	if _, ok := core.NewClient(provider).ModelInfo("o3"); !ok {
+		t.Error("ModelInfo(o3) not found with the default catalog")
+	}
+	if _, ok := core.NewClient(provider, core.WithModelCatalog(nil)).ModelInfo("o3"); ok {
+		t.Error("ModelInfo(o3) found without a catalog")
+	}
+}
+
+func TestDeprecatedEntries(t *testing.T) {
+	info, ok := Default().Lookup("openai", "dall-e-3")
+	if !ok {
+		t.Fatal("dall-e-3 not found")
+	}
+	if !info.IsDeprecated(time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("dall-e-3 should be deprecated after 2026-05-12")
+	}
+	if info.IsDeprecated(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("dall-e-3 should not be deprecated before 2026-05-12")
+	}
+}
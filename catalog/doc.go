@@ -0,0 +1,35 @@
+// Package catalog provides model metadata that providers do not report:
+// context windows, output limits, input and output modalities, knowledge
+// cutoffs, deprecation dates, and pricing.
+//
+// The data ships embedded in the library and is exposed through Default,
+// which clients consult unless given another catalog with
+// core.WithModelCatalog; every provider package links it in.
+// Users can override or extend it with their own YAML, so new models and
+// price changes work without waiting for a release:
+//
+//	cat := catalog.Default()
+//	if err := cat.LoadFile("models.yaml"); err != nil {
+//	    log.Fatal(err)
+//	}
+//	client := core.NewClient(provider, core.WithModelCatalog(cat))
+//	info, ok := client.ModelInfo("gpt-5.2")
+//
+// Files use the same format as the embedded catalog. Entries are keyed by
+// provider ID and model ID; fields set in a later file replace those already
+// loaded, and unset fields are left alone:
+//
+//	providers:
+//	  openai:
+//	    - id: gpt-5.2
+//	      pricing: {input: 1.5, output: 12}
+//	    - id: gpt-6
+//	      display_name: GPT-6
+//	      capabilities: [chat, chat_streaming, tool_calling, reasoning]
+//	      api_endpoint: responses
+//	      context_window: 1000000
+//	      input_modalities: [text, image]
+//
+// Capabilities are usually left to the providers' built-in lists; set them
+// for models the providers do not know yet.
+package catalog
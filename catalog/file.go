@@ -0,0 +1,91 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// file is the YAML catalog format.
+type file struct {
+	Providers map[string][]entry `yaml:"providers"`
+}
+
+// entry is a model in a YAML catalog.
+type entry struct {
+	ID               string           `yaml:"id"`
+	DisplayName      string           `yaml:"display_name"`
+	Capabilities     []core.Feature   `yaml:"capabilities"`
+	APIEndpoint      core.APIEndpoint `yaml:"api_endpoint"`
+	ContextWindow    int              `yaml:"context_window"`
+	MaxOutputTokens  int              `yaml:"max_output_tokens"`
+	InputModalities  []core.Modality  `yaml:"input_modalities"`
+	OutputModalities []core.Modality  `yaml:"output_modalities"`
+	KnowledgeCutoff  string           `yaml:"knowledge_cutoff"`
+	DeprecationDate  string           `yaml:"deprecation_date"`
+	Pricing          *pricing         `yaml:"pricing"`
+}
+
+// pricing is a model's price in US dollars per million tokens.
+type pricing struct {
+	Input       float64 `yaml:"input"`
+	Output      float64 `yaml:"output"`
+	CachedInput float64 `yaml:"cached_input"`
+}
+
+// modelInfo validates the entry and converts it to a ModelInfo.
+func (e *entry) modelInfo() (core.ModelInfo, error) {
+	if e.ID == "" {
+		return core.ModelInfo{}, errors.New("missing id")
+	}
+	for _, mods := range [][]core.Modality{e.InputModalities, e.OutputModalities} {
+		for _, m := range mods {
+			if !validModality(m) {
+				return core.ModelInfo{}, fmt.Errorf("%s: unknown modality %q", e.ID, m)
+			}
+		}
+	}
+	switch e.APIEndpoint {
+	case "", core.APIEndpointCompletions, core.APIEndpointResponses:
+	default:
+		return core.ModelInfo{}, fmt.Errorf("%s: unknown api_endpoint %q", e.ID, e.APIEndpoint)
+	}
+	if e.DeprecationDate != "" {
+		if _, err := time.Parse(time.DateOnly, e.DeprecationDate); err != nil {
+			return core.ModelInfo{}, fmt.Errorf("%s: deprecation_date must be YYYY-MM-DD: %q", e.ID, e.DeprecationDate)
+		}
+	}
+
+	info := core.ModelInfo{
+		ID:               core.ModelID(e.ID),
+		DisplayName:      e.DisplayName,
+		Capabilities:     e.Capabilities,
+		APIEndpoint:      e.APIEndpoint,
+		ContextWindow:    e.ContextWindow,
+		MaxOutputTokens:  e.MaxOutputTokens,
+		InputModalities:  e.InputModalities,
+		OutputModalities: e.OutputModalities,
+		KnowledgeCutoff:  e.KnowledgeCutoff,
+		DeprecationDate:  e.DeprecationDate,
+	}
+	if e.Pricing != nil {
+		info.Pricing = &core.ModelPricing{
+			Input:       e.Pricing.Input,
+			Output:      e.Pricing.Output,
+			CachedInput: e.Pricing.CachedInput,
+		}
+	}
+	return info, nil
+}
+
+// validModality reports whether m is a known modality.
+func validModality(m core.Modality) bool {
+	switch m {
+	case core.ModalityText, core.ModalityImage, core.ModalityAudio, core.ModalityVideo, core.ModalityDocument:
+		return true
+	default:
+		return false
+	}
+}
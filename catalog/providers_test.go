@@ -0,0 +1,86 @@
+package catalog_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/erikhoward/iris/catalog"
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers"
+	_ "github.com/erikhoward/iris/providers/anthropic"
+	_ "github.com/erikhoward/iris/providers/bedrock"
+	_ "github.com/erikhoward/iris/providers/cohere"
+	_ "github.com/erikhoward/iris/providers/gemini"
+	_ "github.com/erikhoward/iris/providers/mistral"
+	"github.com/erikhoward/iris/providers/openai"
+	"github.com/erikhoward/iris/providers/perplexity"
+	_ "github.com/erikhoward/iris/providers/voyageai"
+	_ "github.com/erikhoward/iris/providers/xai"
+	_ "github.com/erikhoward/iris/providers/zai"
+)
+
+// TestDefaultMatchesProviders checks that every embedded entry names a
+// model in its provider's built-in list, so typos in models.yaml are caught.
+func TestDefaultMatchesProviders(t *testing.T) {
+	cat := catalog.Default()
+	if len(cat.Providers()) == 0 {
+		t.Fatal("Default() has no providers")
+	}
+
+	for _, id := range cat.Providers() {
+		var p core.Provider
+		if id == "perplexity" {
+			// Perplexity is not in the provider registry
+			p = perplexity.New("test-key")
+		} else {
+			var err error
+			if p, err = providers.Create(id, "test-key"); err != nil {
+				t.Fatalf("providers.Create(%q) error = %v", id, err)
+			}
+		}
+		known := make(map[core.ModelID]bool)
+		for _, m := range p.Models() {
+			known[m.ID] = true
+		}
+		for _, m := range cat.Models(id) {
+			if !known[m.ID] {
+				t.Errorf("%s: catalog model %q is not in Models()", id, m.ID)
+			}
+		}
+	}
+}
+
+// TestDefaultClientCatalog checks that clients created without
+// WithModelCatalog use the embedded catalog to check input modalities.
+func TestDefaultClientCatalog(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "unexpected request", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := core.NewClient(openai.New("test-key", openai.WithBaseURL(server.URL)))
+	if info, ok := client.ModelInfo("o3-mini"); !ok || info.ContextWindow == 0 {
+		t.Errorf("ModelInfo(o3-mini) = %+v, %v; want catalog metadata", info, ok)
+	}
+
+	_, err := client.Chat("o3-mini").UserWithImageURL("What is this?", "https://example.com/cat.png").GetResponse(context.Background())
+	var featErr *core.UnsupportedFeatureError
+	if !errors.As(err, &featErr) || featErr.Modality != core.ModalityImage {
+		t.Errorf("GetResponse() error = %v, want UnsupportedFeatureError for images", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("provider received %d requests, want none", n)
+	}
+
+	// A nil catalog turns the lookups off
+	client = core.NewClient(openai.New("test-key"), core.WithModelCatalog(nil))
+	if info, _ := client.ModelInfo("o3-mini"); info.ContextWindow != 0 {
+		t.Errorf("ModelInfo(o3-mini) without a catalog = %+v", info)
+	}
+}
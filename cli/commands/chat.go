@@ -7,14 +7,19 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/erikhoward/iris/cli/config"
 	"github.com/erikhoward/iris/cli/keystore"
 	"github.com/erikhoward/iris/core"
 	"github.com/erikhoward/iris/providers"
 	"github.com/erikhoward/iris/providers/anthropic"
+	"github.com/erikhoward/iris/providers/bedrock"
+	"github.com/erikhoward/iris/providers/cohere"
 	"github.com/erikhoward/iris/providers/gemini"
 	"github.com/erikhoward/iris/providers/huggingface"
+	"github.com/erikhoward/iris/providers/mistral"
 	"github.com/erikhoward/iris/providers/ollama"
 	"github.com/erikhoward/iris/providers/openai"
+	"github.com/erikhoward/iris/providers/openaicompat"
 	"github.com/erikhoward/iris/providers/xai"
 	"github.com/erikhoward/iris/providers/zai"
 	"github.com/spf13/cobra"
@@ -80,6 +85,11 @@ func runChat(cmd *cobra.Command, args []string) error {
 	}
 
 	apiKey, err := ks.Get(providerID)
+	if _, ok := err.(*keystore.ErrKeyNotFound); ok && (isOpenAICompatible(providerID) || providerID == "bedrock") {
+		// Local OpenAI-compatible servers usually need no key, and Bedrock
+		// falls back to AWS credentials
+		err = nil
+	}
 	if err != nil {
 		if _, ok := err.(*keystore.ErrKeyNotFound); ok {
 			return exitWithCode(ExitValidation, fmt.Errorf("no API key for %s: run 'iris keys set %s' first", providerID, providerID))
@@ -93,8 +103,13 @@ func runChat(cmd *cobra.Command, args []string) error {
 		return exitWithCode(ExitValidation, err)
 	}
 
+	cat, err := loadCatalog()
+	if err != nil {
+		return exitWithCode(ExitValidation, err)
+	}
+
 	// Create client and build request
-	client := core.NewClient(provider)
+	client := core.NewClient(provider, core.WithModelCatalog(cat))
 	builder := client.Chat(core.ModelID(modelID)).User(prompt)
 
 	if system != "" {
@@ -194,7 +209,44 @@ func runStreamingChat(ctx context.Context, builder *core.ChatBuilder) error {
 	return nil
 }
 
+// registerConfiguredProviders adds every OpenAI-compatible endpoint defined
+// in the config to the providers registry under its config key.
+func registerConfiguredProviders(c *config.Config) {
+	if c == nil {
+		return
+	}
+	for id, pc := range c.Providers {
+		if !pc.IsOpenAICompatible() {
+			continue
+		}
+		var opts []openaicompat.Option
+		if len(pc.Models) > 0 {
+			opts = append(opts, openaicompat.WithModelIDs(pc.Models...))
+		}
+		if pc.AuthHeader != "" {
+			opts = append(opts, openaicompat.WithAuthHeader(pc.AuthHeader))
+		}
+		openaicompat.Register(id, pc.BaseURL, opts...)
+	}
+}
+
+// isOpenAICompatible reports whether the provider is configured as an
+// OpenAI-compatible endpoint.
+func isOpenAICompatible(providerID string) bool {
+	if cfg := GetConfig(); cfg != nil {
+		if pc := cfg.GetProvider(providerID); pc != nil {
+			return pc.IsOpenAICompatible()
+		}
+	}
+	return false
+}
+
 func createProvider(providerID, apiKey string) (core.Provider, error) {
+	// Configured endpoints take precedence, even over built-in names
+	if isOpenAICompatible(providerID) {
+		return providers.Create(providerID, apiKey)
+	}
+
 	switch providerID {
 	case "openai":
 		// Check for custom base URL in config
@@ -205,6 +257,40 @@ func createProvider(providerID, apiKey string) (core.Provider, error) {
 			}
 		}
 		return openai.New(apiKey, opts...), nil
+	case "azure":
+		// Azure has no default endpoint; the resource URL comes from config
+		var pc *config.ProviderConfig
+		if cfg := GetConfig(); cfg != nil {
+			pc = cfg.GetProvider(providerID)
+		}
+		if pc == nil || pc.BaseURL == "" {
+			return nil, fmt.Errorf("azure requires base_url in config: set providers.azure.base_url to the resource endpoint")
+		}
+		var opts []openai.AzureOption
+		if pc.APIVersion != "" {
+			opts = append(opts, openai.WithAzureAPIVersion(pc.APIVersion))
+		}
+		return openai.NewAzure(pc.BaseURL, apiKey, opts...), nil
+	case "bedrock":
+		// Requests are signed with AWS credentials unless a Bedrock API key is stored
+		var opts []bedrock.Option
+		if cfg := GetConfig(); cfg != nil {
+			if pc := cfg.GetProvider(providerID); pc != nil {
+				if pc.BaseURL != "" {
+					opts = append(opts, bedrock.WithBaseURL(pc.BaseURL))
+				}
+				if pc.Region != "" {
+					opts = append(opts, bedrock.WithRegion(pc.Region))
+				}
+				if pc.Profile != "" {
+					opts = append(opts, bedrock.WithProfile(pc.Profile))
+				}
+			}
+		}
+		if apiKey != "" {
+			opts = append(opts, bedrock.WithAPIKey(apiKey))
+		}
+		return bedrock.New(opts...), nil
 	case "anthropic":
 		// Check for custom base URL in config
 		var opts []anthropic.Option
@@ -241,6 +327,24 @@ func createProvider(providerID, apiKey string) (core.Provider, error) {
 			}
 		}
 		return zai.New(apiKey, opts...), nil
+	case "mistral":
+		// Check for custom base URL in config
+		var opts []mistral.Option
+		if cfg := GetConfig(); cfg != nil {
+			if pc := cfg.GetProvider(providerID); pc != nil && pc.BaseURL != "" {
+				opts = append(opts, mistral.WithBaseURL(pc.BaseURL))
+			}
+		}
+		return mistral.New(apiKey, opts...), nil
+	case "cohere":
+		// Check for custom base URL in config
+		var opts []cohere.Option
+		if cfg := GetConfig(); cfg != nil {
+			if pc := cfg.GetProvider(providerID); pc != nil && pc.BaseURL != "" {
+				opts = append(opts, cohere.WithBaseURL(pc.BaseURL))
+			}
+		}
+		return cohere.New(apiKey, opts...), nil
 	case "ollama":
 		// Check for custom base URL in config
 		var opts []ollama.Option
@@ -304,7 +408,8 @@ func handleChatError(err error) error {
 	}
 
 	// Validation errors
-	if errors.Is(err, core.ErrModelRequired) || errors.Is(err, core.ErrNoMessages) {
+	var featErr *core.UnsupportedFeatureError
+	if errors.Is(err, core.ErrModelRequired) || errors.Is(err, core.ErrNoMessages) || errors.As(err, &featErr) {
 		if IsJSONOutput() {
 			outputSimpleErrorJSON("validation_error", err.Error())
 		} else {
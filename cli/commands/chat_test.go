@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/erikhoward/iris/cli/config"
 	"github.com/erikhoward/iris/core"
 )
 
@@ -56,6 +57,18 @@ func TestCreateProviderOpenAI(t *testing.T) {
 	}
 }
 
+func TestCreateProviderMistralAndCohere(t *testing.T) {
+	for _, id := range []string{"mistral", "cohere"} {
+		provider, err := createProvider(id, "test-key")
+		if err != nil {
+			t.Fatalf("createProvider(%q) error = %v", id, err)
+		}
+		if provider.ID() != id {
+			t.Errorf("provider.ID() = %q, want %q", provider.ID(), id)
+		}
+	}
+}
+
 func TestCreateProviderUnsupported(t *testing.T) {
 	_, err := createProvider("unsupported", "test-key")
 	if err == nil {
@@ -77,6 +90,19 @@ func TestHandleChatErrorValidation(t *testing.T) {
 	}
 }
 
+func TestHandleChatErrorUnsupportedFeature(t *testing.T) {
+	err := handleChatError(&core.UnsupportedFeatureError{Provider: "anthropic", Model: "claude-haiku-4-5", Feature: core.FeatureBuiltInTools})
+
+	exitErr, ok := err.(*exitError)
+	if !ok {
+		t.Fatal("expected *exitError type")
+	}
+
+	if exitErr.ExitCode() != ExitValidation {
+		t.Errorf("ExitCode() = %d, want %d (ExitValidation)", exitErr.ExitCode(), ExitValidation)
+	}
+}
+
 func TestHandleChatErrorNetwork(t *testing.T) {
 	err := handleChatError(core.ErrNetwork)
 
@@ -111,3 +137,69 @@ func TestHandleChatErrorProvider(t *testing.T) {
 		t.Errorf("ExitCode() = %d, want %d (ExitProvider)", exitErr.ExitCode(), ExitProvider)
 	}
 }
+
+func TestCreateProviderOpenAICompatible(t *testing.T) {
+	orig := cfg
+	defer func() { cfg = orig }()
+
+	cfg = &config.Config{
+		Providers: map[string]config.ProviderConfig{
+			"my-vllm": {
+				Type:    config.ProviderTypeOpenAICompatible,
+				BaseURL: "http://localhost:8000/v1",
+				Models:  []string{"llama"},
+			},
+		},
+	}
+	registerConfiguredProviders(cfg)
+
+	provider, err := createProvider("my-vllm", "")
+	if err != nil {
+		t.Fatalf("createProvider() error = %v", err)
+	}
+	if provider.ID() != "my-vllm" {
+		t.Errorf("provider.ID() = %q, want 'my-vllm'", provider.ID())
+	}
+	if len(provider.Models()) != 1 {
+		t.Errorf("len(Models()) = %d, want 1", len(provider.Models()))
+	}
+}
+
+func TestCreateProviderAzure(t *testing.T) {
+	orig := cfg
+	defer func() { cfg = orig }()
+
+	cfg = &config.Config{Providers: map[string]config.ProviderConfig{}}
+	if _, err := createProvider("azure", "test-key"); err == nil {
+		t.Fatal("createProvider(azure) should require base_url")
+	}
+
+	cfg.Providers["azure"] = config.ProviderConfig{
+		BaseURL:    "https://my-resource.openai.azure.com",
+		APIVersion: "2024-10-21",
+	}
+	provider, err := createProvider("azure", "test-key")
+	if err != nil {
+		t.Fatalf("createProvider() error = %v", err)
+	}
+	if provider.ID() != "azure" {
+		t.Errorf("provider.ID() = %q, want 'azure'", provider.ID())
+	}
+}
+
+func TestCreateProviderBedrock(t *testing.T) {
+	orig := cfg
+	defer func() { cfg = orig }()
+
+	cfg = &config.Config{Providers: map[string]config.ProviderConfig{
+		"bedrock": {Region: "eu-west-1"},
+	}}
+
+	provider, err := createProvider("bedrock", "")
+	if err != nil {
+		t.Fatalf("createProvider() error = %v", err)
+	}
+	if provider.ID() != "bedrock" {
+		t.Errorf("provider.ID() = %q, want 'bedrock'", provider.ID())
+	}
+}
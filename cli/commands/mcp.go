@@ -0,0 +1,187 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/erikhoward/iris/cli/config"
+	"github.com/erikhoward/iris/tools"
+	"github.com/erikhoward/iris/tools/mcp"
+	"github.com/spf13/cobra"
+)
+
+// defaultMCPAddr is the address the HTTP transport listens on by default.
+const defaultMCPAddr = "127.0.0.1:8080"
+
+var (
+	mcpTransport string
+	mcpAddr      string
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Work with Model Context Protocol servers",
+}
+
+var mcpServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve tools to MCP clients",
+	Long: `Serve tools to MCP-capable agents and IDEs.
+
+The tools of every server listed under mcp.servers in the config are
+collected and served as one MCP server, over stdio by default or over the
+streamable HTTP transport with --transport http.
+
+Example config:
+  mcp:
+    servers:
+      files:
+        command: npx
+        args: [-y, "@modelcontextprotocol/server-filesystem", /tmp]
+      search:
+        url: https://mcp.example.com/mcp
+        tool_prefix: search_
+
+Examples:
+  iris mcp serve --config mcp.yaml
+  iris mcp serve --transport http --addr 127.0.0.1:9000`,
+	RunE: runMCPServe,
+}
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+	mcpCmd.AddCommand(mcpServeCmd)
+
+	mcpServeCmd.Flags().StringVar(&mcpTransport, "transport", "", "transport: stdio or http (default stdio)")
+	mcpServeCmd.Flags().StringVar(&mcpAddr, "addr", "", "address for the http transport (default "+defaultMCPAddr+")")
+}
+
+func runMCPServe(cmd *cobra.Command, args []string) error {
+	var mc config.MCPConfig
+	if c := GetConfig(); c != nil {
+		mc = c.MCP
+	}
+
+	transport := firstNonEmpty(mcpTransport, mc.Transport, "stdio")
+	if transport != "stdio" && transport != "http" {
+		return exitWithCode(ExitValidation, fmt.Errorf("unknown transport %q: use stdio or http", transport))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	reg, clients, err := connectMCPServers(ctx, mc.Servers)
+	defer func() {
+		for _, c := range clients {
+			c.Close()
+		}
+	}()
+	if err != nil {
+		return exitWithCode(ExitNetwork, err)
+	}
+	if len(reg.List()) == 0 {
+		return exitWithCode(ExitValidation, errors.New("no tools to serve: list servers under mcp.servers in the config"))
+	}
+
+	srv := mcp.NewServer(reg, mcp.WithServerInfo("iris", Version))
+	defer srv.Close()
+
+	if transport == "stdio" {
+		// Stdout carries the protocol, so nothing else may be written to it
+		return srv.ServeStdio(ctx, os.Stdin, os.Stdout)
+	}
+
+	addr := firstNonEmpty(mcpAddr, mc.Addr, defaultMCPAddr)
+	httpSrv := &http.Server{Addr: addr, Handler: srv}
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- httpSrv.ListenAndServe() }()
+	fmt.Fprintf(os.Stderr, "Serving %d tools at http://%s\n", len(reg.List()), addr)
+
+	select {
+	case err := <-serveErr:
+		return exitWithCode(ExitNetwork, err)
+	case <-ctx.Done():
+	}
+
+	// End event streams so that shutdown does not wait for them
+	srv.Close()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return httpSrv.Shutdown(shutdownCtx)
+}
+
+// connectMCPServers connects to each configured server, in name order, and
+// registers its tools. The clients connected so far are returned even on
+// error, for the caller to close.
+func connectMCPServers(ctx context.Context, servers map[string]config.MCPServerConfig) (*tools.Registry, []*mcp.Client, error) {
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	reg := tools.NewRegistry()
+	var clients []*mcp.Client
+	for _, name := range names {
+		client, err := connectMCPServer(ctx, servers[name])
+		if err != nil {
+			return reg, clients, fmt.Errorf("mcp server %s: %w", name, err)
+		}
+		clients = append(clients, client)
+		if err := client.RegisterTools(ctx, reg); err != nil {
+			return reg, clients, fmt.Errorf("mcp server %s: %w", name, err)
+		}
+	}
+	return reg, clients, nil
+}
+
+// connectMCPServer starts or connects to a configured server.
+func connectMCPServer(ctx context.Context, sc config.MCPServerConfig) (*mcp.Client, error) {
+	opts := []mcp.Option{mcp.WithClientInfo("iris", Version)}
+	if sc.ToolPrefix != "" {
+		opts = append(opts, mcp.WithToolPrefix(sc.ToolPrefix))
+	}
+
+	switch {
+	case sc.Command != "" && sc.URL != "":
+		return nil, errors.New("set either command or url, not both")
+
+	case sc.Command != "":
+		cmd := exec.Command(sc.Command, sc.Args...)
+		cmd.Stderr = os.Stderr
+		if len(sc.Env) > 0 {
+			cmd.Env = os.Environ()
+			for key, value := range sc.Env {
+				cmd.Env = append(cmd.Env, key+"="+value)
+			}
+		}
+		return mcp.ConnectStdio(ctx, cmd, opts...)
+
+	case sc.URL != "":
+		for key, value := range sc.Headers {
+			opts = append(opts, mcp.WithHeader(key, value))
+		}
+		return mcp.ConnectHTTP(ctx, sc.URL, opts...)
+
+	default:
+		return nil, errors.New("command or url required")
+	}
+}
+
+// firstNonEmpty returns the first value that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/cli/config"
+	"github.com/erikhoward/iris/tools"
+	"github.com/erikhoward/iris/tools/mcp"
+)
+
+// echoTool returns its arguments.
+type echoTool struct{}
+
+func (echoTool) Name() string             { return "echo" }
+func (echoTool) Description() string      { return "Echoes its arguments" }
+func (echoTool) Schema() tools.ToolSchema { return tools.ToolSchema{} }
+func (echoTool) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	return args, nil
+}
+
+func TestConnectMCPServers(t *testing.T) {
+	upstream := tools.NewRegistry()
+	upstream.Register(echoTool{})
+	srv := mcp.NewServer(upstream)
+	defer srv.Close()
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	reg, clients, err := connectMCPServers(context.Background(), map[string]config.MCPServerConfig{
+		"a": {URL: ts.URL, ToolPrefix: "a_"},
+		"b": {URL: ts.URL, ToolPrefix: "b_", Headers: map[string]string{"X-Test": "1"}},
+	})
+	defer func() {
+		for _, c := range clients {
+			c.Close()
+		}
+	}()
+	if err != nil {
+		t.Fatalf("connectMCPServers() error = %v", err)
+	}
+	if len(clients) != 2 {
+		t.Errorf("len(clients) = %d, want 2", len(clients))
+	}
+
+	tool, ok := reg.Get("b_echo")
+	if !ok || len(reg.List()) != 2 {
+		t.Fatalf("registry tools = %d, b_echo found = %v", len(reg.List()), ok)
+	}
+	got, err := tool.Call(context.Background(), json.RawMessage(`{"x":1}`))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if raw, ok := got.(json.RawMessage); !ok || string(raw) != `{"x":1}` {
+		t.Errorf("Call() = %v", got)
+	}
+}
+
+func TestConnectMCPServersDuplicateTool(t *testing.T) {
+	upstream := tools.NewRegistry()
+	upstream.Register(echoTool{})
+	srv := mcp.NewServer(upstream)
+	defer srv.Close()
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	_, clients, err := connectMCPServers(context.Background(), map[string]config.MCPServerConfig{
+		"a": {URL: ts.URL},
+		"b": {URL: ts.URL},
+	})
+	for _, c := range clients {
+		c.Close()
+	}
+	if err == nil || !strings.Contains(err.Error(), "mcp server b") {
+		t.Errorf("connectMCPServers() error = %v, want duplicate tool from b", err)
+	}
+}
+
+func TestConnectMCPServerInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		sc   config.MCPServerConfig
+		want string
+	}{
+		{"neither", config.MCPServerConfig{}, "command or url required"},
+		{"both", config.MCPServerConfig{Command: "server", URL: "http://localhost"}, "not both"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := connectMCPServer(context.Background(), tt.sc)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("connectMCPServer() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirstNonEmpty(t *testing.T) {
+	if got := firstNonEmpty("", "b", "c"); got != "b" {
+		t.Errorf("firstNonEmpty() = %q, want b", got)
+	}
+	if got := firstNonEmpty("", ""); got != "" {
+		t.Errorf("firstNonEmpty() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/erikhoward/iris/catalog"
+	"github.com/erikhoward/iris/core"
+	"github.com/spf13/cobra"
+)
+
+var modelsCmd = &cobra.Command{
+	Use:   "models",
+	Short: "List models with their context window, modalities, and pricing",
+	Long: `List the models known for a provider, with metadata from the model catalog.
+
+Without --provider or a default provider, models from every provider in the
+catalog are listed. Set model_catalog in the config to a YAML file to
+override or extend the built-in catalog.
+
+Examples:
+  iris models --provider anthropic
+  iris models --json`,
+	RunE: runModels,
+}
+
+func init() {
+	rootCmd.AddCommand(modelsCmd)
+}
+
+// providerModels is one provider's entry in the models listing.
+type providerModels struct {
+	Provider string           `json:"provider"`
+	Models   []core.ModelInfo `json:"models"`
+}
+
+func runModels(cmd *cobra.Command, args []string) error {
+	cat, err := loadCatalog()
+	if err != nil {
+		return exitWithCode(ExitValidation, err)
+	}
+
+	var listing []providerModels
+	if providerID := GetProvider(); providerID != "" {
+		// Built-in model lists need no credentials
+		p, err := createProvider(providerID, "")
+		if err != nil {
+			return exitWithCode(ExitValidation, err)
+		}
+		listing = append(listing, providerModels{providerID, cat.Enrich(providerID, p.Models())})
+	} else {
+		for _, id := range cat.Providers() {
+			listing = append(listing, providerModels{id, cat.Models(id)})
+		}
+	}
+
+	if IsJSONOutput() {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(listing)
+	}
+	return writeModelTable(os.Stdout, listing)
+}
+
+// loadCatalog returns the built-in model catalog with the config's
+// model_catalog file, if any, merged on top.
+func loadCatalog() (*catalog.Catalog, error) {
+	// Copy the shared default so the config file does not modify it
+	cat := catalog.New()
+	for _, id := range catalog.Default().Providers() {
+		cat.Add(id, catalog.Default().Models(id)...)
+	}
+
+	if c := GetConfig(); c != nil && c.ModelCatalog != "" {
+		if err := cat.LoadFile(c.ModelCatalog); err != nil {
+			return nil, fmt.Errorf("failed to load model catalog: %w", err)
+		}
+	}
+	return cat, nil
+}
+
+func writeModelTable(w io.Writer, listing []providerModels) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROVIDER\tMODEL\tCONTEXT\tMAX OUTPUT\tINPUT\tPRICE (IN/OUT PER 1M)")
+	for _, pm := range listing {
+		for _, m := range pm.Models {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				pm.Provider, m.ID, formatTokens(m.ContextWindow), formatTokens(m.MaxOutputTokens),
+				formatModalities(m.InputModalities), formatPricing(m.Pricing))
+		}
+	}
+	return tw.Flush()
+}
+
+// formatTokens abbreviates a token count, using "-" when it is unknown.
+func formatTokens(n int) string {
+	switch {
+	case n <= 0:
+		return "-"
+	case n >= 1_000_000 && n%1_000_000 == 0:
+		return strconv.Itoa(n/1_000_000) + "M"
+	case n >= 1000 && n%1000 == 0:
+		return strconv.Itoa(n/1000) + "K"
+	default:
+		return strconv.Itoa(n)
+	}
+}
+
+func formatModalities(mods []core.Modality) string {
+	if len(mods) == 0 {
+		return "-"
+	}
+	names := make([]string, len(mods))
+	for i, m := range mods {
+		names[i] = string(m)
+	}
+	return strings.Join(names, ",")
+}
+
+func formatPricing(p *core.ModelPricing) string {
+	if p == nil {
+		return "-"
+	}
+	return "$" + strconv.FormatFloat(p.Input, 'f', -1, 64) + " / $" + strconv.FormatFloat(p.Output, 'f', -1, 64)
+}
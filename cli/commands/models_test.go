@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/catalog"
+	"github.com/erikhoward/iris/cli/config"
+	"github.com/erikhoward/iris/core"
+)
+
+func TestFormatTokens(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "-"},
+		{8192, "8192"},
+		{128000, "128K"},
+		{1000000, "1M"},
+		{1048576, "1048576"},
+	}
+
+	for _, tt := range tests {
+		if got := formatTokens(tt.n); got != tt.want {
+			t.Errorf("formatTokens(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestLoadCatalogWithConfigFile(t *testing.T) {
+	orig := cfg
+	defer func() { cfg = orig }()
+
+	path := filepath.Join(t.TempDir(), "models.yaml")
+	content := "providers:\n  openai:\n    - id: gpt-4o\n      context_window: 64000\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+	cfg = &config.Config{ModelCatalog: path}
+
+	cat, err := loadCatalog()
+	if err != nil {
+		t.Fatalf("loadCatalog() error = %v", err)
+	}
+	if info, _ := cat.Lookup("openai", "gpt-4o"); info.ContextWindow != 64000 || info.Pricing == nil {
+		t.Errorf("gpt-4o = %+v, want override merged with built-in data", info)
+	}
+	if info, _ := catalog.Default().Lookup("openai", "gpt-4o"); info.ContextWindow == 64000 {
+		t.Error("config catalog modified the shared default catalog")
+	}
+
+	cfg = &config.Config{ModelCatalog: filepath.Join(t.TempDir(), "missing.yaml")}
+	if _, err := loadCatalog(); err == nil {
+		t.Error("loadCatalog() should fail for a missing file")
+	}
+}
+
+func TestWriteModelTable(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeModelTable(&buf, []providerModels{{
+		Provider: "openai",
+		Models: []core.ModelInfo{{
+			ID:              "gpt-4o",
+			ContextWindow:   128000,
+			MaxOutputTokens: 16384,
+			InputModalities: []core.Modality{core.ModalityText, core.ModalityImage},
+			Pricing:         &core.ModelPricing{Input: 2.5, Output: 10},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("writeModelTable() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want header and one row:\n%s", len(lines), buf.String())
+	}
+	for _, want := range []string{"openai", "gpt-4o", "128K", "16384", "text,image", "$2.5 / $10"} {
+		if !strings.Contains(lines[1], want) {
+			t.Errorf("row %q does not contain %q", lines[1], want)
+		}
+	}
+}
@@ -66,6 +66,8 @@ func initConfig() error {
 		model = cfg.DefaultModel
 	}
 
+	registerConfiguredProviders(cfg)
+
 	return nil
 }
 
@@ -14,12 +14,76 @@ type Config struct {
 	DefaultProvider string                    `yaml:"default_provider"`
 	DefaultModel    string                    `yaml:"default_model"`
 	Providers       map[string]ProviderConfig `yaml:"providers"`
+
+	// ModelCatalog is the path of a YAML model catalog that overrides or
+	// extends the built-in model metadata.
+	ModelCatalog string `yaml:"model_catalog,omitempty"`
+
+	// MCP configures the MCP server run by 'iris mcp serve'.
+	MCP MCPConfig `yaml:"mcp,omitempty"`
+}
+
+// MCPConfig configures the MCP server run by 'iris mcp serve'.
+type MCPConfig struct {
+	// Transport is "stdio" (the default) or "http".
+	Transport string `yaml:"transport,omitempty"`
+
+	// Addr is the address the HTTP transport listens on.
+	Addr string `yaml:"addr,omitempty"`
+
+	// Servers are the MCP servers whose tools are served, by name.
+	Servers map[string]MCPServerConfig `yaml:"servers,omitempty"`
+}
+
+// MCPServerConfig describes an MCP server whose tools are served. Set
+// Command to start the server and talk to it over stdio, or URL to connect
+// to it over HTTP.
+type MCPServerConfig struct {
+	Command string            `yaml:"command,omitempty"`
+	Args    []string          `yaml:"args,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty"`
+
+	URL     string            `yaml:"url,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// ToolPrefix is prepended to the server's tool names, to keep tools
+	// from several servers apart.
+	ToolPrefix string `yaml:"tool_prefix,omitempty"`
 }
 
+// ProviderTypeOpenAICompatible marks a provider entry as an endpoint that
+// implements the OpenAI Chat Completions API, such as vLLM or Groq.
+const ProviderTypeOpenAICompatible = "openai-compatible"
+
 // ProviderConfig holds configuration for a specific provider.
 type ProviderConfig struct {
 	APIKeyRef string `yaml:"api_key_ref"`
 	BaseURL   string `yaml:"base_url,omitempty"`
+
+	// Type selects a generic provider implementation for entries that are
+	// not built-in providers. Only ProviderTypeOpenAICompatible is supported.
+	Type string `yaml:"type,omitempty"`
+
+	// Models lists the model IDs served by an OpenAI-compatible endpoint.
+	Models []string `yaml:"models,omitempty"`
+
+	// AuthHeader sends the API key in this header instead of as a bearer
+	// token, for OpenAI-compatible endpoints that require it.
+	AuthHeader string `yaml:"auth_header,omitempty"`
+
+	// APIVersion is the api-version query parameter sent to Azure OpenAI.
+	APIVersion string `yaml:"api_version,omitempty"`
+
+	// Region is the AWS region used by Bedrock.
+	Region string `yaml:"region,omitempty"`
+
+	// Profile is the AWS shared credentials profile used by Bedrock.
+	Profile string `yaml:"profile,omitempty"`
+}
+
+// IsOpenAICompatible reports whether the entry describes an OpenAI-compatible endpoint.
+func (pc ProviderConfig) IsOpenAICompatible() bool {
+	return pc.Type == ProviderTypeOpenAICompatible
 }
 
 // DefaultConfigPath returns the default configuration file path for the current platform.
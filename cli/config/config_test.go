@@ -73,6 +73,7 @@ func TestLoadConfigValid(t *testing.T) {
 	content := `
 default_provider: openai
 default_model: gpt-4o
+model_catalog: /etc/iris/models.yaml
 
 providers:
   openai:
@@ -98,6 +99,9 @@ providers:
 	if cfg.DefaultModel != "gpt-4o" {
 		t.Errorf("DefaultModel = %q, want gpt-4o", cfg.DefaultModel)
 	}
+	if cfg.ModelCatalog != "/etc/iris/models.yaml" {
+		t.Errorf("ModelCatalog = %q, want /etc/iris/models.yaml", cfg.ModelCatalog)
+	}
 	if len(cfg.Providers) != 2 {
 		t.Errorf("len(Providers) = %d, want 2", len(cfg.Providers))
 	}
@@ -111,6 +115,82 @@ providers:
 	}
 }
 
+func TestLoadConfigOpenAICompatible(t *testing.T) {
+	content := `
+providers:
+  groq:
+    type: openai-compatible
+    base_url: https://api.groq.com/openai/v1
+    models: [llama-3.3-70b-versatile, qwen-qwq-32b]
+  azure-proxy:
+    type: openai-compatible
+    base_url: https://proxy.example.com/v1
+    auth_header: api-key
+`
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write temp config: %v", err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	groq := cfg.Providers["groq"]
+	if !groq.IsOpenAICompatible() {
+		t.Errorf("groq.Type = %q, want %q", groq.Type, ProviderTypeOpenAICompatible)
+	}
+	if len(groq.Models) != 2 || groq.Models[0] != "llama-3.3-70b-versatile" {
+		t.Errorf("groq.Models = %v", groq.Models)
+	}
+	if cfg.Providers["azure-proxy"].AuthHeader != "api-key" {
+		t.Errorf("AuthHeader = %q, want api-key", cfg.Providers["azure-proxy"].AuthHeader)
+	}
+}
+
+func TestLoadConfigMCP(t *testing.T) {
+	content := `
+mcp:
+  transport: http
+  addr: 127.0.0.1:9000
+  servers:
+    files:
+      command: npx
+      args: [-y, "@modelcontextprotocol/server-filesystem", /tmp]
+      env:
+        DEBUG: "1"
+    remote:
+      url: https://mcp.example.com/mcp
+      headers:
+        Authorization: Bearer token
+      tool_prefix: remote_
+`
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write temp config: %v", err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if cfg.MCP.Transport != "http" || cfg.MCP.Addr != "127.0.0.1:9000" {
+		t.Errorf("MCP = %+v", cfg.MCP)
+	}
+	files := cfg.MCP.Servers["files"]
+	if files.Command != "npx" || len(files.Args) != 3 || files.Env["DEBUG"] != "1" {
+		t.Errorf("files = %+v", files)
+	}
+	remote := cfg.MCP.Servers["remote"]
+	if remote.URL != "https://mcp.example.com/mcp" || remote.Headers["Authorization"] != "Bearer token" || remote.ToolPrefix != "remote_" {
+		t.Errorf("remote = %+v", remote)
+	}
+}
+
 func TestLoadConfigInvalidYAML(t *testing.T) {
 	// YAML that will cause unmarshal error (wrong type)
 	content := `
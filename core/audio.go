@@ -0,0 +1,187 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// Audio features.
+const (
+	// FeatureTranscription indicates support for speech-to-text via Transcriber.
+	FeatureTranscription Feature = "transcription"
+	// FeatureSpeechSynthesis indicates support for text-to-speech via SpeechSynthesizer.
+	FeatureSpeechSynthesis Feature = "speech_synthesis"
+)
+
+// Transcriber is an optional interface for providers that convert speech to text.
+type Transcriber interface {
+	// Transcribe converts the audio in req to text. If req.Translate is
+	// set, the text is translated into English.
+	Transcribe(ctx context.Context, req *TranscriptionRequest) (*Transcription, error)
+}
+
+// SpeechSynthesizer is an optional interface for providers that convert text to speech.
+type SpeechSynthesizer interface {
+	// SynthesizeSpeech generates the complete audio for req.Input.
+	SynthesizeSpeech(ctx context.Context, req *SpeechRequest) (*SpeechResponse, error)
+
+	// StreamSpeech generates audio for req.Input, returning it as it is
+	// produced so playback can start before synthesis finishes.
+	// The caller is responsible for closing the returned stream.
+	StreamSpeech(ctx context.Context, req *SpeechRequest) (*SpeechStream, error)
+}
+
+// Audio validation errors.
+var (
+	ErrNoAudio       = errors.New("no audio")
+	ErrNoSpeechInput = errors.New("no speech input")
+)
+
+// AudioFormat represents an audio encoding.
+type AudioFormat string
+
+const (
+	AudioFormatMP3  AudioFormat = "mp3"
+	AudioFormatOpus AudioFormat = "opus"
+	AudioFormatAAC  AudioFormat = "aac"
+	AudioFormatFLAC AudioFormat = "flac"
+	AudioFormatWAV  AudioFormat = "wav"
+	AudioFormatPCM  AudioFormat = "pcm" // Raw 16-bit little-endian samples
+)
+
+// IsValid reports whether the audio format is a recognized value.
+func (f AudioFormat) IsValid() bool {
+	switch f {
+	case AudioFormatMP3, AudioFormatOpus, AudioFormatAAC, AudioFormatFLAC, AudioFormatWAV, AudioFormatPCM:
+		return true
+	default:
+		return false
+	}
+}
+
+// MimeType returns the MIME type of the format, or
+// application/octet-stream if it is not recognized.
+func (f AudioFormat) MimeType() string {
+	switch f {
+	case AudioFormatMP3:
+		return "audio/mpeg"
+	case AudioFormatOpus:
+		return "audio/ogg"
+	case AudioFormatAAC:
+		return "audio/aac"
+	case AudioFormatFLAC:
+		return "audio/flac"
+	case AudioFormatWAV:
+		return "audio/wav"
+	case AudioFormatPCM:
+		return "audio/pcm"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// TimestampGranularity selects the timestamps returned with a transcription.
+type TimestampGranularity string
+
+const (
+	TimestampGranularitySegment TimestampGranularity = "segment"
+	TimestampGranularityWord    TimestampGranularity = "word"
+)
+
+// TranscriptionRequest represents a request to transcribe audio.
+type TranscriptionRequest struct {
+	Model ModelID
+
+	// Audio is the audio to transcribe, read until EOF. Files and live
+	// streams work alike; providers that can, stream it to the API.
+	Audio io.Reader
+
+	// Filename names the audio, e.g. "memo.m4a". Providers use its
+	// extension to detect the format when MimeType is empty.
+	Filename string
+
+	// MimeType is the MIME type of the audio, if known.
+	MimeType string
+
+	// Optional parameters
+	Language    string                 // ISO-639-1 language of the audio, as a hint
+	Prompt      string                 // Text to guide style or spelling
+	Temperature *float32               // Sampling temperature
+	Translate   bool                   // Translate the speech into English
+	Timestamps  []TimestampGranularity // Timestamps to return, where supported
+}
+
+// Validate checks that the request names a model and has audio.
+func (r *TranscriptionRequest) Validate() error {
+	if r.Model == "" {
+		return ErrModelRequired
+	}
+	if r.Audio == nil {
+		return ErrNoAudio
+	}
+	return nil
+}
+
+// Transcription is the text of transcribed audio.
+type Transcription struct {
+	Text     string                 `json:"text"`
+	Language string                 `json:"language,omitempty"` // Detected language, if reported
+	Duration time.Duration          `json:"duration,omitempty"` // Length of the audio, if reported
+	Segments []TranscriptionSegment `json:"segments,omitempty"`
+	Words    []TranscriptionWord    `json:"words,omitempty"`
+}
+
+// TranscriptionSegment is a timed span of a transcription.
+type TranscriptionSegment struct {
+	Start time.Duration `json:"start"`
+	End   time.Duration `json:"end"`
+	Text  string        `json:"text"`
+}
+
+// TranscriptionWord is a timed word of a transcription.
+type TranscriptionWord struct {
+	Word  string        `json:"word"`
+	Start time.Duration `json:"start"`
+	End   time.Duration `json:"end"`
+}
+
+// SpeechRequest represents a request to synthesize speech.
+type SpeechRequest struct {
+	Model ModelID `json:"model"`
+	Input string  `json:"input"`
+
+	// Optional parameters
+	Voice        string      `json:"voice,omitempty"`        // Provider voice name; providers choose a default
+	Format       AudioFormat `json:"format,omitempty"`       // Output format; providers choose a default
+	Speed        float64     `json:"speed,omitempty"`        // Playback speed multiplier; 0 means normal speed
+	Instructions string      `json:"instructions,omitempty"` // Guidance on tone and delivery, where supported
+}
+
+// Validate checks that the request names a model and has input text.
+func (r *SpeechRequest) Validate() error {
+	if r.Model == "" {
+		return ErrModelRequired
+	}
+	if r.Input == "" {
+		return ErrNoSpeechInput
+	}
+	return nil
+}
+
+// SpeechResponse contains synthesized audio.
+type SpeechResponse struct {
+	Audio    []byte      `json:"-"`
+	Format   AudioFormat `json:"format"`
+	MimeType string      `json:"mime_type"`
+}
+
+// SpeechStream is synthesized audio delivered as it is generated.
+// Read it like any io.Reader and close it when done.
+type SpeechStream struct {
+	io.ReadCloser
+
+	Format   AudioFormat
+	MimeType string
+}
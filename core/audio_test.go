@@ -0,0 +1,73 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAudioFormatValidation(t *testing.T) {
+	tests := []struct {
+		format AudioFormat
+		valid  bool
+		mime   string
+	}{
+		{AudioFormatMP3, true, "audio/mpeg"},
+		{AudioFormatOpus, true, "audio/ogg"},
+		{AudioFormatAAC, true, "audio/aac"},
+		{AudioFormatFLAC, true, "audio/flac"},
+		{AudioFormatWAV, true, "audio/wav"},
+		{AudioFormatPCM, true, "audio/pcm"},
+		{AudioFormat("midi"), false, "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.format.IsValid(); got != tt.valid {
+			t.Errorf("AudioFormat(%q).IsValid() = %v, want %v", tt.format, got, tt.valid)
+		}
+		if got := tt.format.MimeType(); got != tt.mime {
+			t.Errorf("AudioFormat(%q).MimeType() = %q, want %q", tt.format, got, tt.mime)
+		}
+	}
+}
+
+func TestTranscriptionRequestValidate(t *testing.T) {
+	audio := strings.NewReader("RIFF")
+	tests := []struct {
+		name string
+		req  TranscriptionRequest
+		want error
+	}{
+		{"valid", TranscriptionRequest{Model: "whisper-1", Audio: audio}, nil},
+		{"missing model", TranscriptionRequest{Audio: audio}, ErrModelRequired},
+		{"missing audio", TranscriptionRequest{Model: "whisper-1"}, ErrNoAudio},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpeechRequestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  SpeechRequest
+		want error
+	}{
+		{"valid", SpeechRequest{Model: "tts-1", Input: "Hello"}, nil},
+		{"missing model", SpeechRequest{Input: "Hello"}, ErrModelRequired},
+		{"missing input", SpeechRequest{Model: "tts-1"}, ErrNoSpeechInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// FeatureBatch indicates support for asynchronous batch jobs via BatchProvider.
+const FeatureBatch Feature = "batch"
+
+// BatchProvider is an optional interface for providers that run many chat
+// requests as one asynchronous job, typically at a discount. Jobs complete
+// within the provider's window (usually 24 hours); use WaitForBatch to poll.
+type BatchProvider interface {
+	// CreateBatch submits the requests as a new batch job.
+	CreateBatch(ctx context.Context, req *BatchRequest) (*BatchJob, error)
+
+	// GetBatch returns the current state of a batch job.
+	GetBatch(ctx context.Context, id string) (*BatchJob, error)
+
+	// CancelBatch requests cancellation of a batch job. Cancellation is
+	// asynchronous: the returned job is usually still cancelling, and
+	// items that already finished keep their results.
+	CancelBatch(ctx context.Context, id string) (*BatchJob, error)
+
+	// BatchResults returns the result of every finished item of a job that
+	// has reached a terminal status. Results are matched to items by
+	// CustomID and are not guaranteed to be in submission order.
+	BatchResults(ctx context.Context, id string) ([]BatchResult, error)
+}
+
+// BatchStatus is the lifecycle status of a batch job.
+type BatchStatus string
+
+const (
+	// BatchStatusPending means the job is queued or being validated.
+	BatchStatusPending BatchStatus = "pending"
+	// BatchStatusInProgress means items are being processed.
+	BatchStatusInProgress BatchStatus = "in_progress"
+	// BatchStatusCancelling means cancellation was requested and is in progress.
+	BatchStatusCancelling BatchStatus = "cancelling"
+	// BatchStatusCompleted means processing finished; individual items may still have failed.
+	BatchStatusCompleted BatchStatus = "completed"
+	// BatchStatusFailed means the job as a whole failed.
+	BatchStatusFailed BatchStatus = "failed"
+	// BatchStatusCancelled means the job was cancelled.
+	BatchStatusCancelled BatchStatus = "cancelled"
+	// BatchStatusExpired means the job did not finish within the provider's window.
+	BatchStatusExpired BatchStatus = "expired"
+)
+
+// IsTerminal reports whether the status is final. Results of a job can be
+// retrieved once it reaches a terminal status.
+func (s BatchStatus) IsTerminal() bool {
+	switch s {
+	case BatchStatusCompleted, BatchStatusFailed, BatchStatusCancelled, BatchStatusExpired:
+		return true
+	default:
+		return false
+	}
+}
+
+// BatchItem is a single chat request within a batch.
+type BatchItem struct {
+	// CustomID identifies the item in the results. It must be unique
+	// within the batch.
+	CustomID string `json:"custom_id"`
+
+	// Request is the chat request to run. Streaming is not available in batches.
+	Request *ChatRequest `json:"request"`
+}
+
+// BatchRequest represents a request to create a batch job.
+type BatchRequest struct {
+	// Items are the requests to run.
+	Items []BatchItem `json:"items"`
+
+	// Metadata is attached to the job where the provider supports it.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Batch validation errors.
+var (
+	ErrNoBatchItems     = errors.New("no batch items")
+	ErrInvalidBatchItem = errors.New("invalid batch item")
+)
+
+// Batch item errors, reported in BatchResult.Err for items that did not run.
+var (
+	ErrBatchItemCancelled = errors.New("batch item cancelled")
+	ErrBatchItemExpired   = errors.New("batch item expired")
+)
+
+// Validate checks that the batch has items, that every item has a unique
+// CustomID, and that every request names a model and has messages.
+// Providers call Validate before uploading anything.
+func (r *BatchRequest) Validate() error {
+	if len(r.Items) == 0 {
+		return ErrNoBatchItems
+	}
+
+	seen := make(map[string]bool, len(r.Items))
+	for i, item := range r.Items {
+		switch {
+		case item.CustomID == "":
+			return fmt.Errorf("%w: item %d has no custom ID", ErrInvalidBatchItem, i)
+		case seen[item.CustomID]:
+			return fmt.Errorf("%w: duplicate custom ID %q", ErrInvalidBatchItem, item.CustomID)
+		case item.Request == nil:
+			return fmt.Errorf("%w: item %q has no request", ErrInvalidBatchItem, item.CustomID)
+		case item.Request.Model == "":
+			return fmt.Errorf("%w: item %q: %w", ErrInvalidBatchItem, item.CustomID, ErrModelRequired)
+		case len(item.Request.Messages) == 0:
+			return fmt.Errorf("%w: item %q: %w", ErrInvalidBatchItem, item.CustomID, ErrNoMessages)
+		}
+		seen[item.CustomID] = true
+	}
+	return nil
+}
+
+// BatchCounts tracks the progress of a batch job's items.
+type BatchCounts struct {
+	Total     int `json:"total"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+// BatchJob describes a batch job.
+type BatchJob struct {
+	// ID identifies the job in BatchProvider calls.
+	ID string `json:"id"`
+
+	// Status is the lifecycle status of the job.
+	Status BatchStatus `json:"status"`
+
+	// Counts reports item progress, as far as the provider reports it.
+	Counts BatchCounts `json:"counts"`
+
+	// CreatedAt is when the job was created.
+	CreatedAt time.Time `json:"created_at"`
+
+	// EndedAt is when the job reached a terminal status, if it has.
+	EndedAt *time.Time `json:"ended_at,omitempty"`
+
+	// ExpiresAt is when the job or its results expire, if known.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Metadata is the metadata attached to the job.
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Errors explains why a job failed, if the provider reports it.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// BatchResult is the outcome of a single batch item.
+type BatchResult struct {
+	// CustomID matches the BatchItem the result belongs to.
+	CustomID string `json:"custom_id"`
+
+	// Response is the chat response. It is nil when Err is set.
+	Response *ChatResponse `json:"response,omitempty"`
+
+	// Err is the error for an item that failed, was cancelled, or expired.
+	// Provider errors are *ProviderError values wrapping the usual sentinels.
+	Err error `json:"-"`
+}
+
+// BatchPollConfig configures WaitForBatch.
+type BatchPollConfig struct {
+	InitialInterval time.Duration // Delay before the second poll (default: 5s)
+	MaxInterval     time.Duration // Maximum delay between polls (default: 5m)
+	Multiplier      float64       // Growth factor between polls (default: 1.5)
+}
+
+// WaitForBatch polls a batch job until it reaches a terminal status or ctx
+// is done. The interval between polls starts at cfg.InitialInterval and
+// grows by cfg.Multiplier up to cfg.MaxInterval. Transient errors while
+// polling (see RetryPolicy) are retried on the same schedule. It returns
+// the last job state on success.
+func WaitForBatch(ctx context.Context, p BatchProvider, id string, cfg BatchPollConfig) (*BatchJob, error) {
+	if cfg.InitialInterval <= 0 {
+		cfg.InitialInterval = 5 * time.Second
+	}
+	if cfg.MaxInterval <= 0 {
+		cfg.MaxInterval = 5 * time.Minute
+	}
+	if cfg.Multiplier < 1 {
+		cfg.Multiplier = 1.5
+	}
+
+	interval := cfg.InitialInterval
+	for {
+		job, err := p.GetBatch(ctx, id)
+		if err != nil && !isRetryable(err) {
+			return nil, err
+		}
+		if err == nil && job.Status.IsTerminal() {
+			return job, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		interval = min(time.Duration(float64(interval)*cfg.Multiplier), cfg.MaxInterval)
+	}
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// mockBatchProvider implements BatchProvider for testing. GetBatch returns
+// the next entry of statuses (or errs) on each call.
+type mockBatchProvider struct {
+	statuses []BatchStatus
+	errs     []error
+	calls    int
+}
+
+func (m *mockBatchProvider) CreateBatch(ctx context.Context, req *BatchRequest) (*BatchJob, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return &BatchJob{ID: "batch-1", Status: BatchStatusPending}, nil
+}
+
+func (m *mockBatchProvider) GetBatch(ctx context.Context, id string) (*BatchJob, error) {
+	i := min(m.calls, len(m.statuses)-1)
+	m.calls++
+	if i < len(m.errs) && m.errs[i] != nil {
+		return nil, m.errs[i]
+	}
+	return &BatchJob{ID: id, Status: m.statuses[i]}, nil
+}
+
+func (m *mockBatchProvider) CancelBatch(ctx context.Context, id string) (*BatchJob, error) {
+	return &BatchJob{ID: id, Status: BatchStatusCancelling}, nil
+}
+
+func (m *mockBatchProvider) BatchResults(ctx context.Context, id string) ([]BatchResult, error) {
+	return nil, nil
+}
+
+func validBatchItem(id string) BatchItem {
+	return BatchItem{CustomID: id, Request: &ChatRequest{
+		Model:    "test-model",
+		Messages: []Message{{Role: RoleUser, Content: "hi"}},
+	}}
+}
+
+func TestBatchRequestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []BatchItem
+		want  error
+	}{
+		{"valid", []BatchItem{validBatchItem("a"), validBatchItem("b")}, nil},
+		{"empty", nil, ErrNoBatchItems},
+		{"missing custom ID", []BatchItem{validBatchItem("")}, ErrInvalidBatchItem},
+		{"duplicate custom ID", []BatchItem{validBatchItem("a"), validBatchItem("a")}, ErrInvalidBatchItem},
+		{"nil request", []BatchItem{{CustomID: "a"}}, ErrInvalidBatchItem},
+		{"missing model", []BatchItem{{CustomID: "a", Request: &ChatRequest{
+			Messages: []Message{{Role: RoleUser, Content: "hi"}},
+		}}}, ErrModelRequired},
+		{"no messages", []BatchItem{{CustomID: "a", Request: &ChatRequest{Model: "m"}}}, ErrNoMessages},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&BatchRequest{Items: tt.items}).Validate()
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchStatusIsTerminal(t *testing.T) {
+	terminal := map[BatchStatus]bool{
+		BatchStatusPending:    false,
+		BatchStatusInProgress: false,
+		BatchStatusCancelling: false,
+		BatchStatusCompleted:  true,
+		BatchStatusFailed:     true,
+		BatchStatusCancelled:  true,
+		BatchStatusExpired:    true,
+	}
+	for status, want := range terminal {
+		if got := status.IsTerminal(); got != want {
+			t.Errorf("%s.IsTerminal() = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestWaitForBatch(t *testing.T) {
+	provider := &mockBatchProvider{
+		statuses: []BatchStatus{BatchStatusPending, BatchStatusInProgress, BatchStatusInProgress, BatchStatusCompleted},
+		errs:     []error{nil, ErrServer},
+	}
+	cfg := BatchPollConfig{InitialInterval: time.Millisecond, MaxInterval: 2 * time.Millisecond}
+
+	job, err := WaitForBatch(context.Background(), provider, "batch-1", cfg)
+	if err != nil {
+		t.Fatalf("WaitForBatch() error = %v", err)
+	}
+	if job.Status != BatchStatusCompleted {
+		t.Errorf("Status = %s, want completed", job.Status)
+	}
+	if provider.calls != 4 {
+		t.Errorf("GetBatch calls = %d, want 4", provider.calls)
+	}
+}
+
+func TestWaitForBatchPermanentError(t *testing.T) {
+	provider := &mockBatchProvider{
+		statuses: []BatchStatus{BatchStatusPending},
+		errs:     []error{ErrUnauthorized},
+	}
+
+	_, err := WaitForBatch(context.Background(), provider, "batch-1", BatchPollConfig{InitialInterval: time.Millisecond})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("WaitForBatch() error = %v, want ErrUnauthorized", err)
+	}
+	if provider.calls != 1 {
+		t.Errorf("GetBatch calls = %d, want 1", provider.calls)
+	}
+}
+
+func TestWaitForBatchContextDone(t *testing.T) {
+	provider := &mockBatchProvider{statuses: []BatchStatus{BatchStatusInProgress}}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := WaitForBatch(ctx, provider, "batch-1", BatchPollConfig{InitialInterval: time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForBatch() error = %v, want DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,118 @@
+package core
+
+import "fmt"
+
+// UnsupportedFeatureError reports a request that uses a feature or input
+// modality the model does not support. It is returned by ChatBuilder before
+// the request is sent, and matches ErrNotSupported with errors.Is.
+type UnsupportedFeatureError struct {
+	Provider string
+	Model    ModelID
+	Feature  Feature  // Set when a request option is unsupported
+	Modality Modality // Set when message content is unsupported
+}
+
+// Error implements the error interface.
+func (e *UnsupportedFeatureError) Error() string {
+	if e.Modality != "" {
+		return fmt.Sprintf("%s: model %s does not accept %s input", e.Provider, e.Model, e.Modality)
+	}
+	return fmt.Sprintf("%s: model %s does not support %s", e.Provider, e.Model, e.Feature)
+}
+
+// Unwrap returns ErrNotSupported.
+func (e *UnsupportedFeatureError) Unwrap() error {
+	return ErrNotSupported
+}
+
+// WithoutCapabilityValidation disables the capability checks ChatBuilder
+// runs before sending a request. Use it when model metadata is missing or
+// out of date, such as for fine-tuned or newly released models.
+func WithoutCapabilityValidation() ClientOption {
+	return func(c *Client) {
+		c.skipCapabilities = true
+	}
+}
+
+// SkipCapabilityValidation disables the capability checks for this request.
+func (b *ChatBuilder) SkipCapabilityValidation() *ChatBuilder {
+	b.skipCapabilities = true
+	return b
+}
+
+// requiredFeatures returns the features the request depends on.
+func (r *ChatRequest) requiredFeatures() []Feature {
+	var features []Feature
+	if len(r.Tools) > 0 {
+		features = append(features, FeatureToolCalling)
+	}
+	if r.ReasoningEffort != "" && r.ReasoningEffort != ReasoningEffortNone {
+		features = append(features, FeatureReasoning)
+	}
+	if len(r.BuiltInTools) > 0 {
+		features = append(features, FeatureBuiltInTools)
+	}
+	if r.PreviousResponseID != "" {
+		features = append(features, FeatureResponseChain)
+	}
+	return features
+}
+
+// partModality returns the input modality of a content part.
+func partModality(p ContentPart) Modality {
+	switch p.(type) {
+	case InputImage, *InputImage:
+		return ModalityImage
+	case InputFile, *InputFile:
+		return ModalityDocument
+	default:
+		return ModalityText
+	}
+}
+
+// checkCapabilities reports the first feature or modality in the request
+// that the model does not support. Models with listed capabilities are
+// checked against them; other models only need the provider to offer the
+// feature on some model. Modalities are checked only when they are known.
+func (b *ChatBuilder) checkCapabilities() error {
+	if b.skipCapabilities || b.client.skipCapabilities {
+		return nil
+	}
+
+	p := b.client.provider
+	info, _ := b.client.ModelInfo(b.req.Model)
+	for _, f := range b.req.requiredFeatures() {
+		supported := info.HasCapability(f)
+		if len(info.Capabilities) == 0 {
+			supported = providerOffers(p, f)
+		}
+		if !supported {
+			return &UnsupportedFeatureError{Provider: p.ID(), Model: b.req.Model, Feature: f}
+		}
+	}
+
+	if len(info.InputModalities) > 0 {
+		for _, msg := range b.req.Messages {
+			for _, part := range msg.Parts {
+				if m := partModality(part); !info.AcceptsInput(m) {
+					return &UnsupportedFeatureError{Provider: p.ID(), Model: b.req.Model, Modality: m}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// providerOffers reports whether the provider supports f itself or lists a
+// model that does.
+func providerOffers(p Provider, f Feature) bool {
+	if p.Supports(f) {
+		return true
+	}
+	for _, m := range p.Models() {
+		if m.HasCapability(f) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// capabilityProvider is a mockProvider with a configurable model list and
+// feature set.
+type capabilityProvider struct {
+	mockProvider
+	models   []ModelInfo
+	features []Feature
+}
+
+func (p *capabilityProvider) Models() []ModelInfo { return p.models }
+
+func (p *capabilityProvider) Supports(f Feature) bool {
+	for _, feature := range p.features {
+		if feature == f {
+			return true
+		}
+	}
+	return false
+}
+
+func newCapabilityProvider() *capabilityProvider {
+	return &capabilityProvider{
+		mockProvider: mockProvider{id: "test"},
+		models: []ModelInfo{
+			{ID: "basic", Capabilities: []Feature{FeatureChat, FeatureChatStreaming}},
+			{ID: "smart", Capabilities: []Feature{FeatureChat, FeatureToolCalling, FeatureReasoning}},
+		},
+		features: []Feature{FeatureChat, FeatureChatStreaming, FeatureToolCalling},
+	}
+}
+
+func TestCapabilityValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(c *Client) *ChatBuilder
+		feature Feature
+	}{
+		{
+			name:    "tools on model without tool calling",
+			build:   func(c *Client) *ChatBuilder { return c.Chat("basic").User("hi").Tools(&mockTool{name: "t"}) },
+			feature: FeatureToolCalling,
+		},
+		{
+			name:    "reasoning effort on non-reasoning model",
+			build:   func(c *Client) *ChatBuilder { return c.Chat("basic").User("hi").ReasoningEffort(ReasoningEffortHigh) },
+			feature: FeatureReasoning,
+		},
+		{
+			name:    "built-in tools on provider without them",
+			build:   func(c *Client) *ChatBuilder { return c.Chat("smart").User("hi").WebSearch() },
+			feature: FeatureBuiltInTools,
+		},
+		{
+			name:    "response chaining on provider without it",
+			build:   func(c *Client) *ChatBuilder { return c.Chat("smart").User("hi").ContinueFrom("resp_1") },
+			feature: FeatureResponseChain,
+		},
+		{
+			name:    "unknown model on provider without built-in tools",
+			build:   func(c *Client) *ChatBuilder { return c.Chat("custom").User("hi").CodeInterpreter() },
+			feature: FeatureBuiltInTools,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newCapabilityProvider()
+			_, err := tt.build(NewClient(p)).GetResponse(context.Background())
+
+			var featErr *UnsupportedFeatureError
+			if !errors.As(err, &featErr) {
+				t.Fatalf("GetResponse() error = %v, want *UnsupportedFeatureError", err)
+			}
+			if featErr.Feature != tt.feature || featErr.Provider != "test" {
+				t.Errorf("error = %+v, want feature %s", featErr, tt.feature)
+			}
+			if !errors.Is(err, ErrNotSupported) {
+				t.Error("error should match ErrNotSupported")
+			}
+			if p.callCount != 0 {
+				t.Error("request was sent to the provider")
+			}
+		})
+	}
+}
+
+func TestCapabilityValidationAllowsSupportedFeatures(t *testing.T) {
+	c := NewClient(newCapabilityProvider())
+	ctx := context.Background()
+
+	builders := map[string]*ChatBuilder{
+		"tools and reasoning": c.Chat("smart").User("hi").Tools(&mockTool{name: "t"}).ReasoningEffort(ReasoningEffortLow),
+		"reasoning disabled":  c.Chat("basic").User("hi").ReasoningEffort(ReasoningEffortNone),
+		"unknown model":       c.Chat("custom").User("hi").Tools(&mockTool{name: "t"}).ReasoningEffort(ReasoningEffortHigh),
+		"skipped":             c.Chat("basic").User("hi").WebSearch().SkipCapabilityValidation(),
+	}
+	for name, b := range builders {
+		if _, err := b.GetResponse(ctx); err != nil {
+			t.Errorf("%s: GetResponse() error = %v", name, err)
+		}
+	}
+
+	unchecked := NewClient(newCapabilityProvider(), WithoutCapabilityValidation())
+	if _, err := unchecked.Chat("basic").User("hi").ContinueFrom("resp_1").GetResponse(ctx); err != nil {
+		t.Errorf("WithoutCapabilityValidation: GetResponse() error = %v", err)
+	}
+}
+
+func TestBackgroundRequiresResponsesAPI(t *testing.T) {
+	p := newCapabilityProvider()
+	p.models = append(p.models, ModelInfo{ID: "responses", APIEndpoint: APIEndpointResponses, Capabilities: []Feature{FeatureChat}})
+	c := NewClient(p)
+	ctx := context.Background()
+
+	for name, b := range map[string]*ChatBuilder{
+		"background": c.Chat("basic").User("hi").Background(),
+		"store":      c.Chat("basic").User("hi").Store(false),
+	} {
+		if _, err := b.GetResponse(ctx); !errors.Is(err, ErrResponsesAPIRequired) {
+			t.Errorf("%s: GetResponse() error = %v, want ErrResponsesAPIRequired", name, err)
+		}
+	}
+
+	// Responses API models, and unknown models the provider routes itself
+	for _, model := range []ModelID{"responses", "custom"} {
+		if _, err := c.Chat(model).User("hi").Background().Store(true).GetResponse(ctx); err != nil {
+			t.Errorf("%s: GetResponse() error = %v", model, err)
+		}
+	}
+}
+
+func TestCapabilityValidationModalities(t *testing.T) {
+	p := newCapabilityProvider()
+	c := NewClient(p, WithModelCatalog(mapCatalog{
+		"test/basic": {InputModalities: []Modality{ModalityText}},
+		"test/smart": {InputModalities: []Modality{ModalityText, ModalityImage}},
+	}))
+	ctx := context.Background()
+
+	_, err := c.Chat("basic").UserWithImageURL("what is this?", "https://example.com/cat.png").Stream(ctx)
+	var featErr *UnsupportedFeatureError
+	if !errors.As(err, &featErr) || featErr.Modality != ModalityImage {
+		t.Fatalf("Stream() error = %v, want unsupported image input", err)
+	}
+
+	if _, err := c.Chat("smart").UserWithImageURL("what is this?", "https://example.com/cat.png").GetResponse(ctx); err != nil {
+		t.Errorf("image on vision model: GetResponse() error = %v", err)
+	}
+
+	_, err = c.Chat("smart").UserWithFileURL("summarize", "https://example.com/doc.pdf").GetResponse(ctx)
+	if !errors.As(err, &featErr) || featErr.Modality != ModalityDocument {
+		t.Errorf("GetResponse() error = %v, want unsupported document input", err)
+	}
+
+	// Without modality metadata, content is not checked
+	if _, err := NewClient(p).Chat("basic").UserWithImageURL("hi", "https://example.com/cat.png").GetResponse(ctx); err != nil {
+		t.Errorf("no modality metadata: GetResponse() error = %v", err)
+	}
+}
+
+func TestUnsupportedFeatureErrorMessage(t *testing.T) {
+	err := &UnsupportedFeatureError{Provider: "anthropic", Model: "claude-haiku-4-5", Feature: FeatureBuiltInTools}
+	if got, want := err.Error(), "anthropic: model claude-haiku-4-5 does not support builtin_tools"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	err = &UnsupportedFeatureError{Provider: "openai", Model: "gpt-3.5-turbo", Modality: ModalityImage}
+	if got, want := err.Error(), "openai: model gpt-3.5-turbo does not accept image input"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
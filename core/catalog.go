@@ -0,0 +1,30 @@
+package core
+
+// ModelCatalog supplies model metadata beyond what providers report, such
+// as context windows, modalities, and pricing. The catalog package provides
+// an implementation backed by an embedded, user-extensible YAML file.
+type ModelCatalog interface {
+	// Lookup returns the catalog entry for a provider's model. Zero fields
+	// in the entry are unknown and leave the provider's values in place.
+	Lookup(provider string, id ModelID) (ModelInfo, bool)
+}
+
+// WithModelCatalog sets the catalog consulted by Client.ModelInfo, in
+// place of the default catalog. A nil catalog turns catalog lookups off.
+func WithModelCatalog(cat ModelCatalog) ClientOption {
+	return func(c *Client) {
+		c.catalog = cat
+	}
+}
+
+// defaultCatalog is the catalog clients consult unless WithModelCatalog
+// says otherwise.
+var defaultCatalog ModelCatalog
+
+// SetDefaultModelCatalog sets the catalog consulted by clients created
+// without WithModelCatalog. The catalog package registers its embedded
+// catalog when it is linked in, which every provider package does, so that
+// capability checks know the models' input modalities.
+func SetDefaultModelCatalog(cat ModelCatalog) {
+	defaultCatalog = cat
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
@@ -44,6 +45,9 @@ type Client struct {
 	provider  Provider
 	telemetry TelemetryHook
 	retry     RetryPolicy
+	catalog   ModelCatalog
+
+	skipCapabilities bool
 }
 
 // ClientOption configures a Client.
@@ -55,6 +59,7 @@ func NewClient(p Provider, opts ...ClientOption) *Client {
 		provider:  p,
 		telemetry: NoopTelemetryHook{},
 		retry:     DefaultRetryPolicy(),
+		catalog:   defaultCatalog,
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -85,6 +90,27 @@ func (c *Client) Provider() Provider {
 	return c.provider
 }
 
+// ModelInfo returns what is known about a model: the provider's entry from
+// Models, with any catalog metadata (see WithModelCatalog) layered on top.
+// It reports false if neither knows the model.
+func (c *Client) ModelInfo(id ModelID) (ModelInfo, bool) {
+	var info ModelInfo
+	found := false
+	for _, m := range c.provider.Models() {
+		if m.ID == id {
+			info, found = m, true
+			break
+		}
+	}
+
+	if c.catalog != nil {
+		if entry, ok := c.catalog.Lookup(c.provider.ID(), id); ok {
+			info, found = info.WithOverrides(entry), true
+		}
+	}
+	return info, found
+}
+
 // Chat returns a ChatBuilder for constructing and executing a chat request.
 func (c *Client) Chat(model ModelID) *ChatBuilder {
 	return &ChatBuilder{
@@ -100,6 +126,8 @@ func (c *Client) Chat(model ModelID) *ChatBuilder {
 type ChatBuilder struct {
 	client *Client
 	req    ChatRequest
+
+	skipCapabilities bool
 }
 
 // System appends a system message.
@@ -108,6 +136,13 @@ func (b *ChatBuilder) System(s string) *ChatBuilder {
 	return b
 }
 
+// PrependSystem inserts a system message ahead of every other message, for
+// helpers that add instructions to a conversation built elsewhere.
+func (b *ChatBuilder) PrependSystem(s string) *ChatBuilder {
+	b.req.Messages = append([]Message{{Role: RoleSystem, Content: s}}, b.req.Messages...)
+	return b
+}
+
 // User appends a user message.
 func (b *ChatBuilder) User(s string) *ChatBuilder {
 	b.req.Messages = append(b.req.Messages, Message{Role: RoleUser, Content: s})
@@ -191,13 +226,49 @@ func (b *ChatBuilder) ContinueFrom(responseID string) *ChatBuilder {
 	return b
 }
 
+// ContinueWith continues the conversation from a previous response. A
+// response with Items, such as a stateless one created with Store(false),
+// is replayed from them as an assistant message, so the request must also carry the
+// messages that preceded it; any other response is chained by ID as with
+// ContinueFrom.
+func (b *ChatBuilder) ContinueWith(resp *ChatResponse) *ChatBuilder {
+	if len(resp.Items) == 0 {
+		return b.ContinueFrom(resp.ID)
+	}
+	b.req.Messages = append(b.req.Messages, Message{
+		Role:    RoleAssistant,
+		Content: resp.Output,
+		Items:   resp.Items,
+	})
+	return b
+}
+
 // Truncation sets the truncation mode for the request.
 func (b *ChatBuilder) Truncation(mode string) *ChatBuilder {
 	b.req.Truncation = mode
 	return b
 }
 
-// validate checks that the request is valid.
+// Background runs the request asynchronously. The response is returned
+// while still queued; its ID can be used to poll for or stream the result.
+// Only models served by the Responses API run in the background; for
+// others the request fails with ErrResponsesAPIRequired.
+func (b *ChatBuilder) Background() *ChatBuilder {
+	b.req.Background = true
+	return b
+}
+
+// Store sets whether the provider keeps the response for later retrieval
+// and chaining. Store(false) makes the request stateless: the response
+// carries its output items, and ContinueWith replays them. Like
+// Background, it requires a model served by the Responses API.
+func (b *ChatBuilder) Store(store bool) *ChatBuilder {
+	b.req.Store = &store
+	return b
+}
+
+// validate checks that the request is valid and that the model supports
+// the features it uses.
 func (b *ChatBuilder) validate() error {
 	if b.req.Model == "" {
 		return ErrModelRequired
@@ -213,7 +284,23 @@ func (b *ChatBuilder) validate() error {
 		}
 	}
 
-	return nil
+	if (b.req.Background || b.req.Store != nil) && !b.usesResponsesAPI() {
+		return fmt.Errorf("%w: %s", ErrResponsesAPIRequired, b.req.Model)
+	}
+
+	return b.checkCapabilities()
+}
+
+// usesResponsesAPI reports whether the model may be served by the
+// Responses API. Models whose endpoint is unknown, because neither the
+// provider nor the catalog describes it, are left to the provider to
+// decide.
+func (b *ChatBuilder) usesResponsesAPI() bool {
+	info, _ := b.client.ModelInfo(b.req.Model)
+	if info.APIEndpoint == "" && len(info.Capabilities) == 0 {
+		return true
+	}
+	return info.GetAPIEndpoint() == APIEndpointResponses
 }
 
 // GetResponse executes the chat request and returns the response.
@@ -309,7 +396,7 @@ func (b *ChatBuilder) Stream(ctx context.Context) (*ChatStream, error) {
 	}
 
 	// Wrap the stream to emit telemetry when it completes
-	return wrapStreamWithTelemetry(stream, b.client.telemetry, providerID, b.req.Model, start), nil
+	return wrapStreamWithTelemetry(ctx, stream, b.client.telemetry, providerID, b.req.Model, start), nil
 }
 
 // MessageBuilder provides a fluent API for building multimodal messages.
@@ -425,7 +512,10 @@ func (b *ChatBuilder) UserWithFileID(text, fileID string) *ChatBuilder {
 }
 
 // wrapStreamWithTelemetry wraps a ChatStream to emit telemetry on completion.
+// If the provider never finishes the stream, it ends with ctx's error once
+// ctx is done.
 func wrapStreamWithTelemetry(
+	ctx context.Context,
 	stream *ChatStream,
 	hook TelemetryHook,
 	provider string,
@@ -450,10 +540,26 @@ func wrapStreamWithTelemetry(
 				finalCh <- resp
 			}
 		case err, ok := <-stream.Err:
-			if ok {
+			if ok && err != nil {
 				finalErr = err
 				errCh <- err
+			} else {
+				// Err may close without an error before Final is read; a
+				// closed Final means the stream ended without a response
+				select {
+				case resp, ok := <-stream.Final:
+					if ok {
+						finalResp = resp
+						finalCh <- resp
+					}
+				case <-ctx.Done():
+					finalErr = ctx.Err()
+					errCh <- finalErr
+				}
 			}
+		case <-ctx.Done():
+			finalErr = ctx.Err()
+			errCh <- finalErr
 		}
 
 		// Emit telemetry end
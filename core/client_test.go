@@ -2,7 +2,9 @@ package core
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -128,6 +130,43 @@ func TestNewClientWithOptions(t *testing.T) {
 	}
 }
 
+// mapCatalog is a ModelCatalog backed by a map keyed by provider and model.
+type mapCatalog map[string]ModelInfo
+
+func (c mapCatalog) Lookup(provider string, id ModelID) (ModelInfo, bool) {
+	m, ok := c[provider+"/"+string(id)]
+	return m, ok
+}
+
+func TestClientModelInfo(t *testing.T) {
+	p := &mockProvider{id: "test"}
+
+	info, ok := NewClient(p).ModelInfo("mock-model")
+	if !ok || info.DisplayName != "Mock Model" {
+		t.Errorf("ModelInfo(mock-model) = %+v, %v", info, ok)
+	}
+	if _, ok := NewClient(p).ModelInfo("other"); ok {
+		t.Error("ModelInfo(other) found without a catalog")
+	}
+
+	c := NewClient(p, WithModelCatalog(mapCatalog{
+		"test/mock-model":  {ContextWindow: 32000},
+		"test/other":       {ID: "other", ContextWindow: 8000},
+		"elsewhere/absent": {ID: "absent"},
+	}))
+
+	info, ok = c.ModelInfo("mock-model")
+	if !ok || info.DisplayName != "Mock Model" || info.ContextWindow != 32000 || !info.HasCapability(FeatureChat) {
+		t.Errorf("ModelInfo(mock-model) = %+v, want provider entry with catalog metadata", info)
+	}
+	if info, ok := c.ModelInfo("other"); !ok || info.ContextWindow != 8000 {
+		t.Errorf("ModelInfo(other) = %+v, %v", info, ok)
+	}
+	if _, ok := c.ModelInfo("absent"); ok {
+		t.Error("ModelInfo(absent) found another provider's catalog entry")
+	}
+}
+
 func TestChatBuilderFluentAPI(t *testing.T) {
 	p := &mockProvider{id: "test"}
 	c := NewClient(p)
@@ -154,6 +193,50 @@ func TestChatBuilderFluentAPI(t *testing.T) {
 	}
 }
 
+func TestChatBuilderBackgroundAndStore(t *testing.T) {
+	c := NewClient(&mockProvider{id: "test"})
+
+	builder := c.Chat("gpt-5").User("Hello")
+	if builder.req.Background || builder.req.Store != nil {
+		t.Error("Background and Store should be unset by default")
+	}
+
+	builder.Background().Store(false)
+	if !builder.req.Background {
+		t.Error("Background = false, want true")
+	}
+	if builder.req.Store == nil || *builder.req.Store {
+		t.Errorf("Store = %v, want false", builder.req.Store)
+	}
+}
+
+func TestChatBuilderContinueWith(t *testing.T) {
+	c := NewClient(&mockProvider{id: "test"})
+
+	stored := &ChatResponse{ID: "resp_1", Output: "4"}
+	builder := c.Chat("gpt-5").User("What is 2+2?").ContinueWith(stored)
+	if builder.req.PreviousResponseID != "resp_1" || len(builder.req.Messages) != 1 {
+		t.Errorf("request = %+v, want chaining by ID", builder.req)
+	}
+
+	stateless := &ChatResponse{
+		ID:     "resp_2",
+		Output: "4",
+		Items:  []ResponseItem{{Type: "reasoning", Raw: json.RawMessage(`{"type":"reasoning"}`)}},
+	}
+	builder = c.Chat("gpt-5").User("What is 2+2?").ContinueWith(stateless).User("And times 3?")
+	if builder.req.PreviousResponseID != "" {
+		t.Errorf("PreviousResponseID = %q, want empty", builder.req.PreviousResponseID)
+	}
+	if len(builder.req.Messages) != 3 {
+		t.Fatalf("len(Messages) = %d, want 3", len(builder.req.Messages))
+	}
+	replay := builder.req.Messages[1]
+	if replay.Role != RoleAssistant || replay.Content != "4" || len(replay.Items) != 1 {
+		t.Errorf("Messages[1] = %+v, want assistant message with items", replay)
+	}
+}
+
 func TestChatBuilderMessageOrder(t *testing.T) {
 	p := &mockProvider{id: "test"}
 	c := NewClient(p)
@@ -189,6 +272,21 @@ func TestChatBuilderMessageOrder(t *testing.T) {
 	}
 }
 
+func TestChatBuilderPrependSystem(t *testing.T) {
+	b := NewClient(&mockProvider{id: "test"}).Chat("gpt-4").
+		System("Be brief.").
+		User("Hi").
+		PrependSystem("Reply in JSON.")
+
+	var roles []string
+	for _, msg := range b.req.Messages {
+		roles = append(roles, string(msg.Role)+":"+msg.Content)
+	}
+	if got := strings.Join(roles, ","); got != "system:Reply in JSON.,system:Be brief.,user:Hi" {
+		t.Errorf("Messages = %s", got)
+	}
+}
+
 func TestGetResponseValidationModelRequired(t *testing.T) {
 	p := &mockProvider{id: "test"}
 	c := NewClient(p)
@@ -426,6 +524,71 @@ func TestStreamTelemetry(t *testing.T) {
 	}
 }
 
+func TestStreamFinalAfterErrClosed(t *testing.T) {
+	// A stream whose Err is already closed when Final is read
+	p := &mockProvider{
+		id: "test",
+		streamFunc: func(ctx context.Context, req *ChatRequest) (*ChatStream, error) {
+			ch := make(chan ChatChunk)
+			errCh := make(chan error)
+			finalCh := make(chan *ChatResponse, 1)
+			finalCh <- &ChatResponse{Output: "done"}
+			close(ch)
+			close(errCh)
+			close(finalCh)
+			return &ChatStream{Ch: ch, Err: errCh, Final: finalCh}, nil
+		},
+	}
+	c := NewClient(p)
+
+	for i := 0; i < 20; i++ {
+		stream, err := c.Chat("gpt-4").User("Hello").Stream(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp, err := DrainStream(context.Background(), stream)
+		if err != nil || resp == nil || resp.Output != "done" {
+			t.Fatalf("DrainStream() = %+v, %v, want final response", resp, err)
+		}
+	}
+}
+
+func TestStreamErrClosedWithoutFinal(t *testing.T) {
+	// A provider that closes Err but never sends or closes Final
+	p := &mockProvider{
+		id: "test",
+		streamFunc: func(ctx context.Context, req *ChatRequest) (*ChatStream, error) {
+			ch := make(chan ChatChunk)
+			errCh := make(chan error)
+			close(ch)
+			close(errCh)
+			return &ChatStream{Ch: ch, Err: errCh, Final: make(chan *ChatResponse)}, nil
+		},
+	}
+	c := NewClient(p)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	stream, err := c.Chat("gpt-4").User("Hello").Stream(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for range stream.Ch {
+	}
+	select {
+	case resp, ok := <-stream.Final:
+		if ok {
+			t.Errorf("Final = %+v, want closed", resp)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Final hung after Err closed")
+	}
+	if err := <-stream.Err; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
 func TestClientConcurrentUse(t *testing.T) {
 	p := &mockProvider{id: "test"}
 	c := NewClient(p)
@@ -46,4 +46,8 @@ var (
 var (
 	ErrModelRequired = errors.New("model required")
 	ErrNoMessages    = errors.New("no messages")
+
+	// ErrResponsesAPIRequired is returned for a Background or Store
+	// request to a model that does not use the Responses API.
+	ErrResponsesAPIRequired = errors.New("background and store require a Responses API model")
 )
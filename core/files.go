@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// FeatureFiles indicates support for the Files API via FileProvider.
+const FeatureFiles Feature = "files"
+
+// FileProvider is an optional interface for providers that store uploaded
+// files. Files are referenced in chat requests by ID, for example with
+// MessageBuilder.FileID, and the ID returned by CreateFile works the same
+// way with every provider.
+type FileProvider interface {
+	// CreateFile uploads a file, streaming its content from req.File.
+	CreateFile(ctx context.Context, req *FileUploadRequest) (*File, error)
+
+	// RetrieveFile returns metadata for a file.
+	RetrieveFile(ctx context.Context, id string) (*File, error)
+
+	// RetrieveFileContent returns the content of a file.
+	// The caller is responsible for closing the returned ReadCloser.
+	// Not every file can be downloaded: some providers only serve files
+	// they generated, and providers without downloads return ErrNotSupported.
+	RetrieveFileContent(ctx context.Context, id string) (io.ReadCloser, error)
+
+	// EnumerateFiles lists files, following pagination until all matching
+	// files are returned or req.Limit is reached.
+	EnumerateFiles(ctx context.Context, req *FileListRequest) ([]File, error)
+
+	// RemoveFile deletes a file.
+	RemoveFile(ctx context.Context, id string) error
+
+	// WaitForFile blocks until the file is ready to be referenced in a
+	// request, processing fails, or ctx is done. Files that need no
+	// processing are returned immediately.
+	WaitForFile(ctx context.Context, id string) (*File, error)
+}
+
+// FileState is the processing state of an uploaded file.
+type FileState string
+
+const (
+	// FileStateProcessing means the file cannot be used yet.
+	FileStateProcessing FileState = "processing"
+	// FileStateReady means the file can be referenced in requests.
+	FileStateReady FileState = "ready"
+	// FileStateFailed means the provider could not process the file.
+	FileStateFailed FileState = "failed"
+)
+
+// FileUploadRequest represents a request to upload a file.
+type FileUploadRequest struct {
+	// File is the content to upload. It is read until EOF and is not
+	// buffered in memory as a whole.
+	File io.Reader
+
+	// Filename is the name of the file.
+	Filename string
+
+	// MimeType is the MIME type of the file. Providers that need one
+	// infer it from Filename when empty.
+	MimeType string
+
+	// Purpose is the intended use of the file, for providers that
+	// require one (e.g. "user_data", "batch" for OpenAI). Providers
+	// without purposes ignore it.
+	Purpose string
+}
+
+// FileListRequest controls EnumerateFiles.
+type FileListRequest struct {
+	// Purpose filters files by purpose where the provider supports it.
+	Purpose string
+
+	// Limit caps the number of files returned. Zero returns all files.
+	Limit int
+}
+
+// File describes an uploaded file.
+type File struct {
+	// ID identifies the file in FileProvider calls and in chat requests.
+	ID string `json:"id"`
+
+	// Filename is the name of the file.
+	Filename string `json:"filename,omitempty"`
+
+	// MimeType is the MIME type, if reported by the provider.
+	MimeType string `json:"mime_type,omitempty"`
+
+	// Bytes is the size of the file in bytes.
+	Bytes int64 `json:"bytes"`
+
+	// Purpose is the intended use of the file, if the provider has purposes.
+	Purpose string `json:"purpose,omitempty"`
+
+	// State is the processing state of the file.
+	State FileState `json:"state"`
+
+	// CreatedAt is when the file was uploaded.
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExpiresAt is when the provider deletes the file, if it expires.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
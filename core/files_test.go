@@ -0,0 +1,86 @@
+package core
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+// mockFileProvider implements FileProvider for testing.
+type mockFileProvider struct {
+	files map[string]File
+}
+
+func (m *mockFileProvider) CreateFile(ctx context.Context, req *FileUploadRequest) (*File, error) {
+	data, err := io.ReadAll(req.File)
+	if err != nil {
+		return nil, err
+	}
+	f := File{ID: "file-1", Filename: req.Filename, Bytes: int64(len(data)), State: FileStateReady}
+	m.files[f.ID] = f
+	return &f, nil
+}
+
+func (m *mockFileProvider) RetrieveFile(ctx context.Context, id string) (*File, error) {
+	f, ok := m.files[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &f, nil
+}
+
+func (m *mockFileProvider) RetrieveFileContent(ctx context.Context, id string) (io.ReadCloser, error) {
+	return nil, ErrNotSupported
+}
+
+func (m *mockFileProvider) EnumerateFiles(ctx context.Context, req *FileListRequest) ([]File, error) {
+	files := make([]File, 0, len(m.files))
+	for _, f := range m.files {
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+func (m *mockFileProvider) RemoveFile(ctx context.Context, id string) error {
+	delete(m.files, id)
+	return nil
+}
+
+func (m *mockFileProvider) WaitForFile(ctx context.Context, id string) (*File, error) {
+	return m.RetrieveFile(ctx, id)
+}
+
+func TestFileProvider_Interface(t *testing.T) {
+	var provider FileProvider = &mockFileProvider{files: map[string]File{}}
+	ctx := context.Background()
+
+	f, err := provider.CreateFile(ctx, &FileUploadRequest{
+		File:     strings.NewReader("hello"),
+		Filename: "hello.txt",
+	})
+	if err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+	if f.Bytes != 5 || f.State != FileStateReady {
+		t.Errorf("CreateFile() = %+v", f)
+	}
+
+	files, err := provider.EnumerateFiles(ctx, nil)
+	if err != nil || len(files) != 1 {
+		t.Fatalf("EnumerateFiles() = %v, %v", files, err)
+	}
+
+	if err := provider.RemoveFile(ctx, f.ID); err != nil {
+		t.Fatalf("RemoveFile() error = %v", err)
+	}
+	if _, err := provider.WaitForFile(ctx, f.ID); err != ErrNotFound {
+		t.Errorf("WaitForFile() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFeature_Files(t *testing.T) {
+	if FeatureFiles != "files" {
+		t.Errorf("FeatureFiles = %q, want files", FeatureFiles)
+	}
+}
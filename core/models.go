@@ -0,0 +1,171 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// FeatureModelListing indicates support for live model discovery via ModelLister.
+const FeatureModelListing Feature = "model_listing"
+
+// ModelLister is an optional interface for providers that can query their
+// API for the models currently available. Unlike Provider.Models, which
+// returns a static list, the result reflects the account and server the
+// provider is configured for.
+type ModelLister interface {
+	// ListModels returns the models reported by the provider's API, merged
+	// with its static capability metadata. Results may be cached.
+	ListModels(ctx context.Context) ([]ModelInfo, error)
+}
+
+// DefaultModelCacheTTL is how long providers cache ListModels results by default.
+const DefaultModelCacheTTL = 10 * time.Minute
+
+// ModelCache caches the result of a model listing for a fixed duration.
+// Concurrent callers share a single fetch. ModelCache is safe for concurrent use.
+type ModelCache struct {
+	ttl   time.Duration
+	fetch func(ctx context.Context) ([]ModelInfo, error)
+
+	mu        sync.Mutex
+	models    []ModelInfo
+	fetchedAt time.Time
+	inflight  *modelFetch
+}
+
+// modelFetch is a fetch in progress, shared by the callers waiting on it.
+type modelFetch struct {
+	done   chan struct{}
+	models []ModelInfo
+	err    error
+}
+
+// NewModelCache creates a cache that calls fetch at most once per ttl.
+// A ttl of zero or less disables caching, so every Get fetches, though
+// concurrent calls still share one fetch.
+func NewModelCache(ttl time.Duration, fetch func(ctx context.Context) ([]ModelInfo, error)) *ModelCache {
+	return &ModelCache{ttl: ttl, fetch: fetch}
+}
+
+// Get returns the cached models, fetching them if the cache is empty or
+// has expired. Failed fetches are not cached.
+//
+// Callers that miss the cache while a fetch is in progress wait for it
+// rather than starting another. The fetch runs without the caller's
+// cancellation, so one caller giving up does not fail the others; each
+// caller returns early with ctx's error when ctx ends.
+func (c *ModelCache) Get(ctx context.Context) ([]ModelInfo, error) {
+	c.mu.Lock()
+	if c.models != nil && c.ttl > 0 && time.Since(c.fetchedAt) < c.ttl {
+		models := copyModels(c.models)
+		c.mu.Unlock()
+		return models, nil
+	}
+	f := c.startLocked(ctx)
+	c.mu.Unlock()
+	return f.wait(ctx)
+}
+
+// Refresh fetches the models regardless of the cache and stores the result.
+// A fetch already in progress is shared rather than repeated.
+func (c *ModelCache) Refresh(ctx context.Context) ([]ModelInfo, error) {
+	c.mu.Lock()
+	f := c.startLocked(ctx)
+	c.mu.Unlock()
+	return f.wait(ctx)
+}
+
+// startLocked returns the fetch in progress, starting one if there is none.
+// The caller holds c.mu.
+func (c *ModelCache) startLocked(ctx context.Context) *modelFetch {
+	if c.inflight != nil {
+		return c.inflight
+	}
+	f := &modelFetch{done: make(chan struct{})}
+	c.inflight = f
+	go c.run(context.WithoutCancel(ctx), f)
+	return f
+}
+
+// run fetches the models and stores them unless the fetch failed or the
+// cache was invalidated meanwhile.
+func (c *ModelCache) run(ctx context.Context, f *modelFetch) {
+	models, err := c.fetch(ctx)
+	if err == nil && models == nil {
+		models = []ModelInfo{}
+	}
+	f.models, f.err = models, err
+
+	c.mu.Lock()
+	if c.inflight == f {
+		c.inflight = nil
+		if err == nil {
+			c.models = models
+			c.fetchedAt = time.Now()
+		}
+	}
+	c.mu.Unlock()
+	close(f.done)
+}
+
+// wait returns the result of the fetch, or ctx's error if ctx ends first.
+func (f *modelFetch) wait(ctx context.Context) ([]ModelInfo, error) {
+	select {
+	case <-f.done:
+		if f.err != nil {
+			return nil, f.err
+		}
+		return copyModels(f.models), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Invalidate clears the cache so the next Get fetches again. The result of
+// a fetch in progress is not stored.
+func (c *ModelCache) Invalidate() {
+	c.mu.Lock()
+	c.models = nil
+	c.inflight = nil
+	c.mu.Unlock()
+}
+
+func copyModels(models []ModelInfo) []ModelInfo {
+	result := make([]ModelInfo, len(models))
+	copy(result, models)
+	return result
+}
+
+// MergeModels combines models reported by a provider's API with its static
+// metadata. The live list decides which models are returned and in what
+// order; duplicates are dropped. A live model found in static takes its
+// capabilities and API endpoint from there, and its display name unless
+// static has none. Live models unknown to static are returned as given.
+func MergeModels(live, static []ModelInfo) []ModelInfo {
+	known := make(map[ModelID]ModelInfo, len(static))
+	for _, m := range static {
+		known[m.ID] = m
+	}
+
+	seen := make(map[ModelID]bool, len(live))
+	merged := make([]ModelInfo, 0, len(live))
+	for _, m := range live {
+		if seen[m.ID] {
+			continue
+		}
+		seen[m.ID] = true
+
+		if s, ok := known[m.ID]; ok {
+			if s.DisplayName == "" {
+				s.DisplayName = m.DisplayName
+			}
+			m = s
+		}
+		if m.DisplayName == "" {
+			m.DisplayName = string(m.ID)
+		}
+		merged = append(merged, m)
+	}
+	return merged
+}
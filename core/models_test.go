@@ -0,0 +1,192 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestModelCacheGet(t *testing.T) {
+	calls := 0
+	cache := NewModelCache(time.Hour, func(ctx context.Context) ([]ModelInfo, error) {
+		calls++
+		return []ModelInfo{{ID: "a"}}, nil
+	})
+
+	for i := 0; i < 3; i++ {
+		models, err := cache.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if len(models) != 1 || models[0].ID != "a" {
+			t.Fatalf("Get() = %v", models)
+		}
+		models[0].ID = "mutated"
+	}
+	if calls != 1 {
+		t.Errorf("fetch calls = %d, want 1", calls)
+	}
+
+	cache.Invalidate()
+	models, _ := cache.Get(context.Background())
+	if calls != 2 {
+		t.Errorf("fetch calls after Invalidate = %d, want 2", calls)
+	}
+	if models[0].ID != "a" {
+		t.Errorf("cached model mutated: %q", models[0].ID)
+	}
+
+	if _, err := cache.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("fetch calls after Refresh = %d, want 3", calls)
+	}
+}
+
+func TestModelCacheExpiry(t *testing.T) {
+	calls := 0
+	cache := NewModelCache(time.Millisecond, func(ctx context.Context) ([]ModelInfo, error) {
+		calls++
+		return nil, nil
+	})
+
+	models, err := cache.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if models == nil {
+		t.Error("Get() = nil, want empty slice")
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	cache.Get(context.Background())
+	if calls != 2 {
+		t.Errorf("fetch calls = %d, want 2", calls)
+	}
+}
+
+func TestModelCacheDisabled(t *testing.T) {
+	calls := 0
+	cache := NewModelCache(0, func(ctx context.Context) ([]ModelInfo, error) {
+		calls++
+		return []ModelInfo{{ID: "a"}}, nil
+	})
+
+	cache.Get(context.Background())
+	cache.Get(context.Background())
+	if calls != 2 {
+		t.Errorf("fetch calls = %d, want 2", calls)
+	}
+}
+
+func TestModelCacheErrorNotCached(t *testing.T) {
+	errFetch := errors.New("boom")
+	fail := true
+	cache := NewModelCache(time.Hour, func(ctx context.Context) ([]ModelInfo, error) {
+		if fail {
+			return nil, errFetch
+		}
+		return []ModelInfo{{ID: "a"}}, nil
+	})
+
+	if _, err := cache.Get(context.Background()); !errors.Is(err, errFetch) {
+		t.Fatalf("Get() error = %v, want %v", err, errFetch)
+	}
+
+	fail = false
+	models, err := cache.Get(context.Background())
+	if err != nil || len(models) != 1 {
+		t.Errorf("Get() = %v, %v; want one model", models, err)
+	}
+}
+
+func TestModelCacheSharedFetch(t *testing.T) {
+	var calls atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	cache := NewModelCache(time.Hour, func(ctx context.Context) ([]ModelInfo, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return []ModelInfo{{ID: "a"}}, nil
+	})
+
+	// The first caller gives up while the fetch is blocked
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		_, err := cache.Get(ctx)
+		errc <- err
+	}()
+	<-started
+
+	var wg sync.WaitGroup
+	results := make([][]ModelInfo, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = cache.Get(context.Background())
+		}()
+	}
+
+	cancel()
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Get() with canceled context error = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Get() did not return when its context was canceled")
+	}
+
+	// The lock is not held during the fetch, so this returns at once
+	if _, err := cache.Get(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Get() with canceled context error = %v, want context.Canceled", err)
+	}
+	close(release)
+	wg.Wait()
+
+	for i, models := range results {
+		if len(models) != 1 || models[0].ID != "a" {
+			t.Errorf("results[%d] = %v", i, models)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("fetch calls = %d, want 1", n)
+	}
+}
+
+func TestMergeModels(t *testing.T) {
+	static := []ModelInfo{
+		{ID: "known", DisplayName: "Known", Capabilities: []Feature{FeatureChat, FeatureReasoning}, APIEndpoint: APIEndpointResponses},
+		{ID: "unnamed", Capabilities: []Feature{FeatureEmbeddings}},
+		{ID: "retired", DisplayName: "Retired", Capabilities: []Feature{FeatureChat}},
+	}
+	live := []ModelInfo{
+		{ID: "new", Capabilities: []Feature{FeatureChat}},
+		{ID: "known", DisplayName: "known-live", Capabilities: []Feature{FeatureChat}},
+		{ID: "unnamed", DisplayName: "Unnamed Live"},
+		{ID: "new"},
+	}
+
+	got := MergeModels(live, static)
+	if len(got) != 3 {
+		t.Fatalf("MergeModels() returned %d models, want 3: %v", len(got), got)
+	}
+
+	if got[0].ID != "new" || got[0].DisplayName != "new" || !got[0].HasCapability(FeatureChat) {
+		t.Errorf("got[0] = %+v", got[0])
+	}
+	if got[1].DisplayName != "Known" || !got[1].HasCapability(FeatureReasoning) || got[1].APIEndpoint != APIEndpointResponses {
+		t.Errorf("got[1] = %+v, want static metadata", got[1])
+	}
+	if got[2].DisplayName != "Unnamed Live" || !got[2].HasCapability(FeatureEmbeddings) {
+		t.Errorf("got[2] = %+v", got[2])
+	}
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"context"
+	"errors"
+)
+
+// FeatureModeration indicates support for content moderation via Moderator.
+const FeatureModeration Feature = "moderation"
+
+// Moderator is an optional interface for providers that classify content
+// as potentially harmful.
+type Moderator interface {
+	// Moderate classifies each input, returning one result per input in
+	// the same order.
+	Moderate(ctx context.Context, req *ModerationRequest) (*ModerationResponse, error)
+}
+
+// ErrNoModerationInput indicates a moderation request without input.
+var ErrNoModerationInput = errors.New("no moderation input")
+
+// ModerationCategory is a normalized category of harmful content.
+// Provider categories are mapped onto these names, e.g. OpenAI's
+// "self-harm/intent" becomes ModerationSelfHarmIntent.
+type ModerationCategory string
+
+const (
+	ModerationHarassment            ModerationCategory = "harassment"
+	ModerationHarassmentThreatening ModerationCategory = "harassment_threatening"
+	ModerationHate                  ModerationCategory = "hate"
+	ModerationHateThreatening       ModerationCategory = "hate_threatening"
+	ModerationIllicit               ModerationCategory = "illicit"
+	ModerationIllicitViolent        ModerationCategory = "illicit_violent"
+	ModerationSelfHarm              ModerationCategory = "self_harm"
+	ModerationSelfHarmIntent        ModerationCategory = "self_harm_intent"
+	ModerationSelfHarmInstructions  ModerationCategory = "self_harm_instructions"
+	ModerationSexual                ModerationCategory = "sexual"
+	ModerationSexualMinors          ModerationCategory = "sexual_minors"
+	ModerationViolence              ModerationCategory = "violence"
+	ModerationViolenceGraphic       ModerationCategory = "violence_graphic"
+)
+
+// ModerationCategories lists every normalized category.
+var ModerationCategories = []ModerationCategory{
+	ModerationHarassment,
+	ModerationHarassmentThreatening,
+	ModerationHate,
+	ModerationHateThreatening,
+	ModerationIllicit,
+	ModerationIllicitViolent,
+	ModerationSelfHarm,
+	ModerationSelfHarmIntent,
+	ModerationSelfHarmInstructions,
+	ModerationSexual,
+	ModerationSexualMinors,
+	ModerationViolence,
+	ModerationViolenceGraphic,
+}
+
+// ModerationInput is a single piece of content to classify.
+// Set Text, ImageURL, or both; text and image are classified together.
+type ModerationInput struct {
+	Text     string `json:"text,omitempty"`
+	ImageURL string `json:"image_url,omitempty"` // URL or data URL
+}
+
+// ModerationRequest represents a request to moderate content.
+type ModerationRequest struct {
+	// Model is the moderation model. Providers choose a default when empty.
+	Model ModelID `json:"model,omitempty"`
+
+	// Input is the content to classify.
+	Input []ModerationInput `json:"input"`
+}
+
+// Validate checks that the request has input and that every input has
+// text or an image.
+func (r *ModerationRequest) Validate() error {
+	if len(r.Input) == 0 {
+		return ErrNoModerationInput
+	}
+	for _, in := range r.Input {
+		if in.Text == "" && in.ImageURL == "" {
+			return ErrNoModerationInput
+		}
+	}
+	return nil
+}
+
+// ModerationResult is the classification of one input.
+type ModerationResult struct {
+	// Flagged reports whether the input was flagged in any category.
+	Flagged bool `json:"flagged"`
+
+	// Categories reports whether each category was flagged.
+	Categories map[ModerationCategory]bool `json:"categories"`
+
+	// Scores is the confidence for each category, from 0 to 1.
+	Scores map[ModerationCategory]float64 `json:"scores"`
+}
+
+// ModerationResponse contains one result per input.
+type ModerationResponse struct {
+	Model   ModelID            `json:"model"`
+	Results []ModerationResult `json:"results"`
+}
+
+// Flagged reports whether any input was flagged.
+func (r *ModerationResponse) Flagged() bool {
+	for _, res := range r.Results {
+		if res.Flagged {
+			return true
+		}
+	}
+	return false
+}
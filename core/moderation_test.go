@@ -0,0 +1,40 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestModerationRequestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []ModerationInput
+		want  error
+	}{
+		{"text", []ModerationInput{{Text: "hello"}}, nil},
+		{"image", []ModerationInput{{ImageURL: "https://example.com/a.png"}}, nil},
+		{"empty", nil, ErrNoModerationInput},
+		{"blank input", []ModerationInput{{Text: "hello"}, {}}, ErrNoModerationInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&ModerationRequest{Input: tt.input}).Validate()
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestModerationResponseFlagged(t *testing.T) {
+	resp := &ModerationResponse{Results: []ModerationResult{{Flagged: false}, {Flagged: true}}}
+	if !resp.Flagged() {
+		t.Error("Flagged() = false, want true")
+	}
+
+	resp.Results[1].Flagged = false
+	if resp.Flagged() {
+		t.Error("Flagged() = true, want false")
+	}
+}
@@ -1,7 +1,10 @@
 // Package core provides the Iris SDK client and types.
 package core
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Feature represents a capability that a provider may support.
 type Feature string
@@ -50,12 +53,45 @@ type ReasoningOutput struct {
 	Summary []string `json:"summary,omitempty"`
 }
 
+// Modality is a kind of content a model accepts or produces.
+type Modality string
+
+const (
+	ModalityText     Modality = "text"
+	ModalityImage    Modality = "image"
+	ModalityAudio    Modality = "audio"
+	ModalityVideo    Modality = "video"
+	ModalityDocument Modality = "document" // PDFs and other files
+)
+
+// ModelPricing is the list price of a model in US dollars per million tokens.
+type ModelPricing struct {
+	Input       float64 `json:"input"`
+	Output      float64 `json:"output"`
+	CachedInput float64 `json:"cached_input,omitempty"`
+}
+
+// Cost returns the list price in US dollars of the given usage, charging
+// every prompt token at the uncached Input rate.
+func (p ModelPricing) Cost(u TokenUsage) float64 {
+	return (float64(u.PromptTokens)*p.Input + float64(u.CompletionTokens)*p.Output) / 1e6
+}
+
 // ModelInfo describes a model available from a provider.
+// Fields other than ID are zero when unknown.
 type ModelInfo struct {
 	ID           ModelID     `json:"id"`
 	DisplayName  string      `json:"display_name"`
 	Capabilities []Feature   `json:"capabilities"`
 	APIEndpoint  APIEndpoint `json:"api_endpoint,omitempty"` // defaults to completions
+
+	ContextWindow    int           `json:"context_window,omitempty"`    // Input and output tokens combined
+	MaxOutputTokens  int           `json:"max_output_tokens,omitempty"` // Largest output per request
+	InputModalities  []Modality    `json:"input_modalities,omitempty"`
+	OutputModalities []Modality    `json:"output_modalities,omitempty"`
+	KnowledgeCutoff  string        `json:"knowledge_cutoff,omitempty"` // YYYY-MM or YYYY-MM-DD
+	DeprecationDate  string        `json:"deprecation_date,omitempty"` // YYYY-MM-DD the model is retired
+	Pricing          *ModelPricing `json:"pricing,omitempty"`
 }
 
 // HasCapability reports whether the model supports the given feature.
@@ -76,6 +112,69 @@ func (m ModelInfo) GetAPIEndpoint() APIEndpoint {
 	return m.APIEndpoint
 }
 
+// AcceptsInput reports whether the model accepts the given modality.
+// Models without input modalities are assumed to accept text only.
+func (m ModelInfo) AcceptsInput(modality Modality) bool {
+	if len(m.InputModalities) == 0 {
+		return modality == ModalityText
+	}
+	for _, mod := range m.InputModalities {
+		if mod == modality {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDeprecated reports whether the model's deprecation date is on or
+// before t. Models without a valid deprecation date are never deprecated.
+func (m ModelInfo) IsDeprecated(t time.Time) bool {
+	date, err := time.Parse(time.DateOnly, m.DeprecationDate)
+	if err != nil {
+		return false
+	}
+	return !t.Before(date)
+}
+
+// WithOverrides returns m with every non-zero field of o applied on top.
+// It is used to layer catalog metadata over a provider's built-in list.
+func (m ModelInfo) WithOverrides(o ModelInfo) ModelInfo {
+	if o.ID != "" {
+		m.ID = o.ID
+	}
+	if o.DisplayName != "" {
+		m.DisplayName = o.DisplayName
+	}
+	if o.Capabilities != nil {
+		m.Capabilities = o.Capabilities
+	}
+	if o.APIEndpoint != "" {
+		m.APIEndpoint = o.APIEndpoint
+	}
+	if o.ContextWindow != 0 {
+		m.ContextWindow = o.ContextWindow
+	}
+	if o.MaxOutputTokens != 0 {
+		m.MaxOutputTokens = o.MaxOutputTokens
+	}
+	if o.InputModalities != nil {
+		m.InputModalities = o.InputModalities
+	}
+	if o.OutputModalities != nil {
+		m.OutputModalities = o.OutputModalities
+	}
+	if o.KnowledgeCutoff != "" {
+		m.KnowledgeCutoff = o.KnowledgeCutoff
+	}
+	if o.DeprecationDate != "" {
+		m.DeprecationDate = o.DeprecationDate
+	}
+	if o.Pricing != nil {
+		m.Pricing = o.Pricing
+	}
+	return m
+}
+
 // ModelID is a string identifier for a model.
 // Using string avoids coupling to provider-specific enums.
 type ModelID string
@@ -95,7 +194,19 @@ const (
 type Message struct {
 	Role    Role          `json:"role"`
 	Content string        `json:"content,omitempty"`
-	Parts   []ContentPart `json:"-"` // Multimodal content parts (Responses API only)
+	Parts   []ContentPart `json:"-"` // Multimodal content parts
+
+	// Items replays the output items of a stateless response. Providers that
+	// understand them send them in place of Content.
+	Items []ResponseItem `json:"items,omitempty"`
+}
+
+// ResponseItem is an opaque output item of a stateless response, such as a
+// reasoning item with encrypted content. Raw is sent back unchanged to
+// continue the conversation without server-side storage.
+type ResponseItem struct {
+	Type string          `json:"type"` // e.g. "reasoning", "message", "function_call"
+	Raw  json.RawMessage `json:"raw"`
 }
 
 // TokenUsage tracks token consumption for a request.
@@ -145,6 +256,8 @@ type ChatRequest struct {
 	PreviousResponseID string          `json:"previous_response_id,omitempty"`
 	Truncation         string          `json:"truncation,omitempty"`
 	ToolResources      *ToolResources  `json:"tool_resources,omitempty"`
+	Background         bool            `json:"background,omitempty"` // Run asynchronously; poll or stream by ID
+	Store              *bool           `json:"store,omitempty"`      // Whether the provider keeps the response; nil uses its default
 }
 
 // ChatResponse represents a response from a chat model.
@@ -159,10 +272,19 @@ type ChatResponse struct {
 	// Responses API fields
 	Reasoning *ReasoningOutput `json:"reasoning,omitempty"`
 	Status    string           `json:"status,omitempty"`
+	Items     []ResponseItem   `json:"items,omitempty"` // Output items to replay, of a stateless (Store(false)) response or signed reasoning
 }
 
 // ChatChunk represents an incremental streaming response.
 // Delta contains incremental assistant text.
 type ChatChunk struct {
 	Delta string `json:"delta"`
+
+	// Sequence is the provider's position for the chunk in the stream, for
+	// providers that can resume a stream after it. Zero when not reported.
+	Sequence int `json:"sequence,omitempty"`
+
+	// Reasoning contains incremental reasoning summary text, for providers
+	// that stream it. Delta is empty in chunks that carry reasoning.
+	Reasoning string `json:"reasoning,omitempty"`
 }
@@ -3,6 +3,7 @@ package core
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestMessageJSONMarshal(t *testing.T) {
@@ -355,3 +356,58 @@ func TestChatRequestWithToolResources(t *testing.T) {
 		t.Errorf("expected 1 vector store ID, got %d", len(req.ToolResources.FileSearch.VectorStoreIDs))
 	}
 }
+
+func TestModelInfoAcceptsInput(t *testing.T) {
+	textOnly := ModelInfo{ID: "a"}
+	if !textOnly.AcceptsInput(ModalityText) || textOnly.AcceptsInput(ModalityImage) {
+		t.Error("model without modalities should accept text only")
+	}
+
+	vision := ModelInfo{ID: "b", InputModalities: []Modality{ModalityText, ModalityImage}}
+	if !vision.AcceptsInput(ModalityImage) || vision.AcceptsInput(ModalityAudio) {
+		t.Errorf("AcceptsInput mismatch for %v", vision.InputModalities)
+	}
+}
+
+func TestModelInfoIsDeprecated(t *testing.T) {
+	m := ModelInfo{ID: "old", DeprecationDate: "2026-05-12"}
+	day := time.Date(2026, 5, 12, 0, 0, 0, 0, time.UTC)
+
+	if m.IsDeprecated(day.Add(-time.Second)) {
+		t.Error("deprecated before the deprecation date")
+	}
+	if !m.IsDeprecated(day) {
+		t.Error("not deprecated on the deprecation date")
+	}
+	if (ModelInfo{ID: "new"}).IsDeprecated(day) {
+		t.Error("model without a deprecation date is deprecated")
+	}
+}
+
+func TestModelInfoWithOverrides(t *testing.T) {
+	base := ModelInfo{
+		ID:           "gpt-4o",
+		DisplayName:  "GPT-4o",
+		Capabilities: []Feature{FeatureChat},
+		Pricing:      &ModelPricing{Input: 2.5, Output: 10},
+	}
+
+	got := base.WithOverrides(ModelInfo{ContextWindow: 128000, Pricing: &ModelPricing{Input: 2, Output: 8}})
+	if got.DisplayName != "GPT-4o" || !got.HasCapability(FeatureChat) {
+		t.Errorf("unset override fields replaced existing values: %+v", got)
+	}
+	if got.ContextWindow != 128000 || got.Pricing.Input != 2 {
+		t.Errorf("override fields not applied: %+v", got)
+	}
+	if base.ContextWindow != 0 || base.Pricing.Input != 2.5 {
+		t.Errorf("WithOverrides modified the receiver: %+v", base)
+	}
+}
+
+func TestModelPricingCost(t *testing.T) {
+	p := ModelPricing{Input: 2.5, Output: 10}
+	got := p.Cost(TokenUsage{PromptTokens: 1000, CompletionTokens: 500})
+	if want := 0.0075; got < want-1e-12 || got > want+1e-12 {
+		t.Errorf("Cost() = %v, want %v", got, want)
+	}
+}
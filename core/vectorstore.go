@@ -0,0 +1,61 @@
+package core
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrDimensionMismatch is returned when a vector's length differs from the
+// dimensions of the vectors already in a VectorStore.
+var ErrDimensionMismatch = errors.New("vector dimension mismatch")
+
+// VectorStore stores embedding vectors and finds those most similar to a
+// query vector. Vectors are identified by EmbeddingVector.ID, and their
+// Metadata can be used to filter queries, so the results of
+// EmbeddingProvider.CreateEmbeddings can be stored directly.
+type VectorStore interface {
+	// Upsert adds vectors, replacing any stored with the same ID. Each
+	// vector needs an ID and float values in Vector; all vectors in a
+	// store have the same dimensions.
+	Upsert(ctx context.Context, vectors []EmbeddingVector) error
+
+	// Delete removes the vectors with the given IDs. Unknown IDs are
+	// ignored.
+	Delete(ctx context.Context, ids []string) error
+
+	// Query returns the vectors most similar to req.Vector that match
+	// req.Filter, in descending order of similarity.
+	Query(ctx context.Context, req *VectorQuery) ([]VectorMatch, error)
+}
+
+// VectorQuery is a similarity search in a VectorStore.
+type VectorQuery struct {
+	// Vector is the query embedding, usually created with
+	// InputTypeQuery.
+	Vector []float32
+
+	// TopK is the maximum number of matches to return. Default: 10.
+	TopK int
+
+	// Filter restricts matches to vectors whose Metadata has each of the
+	// given key-value pairs.
+	Filter map[string]string
+
+	// IncludeVectors returns the stored vectors in the matches.
+	IncludeVectors bool
+}
+
+// VectorMatch is a result of a VectorQuery.
+type VectorMatch struct {
+	ID string `json:"id"`
+
+	// Score is the cosine similarity to the query vector, from -1 to 1;
+	// higher is more similar.
+	Score float64 `json:"score"`
+
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Vector is the vector as it was stored, when requested with
+	// VectorQuery.IncludeVectors.
+	Vector []float32 `json:"vector,omitempty"`
+}
@@ -0,0 +1,27 @@
+// Package emulate fills in tool calling and structured output for models
+// that lack them natively.
+//
+// Wrap returns a core.Provider that describes the request's tools in the
+// system prompt for models without native tool calling, and parses the
+// calls the model writes as fenced JSON blocks back into
+// core.ChatResponse.ToolCalls:
+//
+//	provider := emulate.Wrap(ollama.New())
+//	client := core.NewClient(provider)
+//	resp, err := client.Chat("gemma3").User("What's the weather in Paris?").Tools(weather).GetResponse(ctx)
+//	for _, call := range resp.ToolCalls {
+//	    // run the tool as usual
+//	}
+//
+// Models with native tool calling are passed through unchanged. Since
+// core.Message has no tool role, send tool results back as user messages.
+//
+// JSON asks for a JSON answer, decodes it into a Go value, and re-asks the
+// model with the decoding error when the answer is invalid:
+//
+//	type Weather struct {
+//	    City  string  `json:"city"`
+//	    TempC float64 `json:"temp_c"`
+//	}
+//	w, resp, err := emulate.JSON[Weather](ctx, client.Chat("gemma3").User("Weather in Paris?"))
+package emulate
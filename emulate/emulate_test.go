@@ -0,0 +1,215 @@
+package emulate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers/providertest"
+	"github.com/erikhoward/iris/tools"
+)
+
+// scripted is a provider that answers each Chat call with the next output.
+type scripted struct {
+	providertest.Fake
+	mu      sync.Mutex
+	outputs []string
+	calls   int
+}
+
+func (s *scripted) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	if _, err := s.Fake.Chat(ctx, req); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := s.outputs[min(s.calls, len(s.outputs)-1)]
+	s.calls++
+	return &core.ChatResponse{
+		Model:  req.Model,
+		Output: out,
+		Usage:  core.TokenUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15},
+	}, nil
+}
+
+func newScripted(outputs ...string) *scripted {
+	return &scripted{
+		Fake: providertest.Fake{
+			ProviderID: "ollama",
+			ModelList: []core.ModelInfo{
+				{ID: "gemma3", Capabilities: []core.Feature{core.FeatureChat, core.FeatureChatStreaming}},
+				{ID: "llama3.2", Capabilities: []core.Feature{core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling}},
+			},
+			Features: []core.Feature{core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling},
+		},
+		outputs: outputs,
+	}
+}
+
+// weatherTool is a tool with a parameter schema.
+type weatherTool struct{}
+
+func (weatherTool) Name() string        { return "get_weather" }
+func (weatherTool) Description() string { return "Get the current weather for a city" }
+func (weatherTool) Schema() tools.ToolSchema {
+	return tools.ToolSchema{JSONSchema: json.RawMessage(`{
+		"type": "object",
+		"properties": {"city": {"type": "string"}}
+	}`)}
+}
+
+func TestWrapEmulatesToolCalls(t *testing.T) {
+	inner := newScripted("Let me check.\n\n```tool_call\n{\"name\": \"get_weather\", \"arguments\": {\"city\":  \"Paris\"}}\n```")
+	client := core.NewClient(Wrap(inner))
+
+	resp, err := client.Chat("gemma3").User("Weather in Paris?").Tools(weatherTool{}).GetResponse(context.Background())
+	if err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+
+	if len(resp.ToolCalls) != 1 {
+		t.Fatalf("len(ToolCalls) = %d, want 1", len(resp.ToolCalls))
+	}
+	call := resp.ToolCalls[0]
+	if call.ID == "" || call.Name != "get_weather" || string(call.Arguments) != `{"city":  "Paris"}` {
+		t.Errorf("ToolCalls[0] = %+v, want raw arguments preserved", call)
+	}
+	if resp.Output != "Let me check." {
+		t.Errorf("Output = %q, want the text around the call", resp.Output)
+	}
+
+	req := inner.Requests()[0]
+	if len(req.Tools) != 0 {
+		t.Error("tools were sent to a model without tool calling")
+	}
+	if req.Messages[0].Role != core.RoleSystem || !strings.Contains(req.Messages[0].Content, "get_weather") ||
+		!strings.Contains(req.Messages[0].Content, `"city":{"type":"string"}`) {
+		t.Errorf("system prompt = %q, want tool name and schema", req.Messages[0].Content)
+	}
+}
+
+func TestWrapPassesThroughNativeToolCalling(t *testing.T) {
+	inner := newScripted("plain answer")
+	client := core.NewClient(Wrap(inner))
+
+	if _, err := client.Chat("llama3.2").User("hi").Tools(weatherTool{}).GetResponse(context.Background()); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	req := inner.Requests()[0]
+	if len(req.Tools) != 1 || len(req.Messages) != 1 {
+		t.Errorf("request = %+v, want it unchanged", req)
+	}
+
+	// WithModels forces emulation for listed models
+	inner = newScripted("plain answer")
+	client = core.NewClient(Wrap(inner, WithModels("llama3.2")))
+	if _, err := client.Chat("llama3.2").User("hi").Tools(weatherTool{}).GetResponse(context.Background()); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if req := inner.Requests()[0]; len(req.Tools) != 0 {
+		t.Error("WithModels did not force emulation")
+	}
+}
+
+func TestWrapRetriesInvalidToolCalls(t *testing.T) {
+	inner := newScripted(
+		"```tool_call\n{\"name\": \"get_weather\", \"arguments\": {\"city\": }\n```",
+		"```tool_call\n{\"name\": \"get_weather\", \"arguments\": {\"city\": \"Paris\"}}\n```",
+	)
+
+	resp, err := Wrap(inner).Chat(context.Background(), &core.ChatRequest{
+		Model:    "gemma3",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Weather in Paris?"}},
+		Tools:    []core.Tool{weatherTool{}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if len(resp.ToolCalls) != 1 || resp.Usage.TotalTokens != 30 {
+		t.Errorf("response = %+v, want one call and usage from both attempts", resp)
+	}
+
+	retry := inner.Requests()[1].Messages
+	if last := retry[len(retry)-1]; last.Role != core.RoleUser || !strings.Contains(last.Content, "not valid JSON") {
+		t.Errorf("re-ask = %+v", last)
+	}
+}
+
+func TestWrapGivesUpAfterRetries(t *testing.T) {
+	inner := newScripted("```tool_call\n{\"name\": \"launch_rocket\", \"arguments\": {}}\n```")
+
+	_, err := Wrap(inner, WithMaxRetries(1)).Chat(context.Background(), &core.ChatRequest{
+		Model:    "gemma3",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "hi"}},
+		Tools:    []core.Tool{weatherTool{}},
+	})
+	if !errors.Is(err, ErrInvalidOutput) || !strings.Contains(err.Error(), "launch_rocket") {
+		t.Errorf("Chat() error = %v, want ErrInvalidOutput naming the unknown tool", err)
+	}
+	if inner.calls != 2 {
+		t.Errorf("calls = %d, want 2", inner.calls)
+	}
+}
+
+func TestParseToolCalls(t *testing.T) {
+	ts := []core.Tool{weatherTool{}}
+
+	calls, text, err := parseToolCalls("```json\n{\"name\": \"get_weather\"}\n```\n```tool_call\n{\"name\": \"get_weather\", \"arguments\": {\"city\": \"Oslo\"}}\n```", ts)
+	if err != nil {
+		t.Fatalf("parseToolCalls() error = %v", err)
+	}
+	if len(calls) != 2 || string(calls[0].Arguments) != "{}" || calls[0].ID == calls[1].ID || text != "" {
+		t.Errorf("calls = %+v, text = %q", calls, text)
+	}
+
+	// JSON that is not a call of a known tool is left in the text
+	calls, text, err = parseToolCalls("Here:\n```json\n{\"city\": \"Oslo\"}\n```", ts)
+	if err != nil || len(calls) != 0 || !strings.Contains(text, `"city"`) {
+		t.Errorf("calls = %+v, text = %q, err = %v", calls, text, err)
+	}
+
+	if _, _, err := parseToolCalls("```tool_call\n{\"name\": \"get_weather\", \"arguments\": \"Oslo\"}\n```", ts); err == nil {
+		t.Error("parseToolCalls() accepted non-object arguments")
+	}
+}
+
+func TestWrapStreamChat(t *testing.T) {
+	inner := newScripted("Checking.\n```tool_call\n{\"name\": \"get_weather\", \"arguments\": {\"city\": \"Rome\"}}\n```")
+	client := core.NewClient(Wrap(inner))
+
+	stream, err := client.Chat("gemma3").User("Weather in Rome?").Tools(weatherTool{}).Stream(context.Background())
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	result, err := providertest.CollectStream(stream, time.Second)
+	if err != nil {
+		t.Fatalf("CollectStream() error = %v", err)
+	}
+	if v := result.Violations(); len(v) > 0 {
+		t.Errorf("stream contract violations: %v", v)
+	}
+	if result.Output() != "Checking." || len(result.Final().ToolCalls) != 1 {
+		t.Errorf("output = %q, final = %+v", result.Output(), result.Final())
+	}
+}
+
+func TestWrapModelsAndSupports(t *testing.T) {
+	p := Wrap(newScripted("x"))
+
+	if !p.Supports(core.FeatureToolCalling) {
+		t.Error("Supports(FeatureToolCalling) = false")
+	}
+	for _, m := range p.Models() {
+		if !m.HasCapability(core.FeatureToolCalling) {
+			t.Errorf("%s lacks tool calling", m.ID)
+		}
+	}
+	if p.Unwrap().Models()[0].HasCapability(core.FeatureToolCalling) {
+		t.Error("Models() modified the wrapped provider's list")
+	}
+}
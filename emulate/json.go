@@ -0,0 +1,88 @@
+package emulate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// Validator is implemented by JSON result types that check their own
+// contents after decoding.
+type Validator interface {
+	Validate() error
+}
+
+// JSON sends the request built by b, asking for a JSON answer, and decodes
+// the answer into a T. If the answer is not valid JSON, has fields T does
+// not define, or fails T's Validate method, the model is re-asked with the
+// error. The instructions are put ahead of b's messages, and any
+// corrections after them.
+//
+// The returned response is the last one received, with usage summed over
+// every attempt.
+func JSON[T any](ctx context.Context, b *core.ChatBuilder, opts ...Option) (*T, *core.ChatResponse, error) {
+	cfg := newConfig(opts)
+	b.PrependSystem(jsonPrompt(cfg.schema))
+
+	var usage core.TokenUsage
+	for attempt := 0; ; attempt++ {
+		resp, err := b.GetResponse(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		usage = addUsage(usage, resp.Usage)
+		resp.Usage = usage
+
+		v, err := decodeJSON[T](resp.Output)
+		if err == nil {
+			return v, resp, nil
+		}
+		if attempt >= cfg.maxRetries {
+			return nil, resp, fmt.Errorf("%w: %v", ErrInvalidOutput, err)
+		}
+
+		b.Assistant(resp.Output).
+			User(fmt.Sprintf("That answer could not be used: %v. Reply again with only the corrected JSON.", err))
+	}
+}
+
+// jsonPrompt asks for a JSON answer, matching schema if it is set.
+func jsonPrompt(schema []byte) string {
+	prompt := "Reply with only a JSON value and no other text."
+	if len(schema) > 0 {
+		prompt += " It must match this JSON Schema: " + compactJSON(schema)
+	}
+	return prompt
+}
+
+// decodeJSON decodes the JSON in output into a T and validates it.
+func decodeJSON[T any](output string) (*T, error) {
+	dec := json.NewDecoder(strings.NewReader(extractJSON(output)))
+	dec.DisallowUnknownFields()
+
+	v := new(T)
+	if err := dec.Decode(v); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("invalid JSON: unexpected text after the value")
+	}
+	if val, ok := any(v).(Validator); ok {
+		if err := val.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// extractJSON returns the body of the first fenced block in output, or the
+// trimmed output if it has none.
+func extractJSON(output string) string {
+	if m := fencedBlock.FindStringSubmatch(output); m != nil {
+		return strings.TrimSpace(m[2])
+	}
+	return strings.TrimSpace(output)
+}
@@ -0,0 +1,83 @@
+package emulate
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+type forecast struct {
+	City  string  `json:"city"`
+	TempC float64 `json:"temp_c"`
+}
+
+func (f forecast) Validate() error {
+	if f.City == "" {
+		return errors.New("city is required")
+	}
+	return nil
+}
+
+func TestJSON(t *testing.T) {
+	inner := newScripted("```json\n{\"city\": \"Paris\", \"temp_c\": 18.5}\n```")
+	client := core.NewClient(inner)
+
+	v, resp, err := JSON[forecast](context.Background(), client.Chat("gemma3").User("Weather in Paris?"),
+		WithSchema([]byte(`{"type": "object", "required": ["city"]}`)))
+	if err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+	if v.City != "Paris" || v.TempC != 18.5 || resp == nil {
+		t.Errorf("JSON() = %+v", v)
+	}
+
+	msgs := inner.Requests()[0].Messages
+	if first := msgs[0]; first.Role != core.RoleSystem || !strings.Contains(first.Content, `{"type":"object","required":["city"]}`) {
+		t.Errorf("instructions = %+v, want leading JSON prompt with schema", first)
+	}
+	if last := msgs[len(msgs)-1]; last.Role != core.RoleUser {
+		t.Errorf("last message = %+v, want the user's question", last)
+	}
+}
+
+func TestJSONRetries(t *testing.T) {
+	inner := newScripted(
+		"Sure! The weather is nice.",
+		`{"city": "Paris", "humidity": 40}`,
+		`{"city": "", "temp_c": 18}`,
+		`{"city": "Paris", "temp_c": 18}`,
+	)
+	client := core.NewClient(inner)
+
+	v, resp, err := JSON[forecast](context.Background(), client.Chat("gemma3").User("Weather?"), WithMaxRetries(3))
+	if err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+	if v.City != "Paris" || resp.Usage.TotalTokens != 60 {
+		t.Errorf("JSON() = %+v, usage = %+v", v, resp.Usage)
+	}
+
+	reqs := inner.Requests()
+	for i, want := range []string{"invalid JSON", "humidity", "city is required"} {
+		msgs := reqs[i+1].Messages
+		if last := msgs[len(msgs)-1]; !strings.Contains(last.Content, want) {
+			t.Errorf("re-ask %d = %q, want mention of %q", i+1, last.Content, want)
+		}
+	}
+}
+
+func TestJSONGivesUp(t *testing.T) {
+	inner := newScripted("not json")
+	client := core.NewClient(inner)
+
+	_, resp, err := JSON[forecast](context.Background(), client.Chat("gemma3").User("Weather?"), WithMaxRetries(0))
+	if !errors.Is(err, ErrInvalidOutput) {
+		t.Errorf("JSON() error = %v, want ErrInvalidOutput", err)
+	}
+	if resp == nil || resp.Output != "not json" {
+		t.Errorf("resp = %+v, want the last response", resp)
+	}
+}
@@ -0,0 +1,162 @@
+package emulate
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// DefaultMaxRetries is how many times the model is re-asked after an answer
+// that cannot be parsed.
+const DefaultMaxRetries = 2
+
+// ErrInvalidOutput is returned when the model's answer still cannot be
+// parsed after every retry.
+var ErrInvalidOutput = errors.New("invalid model output")
+
+// config holds the settings shared by Wrap and JSON.
+type config struct {
+	maxRetries int
+	models     map[core.ModelID]bool
+	schema     []byte
+}
+
+// Option configures emulation.
+type Option func(*config)
+
+// WithMaxRetries sets how many times the model is re-asked after an answer
+// that cannot be parsed. Zero disables retries.
+func WithMaxRetries(n int) Option {
+	return func(c *config) {
+		if n >= 0 {
+			c.maxRetries = n
+		}
+	}
+}
+
+// WithModels forces tool calling emulation for the given models, for
+// models that are listed with native tool calling but use it poorly, or
+// that the provider does not list.
+func WithModels(ids ...core.ModelID) Option {
+	return func(c *config) {
+		if c.models == nil {
+			c.models = make(map[core.ModelID]bool, len(ids))
+		}
+		for _, id := range ids {
+			c.models[id] = true
+		}
+	}
+}
+
+// WithSchema sets the JSON Schema that JSON includes in its instructions.
+func WithSchema(schema []byte) Option {
+	return func(c *config) {
+		c.schema = schema
+	}
+}
+
+func newConfig(opts []Option) config {
+	c := config{maxRetries: DefaultMaxRetries}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// Provider wraps a core.Provider and emulates tool calling for models that
+// do not support it. Provider is safe for concurrent use if the wrapped
+// provider is.
+type Provider struct {
+	provider core.Provider
+	cfg      config
+}
+
+// Wrap returns a provider that emulates tool calling for p's models that
+// lack it.
+func Wrap(p core.Provider, opts ...Option) *Provider {
+	return &Provider{provider: p, cfg: newConfig(opts)}
+}
+
+// Unwrap returns the wrapped provider, for access to its optional
+// interfaces such as core.ModelLister.
+func (p *Provider) Unwrap() core.Provider {
+	return p.provider
+}
+
+// ID returns the wrapped provider's ID.
+func (p *Provider) ID() string {
+	return p.provider.ID()
+}
+
+// Models returns the wrapped provider's models, with tool calling added to
+// every chat model.
+func (p *Provider) Models() []core.ModelInfo {
+	models := p.provider.Models()
+	result := make([]core.ModelInfo, len(models))
+	for i, m := range models {
+		if m.HasCapability(core.FeatureChat) && !m.HasCapability(core.FeatureToolCalling) {
+			m.Capabilities = append(append([]core.Feature(nil), m.Capabilities...), core.FeatureToolCalling)
+		}
+		result[i] = m
+	}
+	return result
+}
+
+// Supports reports whether the wrapped provider supports the feature.
+// Tool calling is always supported.
+func (p *Provider) Supports(feature core.Feature) bool {
+	return feature == core.FeatureToolCalling || p.provider.Supports(feature)
+}
+
+// Chat sends the request, emulating tool calling if the model needs it.
+func (p *Provider) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	if !p.emulates(req) {
+		return p.provider.Chat(ctx, req)
+	}
+	return p.chatWithTools(ctx, req)
+}
+
+// StreamChat streams the request. When tool calling is emulated, the
+// answer is parsed in full first and delivered as a single chunk.
+func (p *Provider) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	if !p.emulates(req) {
+		return p.provider.StreamChat(ctx, req)
+	}
+
+	resp, err := p.chatWithTools(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan core.ChatChunk, 1)
+	errCh := make(chan error)
+	finalCh := make(chan *core.ChatResponse, 1)
+	if resp.Output != "" {
+		ch <- core.ChatChunk{Delta: resp.Output}
+	}
+	finalCh <- resp
+	close(ch)
+	close(errCh)
+	close(finalCh)
+	return &core.ChatStream{Ch: ch, Err: errCh, Final: finalCh}, nil
+}
+
+// emulates reports whether tool calling must be emulated for the request.
+func (p *Provider) emulates(req *core.ChatRequest) bool {
+	if len(req.Tools) == 0 {
+		return false
+	}
+	if p.cfg.models[req.Model] {
+		return true
+	}
+	for _, m := range p.provider.Models() {
+		if m.ID == req.Model && len(m.Capabilities) > 0 {
+			return !m.HasCapability(core.FeatureToolCalling)
+		}
+	}
+	return !p.provider.Supports(core.FeatureToolCalling)
+}
+
+// Compile-time check that Provider implements Provider.
+var _ core.Provider = (*Provider)(nil)
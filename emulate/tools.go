@@ -0,0 +1,154 @@
+package emulate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/tools"
+)
+
+// toolCallFence is the info string of the fenced blocks that hold tool calls.
+const toolCallFence = "tool_call"
+
+// fencedBlock matches a fenced code block and captures its info string and
+// body.
+var fencedBlock = regexp.MustCompile("(?s)```([a-z_]*)[ \t]*\r?\n(.*?)```")
+
+// schemaProvider is implemented by tools that describe their parameters.
+type schemaProvider interface {
+	Schema() tools.ToolSchema
+}
+
+// toolPrompt describes the tools and the tool call format for the system
+// prompt.
+func toolPrompt(ts []core.Tool) string {
+	var b strings.Builder
+	b.WriteString("You can call the following tools:\n")
+	for _, t := range ts {
+		fmt.Fprintf(&b, "\n- %s: %s\n", t.Name(), t.Description())
+		if sp, ok := t.(schemaProvider); ok && len(sp.Schema().JSONSchema) > 0 {
+			fmt.Fprintf(&b, "  Arguments (JSON Schema): %s\n", compactJSON(sp.Schema().JSONSchema))
+		}
+	}
+	b.WriteString("\nTo call a tool, reply with one block per call in exactly this format:\n\n")
+	b.WriteString("```" + toolCallFence + "\n{\"name\": \"<tool name>\", \"arguments\": {<arguments>}}\n```\n\n")
+	b.WriteString("Do not describe the call or guess its result; the result will be sent in the next message. ")
+	b.WriteString("If no tool is needed, answer normally without a " + toolCallFence + " block.")
+	return b.String()
+}
+
+// compactJSON removes insignificant whitespace from raw, returning raw
+// unchanged if it is not valid JSON.
+func compactJSON(raw []byte) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
+
+// emulatedCall is the JSON a model writes to call a tool.
+type emulatedCall struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// parseToolCalls extracts tool calls from the model's output and returns
+// the remaining text. Blocks fenced as tool_call must be valid calls of
+// known tools. Blocks fenced as json are treated as calls only if they name
+// a known tool, since models often use that fence instead.
+func parseToolCalls(output string, ts []core.Tool) ([]core.ToolCall, string, error) {
+	known := make(map[string]bool, len(ts))
+	for _, t := range ts {
+		known[t.Name()] = true
+	}
+
+	var calls []core.ToolCall
+	var parseErr error
+	text := fencedBlock.ReplaceAllStringFunc(output, func(block string) string {
+		m := fencedBlock.FindStringSubmatch(block)
+		if m[1] != toolCallFence && m[1] != "json" {
+			return block
+		}
+
+		var call emulatedCall
+		err := json.Unmarshal([]byte(m[2]), &call)
+		switch {
+		case err == nil && known[call.Name]:
+		case m[1] == "json":
+			return block
+		case err != nil:
+			parseErr = fmt.Errorf("tool call is not valid JSON: %v", err)
+			return block
+		default:
+			parseErr = fmt.Errorf("unknown tool %q", call.Name)
+			return block
+		}
+
+		args := call.Arguments
+		if len(args) == 0 || string(args) == "null" {
+			args = json.RawMessage("{}")
+		} else if args[0] != '{' {
+			parseErr = fmt.Errorf("arguments for %s must be a JSON object", call.Name)
+			return block
+		}
+		calls = append(calls, core.ToolCall{
+			ID:        fmt.Sprintf("call_%d", len(calls)+1),
+			Name:      call.Name,
+			Arguments: args,
+		})
+		return ""
+	})
+	if parseErr != nil {
+		return nil, "", parseErr
+	}
+	return calls, strings.TrimSpace(text), nil
+}
+
+// chatWithTools sends the request with the tools described in the system
+// prompt and parses the calls from the answer, re-asking on parse errors.
+func (p *Provider) chatWithTools(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	emulated := *req
+	emulated.Tools = nil
+	emulated.Messages = append([]core.Message{{Role: core.RoleSystem, Content: toolPrompt(req.Tools)}}, req.Messages...)
+
+	var usage core.TokenUsage
+	for attempt := 0; ; attempt++ {
+		resp, err := p.provider.Chat(ctx, &emulated)
+		if err != nil {
+			return nil, err
+		}
+		usage = addUsage(usage, resp.Usage)
+
+		calls, text, err := parseToolCalls(resp.Output, req.Tools)
+		if err == nil {
+			resp.Output = text
+			resp.ToolCalls = calls
+			resp.Usage = usage
+			return resp, nil
+		}
+		if attempt >= p.cfg.maxRetries {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidOutput, err)
+		}
+
+		emulated.Messages = append(emulated.Messages,
+			core.Message{Role: core.RoleAssistant, Content: resp.Output},
+			core.Message{Role: core.RoleUser, Content: fmt.Sprintf(
+				"Your tool call could not be used: %v. Reply again, writing each call in a ```%s block as instructed.", err, toolCallFence)},
+		)
+	}
+}
+
+// addUsage returns the sum of two usages.
+func addUsage(a, b core.TokenUsage) core.TokenUsage {
+	return core.TokenUsage{
+		PromptTokens:     a.PromptTokens + b.PromptTokens,
+		CompletionTokens: a.CompletionTokens + b.CompletionTokens,
+		TotalTokens:      a.TotalTokens + b.TotalTokens,
+	}
+}
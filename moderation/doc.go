@@ -0,0 +1,18 @@
+// Package moderation provides content moderation backed by any chat model.
+//
+// LLM implements core.Moderator for providers without a moderation API. It
+// sends each input to a chat model with a fixed classification prompt and
+// reads back a score for every core.ModerationCategory, so input can be
+// screened the same way whichever provider serves the model:
+//
+//	mod := moderation.NewLLM(provider, "claude-haiku-4-5")
+//	resp, err := mod.Moderate(ctx, &core.ModerationRequest{
+//	    Input: []core.ModerationInput{{Text: userInput}},
+//	})
+//	if err == nil && resp.Flagged() {
+//	    // reject the input
+//	}
+//
+// Providers with a dedicated moderation endpoint, such as OpenAI, implement
+// core.Moderator directly and are usually faster and cheaper.
+package moderation
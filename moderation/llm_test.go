@@ -0,0 +1,142 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers/providertest"
+)
+
+func TestModerateToolCall(t *testing.T) {
+	fake := &providertest.Fake{Features: []core.Feature{core.FeatureToolCalling}, Response: &core.ChatResponse{
+		ToolCalls: []core.ToolCall{{
+			ID:        "call_1",
+			Name:      reportToolName,
+			Arguments: json.RawMessage(`{"harassment":0.8,"violence":0.2,"hate":1.7}`),
+		}},
+	}}
+
+	resp, err := NewLLM(fake, "judge").Moderate(context.Background(), &core.ModerationRequest{
+		Input: []core.ModerationInput{{Text: "you are awful"}},
+	})
+	if err != nil {
+		t.Fatalf("Moderate() error = %v", err)
+	}
+
+	result := resp.Results[0]
+	if !result.Flagged || !result.Categories[core.ModerationHarassment] || result.Categories[core.ModerationViolence] {
+		t.Errorf("result = %+v", result)
+	}
+	if got := result.Scores[core.ModerationHate]; got != 1 {
+		t.Errorf("Scores[hate] = %v, want clamped to 1", got)
+	}
+	if len(result.Scores) != len(core.ModerationCategories) {
+		t.Errorf("len(Scores) = %d, want %d", len(result.Scores), len(core.ModerationCategories))
+	}
+
+	reqs := fake.Requests()
+	if len(reqs) != 1 || reqs[0].Model != "judge" || len(reqs[0].Tools) != 1 {
+		t.Fatalf("requests = %+v", reqs)
+	}
+	if reqs[0].Messages[0].Role != core.RoleSystem || reqs[0].Messages[1].Content != "you are awful" {
+		t.Errorf("messages = %+v", reqs[0].Messages)
+	}
+	if *reqs[0].Temperature != 0 {
+		t.Errorf("Temperature = %v, want 0", *reqs[0].Temperature)
+	}
+}
+
+func TestModerateJSONOutput(t *testing.T) {
+	fake := &providertest.Fake{Response: &core.ChatResponse{
+		Output: "```json\n{\"self_harm\": 0.4}\n```",
+	}}
+
+	resp, err := NewLLM(fake, "judge", WithThreshold(0.3)).Moderate(context.Background(), &core.ModerationRequest{
+		Input: []core.ModerationInput{{Text: "a"}, {ImageURL: "https://example.com/a.png"}},
+	})
+	if err != nil {
+		t.Fatalf("Moderate() error = %v", err)
+	}
+	if len(resp.Results) != 2 || !resp.Results[0].Categories[core.ModerationSelfHarm] {
+		t.Errorf("Moderate() = %+v", resp)
+	}
+
+	image := fake.Requests()[1].Messages[1]
+	if len(image.Parts) != 1 {
+		t.Fatalf("parts = %+v", image.Parts)
+	}
+	if part, ok := image.Parts[0].(*core.InputImage); !ok || part.ImageURL != "https://example.com/a.png" {
+		t.Errorf("part = %#v", image.Parts[0])
+	}
+}
+
+func TestModerateWithoutToolCalling(t *testing.T) {
+	response := &core.ChatResponse{Output: `{"violence": 0.9}`}
+	providers := map[string]*providertest.Fake{
+		"provider": {Response: response},
+		"model": {
+			Features:  []core.Feature{core.FeatureToolCalling},
+			ModelList: []core.ModelInfo{{ID: "judge", Capabilities: []core.Feature{core.FeatureChat}}},
+			Response:  response,
+		},
+	}
+	for name, fake := range providers {
+		t.Run(name, func(t *testing.T) {
+			resp, err := NewLLM(fake, "judge").Moderate(context.Background(), &core.ModerationRequest{
+				Input: []core.ModerationInput{{Text: "a"}},
+			})
+			if err != nil {
+				t.Fatalf("Moderate() error = %v", err)
+			}
+			if !resp.Results[0].Categories[core.ModerationViolence] {
+				t.Errorf("result = %+v", resp.Results[0])
+			}
+
+			req := fake.Requests()[0]
+			if len(req.Tools) != 0 {
+				t.Errorf("Tools = %v, want none", req.Tools)
+			}
+			if prompt := req.Messages[0].Content; !strings.HasSuffix(prompt, jsonInstruction) || strings.Contains(prompt, reportToolName) {
+				t.Errorf("system prompt does not ask for JSON only: %q", prompt)
+			}
+		})
+	}
+}
+
+func TestModerateMalformedOutput(t *testing.T) {
+	fake := &providertest.Fake{Response: &core.ChatResponse{Output: "I can't help with that."}}
+
+	_, err := NewLLM(fake, "judge").Moderate(context.Background(), &core.ModerationRequest{
+		Input: []core.ModerationInput{{Text: "a"}},
+	})
+	if !errors.Is(err, core.ErrDecode) {
+		t.Errorf("Moderate() error = %v, want ErrDecode", err)
+	}
+}
+
+func TestModerateProviderError(t *testing.T) {
+	fake := &providertest.Fake{Err: core.ErrRateLimited}
+
+	_, err := NewLLM(fake, "judge").Moderate(context.Background(), &core.ModerationRequest{
+		Input: []core.ModerationInput{{Text: "a"}},
+	})
+	if !errors.Is(err, core.ErrRateLimited) {
+		t.Errorf("Moderate() error = %v, want ErrRateLimited", err)
+	}
+}
+
+func TestReportSchema(t *testing.T) {
+	var schema struct {
+		Required []string `json:"required"`
+	}
+	if err := json.Unmarshal(reportTool{}.Schema().JSONSchema, &schema); err != nil {
+		t.Fatalf("invalid schema: %v", err)
+	}
+	if len(schema.Required) != len(core.ModerationCategories) {
+		t.Errorf("required = %v", schema.Required)
+	}
+}
@@ -31,8 +31,12 @@ func (p *Anthropic) doChat(ctx context.Context, req *core.ChatRequest) (*core.Ch
 		return nil, newNetworkError(err)
 	}
 
-	// Set headers
-	for key, values := range p.buildHeaders() {
+	// Set headers; file references need the Files API beta
+	headers := p.buildHeaders()
+	if usesFiles(antReq) {
+		headers = p.buildFilesHeaders()
+	}
+	for key, values := range headers {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
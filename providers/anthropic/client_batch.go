@@ -0,0 +1,301 @@
+package anthropic
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// batchesPath is the API endpoint for Message Batches.
+const batchesPath = "/v1/messages/batches"
+
+// CreateBatch submits the requests as a Message Batch. The request body is
+// streamed, so large batches are never held in memory as a whole.
+func (p *Anthropic) CreateBatch(ctx context.Context, req *core.BatchRequest) (*core.BatchJob, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	headers := p.buildHeaders()
+	for _, item := range req.Items {
+		if usesFiles(buildRequest(item.Request, false)) {
+			headers = p.buildFilesHeaders()
+			break
+		}
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeBatchBody(pw, req.Items))
+	}()
+
+	var batch anthropicBatch
+	err := p.doBatchRequest(ctx, http.MethodPost, p.config.BaseURL+batchesPath, pr, headers, &batch)
+	pr.Close()
+	if err != nil {
+		return nil, err
+	}
+	return mapBatchJob(&batch), nil
+}
+
+// writeBatchBody writes the {"requests": [...]} body one item at a time.
+func writeBatchBody(w io.Writer, items []core.BatchItem) error {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString(`{"requests":[`); err != nil {
+		return err
+	}
+	for i, item := range items {
+		if i > 0 {
+			if err := bw.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		data, err := json.Marshal(anthropicBatchItem{
+			CustomID: item.CustomID,
+			Params:   buildRequest(item.Request, false),
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := bw.Write(data); err != nil {
+			return err
+		}
+	}
+	if _, err := bw.WriteString(`]}`); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// GetBatch returns the current state of a Message Batch.
+func (p *Anthropic) GetBatch(ctx context.Context, id string) (*core.BatchJob, error) {
+	batch, err := p.getBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapBatchJob(batch), nil
+}
+
+// CancelBatch requests cancellation of a Message Batch.
+func (p *Anthropic) CancelBatch(ctx context.Context, id string) (*core.BatchJob, error) {
+	var batch anthropicBatch
+	url := p.config.BaseURL + batchesPath + "/" + id + "/cancel"
+	if err := p.doBatchRequest(ctx, http.MethodPost, url, nil, p.buildHeaders(), &batch); err != nil {
+		return nil, err
+	}
+	return mapBatchJob(&batch), nil
+}
+
+// BatchResults returns the results of an ended Message Batch.
+// Cancelled and expired requests are reported with ErrBatchItemCancelled
+// and ErrBatchItemExpired.
+func (p *Anthropic) BatchResults(ctx context.Context, id string) ([]core.BatchResult, error) {
+	batch, err := p.getBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if batch.ResultsURL == nil {
+		return nil, &core.ProviderError{
+			Provider: "anthropic",
+			Code:     "batch_not_finished",
+			Message:  fmt.Sprintf("batch %s is %s", id, batch.ProcessingStatus),
+			Err:      core.ErrBadRequest,
+		}
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, *batch.ResultsURL, nil)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, normalizeError(resp.StatusCode, body, resp.Header.Get("request-id"))
+	}
+
+	var results []core.BatchResult
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var line anthropicBatchResultLine
+		if err := dec.Decode(&line); err != nil {
+			if errors.Is(err, io.EOF) {
+				return results, nil
+			}
+			return nil, newDecodeError(err)
+		}
+		results = append(results, mapBatchResult(&line))
+	}
+}
+
+// mapBatchResult converts one results line to a core result.
+func mapBatchResult(line *anthropicBatchResultLine) core.BatchResult {
+	result := core.BatchResult{CustomID: line.CustomID}
+
+	switch line.Result.Type {
+	case "succeeded":
+		if line.Result.Message == nil {
+			result.Err = newDecodeError(fmt.Errorf("batch result %q has no message", line.CustomID))
+			break
+		}
+		result.Response, result.Err = mapResponse(line.Result.Message)
+	case "errored":
+		var apiErr anthropicError
+		if line.Result.Error != nil {
+			apiErr = line.Result.Error.Error
+		}
+		result.Err = &core.ProviderError{
+			Provider: "anthropic",
+			Code:     apiErr.Type,
+			Message:  apiErr.Message,
+			Err:      errorTypeSentinel(apiErr.Type),
+		}
+	case "canceled":
+		result.Err = &core.ProviderError{
+			Provider: "anthropic",
+			Code:     "canceled",
+			Message:  "request was canceled before processing",
+			Err:      core.ErrBatchItemCancelled,
+		}
+	case "expired":
+		result.Err = &core.ProviderError{
+			Provider: "anthropic",
+			Code:     "expired",
+			Message:  "request expired before processing",
+			Err:      core.ErrBatchItemExpired,
+		}
+	default:
+		result.Err = newDecodeError(fmt.Errorf("unknown batch result type %q", line.Result.Type))
+	}
+	return result
+}
+
+// errorTypeSentinel maps an Anthropic error type to a core sentinel.
+func errorTypeSentinel(errType string) error {
+	switch errType {
+	case "invalid_request_error", "request_too_large":
+		return core.ErrBadRequest
+	case "authentication_error", "permission_error":
+		return core.ErrUnauthorized
+	case "not_found_error":
+		return core.ErrNotFound
+	case "rate_limit_error":
+		return core.ErrRateLimited
+	default:
+		return core.ErrServer
+	}
+}
+
+// getBatch retrieves the raw Message Batch object.
+func (p *Anthropic) getBatch(ctx context.Context, id string) (*anthropicBatch, error) {
+	var batch anthropicBatch
+	url := p.config.BaseURL + batchesPath + "/" + id
+	if err := p.doBatchRequest(ctx, http.MethodGet, url, nil, p.buildHeaders(), &batch); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// doBatchRequest sends a Message Batches request and decodes the JSON response into out.
+func (p *Anthropic) doBatchRequest(ctx context.Context, method, url string, body io.Reader, headers http.Header, out any) error {
+	if body == nil {
+		body = bytes.NewReader(nil)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return newNetworkError(err)
+	}
+
+	for key, values := range headers {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return normalizeError(resp.StatusCode, respBody, resp.Header.Get("request-id"))
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return newDecodeError(err)
+	}
+	return nil
+}
+
+// mapBatchJob converts a Message Batch to core format.
+func mapBatchJob(b *anthropicBatch) *core.BatchJob {
+	counts := b.RequestCounts
+	job := &core.BatchJob{
+		ID: b.ID,
+		Counts: core.BatchCounts{
+			Total:     counts.Processing + counts.Succeeded + counts.Errored + counts.Canceled + counts.Expired,
+			Succeeded: counts.Succeeded,
+			Failed:    counts.Errored + counts.Canceled + counts.Expired,
+		},
+		CreatedAt: parseTime(b.CreatedAt),
+	}
+	if b.EndedAt != nil {
+		ended := parseTime(*b.EndedAt)
+		job.EndedAt = &ended
+	}
+	if b.ExpiresAt != "" {
+		expires := parseTime(b.ExpiresAt)
+		job.ExpiresAt = &expires
+	}
+
+	switch b.ProcessingStatus {
+	case "canceling":
+		job.Status = core.BatchStatusCancelling
+	case "ended":
+		switch {
+		case b.CancelInitiatedAt != nil:
+			job.Status = core.BatchStatusCancelled
+		case counts.Succeeded+counts.Errored == 0 && counts.Expired > 0:
+			job.Status = core.BatchStatusExpired
+		default:
+			job.Status = core.BatchStatusCompleted
+		}
+	default:
+		job.Status = core.BatchStatusInProgress
+	}
+	return job
+}
+
+// parseTime parses an RFC 3339 timestamp, returning the zero time if it is malformed.
+func parseTime(s string) time.Time {
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
+
+// Compile-time check that Anthropic implements BatchProvider.
+var _ core.BatchProvider = (*Anthropic)(nil)
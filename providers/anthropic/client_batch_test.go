@@ -0,0 +1,182 @@
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func batchItem(id string) core.BatchItem {
+	return core.BatchItem{CustomID: id, Request: &core.ChatRequest{
+		Model:    ModelClaudeSonnet45,
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Hello " + id}},
+	}}
+}
+
+func TestCreateBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/messages/batches" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Requests []anthropicBatchItem `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("invalid body: %v", err)
+		}
+		if len(body.Requests) != 2 || body.Requests[0].CustomID != "a" || body.Requests[1].CustomID != "b" {
+			t.Errorf("requests = %+v", body.Requests)
+		}
+		if body.Requests[0].Params.Model != string(ModelClaudeSonnet45) {
+			t.Errorf("params = %+v", body.Requests[0].Params)
+		}
+		fmt.Fprint(w, `{"id":"msgbatch_1","type":"message_batch","processing_status":"in_progress",
+			"request_counts":{"processing":2,"succeeded":0,"errored":0,"canceled":0,"expired":0},
+			"created_at":"2025-01-01T00:00:00Z","expires_at":"2025-01-02T00:00:00Z"}`)
+	}))
+	defer server.Close()
+
+	var provider core.BatchProvider = New("test-key", WithBaseURL(server.URL))
+	job, err := provider.CreateBatch(context.Background(), &core.BatchRequest{
+		Items: []core.BatchItem{batchItem("a"), batchItem("b")},
+	})
+	if err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+	if job.ID != "msgbatch_1" || job.Status != core.BatchStatusInProgress || job.Counts.Total != 2 {
+		t.Errorf("job = %+v", job)
+	}
+	if job.ExpiresAt == nil || job.ExpiresAt.Day() != 2 {
+		t.Errorf("ExpiresAt = %v", job.ExpiresAt)
+	}
+}
+
+func TestCreateBatchFilesHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("anthropic-beta"); !strings.Contains(got, "files-api") {
+			t.Errorf("anthropic-beta = %q, want files API beta", got)
+		}
+		fmt.Fprint(w, `{"id":"msgbatch_1","processing_status":"in_progress","created_at":"2025-01-01T00:00:00Z"}`)
+	}))
+	defer server.Close()
+
+	item := batchItem("a")
+	item.Request.Messages[0].Parts = []core.ContentPart{&core.InputFile{FileID: "file_1"}}
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	if _, err := provider.CreateBatch(context.Background(), &core.BatchRequest{Items: []core.BatchItem{item}}); err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+}
+
+func TestBatchResults(t *testing.T) {
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/messages/batches/msgbatch_1":
+			fmt.Fprintf(w, `{"id":"msgbatch_1","processing_status":"ended",
+				"request_counts":{"processing":0,"succeeded":1,"errored":1,"canceled":1,"expired":1},
+				"created_at":"2025-01-01T00:00:00Z","ended_at":"2025-01-01T01:00:00Z",
+				"results_url":"%s/v1/messages/batches/msgbatch_1/results"}`, serverURL)
+		case "/v1/messages/batches/msgbatch_1/results":
+			if r.Header.Get("x-api-key") != "test-key" {
+				t.Error("results request is missing the API key")
+			}
+			fmt.Fprintln(w, `{"custom_id":"a","result":{"type":"succeeded","message":{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4-5","content":[{"type":"text","text":"Hi a"}],"stop_reason":"end_turn","usage":{"input_tokens":3,"output_tokens":2}}}}`)
+			fmt.Fprintln(w, `{"custom_id":"b","result":{"type":"errored","error":{"type":"error","error":{"type":"invalid_request_error","message":"bad"}}}}`)
+			fmt.Fprintln(w, `{"custom_id":"c","result":{"type":"canceled"}}`)
+			fmt.Fprintln(w, `{"custom_id":"d","result":{"type":"expired"}}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	results, err := provider.BatchResults(context.Background(), "msgbatch_1")
+	if err != nil {
+		t.Fatalf("BatchResults() error = %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("len(results) = %d, want 4", len(results))
+	}
+
+	if results[0].Err != nil || results[0].Response.Output != "Hi a" {
+		t.Errorf("results[0] = %+v", results[0])
+	}
+	if !errors.Is(results[1].Err, core.ErrBadRequest) {
+		t.Errorf("results[1].Err = %v, want ErrBadRequest", results[1].Err)
+	}
+	if !errors.Is(results[2].Err, core.ErrBatchItemCancelled) {
+		t.Errorf("results[2].Err = %v, want ErrBatchItemCancelled", results[2].Err)
+	}
+	if !errors.Is(results[3].Err, core.ErrBatchItemExpired) {
+		t.Errorf("results[3].Err = %v, want ErrBatchItemExpired", results[3].Err)
+	}
+}
+
+func TestBatchResultsNotFinished(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"msgbatch_1","processing_status":"in_progress","created_at":"2025-01-01T00:00:00Z"}`)
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	_, err := provider.BatchResults(context.Background(), "msgbatch_1")
+	if !errors.Is(err, core.ErrBadRequest) {
+		t.Errorf("BatchResults() error = %v, want ErrBadRequest", err)
+	}
+}
+
+func TestMapBatchJobStatus(t *testing.T) {
+	ended := "2025-01-01T01:00:00Z"
+	tests := []struct {
+		name  string
+		batch anthropicBatch
+		want  core.BatchStatus
+	}{
+		{"in progress", anthropicBatch{ProcessingStatus: "in_progress"}, core.BatchStatusInProgress},
+		{"canceling", anthropicBatch{ProcessingStatus: "canceling"}, core.BatchStatusCancelling},
+		{"completed", anthropicBatch{ProcessingStatus: "ended", EndedAt: &ended,
+			RequestCounts: anthropicBatchCount{Succeeded: 1, Expired: 1}}, core.BatchStatusCompleted},
+		{"cancelled", anthropicBatch{ProcessingStatus: "ended", EndedAt: &ended, CancelInitiatedAt: &ended,
+			RequestCounts: anthropicBatchCount{Succeeded: 1, Canceled: 1}}, core.BatchStatusCancelled},
+		{"expired", anthropicBatch{ProcessingStatus: "ended", EndedAt: &ended,
+			RequestCounts: anthropicBatchCount{Expired: 2}}, core.BatchStatusExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapBatchJob(&tt.batch).Status; got != tt.want {
+				t.Errorf("Status = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCancelBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/messages/batches/msgbatch_1/cancel" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		fmt.Fprint(w, `{"id":"msgbatch_1","processing_status":"canceling","created_at":"2025-01-01T00:00:00Z"}`)
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	job, err := provider.CancelBatch(context.Background(), "msgbatch_1")
+	if err != nil {
+		t.Fatalf("CancelBatch() error = %v", err)
+	}
+	if job.Status != core.BatchStatusCancelling {
+		t.Errorf("Status = %s, want cancelling", job.Status)
+	}
+}
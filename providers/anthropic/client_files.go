@@ -1,13 +1,13 @@
 package anthropic
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"strconv"
 	"strings"
 
@@ -31,25 +31,18 @@ func (p *Anthropic) buildFilesHeaders() http.Header {
 }
 
 // UploadFile uploads a file to Anthropic.
+// The file content is streamed from req.File rather than buffered in memory.
 func (p *Anthropic) UploadFile(ctx context.Context, req *FileUploadRequest) (*File, error) {
-	var buf bytes.Buffer
-	w := multipart.NewWriter(&buf)
-
-	part, err := w.CreateFormFile("file", req.Filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
-	}
-	if _, err := io.Copy(part, req.File); err != nil {
-		return nil, fmt.Errorf("failed to copy file content: %w", err)
-	}
-
-	if err := w.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
-	}
+	pr, pw := io.Pipe()
+	w := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeUploadForm(w, req))
+	}()
 
 	url := p.config.BaseURL + filesPath
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &buf)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, pr)
 	if err != nil {
+		pr.Close()
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
@@ -83,6 +76,34 @@ func (p *Anthropic) UploadFile(ctx context.Context, req *FileUploadRequest) (*Fi
 	return &file, nil
 }
 
+// quoteEscaper escapes a filename for a Content-Disposition header.
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// writeUploadForm writes the multipart form for an upload and closes w.
+func writeUploadForm(w *multipart.Writer, req *FileUploadRequest) error {
+	var part io.Writer
+	var err error
+	if req.MimeType != "" {
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, quoteEscaper.Replace(req.Filename)))
+		h.Set("Content-Type", req.MimeType)
+		part, err = w.CreatePart(h)
+	} else {
+		part, err = w.CreateFormFile("file", req.Filename)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := io.Copy(part, req.File); err != nil {
+		return fmt.Errorf("failed to copy file content: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+	return nil
+}
+
 // GetFile retrieves metadata for a specific file.
 func (p *Anthropic) GetFile(ctx context.Context, fileID string) (*File, error) {
 	url := p.config.BaseURL + filesPath + "/" + fileID
@@ -0,0 +1,104 @@
+package anthropic
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// maxFileListLimit is the largest page size accepted by GET /v1/files.
+const maxFileListLimit = 1000
+
+// CreateFile uploads a file through the core.FileProvider interface.
+func (p *Anthropic) CreateFile(ctx context.Context, req *core.FileUploadRequest) (*core.File, error) {
+	file, err := p.UploadFile(ctx, &FileUploadRequest{
+		File:     req.File,
+		Filename: req.Filename,
+		MimeType: req.MimeType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapCoreFile(file), nil
+}
+
+// RetrieveFile returns metadata for a file.
+func (p *Anthropic) RetrieveFile(ctx context.Context, id string) (*core.File, error) {
+	file, err := p.GetFile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapCoreFile(file), nil
+}
+
+// RetrieveFileContent returns the content of a file.
+// Only files created by tools can be downloaded; uploaded files return
+// ErrFileNotDownloadable.
+func (p *Anthropic) RetrieveFileContent(ctx context.Context, id string) (io.ReadCloser, error) {
+	return p.DownloadFile(ctx, id)
+}
+
+// EnumerateFiles lists files, following pagination.
+// Anthropic files have no purpose, so req.Purpose is ignored.
+func (p *Anthropic) EnumerateFiles(ctx context.Context, req *core.FileListRequest) ([]core.File, error) {
+	if req == nil {
+		req = &core.FileListRequest{}
+	}
+
+	listReq := &FileListRequest{}
+	var files []core.File
+	for {
+		limit := maxFileListLimit
+		if req.Limit > 0 {
+			limit = min(limit, req.Limit-len(files))
+		}
+		listReq.Limit = &limit
+
+		resp, err := p.ListFiles(ctx, listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range resp.Data {
+			files = append(files, *mapCoreFile(&resp.Data[i]))
+		}
+
+		if !resp.HasMore || resp.LastID == "" || (req.Limit > 0 && len(files) >= req.Limit) {
+			break
+		}
+		afterID := resp.LastID
+		listReq.AfterID = &afterID
+	}
+
+	return files, nil
+}
+
+// RemoveFile deletes a file.
+func (p *Anthropic) RemoveFile(ctx context.Context, id string) error {
+	return p.DeleteFile(ctx, id)
+}
+
+// WaitForFile returns the file's metadata. Anthropic files can be
+// referenced as soon as the upload completes, so there is nothing to wait for.
+func (p *Anthropic) WaitForFile(ctx context.Context, id string) (*core.File, error) {
+	return p.RetrieveFile(ctx, id)
+}
+
+// mapCoreFile converts an Anthropic file to core format.
+func mapCoreFile(f *File) *core.File {
+	// created_at is RFC 3339; an unparseable value leaves the zero time.
+	createdAt, _ := time.Parse(time.RFC3339, f.CreatedAt)
+	return &core.File{
+		ID:        f.ID,
+		Filename:  f.Filename,
+		MimeType:  f.MimeType,
+		Bytes:     f.SizeBytes,
+		State:     core.FileStateReady,
+		CreatedAt: createdAt,
+	}
+}
+
+// Compile-time check that Anthropic implements FileProvider.
+var _ core.FileProvider = (*Anthropic)(nil)
@@ -0,0 +1,121 @@
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestCreateFileSendsMimeType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("anthropic-beta"); got != DefaultFilesAPIBeta {
+			t.Errorf("anthropic-beta = %q", got)
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("failed to get file: %v", err)
+		}
+		defer file.Close()
+		if got := header.Header.Get("Content-Type"); got != "application/pdf" {
+			t.Errorf("part Content-Type = %q, want application/pdf", got)
+		}
+		content, _ := io.ReadAll(file)
+		if string(content) != "%PDF-1.7" {
+			t.Errorf("content = %q", content)
+		}
+
+		json.NewEncoder(w).Encode(File{
+			ID:        "file_123",
+			Type:      "file",
+			Filename:  "report.pdf",
+			MimeType:  "application/pdf",
+			SizeBytes: 8,
+			CreatedAt: "2025-04-14T12:00:00Z",
+		})
+	}))
+	defer server.Close()
+
+	var provider core.FileProvider = New("test-key", WithBaseURL(server.URL))
+	file, err := provider.CreateFile(context.Background(), &core.FileUploadRequest{
+		File:     strings.NewReader("%PDF-1.7"),
+		Filename: "report.pdf",
+		MimeType: "application/pdf",
+	})
+	if err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+
+	if file.ID != "file_123" || file.MimeType != "application/pdf" || file.Bytes != 8 {
+		t.Errorf("CreateFile() = %+v", file)
+	}
+	if file.CreatedAt.Year() != 2025 {
+		t.Errorf("CreatedAt = %v", file.CreatedAt)
+	}
+}
+
+func TestEnumerateFilesPaginates(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		resp := FileListResponse{}
+		if r.URL.Query().Get("after_id") == "" {
+			resp.Data = []File{{ID: "file_1"}, {ID: "file_2"}}
+			resp.LastID = "file_2"
+			resp.HasMore = true
+		} else {
+			if got := r.URL.Query().Get("after_id"); got != "file_2" {
+				t.Errorf("after_id = %q, want file_2", got)
+			}
+			resp.Data = []File{{ID: "file_3"}}
+			resp.LastID = "file_3"
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	files, err := provider.EnumerateFiles(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("EnumerateFiles() error = %v", err)
+	}
+	if calls != 2 || len(files) != 3 {
+		t.Errorf("calls = %d, files = %+v", calls, files)
+	}
+}
+
+func TestChatWithFileIDSendsDocumentBlock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("anthropic-beta"); got != DefaultFilesAPIBeta {
+			t.Errorf("anthropic-beta = %q, want %q", got, DefaultFilesAPIBeta)
+		}
+
+		var req anthropicRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		blocks := req.Messages[0].Content
+		if len(blocks) != 2 || blocks[0].Text != "Summarize" {
+			t.Fatalf("content = %+v", blocks)
+		}
+		if blocks[1].Type != "document" || blocks[1].Source == nil || blocks[1].Source.FileID != "file_123" {
+			t.Errorf("document block = %+v", blocks[1])
+		}
+
+		w.Write([]byte(`{"id":"msg_1","type":"message","role":"assistant","model":"claude","content":[{"type":"text","text":"ok"}],"usage":{"input_tokens":1,"output_tokens":1}}`))
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	_, err := core.NewClient(provider).Chat("claude-sonnet-4-5").
+		UserWithFileID("Summarize", "file_123").
+		GetResponse(context.Background())
+	if err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+}
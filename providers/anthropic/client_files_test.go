@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/erikhoward/iris/core"
 )
@@ -114,6 +115,38 @@ func TestUploadFile(t *testing.T) {
 	}
 }
 
+func TestUploadFileStreams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A buffered body would have a known length
+		if r.ContentLength != -1 {
+			t.Errorf("expected streamed body, got ContentLength %d", r.ContentLength)
+		}
+		io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(File{ID: "file_011CNha8iCJcU1wXNR6q4V8w"})
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+
+	if _, err := provider.UploadFile(context.Background(), &FileUploadRequest{
+		File:     strings.NewReader("hello world"),
+		Filename: "test.txt",
+	}); err != nil {
+		t.Fatalf("UploadFile failed: %v", err)
+	}
+
+	// A failing reader aborts the upload
+	readErr := errors.New("read failed")
+	_, err := provider.UploadFile(context.Background(), &FileUploadRequest{
+		File:     io.MultiReader(strings.NewReader("hello"), iotest.ErrReader(readErr)),
+		Filename: "test.txt",
+	})
+	if !errors.Is(err, core.ErrNetwork) || !strings.Contains(err.Error(), readErr.Error()) {
+		t.Errorf("expected network error from reader, got %v", err)
+	}
+}
+
 func TestGetFile(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
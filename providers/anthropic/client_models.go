@@ -0,0 +1,62 @@
+package anthropic
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/erikhoward/iris/core"
+)
+
+const modelsPath = "/v1/models"
+
+// modelsPageLimit is the largest page size accepted by GET /v1/models.
+const modelsPageLimit = "1000"
+
+// ListModels returns the models available to the API key using
+// GET /v1/models, following pagination. Models in the static list keep its
+// capabilities; others are assumed to support chat, streaming, and tool
+// calling. Results are cached for the configured ModelCacheTTL.
+func (p *Anthropic) ListModels(ctx context.Context) ([]core.ModelInfo, error) {
+	return p.modelCache.Get(ctx)
+}
+
+// fetchModels queries every page of GET /v1/models and merges the result
+// with the static list.
+func (p *Anthropic) fetchModels(ctx context.Context) ([]core.ModelInfo, error) {
+	var live []core.ModelInfo
+	afterID := ""
+	for {
+		params := url.Values{"limit": {modelsPageLimit}}
+		if afterID != "" {
+			params.Set("after_id", afterID)
+		}
+
+		var page anthropicModelList
+		u := p.config.BaseURL + modelsPath + "?" + params.Encode()
+		if err := p.doBatchRequest(ctx, http.MethodGet, u, nil, p.buildHeaders(), &page); err != nil {
+			return nil, err
+		}
+
+		for _, m := range page.Data {
+			live = append(live, core.ModelInfo{
+				ID:          core.ModelID(m.ID),
+				DisplayName: m.DisplayName,
+				Capabilities: []core.Feature{
+					core.FeatureChat,
+					core.FeatureChatStreaming,
+					core.FeatureToolCalling,
+				},
+			})
+		}
+
+		if !page.HasMore || page.LastID == "" {
+			break
+		}
+		afterID = page.LastID
+	}
+	return core.MergeModels(live, models), nil
+}
+
+// Compile-time check that Anthropic implements ModelLister.
+var _ core.ModelLister = (*Anthropic)(nil)
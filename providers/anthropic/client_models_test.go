@@ -0,0 +1,75 @@
+package anthropic
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestListModels(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/models" {
+			t.Errorf("request = %s %s, want GET /v1/models", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("x-api-key"); got != "test-key" {
+			t.Errorf("x-api-key = %q", got)
+		}
+		if got := r.URL.Query().Get("limit"); got != "1000" {
+			t.Errorf("limit = %q, want 1000", got)
+		}
+
+		switch r.URL.Query().Get("after_id") {
+		case "":
+			io.WriteString(w, `{"data":[{"type":"model","id":"claude-sonnet-4-5","display_name":"Claude Sonnet 4.5 (live)","created_at":"2025-09-29T00:00:00Z"}],
+				"has_more":true,"first_id":"claude-sonnet-4-5","last_id":"claude-sonnet-4-5"}`)
+		case "claude-sonnet-4-5":
+			io.WriteString(w, `{"data":[{"type":"model","id":"claude-opus-9","display_name":"Claude Opus 9","created_at":"2026-09-01T00:00:00Z"}],
+				"has_more":false,"first_id":"claude-opus-9","last_id":"claude-opus-9"}`)
+		default:
+			t.Errorf("unexpected after_id %q", r.URL.Query().Get("after_id"))
+		}
+	}))
+	defer server.Close()
+
+	var provider core.ModelLister = New("test-key", WithBaseURL(server.URL))
+	models, err := provider.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if len(models) != 2 {
+		t.Fatalf("ListModels() returned %d models, want 2", len(models))
+	}
+	if models[0].ID != ModelClaudeSonnet45 || models[0].DisplayName != "Claude Sonnet 4.5" {
+		t.Errorf("models[0] = %+v, want static metadata", models[0])
+	}
+	if models[1].ID != "claude-opus-9" || models[1].DisplayName != "Claude Opus 9" || !models[1].HasCapability(core.FeatureToolCalling) {
+		t.Errorf("models[1] = %+v", models[1])
+	}
+
+	if _, err := provider.ListModels(context.Background()); err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("API calls = %d, want 2 (one per page, then cached)", calls)
+	}
+}
+
+func TestListModelsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		io.WriteString(w, `{"type":"error","error":{"type":"authentication_error","message":"invalid x-api-key"}}`)
+	}))
+	defer server.Close()
+
+	_, err := New("test-key", WithBaseURL(server.URL), WithModelCacheTTL(0)).ListModels(context.Background())
+	if !errors.Is(err, core.ErrUnauthorized) {
+		t.Errorf("ListModels() error = %v, want ErrUnauthorized", err)
+	}
+}
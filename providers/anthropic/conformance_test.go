@@ -0,0 +1,84 @@
+package anthropic
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers/providertest"
+)
+
+// sseEvents renders alternating event names and data payloads as an SSE body.
+func sseEvents(pairs ...string) string {
+	var sb strings.Builder
+	for i := 0; i+1 < len(pairs); i += 2 {
+		sb.WriteString("event: " + pairs[i] + "\n")
+		sb.WriteString("data: " + pairs[i+1] + "\n\n")
+	}
+	return sb.String()
+}
+
+func TestConformance(t *testing.T) {
+	sse := http.Header{"Content-Type": {"text/event-stream"}}
+
+	suite := providertest.Suite{
+		NewProvider: func(baseURL string) core.Provider {
+			return New("test-key", WithBaseURL(baseURL))
+		},
+		Model: "claude-sonnet-4-5",
+		Chat: &providertest.Fixture{
+			Body:   `{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4-5","content":[{"type":"text","text":"Hello there"}],"stop_reason":"end_turn","usage":{"input_tokens":5,"output_tokens":2}}`,
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		Stream: &providertest.Fixture{
+			Header: sse,
+			Body: sseEvents(
+				"message_start", `{"type":"message_start","message":{"id":"msg_2","model":"claude-sonnet-4-5","usage":{"input_tokens":5,"output_tokens":0}}}`,
+				"content_block_start", `{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+				"content_block_delta", `{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hello"}}`,
+				"content_block_delta", `{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":" there"}}`,
+				"content_block_stop", `{"type":"content_block_stop","index":0}`,
+				"message_delta", `{"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":2}}`,
+				"message_stop", `{"type":"message_stop"}`,
+			),
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		ToolCall: &providertest.Fixture{
+			Body: `{"id":"msg_3","type":"message","role":"assistant","model":"claude-sonnet-4-5","content":[{"type":"tool_use","id":"toolu_1","name":"get_weather","input":{"location": "NYC"}}],"stop_reason":"tool_use","usage":{"input_tokens":5,"output_tokens":2}}`,
+			ToolCalls: []core.ToolCall{
+				{ID: "toolu_1", Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		StreamToolCall: &providertest.Fixture{
+			Header: sse,
+			Body: sseEvents(
+				"message_start", `{"type":"message_start","message":{"id":"msg_4","model":"claude-sonnet-4-5","usage":{"input_tokens":5,"output_tokens":0}}}`,
+				"content_block_start", `{"type":"content_block_start","index":0,"content_block":{"type":"tool_use","id":"toolu_2","name":"get_weather"}}`,
+				"content_block_delta", `{"type":"content_block_delta","index":0,"delta":{"type":"input_json_delta","partial_json":"{\"location\":"}}`,
+				"content_block_delta", `{"type":"content_block_delta","index":0,"delta":{"type":"input_json_delta","partial_json":" \"NYC\"}"}}`,
+				"content_block_stop", `{"type":"content_block_stop","index":0}`,
+				"message_delta", `{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":2}}`,
+				"message_stop", `{"type":"message_stop"}`,
+			),
+			ToolCalls: []core.ToolCall{
+				{ID: "toolu_2", Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		Cancel: &providertest.Fixture{
+			Header: sse,
+			Body: sseEvents(
+				"message_start", `{"type":"message_start","message":{"id":"msg_5","model":"claude-sonnet-4-5","usage":{"input_tokens":5,"output_tokens":0}}}`,
+				"content_block_delta", `{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hello"}}`,
+			),
+		},
+		ErrorBody: func(status int) string {
+			return `{"type":"error","error":{"type":"test_error","message":"request failed"}}`
+		},
+	}
+
+	suite.Run(t)
+}
@@ -60,14 +60,18 @@ func mapMessages(msgs []core.Message) (system string, messages []anthropicMessag
 		case core.RoleSystem:
 			systemParts = append(systemParts, msg.Content)
 		case core.RoleUser, core.RoleAssistant:
-			messages = append(messages, anthropicMessage{
-				Role: string(msg.Role),
-				Content: []anthropicContentBlock{
-					{
-						Type: "text",
-						Text: msg.Content,
-					},
+			content := []anthropicContentBlock{
+				{
+					Type: "text",
+					Text: msg.Content,
 				},
+			}
+			if len(msg.Parts) > 0 {
+				content = mapContentParts(msg.Parts)
+			}
+			messages = append(messages, anthropicMessage{
+				Role:    string(msg.Role),
+				Content: content,
 			})
 		}
 	}
@@ -80,6 +84,85 @@ func mapMessages(msgs []core.Message) (system string, messages []anthropicMessag
 	return system, messages
 }
 
+// mapContentParts converts multimodal content parts to Anthropic content blocks.
+// Files and images referenced by ID use the Files API; see usesFiles.
+func mapContentParts(parts []core.ContentPart) []anthropicContentBlock {
+	blocks := make([]anthropicContentBlock, 0, len(parts))
+	for _, part := range parts {
+		switch p := part.(type) {
+		case core.InputText:
+			blocks = append(blocks, anthropicContentBlock{Type: "text", Text: p.Text})
+		case *core.InputText:
+			blocks = append(blocks, anthropicContentBlock{Type: "text", Text: p.Text})
+		case core.InputImage:
+			blocks = append(blocks, mapInputImage(p))
+		case *core.InputImage:
+			blocks = append(blocks, mapInputImage(*p))
+		case core.InputFile:
+			blocks = append(blocks, mapInputFile(p))
+		case *core.InputFile:
+			blocks = append(blocks, mapInputFile(*p))
+		}
+	}
+	return blocks
+}
+
+// mapInputImage converts an InputImage to an image block.
+func mapInputImage(img core.InputImage) anthropicContentBlock {
+	block := anthropicContentBlock{Type: "image"}
+	switch {
+	case img.FileID != "":
+		block.Source = &anthropicSource{Type: "file", FileID: img.FileID}
+	case strings.HasPrefix(img.ImageURL, "data:"):
+		mediaType, data := parseDataURL(img.ImageURL)
+		block.Source = &anthropicSource{Type: "base64", MediaType: mediaType, Data: data}
+	default:
+		block.Source = &anthropicSource{Type: "url", URL: img.ImageURL}
+	}
+	return block
+}
+
+// mapInputFile converts an InputFile to a document block.
+// Inline file data is sent as a PDF unless the filename says otherwise.
+func mapInputFile(file core.InputFile) anthropicContentBlock {
+	block := anthropicContentBlock{Type: "document"}
+	switch {
+	case file.FileID != "":
+		block.Source = &anthropicSource{Type: "file", FileID: file.FileID}
+	case file.FileData != "":
+		mediaType := "application/pdf"
+		if strings.HasSuffix(strings.ToLower(file.Filename), ".txt") {
+			mediaType = "text/plain"
+		}
+		block.Source = &anthropicSource{Type: "base64", MediaType: mediaType, Data: file.FileData}
+	default:
+		block.Source = &anthropicSource{Type: "url", URL: file.FileURL}
+	}
+	return block
+}
+
+// parseDataURL extracts the media type and base64 data from a data URL.
+// Format: data:mime/type;base64,<data>
+func parseDataURL(dataURL string) (mediaType, data string) {
+	rest := strings.TrimPrefix(dataURL, "data:")
+	meta, data, _ := strings.Cut(rest, ",")
+	mediaType, _, _ = strings.Cut(meta, ";")
+	return mediaType, data
+}
+
+// usesFiles reports whether the request references Files API uploads,
+// which requires the Files API beta header.
+func usesFiles(req *anthropicRequest) bool {
+	for _, msg := range req.Messages {
+		for _, block := range msg.Content {
+			if block.Source != nil && block.Source.Type == "file" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // mapTools converts Iris tools to Anthropic tool format.
 // Tools that implement schemaProvider will have their schema included.
 func mapTools(irisTools []core.Tool) []anthropicTool {
@@ -392,3 +392,35 @@ func TestMapResponseMultipleTextBlocks(t *testing.T) {
 		t.Errorf("Output = %q, want 'First Second'", result.Output)
 	}
 }
+
+func TestMapMessagesWithParts(t *testing.T) {
+	_, messages := mapMessages([]core.Message{{
+		Role: core.RoleUser,
+		Parts: []core.ContentPart{
+			&core.InputText{Text: "Compare these"},
+			&core.InputImage{ImageURL: "data:image/png;base64,iVBORw0KGgo="},
+			core.InputImage{ImageURL: "https://example.com/cat.jpg"},
+			&core.InputFile{Filename: "doc.pdf", FileData: "JVBERi0="},
+		},
+	}})
+
+	blocks := messages[0].Content
+	if len(blocks) != 4 {
+		t.Fatalf("len(blocks) = %d, want 4", len(blocks))
+	}
+	if blocks[0].Type != "text" || blocks[0].Text != "Compare these" {
+		t.Errorf("blocks[0] = %+v", blocks[0])
+	}
+	if src := blocks[1].Source; blocks[1].Type != "image" || src.Type != "base64" || src.MediaType != "image/png" || src.Data != "iVBORw0KGgo=" {
+		t.Errorf("blocks[1] = %+v, source = %+v", blocks[1], src)
+	}
+	if src := blocks[2].Source; src.Type != "url" || src.URL != "https://example.com/cat.jpg" {
+		t.Errorf("blocks[2] source = %+v", src)
+	}
+	if src := blocks[3].Source; blocks[3].Type != "document" || src.MediaType != "application/pdf" {
+		t.Errorf("blocks[3] = %+v, source = %+v", blocks[3], src)
+	}
+	if usesFiles(&anthropicRequest{Messages: messages}) {
+		t.Error("usesFiles() = true for inline content")
+	}
+}
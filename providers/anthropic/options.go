@@ -27,6 +27,10 @@ type Config struct {
 
 	// FilesAPIBeta is the beta version for Files API. Defaults to DefaultFilesAPIBeta.
 	FilesAPIBeta string
+
+	// ModelCacheTTL is how long ListModels results are cached.
+	// Defaults to core.DefaultModelCacheTTL; zero or less disables caching.
+	ModelCacheTTL time.Duration
 }
 
 // DefaultBaseURL is the default Anthropic API base URL.
@@ -85,3 +89,11 @@ func WithFilesAPIBeta(version string) Option {
 		c.FilesAPIBeta = version
 	}
 }
+
+// WithModelCacheTTL sets how long ListModels results are cached.
+// A duration of zero or less disables caching.
+func WithModelCacheTTL(d time.Duration) Option {
+	return func(c *Config) {
+		c.ModelCacheTTL = d
+	}
+}
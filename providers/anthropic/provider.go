@@ -11,23 +11,28 @@ import (
 // Anthropic is safe for concurrent use.
 type Anthropic struct {
 	config Config
+
+	modelCache *core.ModelCache // caches ListModels results
 }
 
 // New creates a new Anthropic provider with the given API key and options.
 func New(apiKey string, opts ...Option) *Anthropic {
 	cfg := Config{
-		APIKey:       apiKey,
-		BaseURL:      DefaultBaseURL,
-		HTTPClient:   http.DefaultClient,
-		Version:      DefaultVersion,
-		FilesAPIBeta: DefaultFilesAPIBeta,
+		APIKey:        apiKey,
+		BaseURL:       DefaultBaseURL,
+		HTTPClient:    http.DefaultClient,
+		Version:       DefaultVersion,
+		FilesAPIBeta:  DefaultFilesAPIBeta,
+		ModelCacheTTL: core.DefaultModelCacheTTL,
 	}
 
 	for _, opt := range opts {
 		opt(&cfg)
 	}
 
-	return &Anthropic{config: cfg}
+	p := &Anthropic{config: cfg}
+	p.modelCache = core.NewModelCache(cfg.ModelCacheTTL, p.fetchModels)
+	return p
 }
 
 // ID returns the provider identifier.
@@ -46,7 +51,7 @@ func (p *Anthropic) Models() []core.ModelInfo {
 // Supports reports whether the provider supports the given feature.
 func (p *Anthropic) Supports(feature core.Feature) bool {
 	switch feature {
-	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling:
+	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureFiles, core.FeatureBatch, core.FeatureModelListing:
 		return true
 	default:
 		return false
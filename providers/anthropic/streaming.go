@@ -106,8 +106,12 @@ func (p *Anthropic) doStreamChat(ctx context.Context, req *core.ChatRequest) (*c
 		return nil, newNetworkError(err)
 	}
 
-	// Set headers
-	for key, values := range p.buildHeaders() {
+	// Set headers; file references need the Files API beta
+	headers := p.buildHeaders()
+	if usesFiles(antReq) {
+		headers = p.buildFilesHeaders()
+	}
+	for key, values := range headers {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -27,6 +27,17 @@ type anthropicContentBlock struct {
 	// For tool_result blocks
 	ToolUseID string `json:"tool_use_id,omitempty"`
 	Content   string `json:"content,omitempty"`
+	// For image and document blocks
+	Source *anthropicSource `json:"source,omitempty"`
+}
+
+// anthropicSource is the source of an image or document block.
+type anthropicSource struct {
+	Type      string `json:"type"` // "base64", "url", or "file"
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	URL       string `json:"url,omitempty"`
+	FileID    string `json:"file_id,omitempty"`
 }
 
 // anthropicTool represents a tool definition in the Anthropic format.
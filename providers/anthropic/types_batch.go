@@ -0,0 +1,42 @@
+package anthropic
+
+// anthropicBatchItem is one request of a Message Batch.
+type anthropicBatchItem struct {
+	CustomID string            `json:"custom_id"`
+	Params   *anthropicRequest `json:"params"`
+}
+
+// anthropicBatch is a Message Batch object.
+type anthropicBatch struct {
+	ID                string              `json:"id"`
+	Type              string              `json:"type"`
+	ProcessingStatus  string              `json:"processing_status"`
+	RequestCounts     anthropicBatchCount `json:"request_counts"`
+	CreatedAt         string              `json:"created_at"`
+	EndedAt           *string             `json:"ended_at"`
+	ExpiresAt         string              `json:"expires_at"`
+	CancelInitiatedAt *string             `json:"cancel_initiated_at"`
+	ResultsURL        *string             `json:"results_url"`
+}
+
+// anthropicBatchCount reports per-request progress of a Message Batch.
+type anthropicBatchCount struct {
+	Processing int `json:"processing"`
+	Succeeded  int `json:"succeeded"`
+	Errored    int `json:"errored"`
+	Canceled   int `json:"canceled"`
+	Expired    int `json:"expired"`
+}
+
+// anthropicBatchResultLine is one line of a Message Batch results file.
+type anthropicBatchResultLine struct {
+	CustomID string               `json:"custom_id"`
+	Result   anthropicBatchResult `json:"result"`
+}
+
+// anthropicBatchResult is the outcome of a single batch request.
+type anthropicBatchResult struct {
+	Type    string                  `json:"type"` // succeeded, errored, canceled, expired
+	Message *anthropicResponse      `json:"message,omitempty"`
+	Error   *anthropicErrorResponse `json:"error,omitempty"`
+}
@@ -0,0 +1,15 @@
+package anthropic
+
+// anthropicModelList is a page of models from GET /v1/models.
+type anthropicModelList struct {
+	Data    []anthropicModel `json:"data"`
+	HasMore bool             `json:"has_more"`
+	LastID  string           `json:"last_id"`
+}
+
+// anthropicModel is a model entry from GET /v1/models.
+type anthropicModel struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+	CreatedAt   string `json:"created_at"`
+}
@@ -0,0 +1,60 @@
+package bedrock
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// doChat performs a non-streaming Converse request.
+func (p *Bedrock) doChat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	// Build Converse request
+	cReq, err := buildRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Marshal request body
+	body, err := json.Marshal(cReq)
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	// Create signed HTTP request
+	httpReq, err := p.newRequest(ctx, req.Model, "converse", body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute request
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	// Extract request ID from response headers
+	requestID := resp.Header.Get("x-amzn-RequestId")
+
+	// Check for error status
+	if resp.StatusCode >= 400 {
+		return nil, normalizeError(resp.StatusCode, respBody, requestID, resp.Header.Get("x-amzn-ErrorType"))
+	}
+
+	// Parse response
+	var cResp converseResponse
+	if err := json.Unmarshal(respBody, &cResp); err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	// Map to Iris response
+	return mapResponse(&cResp, req.Model, requestID)
+}
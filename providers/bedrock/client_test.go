@@ -0,0 +1,166 @@
+package bedrock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestChatSignedRequest(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/model/"+string(ModelClaudeSonnet4)+"/converse" {
+			t.Errorf("Path = %q", r.URL.Path)
+		}
+		if !strings.Contains(r.URL.RawPath, "v1%3A0") {
+			t.Errorf("RawPath = %q, want encoded model ID", r.URL.RawPath)
+		}
+
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/20250102/us-west-2/bedrock/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-security-token, Signature=") {
+			t.Errorf("Authorization = %q", auth)
+		}
+		if r.Header.Get("X-Amz-Date") != "20250102T030405Z" {
+			t.Errorf("X-Amz-Date = %q", r.Header.Get("X-Amz-Date"))
+		}
+		if r.Header.Get("X-Amz-Security-Token") != "token" {
+			t.Errorf("X-Amz-Security-Token = %q", r.Header.Get("X-Amz-Security-Token"))
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("invalid request body: %v", err)
+		}
+
+		w.Header().Set("x-amzn-RequestId", "req-123")
+		w.Write([]byte(`{"output":{"message":{"role":"assistant","content":[{"reasoningContent":{"reasoningText":{"text":"thinking"}}},{"text":"Hi"}]}},"usage":{"inputTokens":3,"outputTokens":1,"totalTokens":4}}`))
+	}))
+	defer server.Close()
+
+	p := New(WithBaseURL(server.URL), WithRegion("us-west-2"), WithCredentials("AKID", "secret", "token"))
+	p.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	temp := float32(0.5)
+	maxTokens := 100
+	resp, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:       ModelClaudeSonnet4,
+		Temperature: &temp,
+		MaxTokens:   &maxTokens,
+		Messages: []core.Message{
+			{Role: core.RoleSystem, Content: "Be brief."},
+			{Role: core.RoleUser, Content: "Hello"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	if resp.ID != "req-123" || resp.Model != ModelClaudeSonnet4 || resp.Output != "Hi" {
+		t.Errorf("resp = %+v", resp)
+	}
+	if resp.Usage.TotalTokens != 4 {
+		t.Errorf("TotalTokens = %d, want 4", resp.Usage.TotalTokens)
+	}
+	if resp.Reasoning == nil || resp.Reasoning.Summary[0] != "thinking" {
+		t.Errorf("Reasoning = %+v", resp.Reasoning)
+	}
+
+	system, _ := got["system"].([]any)
+	if len(system) != 1 {
+		t.Errorf("system = %v, want one block", got["system"])
+	}
+	cfg, _ := got["inferenceConfig"].(map[string]any)
+	if cfg["maxTokens"] != float64(100) || cfg["temperature"] != 0.5 {
+		t.Errorf("inferenceConfig = %v", got["inferenceConfig"])
+	}
+}
+
+func TestChatAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer bedrock-key" {
+			t.Errorf("Authorization = %q, want bearer key", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("X-Amz-Date") != "" {
+			t.Error("request should not be signed")
+		}
+		w.Write([]byte(`{"output":{"message":{"role":"assistant","content":[{"text":"ok"}]}}}`))
+	}))
+	defer server.Close()
+
+	p := New(WithBaseURL(server.URL), WithAPIKey("bedrock-key"))
+	if _, err := p.Chat(context.Background(), &core.ChatRequest{Model: ModelNovaLite}); err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+}
+
+func TestChatMissingCredentials(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", t.TempDir()+"/missing")
+
+	p := New(WithBaseURL("http://127.0.0.1:0"))
+	_, err := p.Chat(context.Background(), &core.ChatRequest{Model: ModelNovaLite})
+
+	if !errors.Is(err, core.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+	if !errors.Is(err, ErrNoCredentials) && !strings.Contains(err.Error(), ErrNoCredentials.Error()) {
+		t.Errorf("error = %v, want mention of missing credentials", err)
+	}
+}
+
+func TestChatErrorType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-amzn-RequestId", "req-err")
+		w.Header().Set("x-amzn-ErrorType", "ValidationException:http://internal.amazon.com/coral/com.amazon.bedrock/")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"Message":"The provided model identifier is invalid."}`))
+	}))
+	defer server.Close()
+
+	p := New(WithBaseURL(server.URL), WithCredentials("AKID", "secret", ""))
+	_, err := p.Chat(context.Background(), &core.ChatRequest{Model: "bogus"})
+
+	var pErr *core.ProviderError
+	if !errors.As(err, &pErr) {
+		t.Fatalf("expected ProviderError, got %T", err)
+	}
+	if pErr.Code != "ValidationException" {
+		t.Errorf("Code = %q, want ValidationException", pErr.Code)
+	}
+	if pErr.Message != "The provided model identifier is invalid." {
+		t.Errorf("Message = %q", pErr.Message)
+	}
+	if pErr.RequestID != "req-err" {
+		t.Errorf("RequestID = %q, want req-err", pErr.RequestID)
+	}
+	if !errors.Is(err, core.ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestNewRegion(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "eu-central-1")
+
+	p := New(WithCredentials("AKID", "secret", ""))
+	if p.config.Region != "eu-central-1" {
+		t.Errorf("Region = %q, want eu-central-1", p.config.Region)
+	}
+	if p.config.BaseURL != "https://bedrock-runtime.eu-central-1.amazonaws.com" {
+		t.Errorf("BaseURL = %q", p.config.BaseURL)
+	}
+
+	p = New(WithRegion("ap-south-1"), WithCredentials("AKID", "secret", ""))
+	if p.config.BaseURL != "https://bedrock-runtime.ap-south-1.amazonaws.com" {
+		t.Errorf("BaseURL = %q", p.config.BaseURL)
+	}
+}
@@ -0,0 +1,80 @@
+package bedrock
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers/providertest"
+)
+
+// eventStream concatenates encoded events into a response body.
+func eventStream(events ...[]byte) string {
+	var body []byte
+	for _, e := range events {
+		body = append(body, e...)
+	}
+	return string(body)
+}
+
+func TestConformance(t *testing.T) {
+	es := http.Header{"Content-Type": {"application/vnd.amazon.eventstream"}}
+
+	suite := providertest.Suite{
+		NewProvider: func(baseURL string) core.Provider {
+			return New(WithBaseURL(baseURL), WithCredentials("AKID", "secret", ""))
+		},
+		Model: ModelClaudeSonnet4,
+		Chat: &providertest.Fixture{
+			Body:   `{"output":{"message":{"role":"assistant","content":[{"text":"Hello there"}]}},"stopReason":"end_turn","usage":{"inputTokens":5,"outputTokens":2,"totalTokens":7}}`,
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		Stream: &providertest.Fixture{
+			Header: es,
+			Body: eventStream(
+				encodeEvent("messageStart", `{"role":"assistant"}`),
+				encodeEvent("contentBlockDelta", `{"contentBlockIndex":0,"delta":{"text":"Hello"}}`),
+				encodeEvent("contentBlockDelta", `{"contentBlockIndex":0,"delta":{"text":" there"}}`),
+				encodeEvent("contentBlockStop", `{"contentBlockIndex":0}`),
+				encodeEvent("messageStop", `{"stopReason":"end_turn"}`),
+				encodeEvent("metadata", `{"usage":{"inputTokens":5,"outputTokens":2,"totalTokens":7},"metrics":{"latencyMs":100}}`),
+			),
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		ToolCall: &providertest.Fixture{
+			Body: `{"output":{"message":{"role":"assistant","content":[{"toolUse":{"toolUseId":"tooluse_1","name":"get_weather","input":{"location": "NYC"}}}]}},"stopReason":"tool_use","usage":{"inputTokens":5,"outputTokens":2,"totalTokens":7}}`,
+			ToolCalls: []core.ToolCall{
+				{ID: "tooluse_1", Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		StreamToolCall: &providertest.Fixture{
+			Header: es,
+			Body: eventStream(
+				encodeEvent("messageStart", `{"role":"assistant"}`),
+				encodeEvent("contentBlockStart", `{"contentBlockIndex":0,"start":{"toolUse":{"toolUseId":"tooluse_2","name":"get_weather"}}}`),
+				encodeEvent("contentBlockDelta", `{"contentBlockIndex":0,"delta":{"toolUse":{"input":"{\"location\":"}}}`),
+				encodeEvent("contentBlockDelta", `{"contentBlockIndex":0,"delta":{"toolUse":{"input":" \"NYC\"}"}}}`),
+				encodeEvent("contentBlockStop", `{"contentBlockIndex":0}`),
+				encodeEvent("messageStop", `{"stopReason":"tool_use"}`),
+			),
+			ToolCalls: []core.ToolCall{
+				{ID: "tooluse_2", Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		Cancel: &providertest.Fixture{
+			Header: es,
+			Body: eventStream(
+				encodeEvent("messageStart", `{"role":"assistant"}`),
+				encodeEvent("contentBlockDelta", `{"contentBlockIndex":0,"delta":{"text":"Hello"}}`),
+			),
+		},
+		ErrorBody: func(status int) string {
+			return `{"message":"request failed"}`
+		},
+	}
+
+	suite.Run(t)
+}
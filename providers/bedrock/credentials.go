@@ -0,0 +1,121 @@
+package bedrock
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNoCredentials is returned when no AWS credentials can be found.
+var ErrNoCredentials = errors.New("no AWS credentials found")
+
+// Credentials are AWS access credentials.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// SessionToken is set for temporary credentials.
+	SessionToken string
+}
+
+// CredentialsFromEnv reads credentials from AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY, and AWS_SESSION_TOKEN.
+func CredentialsFromEnv() (*Credentials, error) {
+	creds := &Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return nil, ErrNoCredentials
+	}
+	return creds, nil
+}
+
+// CredentialsFromFile reads a profile from a shared credentials file.
+// An empty path means AWS_SHARED_CREDENTIALS_FILE or ~/.aws/credentials.
+func CredentialsFromFile(path, profile string) (*Credentials, error) {
+	if path == "" {
+		path = sharedCredentialsPath()
+	}
+	if profile == "" {
+		profile = "default"
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoCredentials
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	creds := &Credentials{}
+	inProfile := false
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			inProfile = strings.TrimSpace(line[1:len(line)-1]) == profile
+			continue
+		}
+		if !inProfile {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "aws_access_key_id":
+			creds.AccessKeyID = strings.TrimSpace(value)
+		case "aws_secret_access_key":
+			creds.SecretAccessKey = strings.TrimSpace(value)
+		case "aws_session_token":
+			creds.SessionToken = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return nil, fmt.Errorf("%w: profile %q in %s", ErrNoCredentials, profile, path)
+	}
+	return creds, nil
+}
+
+// LoadCredentials resolves credentials from the environment, then from the
+// given profile of the shared credentials file. An empty profile means
+// AWS_PROFILE or "default".
+func LoadCredentials(profile string) (*Credentials, error) {
+	if creds, err := CredentialsFromEnv(); err == nil {
+		return creds, nil
+	}
+	if profile == "" {
+		profile = os.Getenv("AWS_PROFILE")
+	}
+	return CredentialsFromFile("", profile)
+}
+
+// sharedCredentialsPath returns the shared credentials file location.
+func sharedCredentialsPath() string {
+	if path := os.Getenv("AWS_SHARED_CREDENTIALS_FILE"); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".aws", "credentials")
+	}
+	return filepath.Join(home, ".aws", "credentials")
+}
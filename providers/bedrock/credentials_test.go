@@ -0,0 +1,108 @@
+package bedrock
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testCredentialsFile = `
+# comment
+[default]
+aws_access_key_id = AKIDDEFAULT
+aws_secret_access_key = secret-default
+
+[work]
+aws_access_key_id=AKIDWORK
+aws_secret_access_key=secret-work
+aws_session_token=token-work
+`
+
+func writeCredentialsFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(path, []byte(testCredentialsFile), 0600); err != nil {
+		t.Fatalf("failed to write credentials file: %v", err)
+	}
+	return path
+}
+
+func TestCredentialsFromEnv(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDENV")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret-env")
+	t.Setenv("AWS_SESSION_TOKEN", "token-env")
+
+	creds, err := CredentialsFromEnv()
+	if err != nil {
+		t.Fatalf("CredentialsFromEnv() error = %v", err)
+	}
+	if creds.AccessKeyID != "AKIDENV" || creds.SecretAccessKey != "secret-env" || creds.SessionToken != "token-env" {
+		t.Errorf("creds = %+v", creds)
+	}
+
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	if _, err := CredentialsFromEnv(); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("CredentialsFromEnv() error = %v, want ErrNoCredentials", err)
+	}
+}
+
+func TestCredentialsFromFile(t *testing.T) {
+	path := writeCredentialsFile(t)
+
+	creds, err := CredentialsFromFile(path, "")
+	if err != nil {
+		t.Fatalf("CredentialsFromFile() error = %v", err)
+	}
+	if creds.AccessKeyID != "AKIDDEFAULT" || creds.SessionToken != "" {
+		t.Errorf("default creds = %+v", creds)
+	}
+
+	creds, err = CredentialsFromFile(path, "work")
+	if err != nil {
+		t.Fatalf("CredentialsFromFile(work) error = %v", err)
+	}
+	if creds.AccessKeyID != "AKIDWORK" || creds.SecretAccessKey != "secret-work" || creds.SessionToken != "token-work" {
+		t.Errorf("work creds = %+v", creds)
+	}
+
+	if _, err := CredentialsFromFile(path, "missing"); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("CredentialsFromFile(missing) error = %v, want ErrNoCredentials", err)
+	}
+	if _, err := CredentialsFromFile(filepath.Join(t.TempDir(), "nope"), ""); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("CredentialsFromFile(nonexistent) error = %v, want ErrNoCredentials", err)
+	}
+}
+
+func TestLoadCredentialsPrecedence(t *testing.T) {
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", writeCredentialsFile(t))
+	t.Setenv("AWS_PROFILE", "work")
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+
+	creds, err := LoadCredentials("")
+	if err != nil {
+		t.Fatalf("LoadCredentials() error = %v", err)
+	}
+	if creds.AccessKeyID != "AKIDWORK" {
+		t.Errorf("AccessKeyID = %q, want profile from AWS_PROFILE", creds.AccessKeyID)
+	}
+
+	creds, err = LoadCredentials("default")
+	if err != nil {
+		t.Fatalf("LoadCredentials(default) error = %v", err)
+	}
+	if creds.AccessKeyID != "AKIDDEFAULT" {
+		t.Errorf("AccessKeyID = %q, want explicit profile", creds.AccessKeyID)
+	}
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDENV")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret-env")
+	creds, err = LoadCredentials("default")
+	if err != nil {
+		t.Fatalf("LoadCredentials() error = %v", err)
+	}
+	if creds.AccessKeyID != "AKIDENV" {
+		t.Errorf("AccessKeyID = %q, want environment to take precedence", creds.AccessKeyID)
+	}
+}
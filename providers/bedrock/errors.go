@@ -0,0 +1,119 @@
+package bedrock
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// ErrToolArgsInvalidJSON is returned when tool call arguments contain invalid JSON.
+var ErrToolArgsInvalidJSON = errors.New("tool args invalid json")
+
+// normalizeError converts an HTTP error response to a ProviderError with the appropriate sentinel.
+// errorType is the x-amzn-ErrorType header, e.g. "ValidationException:http://...".
+func normalizeError(status int, body []byte, requestID, errorType string) error {
+	// Parse error response if possible
+	var errResp errorResponse
+	_ = json.Unmarshal(body, &errResp)
+
+	message := errResp.Message
+	if message == "" {
+		message = errResp.MessageUpper
+	}
+	if message == "" {
+		message = http.StatusText(status)
+	}
+
+	code, _, _ := strings.Cut(errorType, ":")
+
+	// Determine sentinel error based on status
+	var sentinel error
+	switch {
+	case status == http.StatusBadRequest:
+		sentinel = core.ErrBadRequest
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		sentinel = core.ErrUnauthorized
+	case status == http.StatusNotFound:
+		sentinel = core.ErrNotFound
+	case status == http.StatusTooManyRequests:
+		sentinel = core.ErrRateLimited
+	case status >= 500:
+		sentinel = core.ErrServer
+	default:
+		sentinel = core.ErrServer
+	}
+
+	return &core.ProviderError{
+		Provider:  "bedrock",
+		Status:    status,
+		RequestID: requestID,
+		Code:      code,
+		Message:   message,
+		Err:       sentinel,
+	}
+}
+
+// newStreamError creates a ProviderError for an exception sent mid-stream.
+// exceptionType is the :exception-type header, e.g. "throttlingException".
+func newStreamError(exceptionType string, payload []byte, requestID string) error {
+	var errResp errorResponse
+	_ = json.Unmarshal(payload, &errResp)
+
+	message := errResp.Message
+	if message == "" {
+		message = errResp.MessageUpper
+	}
+	if message == "" {
+		message = exceptionType
+	}
+
+	var sentinel error
+	switch exceptionType {
+	case "throttlingException":
+		sentinel = core.ErrRateLimited
+	case "validationException":
+		sentinel = core.ErrBadRequest
+	case "accessDeniedException":
+		sentinel = core.ErrUnauthorized
+	default:
+		sentinel = core.ErrServer
+	}
+
+	return &core.ProviderError{
+		Provider:  "bedrock",
+		RequestID: requestID,
+		Code:      exceptionType,
+		Message:   message,
+		Err:       sentinel,
+	}
+}
+
+// newCredentialsError creates a ProviderError for missing credentials.
+func newCredentialsError(err error) error {
+	return &core.ProviderError{
+		Provider: "bedrock",
+		Message:  err.Error(),
+		Err:      core.ErrUnauthorized,
+	}
+}
+
+// newNetworkError creates a ProviderError for network-related failures.
+func newNetworkError(err error) error {
+	return &core.ProviderError{
+		Provider: "bedrock",
+		Message:  err.Error(),
+		Err:      core.ErrNetwork,
+	}
+}
+
+// newDecodeError creates a ProviderError for decode failures.
+func newDecodeError(err error) error {
+	return &core.ProviderError{
+		Provider: "bedrock",
+		Message:  err.Error(),
+		Err:      core.ErrDecode,
+	}
+}
@@ -0,0 +1,153 @@
+package bedrock
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// AWS event-stream framing, used by ConverseStream responses.
+//
+// Each message is laid out as:
+//
+//	total length   uint32
+//	headers length uint32
+//	prelude CRC    uint32   (CRC-32 of the first 8 bytes)
+//	headers        [headers length]byte
+//	payload        [total length - headers length - 16]byte
+//	message CRC    uint32   (CRC-32 of everything before it)
+const (
+	preludeLen       = 12
+	messageCRCLen    = 4
+	minMessageLen    = preludeLen + messageCRCLen
+	maxMessageLen    = 16 << 20
+	maxHeadersLength = 128 << 10
+)
+
+// Header value types defined by the event-stream encoding.
+const (
+	headerBoolTrue  = 0
+	headerBoolFalse = 1
+	headerByte      = 2
+	headerInt16     = 3
+	headerInt32     = 4
+	headerInt64     = 5
+	headerBytes     = 6
+	headerString    = 7
+	headerTimestamp = 8
+	headerUUID      = 9
+)
+
+// ErrEventStreamCorrupt is returned when an event-stream message is malformed
+// or fails its checksum.
+var ErrEventStreamCorrupt = errors.New("corrupt event stream message")
+
+// eventMessage is a decoded event-stream message.
+// Only string-typed headers are kept; other header types are skipped.
+type eventMessage struct {
+	Headers map[string]string
+	Payload []byte
+}
+
+// eventStreamDecoder reads event-stream messages from a reader.
+type eventStreamDecoder struct {
+	r io.Reader
+}
+
+func newEventStreamDecoder(r io.Reader) *eventStreamDecoder {
+	return &eventStreamDecoder{r: r}
+}
+
+// Decode reads the next message. It returns io.EOF at a clean end of stream
+// and io.ErrUnexpectedEOF if the stream ends mid-message.
+func (d *eventStreamDecoder) Decode() (*eventMessage, error) {
+	var prelude [preludeLen]byte
+	if _, err := io.ReadFull(d.r, prelude[:]); err != nil {
+		return nil, err
+	}
+
+	totalLen := binary.BigEndian.Uint32(prelude[0:4])
+	headersLen := binary.BigEndian.Uint32(prelude[4:8])
+	if crc32.ChecksumIEEE(prelude[:8]) != binary.BigEndian.Uint32(prelude[8:12]) {
+		return nil, fmt.Errorf("%w: prelude checksum mismatch", ErrEventStreamCorrupt)
+	}
+	if totalLen < minMessageLen || totalLen > maxMessageLen ||
+		headersLen > maxHeadersLength || headersLen > totalLen-minMessageLen {
+		return nil, fmt.Errorf("%w: invalid lengths (total=%d, headers=%d)", ErrEventStreamCorrupt, totalLen, headersLen)
+	}
+
+	rest := make([]byte, totalLen-preludeLen)
+	if _, err := io.ReadFull(d.r, rest); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	body := rest[:len(rest)-messageCRCLen]
+	crc := crc32.NewIEEE()
+	crc.Write(prelude[:])
+	crc.Write(body)
+	if crc.Sum32() != binary.BigEndian.Uint32(rest[len(rest)-messageCRCLen:]) {
+		return nil, fmt.Errorf("%w: message checksum mismatch", ErrEventStreamCorrupt)
+	}
+
+	headers, err := decodeHeaders(body[:headersLen])
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventMessage{
+		Headers: headers,
+		Payload: body[headersLen:],
+	}, nil
+}
+
+// decodeHeaders parses the header block of a message.
+func decodeHeaders(b []byte) (map[string]string, error) {
+	headers := make(map[string]string)
+	for len(b) > 0 {
+		nameLen := int(b[0])
+		if len(b) < 1+nameLen+1 {
+			return nil, fmt.Errorf("%w: truncated header", ErrEventStreamCorrupt)
+		}
+		name := string(b[1 : 1+nameLen])
+		valueType := b[1+nameLen]
+		b = b[2+nameLen:]
+
+		var size int
+		switch valueType {
+		case headerBoolTrue, headerBoolFalse:
+			size = 0
+		case headerByte:
+			size = 1
+		case headerInt16:
+			size = 2
+		case headerInt32:
+			size = 4
+		case headerInt64, headerTimestamp:
+			size = 8
+		case headerUUID:
+			size = 16
+		case headerBytes, headerString:
+			if len(b) < 2 {
+				return nil, fmt.Errorf("%w: truncated header %q", ErrEventStreamCorrupt, name)
+			}
+			size = int(binary.BigEndian.Uint16(b[:2]))
+			b = b[2:]
+		default:
+			return nil, fmt.Errorf("%w: unknown header type %d", ErrEventStreamCorrupt, valueType)
+		}
+
+		if len(b) < size {
+			return nil, fmt.Errorf("%w: truncated header %q", ErrEventStreamCorrupt, name)
+		}
+		if valueType == headerString {
+			headers[name] = string(b[:size])
+		}
+		b = b[size:]
+	}
+	return headers, nil
+}
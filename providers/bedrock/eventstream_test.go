@@ -0,0 +1,118 @@
+package bedrock
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"testing"
+)
+
+// encodeEventMessage frames string headers and a payload as an event-stream message.
+func encodeEventMessage(headers map[string]string, payload []byte) []byte {
+	var hb bytes.Buffer
+	for name, value := range headers {
+		hb.WriteByte(byte(len(name)))
+		hb.WriteString(name)
+		hb.WriteByte(headerString)
+		binary.Write(&hb, binary.BigEndian, uint16(len(value)))
+		hb.WriteString(value)
+	}
+
+	totalLen := uint32(preludeLen + hb.Len() + len(payload) + messageCRCLen)
+
+	var msg bytes.Buffer
+	binary.Write(&msg, binary.BigEndian, totalLen)
+	binary.Write(&msg, binary.BigEndian, uint32(hb.Len()))
+	binary.Write(&msg, binary.BigEndian, crc32.ChecksumIEEE(msg.Bytes()))
+	msg.Write(hb.Bytes())
+	msg.Write(payload)
+	binary.Write(&msg, binary.BigEndian, crc32.ChecksumIEEE(msg.Bytes()))
+	return msg.Bytes()
+}
+
+// encodeEvent frames a Converse stream event.
+func encodeEvent(eventType, payload string) []byte {
+	return encodeEventMessage(map[string]string{
+		":message-type": "event",
+		":event-type":   eventType,
+		":content-type": "application/json",
+	}, []byte(payload))
+}
+
+func TestEventStreamDecode(t *testing.T) {
+	var stream bytes.Buffer
+	stream.Write(encodeEvent("messageStart", `{"role":"assistant"}`))
+	stream.Write(encodeEvent("messageStop", `{"stopReason":"end_turn"}`))
+
+	dec := newEventStreamDecoder(&stream)
+
+	msg, err := dec.Decode()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if msg.Headers[":event-type"] != "messageStart" {
+		t.Errorf(":event-type = %q, want messageStart", msg.Headers[":event-type"])
+	}
+	if string(msg.Payload) != `{"role":"assistant"}` {
+		t.Errorf("Payload = %s", msg.Payload)
+	}
+
+	msg, err = dec.Decode()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if msg.Headers[":event-type"] != "messageStop" {
+		t.Errorf(":event-type = %q, want messageStop", msg.Headers[":event-type"])
+	}
+
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("Decode() at end = %v, want io.EOF", err)
+	}
+}
+
+func TestEventStreamDecodeNonStringHeaders(t *testing.T) {
+	// Header block: ":a" bool true, "n" int32 7, "s" string "v"
+	var hb bytes.Buffer
+	hb.Write([]byte{2, ':', 'a', headerBoolTrue})
+	hb.Write([]byte{1, 'n', headerInt32, 0, 0, 0, 7})
+	hb.Write([]byte{1, 's', headerString, 0, 1, 'v'})
+
+	headers, err := decodeHeaders(hb.Bytes())
+	if err != nil {
+		t.Fatalf("decodeHeaders() error = %v", err)
+	}
+	if len(headers) != 1 || headers["s"] != "v" {
+		t.Errorf("headers = %v, want only s=v", headers)
+	}
+}
+
+func TestEventStreamDecodeChecksumMismatch(t *testing.T) {
+	msg := encodeEvent("messageStart", `{"role":"assistant"}`)
+	msg[len(msg)-6] ^= 0xFF // corrupt the payload
+
+	_, err := newEventStreamDecoder(bytes.NewReader(msg)).Decode()
+	if !errors.Is(err, ErrEventStreamCorrupt) {
+		t.Errorf("Decode() error = %v, want ErrEventStreamCorrupt", err)
+	}
+}
+
+func TestEventStreamDecodeBadPrelude(t *testing.T) {
+	msg := encodeEvent("messageStart", `{}`)
+	msg[9] ^= 0xFF // corrupt the prelude CRC
+
+	_, err := newEventStreamDecoder(bytes.NewReader(msg)).Decode()
+	if !errors.Is(err, ErrEventStreamCorrupt) {
+		t.Errorf("Decode() error = %v, want ErrEventStreamCorrupt", err)
+	}
+}
+
+func TestEventStreamDecodeTruncated(t *testing.T) {
+	msg := encodeEvent("messageStart", `{"role":"assistant"}`)
+
+	_, err := newEventStreamDecoder(bytes.NewReader(msg[:len(msg)-3])).Decode()
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("Decode() error = %v, want io.ErrUnexpectedEOF", err)
+	}
+}
@@ -0,0 +1,352 @@
+package bedrock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/tools"
+)
+
+// ErrUnsupportedContent is returned when a message part cannot be sent
+// through the Converse API.
+var ErrUnsupportedContent = errors.New("unsupported content")
+
+// schemaProvider is an interface for tools that provide a JSON schema.
+// This allows us to check if a core.Tool also implements the full tools.Tool interface.
+type schemaProvider interface {
+	Schema() tools.ToolSchema
+}
+
+// buildRequest creates a Converse request from an Iris ChatRequest.
+func buildRequest(req *core.ChatRequest) (*converseRequest, error) {
+	system, messages, err := mapMessages(req.Instructions, req.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	cReq := &converseRequest{
+		Messages: messages,
+		System:   system,
+	}
+
+	// Only set inference config if provided
+	if req.MaxTokens != nil || req.Temperature != nil {
+		cReq.InferenceConfig = &inferenceConfig{
+			MaxTokens:   req.MaxTokens,
+			Temperature: req.Temperature,
+		}
+	}
+
+	// Map tools if present
+	if len(req.Tools) > 0 {
+		cReq.ToolConfig = &toolConfig{Tools: mapTools(req.Tools)}
+	}
+
+	if req.ReasoningEffort != "" && req.ReasoningEffort != core.ReasoningEffortNone {
+		if err := mapThinking(cReq, req); err != nil {
+			return nil, err
+		}
+	}
+
+	return cReq, nil
+}
+
+// minThinkingBudget is the smallest thinking budget Claude accepts.
+const minThinkingBudget = 1024
+
+// mapThinking enables Claude's extended thinking with a token budget for
+// the reasoning effort. The budget must be less than maxTokens, so
+// maxTokens defaults to leave room for the answer, and an explicit limit
+// caps the budget.
+func mapThinking(cReq *converseRequest, req *core.ChatRequest) error {
+	if !strings.Contains(string(req.Model), "anthropic.claude") {
+		return fmt.Errorf("bedrock: %w: reasoning effort on %s; only Claude models support it", core.ErrNotSupported, req.Model)
+	}
+
+	budget := thinkingBudget(req.ReasoningEffort)
+	if cReq.InferenceConfig == nil {
+		cReq.InferenceConfig = &inferenceConfig{}
+	}
+	if maxTokens := cReq.InferenceConfig.MaxTokens; maxTokens == nil {
+		n := budget + 4096
+		cReq.InferenceConfig.MaxTokens = &n
+	} else {
+		if *maxTokens <= minThinkingBudget {
+			return fmt.Errorf("bedrock: %w: reasoning needs max tokens above %d, got %d", core.ErrBadRequest, minThinkingBudget, *maxTokens)
+		}
+		budget = min(budget, *maxTokens-1)
+	}
+
+	cReq.AdditionalModelRequestFields = map[string]any{
+		"thinking": map[string]any{"type": "enabled", "budget_tokens": budget},
+	}
+	return nil
+}
+
+// thinkingBudget maps a reasoning effort to a thinking budget in tokens.
+func thinkingBudget(effort core.ReasoningEffort) int {
+	switch effort {
+	case core.ReasoningEffortLow:
+		return minThinkingBudget
+	case core.ReasoningEffortHigh:
+		return 24576
+	case core.ReasoningEffortXHigh:
+		return 32768
+	default:
+		return 8192
+	}
+}
+
+// mapMessages converts Iris messages to Converse format.
+// System messages and instructions become system blocks. A message with
+// Items is sent as their content blocks, which replays an assistant turn
+// with its signed reasoning, as extended thinking requires when tool use
+// continues, and lets the following user turn carry toolResult blocks.
+func mapMessages(instructions string, msgs []core.Message) ([]systemBlock, []converseMessage, error) {
+	var system []systemBlock
+	if instructions != "" {
+		system = append(system, systemBlock{Text: instructions})
+	}
+
+	messages := make([]converseMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		switch msg.Role {
+		case core.RoleSystem:
+			system = append(system, systemBlock{Text: msg.Content})
+		case core.RoleUser, core.RoleAssistant:
+			content, err := mapContent(msg)
+			if err != nil {
+				return nil, nil, err
+			}
+			messages = append(messages, converseMessage{
+				Role:    string(msg.Role),
+				Content: content,
+			})
+		}
+	}
+
+	return system, messages, nil
+}
+
+// mapContent converts message content to Converse content blocks.
+// Items are replayed as is; otherwise, if Parts is non-empty, it maps
+// multimodal content, and failing that uses Content.
+func mapContent(msg core.Message) ([]contentBlock, error) {
+	if len(msg.Items) > 0 {
+		blocks := make([]contentBlock, len(msg.Items))
+		for i, item := range msg.Items {
+			blocks[i] = contentBlock{raw: item.Raw}
+		}
+		return blocks, nil
+	}
+	if len(msg.Parts) == 0 {
+		return []contentBlock{{Text: msg.Content}}, nil
+	}
+
+	blocks := make([]contentBlock, 0, len(msg.Parts))
+	for _, part := range msg.Parts {
+		switch p := part.(type) {
+		case core.InputText:
+			blocks = append(blocks, contentBlock{Text: p.Text})
+		case *core.InputText:
+			blocks = append(blocks, contentBlock{Text: p.Text})
+		case core.InputImage:
+			img, err := mapImage(p)
+			if err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, contentBlock{Image: img})
+		case *core.InputImage:
+			img, err := mapImage(*p)
+			if err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, contentBlock{Image: img})
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedContent, part.ContentType())
+		}
+	}
+	return blocks, nil
+}
+
+// mapImage converts an InputImage to a Converse image block.
+// Bedrock accepts inline bytes (from a data URL) or an S3 location.
+func mapImage(img core.InputImage) (*imageBlock, error) {
+	switch {
+	case strings.HasPrefix(img.ImageURL, "data:"):
+		mimeType, data := parseDataURL(img.ImageURL)
+		format, err := imageFormat(mimeType)
+		if err != nil {
+			return nil, err
+		}
+		return &imageBlock{Format: format, Source: imageSource{Bytes: data}}, nil
+	case strings.HasPrefix(img.ImageURL, "s3://"):
+		format, err := imageFormat(guessImageType(img.ImageURL))
+		if err != nil {
+			return nil, err
+		}
+		return &imageBlock{Format: format, Source: imageSource{S3Location: &s3Location{URI: img.ImageURL}}}, nil
+	default:
+		return nil, fmt.Errorf("%w: image must be a data URL or s3:// URI", ErrUnsupportedContent)
+	}
+}
+
+// parseDataURL extracts mime type and base64 data from a data URL.
+// Format: data:mime/type;base64,<data>
+func parseDataURL(dataURL string) (mimeType, data string) {
+	meta, data, ok := strings.Cut(strings.TrimPrefix(dataURL, "data:"), ",")
+	if !ok {
+		return "", ""
+	}
+	mimeType, _, _ = strings.Cut(meta, ";")
+	return mimeType, data
+}
+
+// guessImageType guesses an image MIME type from a file name or URI.
+func guessImageType(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".png"):
+		return "image/png"
+	case strings.HasSuffix(lower, ".jpg"), strings.HasSuffix(lower, ".jpeg"):
+		return "image/jpeg"
+	case strings.HasSuffix(lower, ".gif"):
+		return "image/gif"
+	case strings.HasSuffix(lower, ".webp"):
+		return "image/webp"
+	default:
+		return ""
+	}
+}
+
+// imageFormat converts a MIME type to a Converse image format.
+func imageFormat(mimeType string) (string, error) {
+	switch mimeType {
+	case "image/png":
+		return "png", nil
+	case "image/jpeg", "image/jpg":
+		return "jpeg", nil
+	case "image/gif":
+		return "gif", nil
+	case "image/webp":
+		return "webp", nil
+	default:
+		return "", fmt.Errorf("%w: image type %q", ErrUnsupportedContent, mimeType)
+	}
+}
+
+// mapTools converts Iris tools to Converse tool specs.
+// Tools that implement schemaProvider will have their schema included.
+func mapTools(irisTools []core.Tool) []toolEntry {
+	result := make([]toolEntry, len(irisTools))
+	for i, t := range irisTools {
+		var schema json.RawMessage
+
+		// Check if the tool provides a schema
+		if sp, ok := t.(schemaProvider); ok {
+			schema = sp.Schema().JSONSchema
+		}
+
+		// Bedrock requires a schema; default to an empty object
+		if schema == nil {
+			schema = json.RawMessage(`{"type":"object","properties":{}}`)
+		}
+
+		result[i] = toolEntry{
+			ToolSpec: toolSpec{
+				Name:        t.Name(),
+				Description: t.Description(),
+				InputSchema: toolInputSchema{JSON: schema},
+			},
+		}
+	}
+	return result
+}
+
+// mapResponse converts a Converse response to an Iris ChatResponse.
+func mapResponse(resp *converseResponse, model core.ModelID, requestID string) (*core.ChatResponse, error) {
+	result := &core.ChatResponse{
+		ID:    requestID,
+		Model: model,
+		Usage: mapUsage(resp.Usage),
+	}
+
+	if resp.Output.Message == nil {
+		return result, nil
+	}
+
+	var text, reasoning strings.Builder
+	for _, block := range resp.Output.Message.Content {
+		switch {
+		case block.ToolUse != nil:
+			args := block.ToolUse.Input
+			if len(args) == 0 {
+				args = json.RawMessage(`{}`)
+			}
+			if !json.Valid(args) {
+				return nil, ErrToolArgsInvalidJSON
+			}
+			result.ToolCalls = append(result.ToolCalls, core.ToolCall{
+				ID:        block.ToolUse.ToolUseID,
+				Name:      block.ToolUse.Name,
+				Arguments: args,
+			})
+		case block.ReasoningContent != nil:
+			if rt := block.ReasoningContent.ReasoningText; rt != nil {
+				reasoning.WriteString(rt.Text)
+			}
+		default:
+			text.WriteString(block.Text)
+		}
+	}
+
+	result.Output = text.String()
+	if reasoning.Len() > 0 {
+		result.Reasoning = &core.ReasoningOutput{Summary: []string{reasoning.String()}}
+	}
+	result.Items = replayItems(resp.Output.Message.Content)
+
+	return result, nil
+}
+
+// replayItems returns the content blocks of an assistant message as items
+// to send back with ChatBuilder.ContinueWith, when they include reasoning.
+// Claude's reasoning is signed, and a conversation continuing after tool
+// use must return it unchanged.
+func replayItems(blocks []contentBlock) []core.ResponseItem {
+	if !slices.ContainsFunc(blocks, func(b contentBlock) bool { return b.ReasoningContent != nil }) {
+		return nil
+	}
+
+	items := make([]core.ResponseItem, 0, len(blocks))
+	for _, block := range blocks {
+		raw, err := json.Marshal(block)
+		if err != nil {
+			continue
+		}
+		typ := "text"
+		switch {
+		case block.ReasoningContent != nil:
+			typ = "reasoningContent"
+		case block.ToolUse != nil:
+			typ = "toolUse"
+		case block.Image != nil:
+			typ = "image"
+		}
+		items = append(items, core.ResponseItem{Type: typ, Raw: raw})
+	}
+	return items
+}
+
+// mapUsage converts Converse token usage.
+func mapUsage(u converseUsage) core.TokenUsage {
+	return core.TokenUsage{
+		PromptTokens:     u.InputTokens,
+		CompletionTokens: u.OutputTokens,
+		TotalTokens:      u.TotalTokens,
+	}
+}
@@ -0,0 +1,200 @@
+package bedrock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/tools"
+)
+
+type testTool struct{}
+
+func (testTool) Name() string        { return "get_weather" }
+func (testTool) Description() string { return "Get the weather" }
+func (testTool) Schema() tools.ToolSchema {
+	return tools.ToolSchema{JSONSchema: json.RawMessage(`{"type":"object","properties":{"location":{"type":"string"}}}`)}
+}
+func (testTool) Call(ctx context.Context, args json.RawMessage) (any, error) { return nil, nil }
+
+func TestBuildRequestImagesAndTools(t *testing.T) {
+	req := &core.ChatRequest{
+		Model:        ModelClaudeSonnet4,
+		Instructions: "Be helpful.",
+		Tools:        []core.Tool{testTool{}},
+		Messages: []core.Message{{
+			Role: core.RoleUser,
+			Parts: []core.ContentPart{
+				&core.InputText{Text: "What is this?"},
+				&core.InputImage{ImageURL: "data:image/png;base64,iVBORw0KGgo="},
+				core.InputImage{ImageURL: "s3://bucket/photo.JPG"},
+			},
+		}},
+	}
+
+	cReq, err := buildRequest(req)
+	if err != nil {
+		t.Fatalf("buildRequest() error = %v", err)
+	}
+
+	if len(cReq.System) != 1 || cReq.System[0].Text != "Be helpful." {
+		t.Errorf("System = %+v", cReq.System)
+	}
+
+	content := cReq.Messages[0].Content
+	if len(content) != 3 {
+		t.Fatalf("len(content) = %d, want 3", len(content))
+	}
+	if content[0].Text != "What is this?" {
+		t.Errorf("content[0] = %+v", content[0])
+	}
+	if img := content[1].Image; img == nil || img.Format != "png" || img.Source.Bytes != "iVBORw0KGgo=" {
+		t.Errorf("content[1].Image = %+v", content[1].Image)
+	}
+	if img := content[2].Image; img == nil || img.Format != "jpeg" || img.Source.S3Location.URI != "s3://bucket/photo.JPG" {
+		t.Errorf("content[2].Image = %+v", content[2].Image)
+	}
+
+	if cReq.ToolConfig == nil || len(cReq.ToolConfig.Tools) != 1 {
+		t.Fatalf("ToolConfig = %+v", cReq.ToolConfig)
+	}
+	spec := cReq.ToolConfig.Tools[0].ToolSpec
+	if spec.Name != "get_weather" || string(spec.InputSchema.JSON) != `{"type":"object","properties":{"location":{"type":"string"}}}` {
+		t.Errorf("ToolSpec = %+v", spec)
+	}
+	if cReq.InferenceConfig != nil {
+		t.Error("InferenceConfig should be omitted when unset")
+	}
+}
+
+func TestBuildRequestUnsupportedContent(t *testing.T) {
+	tests := []struct {
+		name string
+		part core.ContentPart
+	}{
+		{"https image", core.InputImage{ImageURL: "https://example.com/a.png"}},
+		{"unknown image type", core.InputImage{ImageURL: "data:image/bmp;base64,AAAA"}},
+		{"file", core.InputFile{FileID: "file-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildRequest(&core.ChatRequest{
+				Messages: []core.Message{{Role: core.RoleUser, Parts: []core.ContentPart{tt.part}}},
+			})
+			if !errors.Is(err, ErrUnsupportedContent) {
+				t.Errorf("buildRequest() error = %v, want ErrUnsupportedContent", err)
+			}
+		})
+	}
+}
+
+func TestBuildRequestReasoning(t *testing.T) {
+	thinking := func(cReq *converseRequest) any {
+		return cReq.AdditionalModelRequestFields["thinking"].(map[string]any)["budget_tokens"]
+	}
+	msgs := []core.Message{{Role: core.RoleUser, Content: "Why?"}}
+
+	cReq, err := buildRequest(&core.ChatRequest{Model: ModelClaudeSonnet4, Messages: msgs, ReasoningEffort: core.ReasoningEffortHigh})
+	if err != nil {
+		t.Fatalf("buildRequest() error = %v", err)
+	}
+	if got := thinking(cReq); got != 24576 {
+		t.Errorf("budget_tokens = %v, want 24576", got)
+	}
+	if *cReq.InferenceConfig.MaxTokens != 24576+4096 {
+		t.Errorf("maxTokens = %d, want room above the budget", *cReq.InferenceConfig.MaxTokens)
+	}
+	body, _ := json.Marshal(cReq)
+	if !strings.Contains(string(body), `"additionalModelRequestFields":{"thinking":{"budget_tokens":24576,"type":"enabled"}}`) {
+		t.Errorf("body = %s", body)
+	}
+
+	// An explicit limit caps the budget; cross-region profiles are Claude too
+	maxTokens := 2000
+	cReq, err = buildRequest(&core.ChatRequest{Model: "us." + ModelClaude37Sonnet, Messages: msgs, MaxTokens: &maxTokens, ReasoningEffort: core.ReasoningEffortMedium})
+	if err != nil {
+		t.Fatalf("buildRequest() error = %v", err)
+	}
+	if got := thinking(cReq); got != 1999 || *cReq.InferenceConfig.MaxTokens != 2000 {
+		t.Errorf("budget_tokens = %v with maxTokens %d", got, *cReq.InferenceConfig.MaxTokens)
+	}
+
+	// No thinking without effort, or with effort none
+	for _, effort := range []core.ReasoningEffort{"", core.ReasoningEffortNone} {
+		cReq, _ = buildRequest(&core.ChatRequest{Model: ModelClaudeSonnet4, Messages: msgs, ReasoningEffort: effort})
+		if cReq.AdditionalModelRequestFields != nil || cReq.InferenceConfig != nil {
+			t.Errorf("effort %q: request = %+v", effort, cReq)
+		}
+	}
+
+	maxTokens = 1024
+	if _, err := buildRequest(&core.ChatRequest{Model: ModelClaudeSonnet4, Messages: msgs, MaxTokens: &maxTokens, ReasoningEffort: core.ReasoningEffortLow}); !errors.Is(err, core.ErrBadRequest) {
+		t.Errorf("buildRequest() with small max tokens error = %v, want ErrBadRequest", err)
+	}
+	if _, err := buildRequest(&core.ChatRequest{Model: ModelNovaPro, Messages: msgs, ReasoningEffort: core.ReasoningEffortLow}); !errors.Is(err, core.ErrNotSupported) {
+		t.Errorf("buildRequest() for Nova error = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestBuildRequestReasoningToolFollowUp(t *testing.T) {
+	content := `[{"reasoningContent":{"reasoningText":{"text":"need weather","signature":"sig=="}}},{"text":"Checking."},{"toolUse":{"toolUseId":"t1","name":"get_weather","input":{"location":"Paris"}}}]`
+	var resp converseResponse
+	if err := json.Unmarshal([]byte(`{"output":{"message":{"role":"assistant","content":`+content+`}}}`), &resp); err != nil {
+		t.Fatal(err)
+	}
+	first, err := mapResponse(&resp, ModelClaudeSonnet4, "req-1")
+	if err != nil {
+		t.Fatalf("mapResponse() error = %v", err)
+	}
+	if len(first.Items) != 3 || first.Items[0].Type != "reasoningContent" || first.Items[2].Type != "toolUse" {
+		t.Fatalf("Items = %+v", first.Items)
+	}
+
+	cReq, err := buildRequest(&core.ChatRequest{
+		Model:           ModelClaudeSonnet4,
+		ReasoningEffort: core.ReasoningEffortLow,
+		Tools:           []core.Tool{testTool{}},
+		Messages: []core.Message{
+			{Role: core.RoleUser, Content: "Weather in Paris?"},
+			{Role: core.RoleAssistant, Content: first.Output, Items: first.Items},
+			{Role: core.RoleUser, Items: []core.ResponseItem{{
+				Type: "toolResult",
+				Raw:  json.RawMessage(`{"toolResult":{"toolUseId":"t1","content":[{"json":{"temp":21}}]}}`),
+			}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("buildRequest() error = %v", err)
+	}
+	body, err := json.Marshal(cReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sent struct {
+		Messages []struct {
+			Content json.RawMessage `json:"content"`
+		} `json:"messages"`
+	}
+	json.Unmarshal(body, &sent)
+	if len(sent.Messages) != 3 {
+		t.Fatalf("messages = %s", body)
+	}
+	// The signed reasoning is replayed ahead of the tool use
+	if got := string(sent.Messages[1].Content); got != content {
+		t.Errorf("assistant content = %s\nwant %s", got, content)
+	}
+	if got := string(sent.Messages[2].Content); got != `[{"toolResult":{"toolUseId":"t1","content":[{"json":{"temp":21}}]}}]` {
+		t.Errorf("tool result content = %s", got)
+	}
+
+	// Responses without reasoning have nothing to replay
+	resp.Output.Message.Content = resp.Output.Message.Content[1:]
+	if plain, _ := mapResponse(&resp, ModelClaudeSonnet4, "req-2"); plain.Items != nil {
+		t.Errorf("Items = %+v, want none", plain.Items)
+	}
+}
@@ -0,0 +1,60 @@
+package bedrock
+
+import "github.com/erikhoward/iris/core"
+
+// Model constants for common Bedrock model IDs.
+// Cross-region inference profiles prefix these with a geography, e.g. "us.".
+const (
+	ModelClaudeSonnet4  core.ModelID = "anthropic.claude-sonnet-4-20250514-v1:0"
+	ModelClaude37Sonnet core.ModelID = "anthropic.claude-3-7-sonnet-20250219-v1:0"
+	ModelClaude35Haiku  core.ModelID = "anthropic.claude-3-5-haiku-20241022-v1:0"
+	ModelLlama33_70B    core.ModelID = "meta.llama3-3-70b-instruct-v1:0"
+	ModelLlama31_8B     core.ModelID = "meta.llama3-1-8b-instruct-v1:0"
+	ModelNovaPro        core.ModelID = "amazon.nova-pro-v1:0"
+	ModelNovaLite       core.ModelID = "amazon.nova-lite-v1:0"
+	ModelMistralLarge2  core.ModelID = "mistral.mistral-large-2407-v1:0"
+)
+
+// models is the static list of supported models.
+var models = []core.ModelInfo{
+	{
+		ID:           ModelClaudeSonnet4,
+		DisplayName:  "Claude Sonnet 4",
+		Capabilities: []core.Feature{core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureReasoning},
+	},
+	{
+		ID:           ModelClaude37Sonnet,
+		DisplayName:  "Claude 3.7 Sonnet",
+		Capabilities: []core.Feature{core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureReasoning},
+	},
+	{
+		ID:           ModelClaude35Haiku,
+		DisplayName:  "Claude 3.5 Haiku",
+		Capabilities: []core.Feature{core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling},
+	},
+	{
+		ID:           ModelLlama33_70B,
+		DisplayName:  "Llama 3.3 70B Instruct",
+		Capabilities: []core.Feature{core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling},
+	},
+	{
+		ID:           ModelLlama31_8B,
+		DisplayName:  "Llama 3.1 8B Instruct",
+		Capabilities: []core.Feature{core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling},
+	},
+	{
+		ID:           ModelNovaPro,
+		DisplayName:  "Amazon Nova Pro",
+		Capabilities: []core.Feature{core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling},
+	},
+	{
+		ID:           ModelNovaLite,
+		DisplayName:  "Amazon Nova Lite",
+		Capabilities: []core.Feature{core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling},
+	},
+	{
+		ID:           ModelMistralLarge2,
+		DisplayName:  "Mistral Large 2",
+		Capabilities: []core.Feature{core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling},
+	},
+}
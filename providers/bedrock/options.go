@@ -0,0 +1,113 @@
+package bedrock
+
+import (
+	"net/http"
+	"time"
+)
+
+// DefaultRegion is the AWS region used when none is configured.
+const DefaultRegion = "us-east-1"
+
+// serviceName is the SigV4 signing name of the Bedrock runtime.
+const serviceName = "bedrock"
+
+// Config holds configuration for the Bedrock provider.
+type Config struct {
+	// Region is the AWS region. Defaults to AWS_REGION, AWS_DEFAULT_REGION,
+	// or DefaultRegion.
+	Region string
+
+	// BaseURL overrides the runtime endpoint,
+	// https://bedrock-runtime.{region}.amazonaws.com by default.
+	BaseURL string
+
+	// Credentials are the AWS credentials used to sign requests.
+	// When nil, they are loaded from the environment or the shared
+	// credentials file (see LoadCredentials).
+	Credentials *Credentials
+
+	// Profile selects the shared credentials file profile.
+	// Defaults to AWS_PROFILE or "default".
+	Profile string
+
+	// APIKey is an optional Bedrock API key. When set, it is sent as a
+	// bearer token and requests are not signed.
+	APIKey string
+
+	// HTTPClient is the HTTP client to use. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Headers contains optional extra headers to include in requests.
+	Headers http.Header
+
+	// Timeout is the optional request timeout.
+	Timeout time.Duration
+}
+
+// Option configures the Bedrock provider.
+type Option func(*Config)
+
+// WithRegion sets the AWS region.
+func WithRegion(region string) Option {
+	return func(c *Config) {
+		c.Region = region
+	}
+}
+
+// WithBaseURL overrides the runtime endpoint, e.g. for a VPC endpoint
+// or a local stand-in server.
+func WithBaseURL(url string) Option {
+	return func(c *Config) {
+		c.BaseURL = url
+	}
+}
+
+// WithCredentials sets static AWS credentials.
+// sessionToken may be empty for long-term credentials.
+func WithCredentials(accessKeyID, secretAccessKey, sessionToken string) Option {
+	return func(c *Config) {
+		c.Credentials = &Credentials{
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			SessionToken:    sessionToken,
+		}
+	}
+}
+
+// WithProfile selects the shared credentials file profile.
+func WithProfile(profile string) Option {
+	return func(c *Config) {
+		c.Profile = profile
+	}
+}
+
+// WithAPIKey authenticates with a Bedrock API key instead of SigV4.
+func WithAPIKey(key string) Option {
+	return func(c *Config) {
+		c.APIKey = key
+	}
+}
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Config) {
+		c.HTTPClient = client
+	}
+}
+
+// WithHeader adds an extra header to include in requests.
+func WithHeader(key, value string) Option {
+	return func(c *Config) {
+		if c.Headers == nil {
+			c.Headers = make(http.Header)
+		}
+		c.Headers.Set(key, value)
+	}
+}
+
+// WithTimeout sets the request timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Config) {
+		c.Timeout = d
+	}
+}
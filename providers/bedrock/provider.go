@@ -0,0 +1,145 @@
+// Package bedrock provides an LLM provider for Amazon Bedrock using the
+// Converse and ConverseStream APIs.
+//
+// Requests are signed with AWS Signature Version 4 by a self-contained
+// signer, so the AWS SDK is not required. Credentials are taken from
+// WithCredentials, the AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY environment
+// variables, or the shared credentials file, in that order. A Bedrock API
+// key may be used instead with WithAPIKey.
+//
+// # Usage
+//
+//	provider := bedrock.New(bedrock.WithRegion("us-west-2"))
+//	client := core.NewClient(provider)
+//	resp, err := client.Chat(bedrock.ModelClaudeSonnet4).User("Hello").GetResponse(ctx)
+//
+// ReasoningEffort enables Claude's extended thinking. A response with
+// reasoning carries its content blocks in Items, signatures included, so
+// that ChatBuilder.ContinueWith can replay the turn after tool use.
+package bedrock
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// Bedrock is an LLM provider implementation for Amazon Bedrock.
+// Bedrock is safe for concurrent use.
+type Bedrock struct {
+	config Config
+
+	// credErr records why credentials could not be loaded, if they were needed.
+	credErr error
+
+	// now returns the signing time; replaced in tests.
+	now func() time.Time
+}
+
+// New creates a new Bedrock provider with the given options.
+func New(opts ...Option) *Bedrock {
+	cfg := Config{
+		HTTPClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.Region == "" {
+		cfg.Region = firstNonEmpty(os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"), DefaultRegion)
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://bedrock-runtime." + cfg.Region + ".amazonaws.com"
+	}
+
+	p := &Bedrock{config: cfg, now: time.Now}
+
+	// Resolve credentials once, unless an API key replaces signing
+	if p.config.APIKey == "" && p.config.Credentials == nil {
+		p.config.Credentials, p.credErr = LoadCredentials(cfg.Profile)
+	}
+
+	return p
+}
+
+// ID returns the provider identifier.
+func (p *Bedrock) ID() string {
+	return "bedrock"
+}
+
+// Models returns the list of available models.
+func (p *Bedrock) Models() []core.ModelInfo {
+	// Return a copy to prevent mutation
+	result := make([]core.ModelInfo, len(models))
+	copy(result, models)
+	return result
+}
+
+// Supports reports whether the provider supports the given feature.
+func (p *Bedrock) Supports(feature core.Feature) bool {
+	switch feature {
+	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling:
+		return true
+	default:
+		return false
+	}
+}
+
+// Chat sends a non-streaming chat request.
+func (p *Bedrock) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	return p.doChat(ctx, req)
+}
+
+// StreamChat sends a streaming chat request.
+func (p *Bedrock) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	return p.doStreamChat(ctx, req)
+}
+
+// newRequest builds an authenticated POST request for a model operation,
+// e.g. "converse" or "converse-stream".
+func (p *Bedrock) newRequest(ctx context.Context, model core.ModelID, operation string, body []byte) (*http.Request, error) {
+	url := p.config.BaseURL + "/model/" + uriEncode(string(model)) + "/" + operation
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	// Copy any extra headers
+	for key, values := range p.config.Headers {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	// Authenticate
+	if p.config.APIKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+		return httpReq, nil
+	}
+	if p.credErr != nil {
+		return nil, newCredentialsError(p.credErr)
+	}
+	signRequest(httpReq, body, p.config.Credentials, p.config.Region, serviceName, p.now())
+
+	return httpReq, nil
+}
+
+// firstNonEmpty returns the first non-empty string.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Compile-time check that Bedrock implements Provider.
+var _ core.Provider = (*Bedrock)(nil)
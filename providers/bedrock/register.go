@@ -0,0 +1,16 @@
+package bedrock
+
+import (
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers"
+)
+
+func init() {
+	// Requests are signed with AWS credentials; a key, if given, is a Bedrock API key.
+	providers.Register("bedrock", func(apiKey string) core.Provider {
+		if apiKey != "" {
+			return New(WithAPIKey(apiKey))
+		}
+		return New()
+	})
+}
@@ -0,0 +1,69 @@
+package bedrock
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Credentials and timestamp from the AWS Signature Version 4 test suite.
+var (
+	testCreds = &Credentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+	testTime = time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+)
+
+func TestSignRequestGetVanilla(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+
+	signRequest(req, nil, testCreds, "us-east-1", "service", testTime)
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, " +
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization =\n%s\nwant\n%s", got, want)
+	}
+	if got := req.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+		t.Errorf("X-Amz-Date = %q, want %q", got, "20150830T123600Z")
+	}
+}
+
+func TestSignRequestSessionToken(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://bedrock-runtime.us-west-2.amazonaws.com/model/m/converse", nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	creds := *testCreds
+	creds.SessionToken = "session"
+	signRequest(req, []byte(`{}`), &creds, "us-west-2", "bedrock", testTime)
+
+	if req.Header.Get("X-Amz-Security-Token") != "session" {
+		t.Error("X-Amz-Security-Token not set")
+	}
+	auth := req.Header.Get("Authorization")
+	if !strings.Contains(auth, "SignedHeaders=content-type;host;x-amz-date;x-amz-security-token,") {
+		t.Errorf("Authorization = %q, want session token signed", auth)
+	}
+	if !strings.Contains(auth, "/20150830/us-west-2/bedrock/aws4_request") {
+		t.Errorf("Authorization = %q, want bedrock scope", auth)
+	}
+}
+
+func TestCanonicalURIDoubleEncodes(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://host/model/"+uriEncode("anthropic.claude-v2:1")+"/converse", nil)
+
+	if got, want := canonicalURI(req), "/model/anthropic.claude-v2%253A1/converse"; got != want {
+		t.Errorf("canonicalURI() = %q, want %q", got, want)
+	}
+}
+
+func TestCanonicalQuerySorted(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://host/?b=2&a=x y&a=1", nil)
+
+	if got, want := canonicalQuery(req), "a=1&a=x%20y&b=2"; got != want {
+		t.Errorf("canonicalQuery() = %q, want %q", got, want)
+	}
+}
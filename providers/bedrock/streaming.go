@@ -0,0 +1,334 @@
+package bedrock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// toolCallAssembler accumulates tool use input across content block deltas.
+type toolCallAssembler struct {
+	calls map[int]*assemblingToolCall
+}
+
+type assemblingToolCall struct {
+	ID    string
+	Name  string
+	Input strings.Builder
+}
+
+func newToolCallAssembler() *toolCallAssembler {
+	return &toolCallAssembler{calls: make(map[int]*assemblingToolCall)}
+}
+
+// start records a tool use content block.
+func (a *toolCallAssembler) start(index int, id, name string) {
+	a.calls[index] = &assemblingToolCall{ID: id, Name: name}
+}
+
+// addInput appends an input fragment to the tool use block at index.
+// Fragments for unknown blocks are ignored.
+func (a *toolCallAssembler) addInput(index int, fragment string) {
+	if call, ok := a.calls[index]; ok {
+		call.Input.WriteString(fragment)
+	}
+}
+
+// finalize validates and returns the assembled tool calls in block order.
+func (a *toolCallAssembler) finalize() ([]core.ToolCall, error) {
+	if len(a.calls) == 0 {
+		return nil, nil
+	}
+
+	indices := make([]int, 0, len(a.calls))
+	for idx := range a.calls {
+		indices = append(indices, idx)
+	}
+	sort.Ints(indices)
+
+	result := make([]core.ToolCall, 0, len(indices))
+	for _, idx := range indices {
+		call := a.calls[idx]
+
+		args := call.Input.String()
+		if args == "" {
+			args = "{}"
+		}
+		if !json.Valid([]byte(args)) {
+			return nil, ErrToolArgsInvalidJSON
+		}
+
+		result = append(result, core.ToolCall{
+			ID:        call.ID,
+			Name:      call.Name,
+			Arguments: json.RawMessage(args),
+		})
+	}
+
+	return result, nil
+}
+
+// doStreamChat performs a streaming ConverseStream request.
+func (p *Bedrock) doStreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	// Build Converse request
+	cReq, err := buildRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Marshal request body
+	body, err := json.Marshal(cReq)
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	// Create signed HTTP request
+	httpReq, err := p.newRequest(ctx, req.Model, "converse-stream", body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", "application/vnd.amazon.eventstream")
+
+	// Execute request
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	// Extract request ID from response headers
+	requestID := resp.Header.Get("x-amzn-RequestId")
+
+	// Check for error status
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, normalizeError(resp.StatusCode, respBody, requestID, resp.Header.Get("x-amzn-ErrorType"))
+	}
+
+	// Create channels
+	chunkCh := make(chan core.ChatChunk, 100)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *core.ChatResponse, 1)
+
+	// Start goroutine to process the event stream
+	go processEventStream(ctx, resp.Body, req.Model, requestID, chunkCh, errCh, finalCh)
+
+	return &core.ChatStream{
+		Ch:    chunkCh,
+		Err:   errCh,
+		Final: finalCh,
+	}, nil
+}
+
+// processEventStream decodes ConverseStream events and emits chunks.
+func processEventStream(
+	ctx context.Context,
+	body io.ReadCloser,
+	model core.ModelID,
+	requestID string,
+	chunkCh chan<- core.ChatChunk,
+	errCh chan<- error,
+	finalCh chan<- *core.ChatResponse,
+) {
+	defer body.Close()
+	defer close(chunkCh)
+	defer close(errCh)
+	defer close(finalCh)
+
+	decoder := newEventStreamDecoder(body)
+	assembler := newToolCallAssembler()
+
+	var usage *converseUsage
+	var reasoning strings.Builder
+	message := newMessageRecorder()
+
+	for {
+		// Check for context cancellation
+		select {
+		case <-ctx.Done():
+			errCh <- ctx.Err()
+			return
+		default:
+		}
+
+		// Read next message
+		msg, err := decoder.Decode()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			switch {
+			case ctx.Err() != nil:
+				errCh <- ctx.Err()
+			case errors.Is(err, ErrEventStreamCorrupt):
+				errCh <- newDecodeError(err)
+			default:
+				errCh <- newNetworkError(err)
+			}
+			return
+		}
+
+		switch msg.Headers[":message-type"] {
+		case "exception":
+			errCh <- newStreamError(msg.Headers[":exception-type"], msg.Payload, requestID)
+			return
+		case "error":
+			payload, _ := json.Marshal(errorResponse{Message: msg.Headers[":error-message"]})
+			errCh <- newStreamError(msg.Headers[":error-code"], payload, requestID)
+			return
+		}
+
+		switch msg.Headers[":event-type"] {
+		case "contentBlockStart":
+			var event contentBlockStartEvent
+			if err := json.Unmarshal(msg.Payload, &event); err != nil {
+				errCh <- newDecodeError(err)
+				return
+			}
+			if tu := event.Start.ToolUse; tu != nil {
+				assembler.start(event.ContentBlockIndex, tu.ToolUseID, tu.Name)
+				message.block(event.ContentBlockIndex).ToolUse = &toolUseBlock{ToolUseID: tu.ToolUseID, Name: tu.Name}
+			}
+
+		case "contentBlockDelta":
+			var event contentBlockDeltaEvent
+			if err := json.Unmarshal(msg.Payload, &event); err != nil {
+				errCh <- newDecodeError(err)
+				return
+			}
+
+			if event.Delta.ToolUse != nil {
+				assembler.addInput(event.ContentBlockIndex, event.Delta.ToolUse.Input)
+			}
+			if rc := event.Delta.ReasoningContent; rc != nil {
+				reasoning.WriteString(rc.Text)
+				message.addReasoning(event.ContentBlockIndex, rc.Text, rc.Signature, rc.RedactedContent)
+			}
+			message.addText(event.ContentBlockIndex, event.Delta.Text)
+
+			// Emit text delta
+			if event.Delta.Text != "" {
+				select {
+				case chunkCh <- core.ChatChunk{Delta: event.Delta.Text}:
+				case <-ctx.Done():
+					errCh <- ctx.Err()
+					return
+				}
+			}
+
+		case "metadata":
+			var event metadataEvent
+			if err := json.Unmarshal(msg.Payload, &event); err != nil {
+				errCh <- newDecodeError(err)
+				return
+			}
+			if event.Usage != nil {
+				usage = event.Usage
+			}
+		}
+	}
+
+	// Finalize tool calls
+	toolCalls, err := assembler.finalize()
+	if err != nil {
+		errCh <- err
+		return
+	}
+
+	// Build final response
+	finalResp := &core.ChatResponse{
+		ID:        requestID,
+		Model:     model,
+		ToolCalls: toolCalls,
+	}
+
+	if usage != nil {
+		finalResp.Usage = mapUsage(*usage)
+	}
+
+	if reasoning.Len() > 0 {
+		finalResp.Reasoning = &core.ReasoningOutput{Summary: []string{reasoning.String()}}
+	}
+	finalResp.Items = replayItems(message.blocks(toolCalls))
+
+	finalCh <- finalResp
+}
+
+// messageRecorder rebuilds the content blocks of a streamed message, so
+// that its signed reasoning can be replayed.
+type messageRecorder struct {
+	byIndex map[int]*contentBlock
+}
+
+func newMessageRecorder() *messageRecorder {
+	return &messageRecorder{byIndex: make(map[int]*contentBlock)}
+}
+
+// block returns the block at index, creating it if needed.
+func (m *messageRecorder) block(index int) *contentBlock {
+	b, ok := m.byIndex[index]
+	if !ok {
+		b = &contentBlock{}
+		m.byIndex[index] = b
+	}
+	return b
+}
+
+// addText appends text to the block at index.
+func (m *messageRecorder) addText(index int, text string) {
+	if text != "" {
+		m.block(index).Text += text
+	}
+}
+
+// addReasoning appends a reasoning delta to the block at index. The
+// signature and redacted content arrive whole in their own deltas.
+func (m *messageRecorder) addReasoning(index int, text, signature, redacted string) {
+	b := m.block(index)
+	if b.ReasoningContent == nil {
+		b.ReasoningContent = &reasoningContent{}
+	}
+	rc := b.ReasoningContent
+	if redacted != "" {
+		rc.RedactedContent = redacted
+		return
+	}
+	if rc.ReasoningText == nil {
+		rc.ReasoningText = &reasoningText{}
+	}
+	rc.ReasoningText.Text += text
+	if signature != "" {
+		rc.ReasoningText.Signature = signature
+	}
+}
+
+// blocks returns the blocks in index order, with tool inputs taken from
+// the assembled tool calls.
+func (m *messageRecorder) blocks(toolCalls []core.ToolCall) []contentBlock {
+	indices := make([]int, 0, len(m.byIndex))
+	for idx := range m.byIndex {
+		indices = append(indices, idx)
+	}
+	sort.Ints(indices)
+
+	blocks := make([]contentBlock, 0, len(indices))
+	for _, idx := range indices {
+		b := *m.byIndex[idx]
+		if b.ToolUse != nil {
+			tu := *b.ToolUse
+			for _, call := range toolCalls {
+				if call.ID == tu.ToolUseID {
+					tu.Input = call.Arguments
+				}
+			}
+			b.ToolUse = &tu
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks
+}
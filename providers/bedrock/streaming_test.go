@@ -0,0 +1,138 @@
+package bedrock
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers/providertest"
+)
+
+// streamChat serves body as a ConverseStream response and collects the result.
+func streamChat(t *testing.T, body string) *providertest.StreamResult {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/model/"+string(ModelClaudeSonnet4)+"/converse-stream" {
+			t.Errorf("Path = %q", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/vnd.amazon.eventstream")
+		w.Header().Set("x-amzn-RequestId", "req-stream")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	p := New(WithBaseURL(server.URL), WithCredentials("AKID", "secret", ""))
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{Model: ModelClaudeSonnet4})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	res, err := providertest.CollectStream(stream, time.Second)
+	if err != nil {
+		t.Fatalf("CollectStream() error = %v", err)
+	}
+	if v := res.Violations(); len(v) > 0 {
+		t.Fatalf("contract violations: %v", v)
+	}
+	return res
+}
+
+func TestStreamReasoningAndMultipleTools(t *testing.T) {
+	res := streamChat(t, eventStream(
+		encodeEvent("contentBlockDelta", `{"contentBlockIndex":0,"delta":{"reasoningContent":{"text":"let me "}}}`),
+		encodeEvent("contentBlockDelta", `{"contentBlockIndex":0,"delta":{"reasoningContent":{"text":"think"}}}`),
+		encodeEvent("contentBlockDelta", `{"contentBlockIndex":0,"delta":{"reasoningContent":{"signature":"sig=="}}}`),
+		encodeEvent("contentBlockDelta", `{"contentBlockIndex":1,"delta":{"text":"Checking."}}`),
+		encodeEvent("contentBlockStart", `{"contentBlockIndex":3,"start":{"toolUse":{"toolUseId":"t2","name":"second"}}}`),
+		encodeEvent("contentBlockStart", `{"contentBlockIndex":2,"start":{"toolUse":{"toolUseId":"t1","name":"first"}}}`),
+		encodeEvent("contentBlockDelta", `{"contentBlockIndex":2,"delta":{"toolUse":{"input":"{\"a\":1}"}}}`),
+		encodeEvent("messageStop", `{"stopReason":"tool_use"}`),
+	))
+
+	if res.Err() != nil {
+		t.Fatalf("stream error = %v", res.Err())
+	}
+	if res.Output() != "Checking." {
+		t.Errorf("Output() = %q", res.Output())
+	}
+
+	final := res.Final()
+	if final.ID != "req-stream" {
+		t.Errorf("ID = %q, want request ID", final.ID)
+	}
+	if final.Reasoning == nil || final.Reasoning.Summary[0] != "let me think" {
+		t.Errorf("Reasoning = %+v", final.Reasoning)
+	}
+	if len(final.ToolCalls) != 2 {
+		t.Fatalf("len(ToolCalls) = %d, want 2", len(final.ToolCalls))
+	}
+	if final.ToolCalls[0].ID != "t1" || string(final.ToolCalls[0].Arguments) != `{"a":1}` {
+		t.Errorf("ToolCalls[0] = %+v", final.ToolCalls[0])
+	}
+	if final.ToolCalls[1].ID != "t2" || string(final.ToolCalls[1].Arguments) != `{}` {
+		t.Errorf("ToolCalls[1] = %+v", final.ToolCalls[1])
+	}
+
+	// The message is rebuilt for replay, signed reasoning included
+	want := []string{
+		`{"reasoningContent":{"reasoningText":{"text":"let me think","signature":"sig=="}}}`,
+		`{"text":"Checking."}`,
+		`{"toolUse":{"toolUseId":"t1","name":"first","input":{"a":1}}}`,
+		`{"toolUse":{"toolUseId":"t2","name":"second","input":{}}}`,
+	}
+	if len(final.Items) != len(want) {
+		t.Fatalf("Items = %+v", final.Items)
+	}
+	for i, item := range final.Items {
+		if string(item.Raw) != want[i] {
+			t.Errorf("Items[%d] = %s, want %s", i, item.Raw, want[i])
+		}
+	}
+}
+
+func TestStreamException(t *testing.T) {
+	res := streamChat(t, eventStream(
+		encodeEvent("contentBlockDelta", `{"contentBlockIndex":0,"delta":{"text":"Hel"}}`),
+		encodeEventMessage(map[string]string{
+			":message-type":   "exception",
+			":exception-type": "throttlingException",
+		}, []byte(`{"message":"Too many requests"}`)),
+	))
+
+	var pErr *core.ProviderError
+	if !errors.As(res.Err(), &pErr) {
+		t.Fatalf("expected ProviderError, got %v", res.Err())
+	}
+	if pErr.Code != "throttlingException" || pErr.Message != "Too many requests" || pErr.RequestID != "req-stream" {
+		t.Errorf("ProviderError = %+v", pErr)
+	}
+	if !errors.Is(res.Err(), core.ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", res.Err())
+	}
+}
+
+func TestStreamCorrupt(t *testing.T) {
+	msg := encodeEvent("contentBlockDelta", `{"contentBlockIndex":0,"delta":{"text":"Hi"}}`)
+	msg[len(msg)-1] ^= 0xFF
+
+	res := streamChat(t, string(msg))
+
+	if !errors.Is(res.Err(), core.ErrDecode) {
+		t.Errorf("expected ErrDecode, got %v", res.Err())
+	}
+}
+
+func TestStreamInvalidToolInput(t *testing.T) {
+	res := streamChat(t, eventStream(
+		encodeEvent("contentBlockStart", `{"contentBlockIndex":0,"start":{"toolUse":{"toolUseId":"t1","name":"f"}}}`),
+		encodeEvent("contentBlockDelta", `{"contentBlockIndex":0,"delta":{"toolUse":{"input":"{\"a\":"}}}`),
+	))
+
+	if !errors.Is(res.Err(), ErrToolArgsInvalidJSON) {
+		t.Errorf("expected ErrToolArgsInvalidJSON, got %v", res.Err())
+	}
+}
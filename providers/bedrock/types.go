@@ -0,0 +1,153 @@
+package bedrock
+
+import "encoding/json"
+
+// Converse API request types.
+
+type converseRequest struct {
+	Messages        []converseMessage `json:"messages"`
+	System          []systemBlock     `json:"system,omitempty"`
+	InferenceConfig *inferenceConfig  `json:"inferenceConfig,omitempty"`
+	ToolConfig      *toolConfig       `json:"toolConfig,omitempty"`
+
+	// AdditionalModelRequestFields holds model-specific parameters, such
+	// as Claude's extended thinking.
+	AdditionalModelRequestFields map[string]any `json:"additionalModelRequestFields,omitempty"`
+}
+
+type converseMessage struct {
+	Role    string         `json:"role"`
+	Content []contentBlock `json:"content"`
+}
+
+// contentBlock is a union; exactly one field is set.
+type contentBlock struct {
+	Text             string            `json:"text,omitempty"`
+	Image            *imageBlock       `json:"image,omitempty"`
+	ToolUse          *toolUseBlock     `json:"toolUse,omitempty"`
+	ReasoningContent *reasoningContent `json:"reasoningContent,omitempty"`
+
+	// raw, when set, is sent in place of the fields, to replay a block
+	// from a core.ResponseItem unchanged
+	raw json.RawMessage
+}
+
+// MarshalJSON encodes the block, or its raw form when it has one.
+func (b contentBlock) MarshalJSON() ([]byte, error) {
+	if b.raw != nil {
+		return b.raw, nil
+	}
+	type plain contentBlock
+	return json.Marshal(plain(b))
+}
+
+type imageBlock struct {
+	Format string      `json:"format"`
+	Source imageSource `json:"source"`
+}
+
+type imageSource struct {
+	Bytes      string      `json:"bytes,omitempty"`
+	S3Location *s3Location `json:"s3Location,omitempty"`
+}
+
+type s3Location struct {
+	URI string `json:"uri"`
+}
+
+type toolUseBlock struct {
+	ToolUseID string          `json:"toolUseId"`
+	Name      string          `json:"name"`
+	Input     json.RawMessage `json:"input"`
+}
+
+type reasoningContent struct {
+	ReasoningText   *reasoningText `json:"reasoningText,omitempty"`
+	RedactedContent string         `json:"redactedContent,omitempty"` // Base64 encrypted reasoning
+}
+
+type reasoningText struct {
+	Text      string `json:"text"`
+	Signature string `json:"signature,omitempty"`
+}
+
+type systemBlock struct {
+	Text string `json:"text"`
+}
+
+type inferenceConfig struct {
+	MaxTokens   *int     `json:"maxTokens,omitempty"`
+	Temperature *float32 `json:"temperature,omitempty"`
+}
+
+type toolConfig struct {
+	Tools []toolEntry `json:"tools"`
+}
+
+type toolEntry struct {
+	ToolSpec toolSpec `json:"toolSpec"`
+}
+
+type toolSpec struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	InputSchema toolInputSchema `json:"inputSchema"`
+}
+
+type toolInputSchema struct {
+	JSON json.RawMessage `json:"json"`
+}
+
+// Converse API response types.
+
+type converseResponse struct {
+	Output struct {
+		Message *converseMessage `json:"message"`
+	} `json:"output"`
+	StopReason string        `json:"stopReason"`
+	Usage      converseUsage `json:"usage"`
+}
+
+type converseUsage struct {
+	InputTokens  int `json:"inputTokens"`
+	OutputTokens int `json:"outputTokens"`
+	TotalTokens  int `json:"totalTokens"`
+}
+
+// errorResponse is the body of a Bedrock error response.
+// The service is inconsistent about the capitalization of the field.
+type errorResponse struct {
+	Message      string `json:"message"`
+	MessageUpper string `json:"Message"`
+}
+
+// ConverseStream event payloads.
+
+type contentBlockStartEvent struct {
+	ContentBlockIndex int `json:"contentBlockIndex"`
+	Start             struct {
+		ToolUse *struct {
+			ToolUseID string `json:"toolUseId"`
+			Name      string `json:"name"`
+		} `json:"toolUse"`
+	} `json:"start"`
+}
+
+type contentBlockDeltaEvent struct {
+	ContentBlockIndex int `json:"contentBlockIndex"`
+	Delta             struct {
+		Text    string `json:"text"`
+		ToolUse *struct {
+			Input string `json:"input"`
+		} `json:"toolUse"`
+		ReasoningContent *struct {
+			Text            string `json:"text"`
+			Signature       string `json:"signature"`
+			RedactedContent string `json:"redactedContent"`
+		} `json:"reasoningContent"`
+	} `json:"delta"`
+}
+
+type metadataEvent struct {
+	Usage *converseUsage `json:"usage"`
+}
@@ -0,0 +1,73 @@
+package cohere
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// chatPath is the API endpoint for chat.
+const chatPath = "/chat"
+
+// newRequest builds a POST request to the given API path.
+func (p *Cohere) newRequest(ctx context.Context, path string, body []byte) (*http.Request, error) {
+	url := p.config.BaseURL + path
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	// Set headers
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	return httpReq, nil
+}
+
+// doChat performs a non-streaming chat request.
+func (p *Cohere) doChat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	// Marshal request body
+	body, err := json.Marshal(buildRequest(req, false))
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	httpReq, err := p.newRequest(ctx, chatPath, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute request
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	// Check for error status
+	if resp.StatusCode >= 400 {
+		return nil, normalizeError(resp.StatusCode, respBody, resp.Header.Get("x-request-id"))
+	}
+
+	// Parse response
+	var cResp cohereResponse
+	if err := json.Unmarshal(respBody, &cResp); err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	// Map to Iris response
+	return mapResponse(&cResp, req.Model)
+}
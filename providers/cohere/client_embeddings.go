@@ -0,0 +1,162 @@
+package cohere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/erikhoward/iris/core"
+)
+
+const embedPath = "/embed"
+
+// Cohere-specific input types. core.InputTypeQuery and core.InputTypeDocument
+// map to search_query and search_document.
+const (
+	// InputTypeClassification optimizes embeddings for text classifiers.
+	InputTypeClassification core.InputType = "classification"
+	// InputTypeClustering optimizes embeddings for clustering.
+	InputTypeClustering core.InputType = "clustering"
+)
+
+// CreateEmbeddings generates embeddings for the given input texts.
+// Cohere requires an input type; requests without one embed as documents.
+// Integer and binary output types are returned as float vectors.
+func (p *Cohere) CreateEmbeddings(ctx context.Context, req *core.EmbeddingRequest) (*core.EmbeddingResponse, error) {
+	cReq := buildEmbeddingRequest(req)
+
+	body, err := json.Marshal(cReq)
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	httpReq, err := p.newRequest(ctx, embedPath, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, normalizeError(resp.StatusCode, respBody, resp.Header.Get("x-request-id"))
+	}
+
+	var cResp cohereEmbedResponse
+	if err := json.Unmarshal(respBody, &cResp); err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	return mapEmbeddingResponse(&cResp, req, cReq.EmbeddingTypes[0])
+}
+
+// mapInputType converts a core input type to Cohere's input_type.
+// Values other than query and document are passed through unchanged.
+func mapInputType(t core.InputType) string {
+	switch t {
+	case core.InputTypeNone, core.InputTypeDocument:
+		return "search_document"
+	case core.InputTypeQuery:
+		return "search_query"
+	default:
+		return string(t)
+	}
+}
+
+// buildEmbeddingRequest converts core request to Cohere format.
+func buildEmbeddingRequest(req *core.EmbeddingRequest) *cohereEmbedRequest {
+	texts := make([]string, len(req.Input))
+	for i, input := range req.Input {
+		texts[i] = input.Text
+	}
+
+	embeddingType := string(core.OutputDTypeFloat)
+	if req.OutputDType != "" {
+		embeddingType = string(req.OutputDType)
+	}
+	if req.EncodingFormat == core.EncodingFormatBase64 {
+		embeddingType = string(core.EncodingFormatBase64)
+	}
+
+	cReq := &cohereEmbedRequest{
+		Model:          string(req.Model),
+		Texts:          texts,
+		InputType:      mapInputType(req.InputType),
+		EmbeddingTypes: []string{embeddingType},
+	}
+
+	if req.Dimensions != nil {
+		cReq.OutputDimension = req.Dimensions
+	}
+	if req.Truncation != nil {
+		if *req.Truncation {
+			cReq.Truncate = "END"
+		} else {
+			cReq.Truncate = "NONE"
+		}
+	}
+
+	return cReq
+}
+
+// mapEmbeddingResponse converts Cohere response to core format.
+func mapEmbeddingResponse(resp *cohereEmbedResponse, req *core.EmbeddingRequest, embeddingType string) (*core.EmbeddingResponse, error) {
+	raw, ok := resp.Embeddings[embeddingType]
+	if !ok {
+		return nil, newDecodeError(fmt.Errorf("response has no %s embeddings", embeddingType))
+	}
+
+	var floats [][]float32
+	var encoded []string
+	var count int
+	if embeddingType == string(core.EncodingFormatBase64) {
+		if err := json.Unmarshal(raw, &encoded); err != nil {
+			return nil, newDecodeError(err)
+		}
+		count = len(encoded)
+	} else {
+		if err := json.Unmarshal(raw, &floats); err != nil {
+			return nil, newDecodeError(err)
+		}
+		count = len(floats)
+	}
+
+	vectors := make([]core.EmbeddingVector, count)
+	for i := range vectors {
+		vec := core.EmbeddingVector{Index: i}
+		if encoded != nil {
+			vec.VectorB64 = encoded[i]
+		} else {
+			vec.Vector = floats[i]
+		}
+
+		// Copy ID and Metadata from input
+		if i < len(req.Input) {
+			vec.ID = req.Input[i].ID
+			vec.Metadata = req.Input[i].Metadata
+		}
+
+		vectors[i] = vec
+	}
+
+	return &core.EmbeddingResponse{
+		Vectors: vectors,
+		Model:   req.Model,
+		Usage: core.EmbeddingUsage{
+			PromptTokens: resp.Meta.BilledUnits.InputTokens,
+			TotalTokens:  resp.Meta.BilledUnits.InputTokens,
+		},
+	}, nil
+}
+
+// Compile-time check that Cohere implements EmbeddingProvider.
+var _ core.EmbeddingProvider = (*Cohere)(nil)
@@ -0,0 +1,115 @@
+package cohere
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestCreateEmbeddings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/embed" {
+			t.Errorf("Path = %q, want /embed", r.URL.Path)
+		}
+
+		var req cohereEmbedRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if req.InputType != "search_query" {
+			t.Errorf("InputType = %q, want search_query", req.InputType)
+		}
+		if len(req.EmbeddingTypes) != 1 || req.EmbeddingTypes[0] != "int8" {
+			t.Errorf("EmbeddingTypes = %v, want [int8]", req.EmbeddingTypes)
+		}
+		if req.Truncate != "NONE" {
+			t.Errorf("Truncate = %q, want NONE", req.Truncate)
+		}
+
+		w.Write([]byte(`{"id":"e1","embeddings":{"int8":[[1,-2],[3,4]]},"texts":["a","b"],"meta":{"billed_units":{"input_tokens":2}}}`))
+	}))
+	defer server.Close()
+
+	truncate := false
+	p := New("test-key", WithBaseURL(server.URL))
+	resp, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+		Model:       ModelEmbedV4,
+		Input:       []core.EmbeddingInput{{Text: "a", ID: "q1"}, {Text: "b"}},
+		InputType:   core.InputTypeQuery,
+		OutputDType: core.OutputDTypeInt8,
+		Truncation:  &truncate,
+	})
+	if err != nil {
+		t.Fatalf("CreateEmbeddings() error = %v", err)
+	}
+
+	if len(resp.Vectors) != 2 {
+		t.Fatalf("len(Vectors) = %d, want 2", len(resp.Vectors))
+	}
+	if v := resp.Vectors[0]; v.ID != "q1" || v.Vector[1] != -2 {
+		t.Errorf("Vectors[0] = %+v", v)
+	}
+	if resp.Model != ModelEmbedV4 || resp.Usage.TotalTokens != 2 {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+func TestEmbeddingInputTypes(t *testing.T) {
+	tests := []struct {
+		in   core.InputType
+		want string
+	}{
+		{core.InputTypeNone, "search_document"},
+		{core.InputTypeDocument, "search_document"},
+		{core.InputTypeQuery, "search_query"},
+		{InputTypeClassification, "classification"},
+		{InputTypeClustering, "clustering"},
+	}
+
+	for _, tt := range tests {
+		if got := mapInputType(tt.in); got != tt.want {
+			t.Errorf("mapInputType(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCreateEmbeddingsBase64(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"e2","embeddings":{"base64":["AAAA"]},"meta":{"billed_units":{"input_tokens":1}}}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	resp, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+		Model:          ModelEmbedV4,
+		Input:          []core.EmbeddingInput{{Text: "a"}},
+		EncodingFormat: core.EncodingFormatBase64,
+	})
+	if err != nil {
+		t.Fatalf("CreateEmbeddings() error = %v", err)
+	}
+	if resp.Vectors[0].VectorB64 != "AAAA" {
+		t.Errorf("VectorB64 = %q, want AAAA", resp.Vectors[0].VectorB64)
+	}
+}
+
+func TestCreateEmbeddingsMissingType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"e3","embeddings":{"uint8":[[1]]}}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	_, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+		Model: ModelEmbedV4,
+		Input: []core.EmbeddingInput{{Text: "a"}},
+	})
+	if !errors.Is(err, core.ErrDecode) {
+		t.Errorf("expected ErrDecode, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package cohere
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/erikhoward/iris/core"
+)
+
+const rerankPath = "/rerank"
+
+// Rerank scores and sorts documents by relevance to the query.
+// Cohere always truncates long documents, so Truncation is ignored.
+func (p *Cohere) Rerank(ctx context.Context, req *core.RerankRequest) (*core.RerankResponse, error) {
+	body, err := json.Marshal(buildRerankRequest(req))
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	httpReq, err := p.newRequest(ctx, rerankPath, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, normalizeError(resp.StatusCode, respBody, resp.Header.Get("x-request-id"))
+	}
+
+	var cResp cohereRerankResponse
+	if err := json.Unmarshal(respBody, &cResp); err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	return mapRerankResponse(&cResp, req), nil
+}
+
+// buildRerankRequest converts core request to Cohere format.
+func buildRerankRequest(req *core.RerankRequest) *cohereRerankRequest {
+	return &cohereRerankRequest{
+		Model:     string(req.Model),
+		Query:     req.Query,
+		Documents: req.Documents,
+		TopN:      req.TopK,
+	}
+}
+
+// mapRerankResponse converts Cohere response to core format.
+// Cohere does not return document text, so it is copied from the request
+// when ReturnDocuments is set.
+func mapRerankResponse(resp *cohereRerankResponse, req *core.RerankRequest) *core.RerankResponse {
+	results := make([]core.RerankResult, len(resp.Results))
+
+	for i, r := range resp.Results {
+		results[i] = core.RerankResult{
+			Index:          r.Index,
+			RelevanceScore: r.RelevanceScore,
+		}
+		if req.ReturnDocuments && r.Index >= 0 && r.Index < len(req.Documents) {
+			results[i].Document = req.Documents[r.Index]
+		}
+	}
+
+	var totalTokens int
+	if resp.Meta.Tokens != nil {
+		totalTokens = resp.Meta.Tokens.InputTokens
+	}
+
+	return &core.RerankResponse{
+		Results: results,
+		Model:   req.Model,
+		Usage: core.RerankUsage{
+			TotalTokens: totalTokens,
+		},
+	}
+}
+
+// Compile-time check that Cohere implements RerankerProvider.
+var _ core.RerankerProvider = (*Cohere)(nil)
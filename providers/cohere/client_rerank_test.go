@@ -0,0 +1,79 @@
+package cohere
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestRerank(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rerank" {
+			t.Errorf("Path = %q, want /rerank", r.URL.Path)
+		}
+
+		var req cohereRerankRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if req.Model != "rerank-v3.5" || req.Query != "capital of France" {
+			t.Errorf("req = %+v", req)
+		}
+		if req.TopN == nil || *req.TopN != 2 {
+			t.Errorf("TopN = %v, want 2", req.TopN)
+		}
+
+		w.Write([]byte(`{"id":"rr1","results":[{"index":1,"relevance_score":0.95},{"index":0,"relevance_score":0.12}],"meta":{"billed_units":{"search_units":1},"tokens":{"input_tokens":40}}}`))
+	}))
+	defer server.Close()
+
+	topK := 2
+	p := New("test-key", WithBaseURL(server.URL))
+	resp, err := p.Rerank(context.Background(), &core.RerankRequest{
+		Model:           ModelRerankV35,
+		Query:           "capital of France",
+		Documents:       []string{"Berlin is in Germany.", "Paris is the capital of France.", "Madrid is in Spain."},
+		TopK:            &topK,
+		ReturnDocuments: true,
+	})
+	if err != nil {
+		t.Fatalf("Rerank() error = %v", err)
+	}
+
+	if len(resp.Results) != 2 {
+		t.Fatalf("len(Results) = %d, want 2", len(resp.Results))
+	}
+	if r := resp.Results[0]; r.Index != 1 || r.RelevanceScore != 0.95 || r.Document != "Paris is the capital of France." {
+		t.Errorf("Results[0] = %+v", r)
+	}
+	if resp.Model != ModelRerankV35 || resp.Usage.TotalTokens != 40 {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+func TestRerankError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusInvalidToken)
+		w.Write([]byte(`{"id":"err-9","message":"invalid api token"}`))
+	}))
+	defer server.Close()
+
+	p := New("bad-key", WithBaseURL(server.URL))
+	_, err := p.Rerank(context.Background(), &core.RerankRequest{Model: ModelRerankV35, Query: "q", Documents: []string{"d"}})
+
+	var pErr *core.ProviderError
+	if !errors.As(err, &pErr) {
+		t.Fatalf("expected ProviderError, got %T", err)
+	}
+	if pErr.RequestID != "err-9" || pErr.Message != "invalid api token" {
+		t.Errorf("ProviderError = %+v", pErr)
+	}
+	if !errors.Is(err, core.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package cohere
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestChatRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat" {
+			t.Errorf("Path = %q, want /chat", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+
+		var req cohereRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("invalid request body: %v", err)
+		}
+		if len(req.Messages) != 2 || req.Messages[0].Role != "system" || req.Messages[0].Content != "Be brief." {
+			t.Errorf("Messages = %+v", req.Messages)
+		}
+		if req.Thinking == nil || req.Thinking.Type != "enabled" {
+			t.Errorf("Thinking = %+v, want enabled", req.Thinking)
+		}
+
+		w.Write([]byte(`{"id":"r1","finish_reason":"COMPLETE","message":{"role":"assistant","content":[{"type":"thinking","thinking":"Greeting."},{"type":"text","text":"Hi"}]},"usage":{"billed_units":{"input_tokens":2,"output_tokens":1}}}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	resp, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:           ModelCommandAReasoning,
+		Instructions:    "Be brief.",
+		ReasoningEffort: core.ReasoningEffortMedium,
+		Messages:        []core.Message{{Role: core.RoleUser, Content: "Hello"}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	if resp.Output != "Hi" || resp.ID != "r1" {
+		t.Errorf("resp = %+v", resp)
+	}
+	if resp.Model != ModelCommandAReasoning {
+		t.Errorf("Model = %q, want request model", resp.Model)
+	}
+	if resp.Reasoning == nil || resp.Reasoning.Summary[0] != "Greeting." {
+		t.Errorf("Reasoning = %+v", resp.Reasoning)
+	}
+	if resp.Usage != (core.TokenUsage{PromptTokens: 2, CompletionTokens: 1, TotalTokens: 3}) {
+		t.Errorf("Usage = %+v, want billed units when tokens are absent", resp.Usage)
+	}
+}
+
+func TestBuildRequestThinking(t *testing.T) {
+	tests := []struct {
+		effort core.ReasoningEffort
+		want   string
+	}{
+		{"", ""},
+		{core.ReasoningEffortNone, "disabled"},
+		{core.ReasoningEffortLow, "enabled"},
+	}
+
+	for _, tt := range tests {
+		req := buildRequest(&core.ChatRequest{ReasoningEffort: tt.effort}, false)
+		got := ""
+		if req.Thinking != nil {
+			got = req.Thinking.Type
+		}
+		if got != tt.want {
+			t.Errorf("effort %q: Thinking = %q, want %q", tt.effort, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package cohere
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers/providertest"
+)
+
+func TestConformance(t *testing.T) {
+	sse := http.Header{"Content-Type": {"text/event-stream"}}
+	usage := `"usage":{"billed_units":{"input_tokens":3,"output_tokens":2},"tokens":{"input_tokens":5,"output_tokens":2}}`
+
+	suite := providertest.Suite{
+		NewProvider: func(baseURL string) core.Provider {
+			return New("test-key", WithBaseURL(baseURL))
+		},
+		Model: ModelCommandA,
+		Chat: &providertest.Fixture{
+			Body:   `{"id":"resp-1","finish_reason":"COMPLETE","message":{"role":"assistant","content":[{"type":"text","text":"Hello there"}]},` + usage + `}`,
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		Stream: &providertest.Fixture{
+			Header: sse,
+			Body: sseEvents(
+				`{"type":"message-start","id":"resp-2","delta":{"message":{"role":"assistant"}}}`,
+				`{"type":"content-start","index":0,"delta":{"message":{"content":{"type":"text","text":""}}}}`,
+				`{"type":"content-delta","index":0,"delta":{"message":{"content":{"text":"Hello"}}}}`,
+				`{"type":"content-delta","index":0,"delta":{"message":{"content":{"text":" there"}}}}`,
+				`{"type":"content-end","index":0}`,
+				`{"type":"message-end","delta":{"finish_reason":"COMPLETE",`+usage+`}}`,
+			),
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		ToolCall: &providertest.Fixture{
+			Body: `{"id":"resp-3","finish_reason":"TOOL_CALL","message":{"role":"assistant","tool_plan":"I will check the weather.","tool_calls":[{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"location\": \"NYC\"}"}}]}}`,
+			ToolCalls: []core.ToolCall{
+				{ID: "call_1", Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		StreamToolCall: &providertest.Fixture{
+			Header: sse,
+			Body: sseEvents(
+				`{"type":"message-start","id":"resp-4","delta":{"message":{"role":"assistant"}}}`,
+				`{"type":"tool-plan-delta","delta":{"message":{"tool_plan":"I will check."}}}`,
+				`{"type":"tool-call-start","index":0,"delta":{"message":{"tool_calls":{"id":"call_2","type":"function","function":{"name":"get_weather","arguments":""}}}}}`,
+				`{"type":"tool-call-delta","index":0,"delta":{"message":{"tool_calls":{"function":{"arguments":"{\"location\":"}}}}}`,
+				`{"type":"tool-call-delta","index":0,"delta":{"message":{"tool_calls":{"function":{"arguments":" \"NYC\"}"}}}}}`,
+				`{"type":"tool-call-end","index":0}`,
+				`{"type":"message-end","delta":{"finish_reason":"TOOL_CALL"}}`,
+			),
+			ToolCalls: []core.ToolCall{
+				{ID: "call_2", Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		Cancel: &providertest.Fixture{
+			Header: sse,
+			Body:   sseEvents(`{"type":"content-delta","index":0,"delta":{"message":{"content":{"text":"Hello"}}}}`),
+		},
+		ErrorBody: func(status int) string {
+			return `{"id":"err-1","message":"request failed"}`
+		},
+		ErrorStatuses: map[int]error{
+			http.StatusBadRequest:          core.ErrBadRequest,
+			http.StatusUnauthorized:        core.ErrUnauthorized,
+			http.StatusForbidden:           core.ErrUnauthorized,
+			http.StatusNotFound:            core.ErrNotFound,
+			http.StatusUnprocessableEntity: core.ErrBadRequest,
+			http.StatusTooManyRequests:     core.ErrRateLimited,
+			statusInvalidToken:             core.ErrUnauthorized,
+			http.StatusInternalServerError: core.ErrServer,
+			http.StatusServiceUnavailable:  core.ErrServer,
+		},
+	}
+
+	suite.Run(t)
+}
@@ -0,0 +1,88 @@
+package cohere
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// ErrToolArgsInvalidJSON is returned when tool call arguments contain invalid JSON.
+var ErrToolArgsInvalidJSON = errors.New("tool args invalid json")
+
+// statusInvalidToken is Cohere's non-standard status for an invalid API key.
+const statusInvalidToken = 498
+
+// cohereErrorResponse represents an error response from the Cohere API.
+type cohereErrorResponse struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// normalizeError converts an HTTP error response to a ProviderError with the appropriate sentinel.
+// The error body's ID is used when the response carries no request ID header.
+func normalizeError(status int, body []byte, requestID string) error {
+	// Parse error response if possible
+	var errResp cohereErrorResponse
+	_ = json.Unmarshal(body, &errResp)
+
+	message := errResp.Message
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	if requestID == "" {
+		requestID = errResp.ID
+	}
+
+	// Determine sentinel error based on status
+	var sentinel error
+	switch {
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		sentinel = core.ErrBadRequest
+	case status == http.StatusUnauthorized || status == http.StatusForbidden || status == statusInvalidToken:
+		sentinel = core.ErrUnauthorized
+	case status == http.StatusNotFound:
+		sentinel = core.ErrNotFound
+	case status == http.StatusTooManyRequests:
+		sentinel = core.ErrRateLimited
+	default:
+		sentinel = core.ErrServer
+	}
+
+	return &core.ProviderError{
+		Provider:  "cohere",
+		Status:    status,
+		RequestID: requestID,
+		Message:   message,
+		Err:       sentinel,
+	}
+}
+
+// newStreamError creates a ProviderError for an error reported mid-stream.
+func newStreamError(message, requestID string) error {
+	return &core.ProviderError{
+		Provider:  "cohere",
+		RequestID: requestID,
+		Message:   message,
+		Err:       core.ErrServer,
+	}
+}
+
+// newNetworkError creates a ProviderError for network-related failures.
+func newNetworkError(err error) error {
+	return &core.ProviderError{
+		Provider: "cohere",
+		Message:  err.Error(),
+		Err:      core.ErrNetwork,
+	}
+}
+
+// newDecodeError creates a ProviderError for JSON decode failures.
+func newDecodeError(err error) error {
+	return &core.ProviderError{
+		Provider: "cohere",
+		Message:  err.Error(),
+		Err:      core.ErrDecode,
+	}
+}
@@ -0,0 +1,178 @@
+package cohere
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/tools"
+)
+
+// schemaProvider is an interface for tools that provide a JSON schema.
+// This allows us to check if a core.Tool also implements the full tools.Tool interface.
+type schemaProvider interface {
+	Schema() tools.ToolSchema
+}
+
+// mapMessages converts Iris messages to Cohere message format.
+// Instructions, if set, are sent as a leading system message.
+func mapMessages(instructions string, msgs []core.Message) []cohereMessage {
+	result := make([]cohereMessage, 0, len(msgs)+1)
+	if instructions != "" {
+		result = append(result, cohereMessage{Role: string(core.RoleSystem), Content: instructions})
+	}
+	for _, msg := range msgs {
+		result = append(result, cohereMessage{
+			Role:    string(msg.Role),
+			Content: msg.Content,
+		})
+	}
+	return result
+}
+
+// mapTools converts Iris tools to Cohere tool format.
+// Tools that implement schemaProvider will have their schema included.
+func mapTools(irisTools []core.Tool) []cohereTool {
+	if len(irisTools) == 0 {
+		return nil
+	}
+
+	result := make([]cohereTool, len(irisTools))
+	for i, t := range irisTools {
+		var params json.RawMessage
+
+		// Check if the tool provides a schema
+		if sp, ok := t.(schemaProvider); ok {
+			params = sp.Schema().JSONSchema
+		}
+
+		// Default to an empty object schema
+		if params == nil {
+			params = json.RawMessage(`{"type":"object","properties":{}}`)
+		}
+
+		result[i] = cohereTool{
+			Type: "function",
+			Function: cohereFunction{
+				Name:        t.Name(),
+				Description: t.Description(),
+				Parameters:  params,
+			},
+		}
+	}
+	return result
+}
+
+// buildRequest creates a Cohere API request from an Iris ChatRequest.
+func buildRequest(req *core.ChatRequest, stream bool) *cohereRequest {
+	cReq := &cohereRequest{
+		Model:    string(req.Model),
+		Messages: mapMessages(req.Instructions, req.Messages),
+		Stream:   stream,
+	}
+
+	// Only set optional fields if provided
+	if req.Temperature != nil {
+		cReq.Temperature = req.Temperature
+	}
+
+	if req.MaxTokens != nil {
+		cReq.MaxTokens = req.MaxTokens
+	}
+
+	// Map tools if present
+	if len(req.Tools) > 0 {
+		cReq.Tools = mapTools(req.Tools)
+	}
+
+	// Cohere has no effort levels, only thinking on or off
+	switch req.ReasoningEffort {
+	case "":
+	case core.ReasoningEffortNone:
+		cReq.Thinking = &cohereThinking{Type: "disabled"}
+	default:
+		cReq.Thinking = &cohereThinking{Type: "enabled"}
+	}
+
+	return cReq
+}
+
+// mapResponse converts a Cohere response to an Iris ChatResponse.
+func mapResponse(resp *cohereResponse, model core.ModelID) (*core.ChatResponse, error) {
+	result := &core.ChatResponse{
+		ID:    resp.ID,
+		Model: model,
+	}
+
+	if resp.Usage != nil {
+		result.Usage = mapUsage(resp.Usage)
+	}
+
+	var text, thinking strings.Builder
+	for _, c := range resp.Message.Content {
+		switch c.Type {
+		case "text":
+			text.WriteString(c.Text)
+		case "thinking":
+			thinking.WriteString(c.Thinking)
+		}
+	}
+	result.Output = text.String()
+
+	if thinking.Len() > 0 {
+		result.Reasoning = &core.ReasoningOutput{
+			Summary: []string{thinking.String()},
+		}
+	}
+
+	// Map tool calls if present
+	if len(resp.Message.ToolCalls) > 0 {
+		toolCalls, err := mapToolCalls(resp.Message.ToolCalls)
+		if err != nil {
+			return nil, err
+		}
+		result.ToolCalls = toolCalls
+	}
+
+	return result, nil
+}
+
+// mapToolCalls converts Cohere tool calls to Iris ToolCalls.
+func mapToolCalls(calls []cohereToolCall) ([]core.ToolCall, error) {
+	result := make([]core.ToolCall, len(calls))
+
+	for i, call := range calls {
+		args := call.Function.Arguments
+		if args == "" {
+			args = "{}"
+		}
+
+		// Validate that arguments is valid JSON
+		if !json.Valid([]byte(args)) {
+			return nil, ErrToolArgsInvalidJSON
+		}
+
+		result[i] = core.ToolCall{
+			ID:        call.ID,
+			Name:      call.Function.Name,
+			Arguments: json.RawMessage(args),
+		}
+	}
+
+	return result, nil
+}
+
+// mapUsage converts Cohere usage to Iris TokenUsage.
+// Actual token counts are preferred over billed units, which exclude
+// the system prompt and tool definitions.
+func mapUsage(u *cohereUsage) core.TokenUsage {
+	tokens := u.Tokens
+	if tokens.InputTokens == 0 && tokens.OutputTokens == 0 {
+		tokens = u.BilledUnits
+	}
+	return core.TokenUsage{
+		PromptTokens:     tokens.InputTokens,
+		CompletionTokens: tokens.OutputTokens,
+		TotalTokens:      tokens.InputTokens + tokens.OutputTokens,
+	}
+}
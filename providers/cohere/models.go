@@ -0,0 +1,141 @@
+// Package cohere provides a Cohere API provider implementation for Iris.
+package cohere
+
+import "github.com/erikhoward/iris/core"
+
+// Model constants for Cohere models.
+const (
+	// Command models
+	ModelCommandA          core.ModelID = "command-a-03-2025"
+	ModelCommandAReasoning core.ModelID = "command-a-reasoning-08-2025"
+	ModelCommandRPlus      core.ModelID = "command-r-plus-08-2024"
+	ModelCommandR          core.ModelID = "command-r-08-2024"
+	ModelCommandR7B        core.ModelID = "command-r7b-12-2024"
+
+	// Embedding models
+	ModelEmbedV4             core.ModelID = "embed-v4.0"
+	ModelEmbedEnglishV3      core.ModelID = "embed-english-v3.0"
+	ModelEmbedMultilingualV3 core.ModelID = "embed-multilingual-v3.0"
+
+	// Reranker models
+	ModelRerankV35            core.ModelID = "rerank-v3.5"
+	ModelRerankEnglishV3      core.ModelID = "rerank-english-v3.0"
+	ModelRerankMultilingualV3 core.ModelID = "rerank-multilingual-v3.0"
+)
+
+// models is the static list of supported models.
+var models = []core.ModelInfo{
+	// Command models
+	{
+		ID:          ModelCommandA,
+		DisplayName: "Command A",
+		APIEndpoint: core.APIEndpointCompletions,
+		Capabilities: []core.Feature{
+			core.FeatureChat,
+			core.FeatureChatStreaming,
+			core.FeatureToolCalling,
+		},
+	},
+	{
+		ID:          ModelCommandAReasoning,
+		DisplayName: "Command A Reasoning",
+		APIEndpoint: core.APIEndpointCompletions,
+		Capabilities: []core.Feature{
+			core.FeatureChat,
+			core.FeatureChatStreaming,
+			core.FeatureToolCalling,
+			core.FeatureReasoning,
+		},
+	},
+	{
+		ID:          ModelCommandRPlus,
+		DisplayName: "Command R+",
+		APIEndpoint: core.APIEndpointCompletions,
+		Capabilities: []core.Feature{
+			core.FeatureChat,
+			core.FeatureChatStreaming,
+			core.FeatureToolCalling,
+		},
+	},
+	{
+		ID:          ModelCommandR,
+		DisplayName: "Command R",
+		APIEndpoint: core.APIEndpointCompletions,
+		Capabilities: []core.Feature{
+			core.FeatureChat,
+			core.FeatureChatStreaming,
+			core.FeatureToolCalling,
+		},
+	},
+	{
+		ID:          ModelCommandR7B,
+		DisplayName: "Command R7B",
+		APIEndpoint: core.APIEndpointCompletions,
+		Capabilities: []core.Feature{
+			core.FeatureChat,
+			core.FeatureChatStreaming,
+			core.FeatureToolCalling,
+		},
+	},
+	// Embedding models
+	{
+		ID:          ModelEmbedV4,
+		DisplayName: "Embed v4",
+		Capabilities: []core.Feature{
+			core.FeatureEmbeddings,
+		},
+	},
+	{
+		ID:          ModelEmbedEnglishV3,
+		DisplayName: "Embed English v3",
+		Capabilities: []core.Feature{
+			core.FeatureEmbeddings,
+		},
+	},
+	{
+		ID:          ModelEmbedMultilingualV3,
+		DisplayName: "Embed Multilingual v3",
+		Capabilities: []core.Feature{
+			core.FeatureEmbeddings,
+		},
+	},
+	// Reranker models
+	{
+		ID:          ModelRerankV35,
+		DisplayName: "Rerank v3.5",
+		Capabilities: []core.Feature{
+			core.FeatureReranking,
+		},
+	},
+	{
+		ID:          ModelRerankEnglishV3,
+		DisplayName: "Rerank English v3",
+		Capabilities: []core.Feature{
+			core.FeatureReranking,
+		},
+	},
+	{
+		ID:          ModelRerankMultilingualV3,
+		DisplayName: "Rerank Multilingual v3",
+		Capabilities: []core.Feature{
+			core.FeatureReranking,
+		},
+	},
+}
+
+// modelRegistry is a map for quick model lookup by ID.
+var modelRegistry = buildModelRegistry()
+
+// buildModelRegistry creates a map from model ID to ModelInfo.
+func buildModelRegistry() map[core.ModelID]*core.ModelInfo {
+	registry := make(map[core.ModelID]*core.ModelInfo, len(models))
+	for i := range models {
+		registry[models[i].ID] = &models[i]
+	}
+	return registry
+}
+
+// GetModelInfo returns the ModelInfo for a given model ID, or nil if not found.
+func GetModelInfo(id core.ModelID) *core.ModelInfo {
+	return modelRegistry[id]
+}
@@ -0,0 +1,61 @@
+package cohere
+
+import (
+	"net/http"
+	"time"
+)
+
+// Config holds configuration for the Cohere provider.
+type Config struct {
+	// APIKey is the Cohere API key (required).
+	APIKey string
+
+	// BaseURL is the API base URL. Defaults to https://api.cohere.com/v2
+	BaseURL string
+
+	// HTTPClient is the HTTP client to use. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Headers contains optional extra headers to include in requests.
+	Headers http.Header
+
+	// Timeout is the optional request timeout.
+	Timeout time.Duration
+}
+
+// DefaultBaseURL is the default Cohere API base URL.
+const DefaultBaseURL = "https://api.cohere.com/v2"
+
+// Option configures the Cohere provider.
+type Option func(*Config)
+
+// WithBaseURL sets the API base URL.
+func WithBaseURL(url string) Option {
+	return func(c *Config) {
+		c.BaseURL = url
+	}
+}
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Config) {
+		c.HTTPClient = client
+	}
+}
+
+// WithHeader adds an extra header to include in requests.
+func WithHeader(key, value string) Option {
+	return func(c *Config) {
+		if c.Headers == nil {
+			c.Headers = make(http.Header)
+		}
+		c.Headers.Set(key, value)
+	}
+}
+
+// WithTimeout sets the request timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Config) {
+		c.Timeout = d
+	}
+}
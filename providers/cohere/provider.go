@@ -0,0 +1,84 @@
+package cohere
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// Cohere is an LLM provider implementation for the Cohere API.
+// Cohere is safe for concurrent use.
+type Cohere struct {
+	config Config
+}
+
+// New creates a new Cohere provider with the given API key and options.
+func New(apiKey string, opts ...Option) *Cohere {
+	cfg := Config{
+		APIKey:     apiKey,
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &Cohere{config: cfg}
+}
+
+// ID returns the provider identifier.
+func (p *Cohere) ID() string {
+	return "cohere"
+}
+
+// Models returns the list of available models.
+func (p *Cohere) Models() []core.ModelInfo {
+	// Return a copy to prevent mutation
+	result := make([]core.ModelInfo, len(models))
+	copy(result, models)
+	return result
+}
+
+// Supports reports whether the provider supports the given feature.
+func (p *Cohere) Supports(feature core.Feature) bool {
+	switch feature {
+	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling,
+		core.FeatureReasoning, core.FeatureEmbeddings, core.FeatureReranking:
+		return true
+	default:
+		return false
+	}
+}
+
+// buildHeaders constructs the HTTP headers for an API request.
+func (p *Cohere) buildHeaders() http.Header {
+	headers := make(http.Header)
+
+	// Required headers
+	headers.Set("Authorization", "Bearer "+p.config.APIKey)
+	headers.Set("Content-Type", "application/json")
+
+	// Copy any extra headers
+	for key, values := range p.config.Headers {
+		for _, v := range values {
+			headers.Add(key, v)
+		}
+	}
+
+	return headers
+}
+
+// Chat sends a non-streaming chat request.
+func (p *Cohere) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	return p.doChat(ctx, req)
+}
+
+// StreamChat sends a streaming chat request.
+func (p *Cohere) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	return p.doStreamChat(ctx, req)
+}
+
+// Compile-time check that Cohere implements Provider.
+var _ core.Provider = (*Cohere)(nil)
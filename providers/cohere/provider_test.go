@@ -0,0 +1,44 @@
+package cohere
+
+import (
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers"
+)
+
+func TestSupports(t *testing.T) {
+	p := New("test-key")
+
+	for _, f := range []core.Feature{core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureReasoning, core.FeatureEmbeddings, core.FeatureReranking} {
+		if !p.Supports(f) {
+			t.Errorf("Supports(%q) = false, want true", f)
+		}
+	}
+	if p.Supports(core.FeatureImageGeneration) {
+		t.Error("Supports(image_generation) = true, want false")
+	}
+}
+
+func TestModels(t *testing.T) {
+	p := New("test-key")
+	models := p.Models()
+	models[0].DisplayName = "mutated"
+
+	if p.Models()[0].DisplayName == "mutated" {
+		t.Error("Models() should return a copy")
+	}
+	if info := GetModelInfo(ModelEmbedV4); info == nil || info.Capabilities[0] != core.FeatureEmbeddings {
+		t.Errorf("GetModelInfo(%q) = %+v", ModelEmbedV4, info)
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	p, err := providers.Create("cohere", "test-key")
+	if err != nil {
+		t.Fatalf("providers.Create() error = %v", err)
+	}
+	if p.ID() != "cohere" {
+		t.Errorf("ID() = %q, want cohere", p.ID())
+	}
+}
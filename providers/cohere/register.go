@@ -0,0 +1,12 @@
+package cohere
+
+import (
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers"
+)
+
+func init() {
+	providers.Register("cohere", func(apiKey string) core.Provider {
+		return New(apiKey)
+	})
+}
@@ -0,0 +1,262 @@
+package cohere
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// toolCallAssembler accumulates streaming tool call fragments by index.
+type toolCallAssembler struct {
+	calls map[int]*assemblingToolCall
+}
+
+type assemblingToolCall struct {
+	ID        string
+	Name      string
+	Arguments strings.Builder
+}
+
+func newToolCallAssembler() *toolCallAssembler {
+	return &toolCallAssembler{
+		calls: make(map[int]*assemblingToolCall),
+	}
+}
+
+// addFragment processes a tool-call-start or tool-call-delta event.
+func (a *toolCallAssembler) addFragment(index int, tc *cohereToolCall) {
+	call, exists := a.calls[index]
+	if !exists {
+		call = &assemblingToolCall{}
+		a.calls[index] = call
+	}
+
+	if tc.ID != "" {
+		call.ID = tc.ID
+	}
+	if tc.Function.Name != "" {
+		call.Name = tc.Function.Name
+	}
+	call.Arguments.WriteString(tc.Function.Arguments)
+}
+
+// finalize validates and returns the assembled tool calls in index order.
+func (a *toolCallAssembler) finalize() ([]core.ToolCall, error) {
+	if len(a.calls) == 0 {
+		return nil, nil
+	}
+
+	indices := make([]int, 0, len(a.calls))
+	for idx := range a.calls {
+		indices = append(indices, idx)
+	}
+	sort.Ints(indices)
+
+	result := make([]core.ToolCall, 0, len(a.calls))
+	for _, idx := range indices {
+		call := a.calls[idx]
+
+		args := call.Arguments.String()
+		if args == "" {
+			args = "{}"
+		}
+		if !json.Valid([]byte(args)) {
+			return nil, ErrToolArgsInvalidJSON
+		}
+
+		result = append(result, core.ToolCall{
+			ID:        call.ID,
+			Name:      call.Name,
+			Arguments: json.RawMessage(args),
+		})
+	}
+
+	return result, nil
+}
+
+// doStreamChat performs a streaming chat request.
+func (p *Cohere) doStreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	// Marshal request body with stream=true
+	body, err := json.Marshal(buildRequest(req, true))
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	httpReq, err := p.newRequest(ctx, chatPath, body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	// Execute request
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	// Extract request ID from response headers
+	requestID := resp.Header.Get("x-request-id")
+
+	// Check for error status
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, normalizeError(resp.StatusCode, respBody, requestID)
+	}
+
+	// Create channels
+	chunkCh := make(chan core.ChatChunk, 100)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *core.ChatResponse, 1)
+
+	// Start goroutine to process SSE stream
+	go p.processSSEStream(ctx, resp.Body, req.Model, requestID, chunkCh, errCh, finalCh)
+
+	return &core.ChatStream{
+		Ch:    chunkCh,
+		Err:   errCh,
+		Final: finalCh,
+	}, nil
+}
+
+// processSSEStream reads the SSE stream and emits chunks.
+// Every data line carries a typed event; the stream ends with message-end.
+func (p *Cohere) processSSEStream(
+	ctx context.Context,
+	body io.ReadCloser,
+	model core.ModelID,
+	requestID string,
+	chunkCh chan<- core.ChatChunk,
+	errCh chan<- error,
+	finalCh chan<- *core.ChatResponse,
+) {
+	defer body.Close()
+	defer close(chunkCh)
+	defer close(errCh)
+	defer close(finalCh)
+
+	reader := bufio.NewReader(body)
+	assembler := newToolCallAssembler()
+
+	var responseID string
+	var usage *cohereUsage
+	var reasoning strings.Builder
+
+loop:
+	for {
+		// Check for context cancellation
+		select {
+		case <-ctx.Done():
+			errCh <- ctx.Err()
+			return
+		default:
+		}
+
+		// Read line
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			errCh <- newNetworkError(err)
+			return
+		}
+
+		// Trim whitespace
+		line = strings.TrimSpace(line)
+
+		// The event type is repeated in the payload, so only data lines matter
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		payload := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+
+		// Parse event
+		var event cohereStreamEvent
+		if err := json.Unmarshal([]byte(payload), &event); err != nil {
+			errCh <- newDecodeError(err)
+			return
+		}
+
+		if event.Delta != nil && event.Delta.Error != "" {
+			errCh <- newStreamError(event.Delta.Error, firstNonEmpty(requestID, responseID))
+			return
+		}
+
+		switch event.Type {
+		case "message-start":
+			responseID = event.ID
+
+		case "content-delta":
+			if event.Delta == nil || event.Delta.Message == nil || event.Delta.Message.Content == nil {
+				continue
+			}
+			content := event.Delta.Message.Content
+			reasoning.WriteString(content.Thinking)
+
+			// Emit content delta
+			if content.Text != "" {
+				select {
+				case chunkCh <- core.ChatChunk{Delta: content.Text}:
+				case <-ctx.Done():
+					errCh <- ctx.Err()
+					return
+				}
+			}
+
+		case "tool-call-start", "tool-call-delta":
+			// Accumulate tool calls
+			if event.Delta != nil && event.Delta.Message != nil && event.Delta.Message.ToolCalls != nil {
+				assembler.addFragment(event.Index, event.Delta.Message.ToolCalls)
+			}
+
+		case "message-end":
+			if event.Delta != nil {
+				usage = event.Delta.Usage
+			}
+			break loop
+		}
+	}
+
+	// Finalize tool calls
+	toolCalls, err := assembler.finalize()
+	if err != nil {
+		errCh <- err
+		return
+	}
+
+	// Build final response
+	finalResp := &core.ChatResponse{
+		ID:        responseID,
+		Model:     model,
+		ToolCalls: toolCalls,
+	}
+
+	if reasoning.Len() > 0 {
+		finalResp.Reasoning = &core.ReasoningOutput{
+			Summary: []string{reasoning.String()},
+		}
+	}
+
+	if usage != nil {
+		finalResp.Usage = mapUsage(usage)
+	}
+
+	finalCh <- finalResp
+}
+
+// firstNonEmpty returns the first non-empty string.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
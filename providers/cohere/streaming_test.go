@@ -0,0 +1,98 @@
+package cohere
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers/providertest"
+)
+
+// sseEvents formats typed events as a Cohere SSE response body.
+func sseEvents(events ...string) string {
+	var sb strings.Builder
+	for _, e := range events {
+		typ := e[strings.Index(e, `"type":"`)+8:]
+		typ = typ[:strings.Index(typ, `"`)]
+		sb.WriteString("event: " + typ + "\n")
+		sb.WriteString("data: " + e + "\n\n")
+	}
+	return sb.String()
+}
+
+// streamChat serves body as a streaming response and collects the result.
+func streamChat(t *testing.T, body string) *providertest.StreamResult {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat" {
+			t.Errorf("Path = %q, want /chat", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	p := New("test-key", WithBaseURL(server.URL))
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{Model: ModelCommandAReasoning})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	res, err := providertest.CollectStream(stream, time.Second)
+	if err != nil {
+		t.Fatalf("CollectStream() error = %v", err)
+	}
+	if v := res.Violations(); len(v) > 0 {
+		t.Fatalf("contract violations: %v", v)
+	}
+	return res
+}
+
+func TestStreamThinking(t *testing.T) {
+	res := streamChat(t, sseEvents(
+		`{"type":"message-start","id":"r1","delta":{"message":{"role":"assistant"}}}`,
+		`{"type":"content-start","index":0,"delta":{"message":{"content":{"type":"thinking","thinking":""}}}}`,
+		`{"type":"content-delta","index":0,"delta":{"message":{"content":{"thinking":"Two plus two."}}}}`,
+		`{"type":"content-start","index":1,"delta":{"message":{"content":{"type":"text","text":""}}}}`,
+		`{"type":"content-delta","index":1,"delta":{"message":{"content":{"text":"4"}}}}`,
+		`{"type":"message-end","delta":{"finish_reason":"COMPLETE"}}`,
+	))
+
+	if res.Err() != nil {
+		t.Fatalf("stream error = %v", res.Err())
+	}
+	if res.Output() != "4" {
+		t.Errorf("Output() = %q, want 4", res.Output())
+	}
+
+	final := res.Final()
+	if final.ID != "r1" || final.Model != ModelCommandAReasoning {
+		t.Errorf("final = %+v", final)
+	}
+	if final.Reasoning == nil || final.Reasoning.Summary[0] != "Two plus two." {
+		t.Errorf("Reasoning = %+v", final.Reasoning)
+	}
+}
+
+func TestStreamError(t *testing.T) {
+	res := streamChat(t, sseEvents(
+		`{"type":"message-start","id":"r2","delta":{"message":{"role":"assistant"}}}`,
+		`{"type":"message-end","delta":{"finish_reason":"ERROR","error":"internal failure"}}`,
+	))
+
+	var pErr *core.ProviderError
+	if !errors.As(res.Err(), &pErr) {
+		t.Fatalf("expected ProviderError, got %v", res.Err())
+	}
+	if pErr.Message != "internal failure" || pErr.RequestID != "r2" {
+		t.Errorf("ProviderError = %+v", pErr)
+	}
+	if !errors.Is(res.Err(), core.ErrServer) {
+		t.Errorf("expected ErrServer, got %v", res.Err())
+	}
+}
@@ -0,0 +1,113 @@
+package cohere
+
+import "encoding/json"
+
+// cohereRequest represents a request to the Cohere v2 chat API.
+type cohereRequest struct {
+	Model       string          `json:"model"`
+	Messages    []cohereMessage `json:"messages"`
+	Temperature *float32        `json:"temperature,omitempty"`
+	MaxTokens   *int            `json:"max_tokens,omitempty"`
+	Stream      bool            `json:"stream"`
+	Tools       []cohereTool    `json:"tools,omitempty"`
+	Thinking    *cohereThinking `json:"thinking,omitempty"`
+}
+
+// cohereMessage represents a message in the Cohere format.
+type cohereMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// cohereThinking controls reasoning on reasoning models.
+type cohereThinking struct {
+	Type string `json:"type"` // "enabled" or "disabled"
+}
+
+// cohereTool represents a tool definition in the Cohere format.
+type cohereTool struct {
+	Type     string         `json:"type"`
+	Function cohereFunction `json:"function"`
+}
+
+// cohereFunction represents a function definition for Cohere tools.
+type cohereFunction struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Parameters  json.RawMessage `json:"parameters"`
+}
+
+// cohereResponse represents a response from the Cohere v2 chat API.
+// The response does not echo the model.
+type cohereResponse struct {
+	ID           string        `json:"id"`
+	FinishReason string        `json:"finish_reason"`
+	Message      cohereRespMsg `json:"message"`
+	Usage        *cohereUsage  `json:"usage,omitempty"`
+}
+
+// cohereRespMsg represents the assistant message in a response.
+type cohereRespMsg struct {
+	Role      string           `json:"role"`
+	Content   []cohereContent  `json:"content,omitempty"`
+	ToolPlan  string           `json:"tool_plan,omitempty"`
+	ToolCalls []cohereToolCall `json:"tool_calls,omitempty"`
+}
+
+// cohereContent is one content block of an assistant message.
+type cohereContent struct {
+	Type     string `json:"type"` // "text" or "thinking"
+	Text     string `json:"text,omitempty"`
+	Thinking string `json:"thinking,omitempty"`
+}
+
+// cohereToolCall represents a tool call in a Cohere response.
+type cohereToolCall struct {
+	ID       string             `json:"id,omitempty"`
+	Type     string             `json:"type,omitempty"`
+	Function cohereFunctionCall `json:"function"`
+}
+
+// cohereFunctionCall represents the function details in a tool call.
+type cohereFunctionCall struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+// cohereUsage reports billed and actual token counts.
+type cohereUsage struct {
+	BilledUnits cohereTokens `json:"billed_units"`
+	Tokens      cohereTokens `json:"tokens"`
+}
+
+// cohereTokens holds input and output token counts.
+type cohereTokens struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// Streaming response types for the Cohere v2 SSE protocol.
+
+// cohereStreamEvent is a single typed event in a streaming response.
+type cohereStreamEvent struct {
+	Type  string             `json:"type"`
+	ID    string             `json:"id,omitempty"`
+	Index int                `json:"index"`
+	Delta *cohereStreamDelta `json:"delta,omitempty"`
+}
+
+// cohereStreamDelta carries the payload of a stream event.
+type cohereStreamDelta struct {
+	Message      *cohereStreamMessage `json:"message,omitempty"`
+	FinishReason string               `json:"finish_reason,omitempty"`
+	Usage        *cohereUsage         `json:"usage,omitempty"`
+	Error        string               `json:"error,omitempty"`
+}
+
+// cohereStreamMessage is the partial message in a stream event.
+// Content and tool calls are single objects, not arrays.
+type cohereStreamMessage struct {
+	Content   *cohereContent  `json:"content,omitempty"`
+	ToolPlan  string          `json:"tool_plan,omitempty"`
+	ToolCalls *cohereToolCall `json:"tool_calls,omitempty"`
+}
@@ -0,0 +1,35 @@
+package cohere
+
+import "encoding/json"
+
+// cohereEmbedRequest is the request body for POST /v2/embed.
+type cohereEmbedRequest struct {
+	Model           string   `json:"model"`
+	Texts           []string `json:"texts"`
+	InputType       string   `json:"input_type"`
+	EmbeddingTypes  []string `json:"embedding_types"`
+	OutputDimension *int     `json:"output_dimension,omitempty"`
+	Truncate        string   `json:"truncate,omitempty"`
+}
+
+// cohereEmbedResponse is the response from POST /v2/embed.
+// Embeddings are keyed by embedding type: numeric types hold one
+// vector per text, base64 holds one string per text.
+type cohereEmbedResponse struct {
+	ID         string                     `json:"id"`
+	Embeddings map[string]json.RawMessage `json:"embeddings"`
+	Texts      []string                   `json:"texts"`
+	Meta       cohereMeta                 `json:"meta"`
+}
+
+// cohereMeta carries billing information for embed and rerank responses.
+type cohereMeta struct {
+	BilledUnits cohereBilledUnits `json:"billed_units"`
+	Tokens      *cohereTokens     `json:"tokens,omitempty"`
+}
+
+// cohereBilledUnits holds billed input tokens and search units.
+type cohereBilledUnits struct {
+	InputTokens int `json:"input_tokens"`
+	SearchUnits int `json:"search_units"`
+}
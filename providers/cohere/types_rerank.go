@@ -0,0 +1,22 @@
+package cohere
+
+// cohereRerankRequest is the request body for POST /v2/rerank.
+type cohereRerankRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopN      *int     `json:"top_n,omitempty"`
+}
+
+// cohereRerankResponse is the response from POST /v2/rerank.
+type cohereRerankResponse struct {
+	ID      string               `json:"id"`
+	Results []cohereRerankResult `json:"results"`
+	Meta    cohereMeta           `json:"meta"`
+}
+
+// cohereRerankResult represents a single reranking result.
+type cohereRerankResult struct {
+	Index          int     `json:"index"`
+	RelevanceScore float64 `json:"relevance_score"`
+}
@@ -0,0 +1,371 @@
+package gemini
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+)
+
+const (
+	// defaultVoice is used when a speech request names no voice.
+	defaultVoice = "Kore"
+
+	// defaultSampleRate is the sample rate of Gemini speech output when
+	// the response does not report one.
+	defaultSampleRate = 24000
+)
+
+// Transcribe converts speech to text by prompting a Gemini model with the
+// audio. The audio is sent inline, so it is read into memory and is limited
+// by the API's request size (about 20 MB); upload longer recordings with
+// CreateFile and reference them in a chat request instead.
+//
+// Gemini does not report timing, so Segments and Words are always empty.
+func (p *Gemini) Transcribe(ctx context.Context, req *core.TranscriptionRequest) (*core.Transcription, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	audio, err := io.ReadAll(req.Audio)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audio: %w", err)
+	}
+
+	mimeType := req.MimeType
+	if mimeType == "" {
+		mimeType = guessMimeType(req.Filename)
+	}
+
+	gemReq := &geminiRequest{
+		Contents: []geminiContent{{
+			Role: "user",
+			Parts: []geminiPart{
+				{Text: transcriptionPrompt(req)},
+				{InlineData: &geminiInlineData{
+					MimeType: mimeType,
+					Data:     base64.StdEncoding.EncodeToString(audio),
+				}},
+			},
+		}},
+	}
+	if req.Temperature != nil {
+		gemReq.GenerationConfig = &geminiGenConfig{Temperature: req.Temperature}
+	}
+
+	var gemResp geminiResponse
+	if err := p.doGenerate(ctx, string(req.Model), gemReq, &gemResp); err != nil {
+		return nil, err
+	}
+
+	resp, err := mapResponse(&gemResp, string(req.Model))
+	if err != nil {
+		return nil, err
+	}
+	return &core.Transcription{Text: strings.TrimSpace(resp.Output)}, nil
+}
+
+// transcriptionPrompt builds the instruction sent alongside the audio.
+func transcriptionPrompt(req *core.TranscriptionRequest) string {
+	var b strings.Builder
+	if req.Translate {
+		b.WriteString("Translate the speech in this audio into English.")
+	} else {
+		b.WriteString("Transcribe the speech in this audio verbatim.")
+	}
+	if req.Language != "" {
+		fmt.Fprintf(&b, " The speech is in language %q.", req.Language)
+	}
+	b.WriteString(" Respond with the text only.")
+	if req.Prompt != "" {
+		b.WriteString("\n\nContext: ")
+		b.WriteString(req.Prompt)
+	}
+	return b.String()
+}
+
+// SynthesizeSpeech generates speech with a Gemini TTS model. Gemini
+// produces 16-bit mono PCM; the format may be AudioFormatPCM (the default)
+// or AudioFormatWAV, which adds a WAV header. The voice defaults to "Kore".
+// Speed is not supported; describe the pace in Instructions instead.
+func (p *Gemini) SynthesizeSpeech(ctx context.Context, req *core.SpeechRequest) (*core.SpeechResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	format := req.Format
+	if format == "" {
+		format = core.AudioFormatPCM
+	}
+	if format != core.AudioFormatPCM && format != core.AudioFormatWAV {
+		return nil, unsupportedFormatError(format)
+	}
+
+	var gemResp geminiResponse
+	if err := p.doGenerate(ctx, string(req.Model), buildSpeechRequest(req), &gemResp); err != nil {
+		return nil, err
+	}
+
+	var audio []byte
+	sampleRate := defaultSampleRate
+	for _, c := range gemResp.Candidates {
+		for _, part := range c.Content.Parts {
+			if part.InlineData == nil {
+				continue
+			}
+			data, err := base64.StdEncoding.DecodeString(part.InlineData.Data)
+			if err != nil {
+				return nil, newDecodeError(err)
+			}
+			audio = append(audio, data...)
+			sampleRate = pcmSampleRate(part.InlineData.MimeType)
+		}
+	}
+
+	if format == core.AudioFormatWAV {
+		audio = append(wavHeader(len(audio), sampleRate), audio...)
+	}
+
+	return &core.SpeechResponse{
+		Audio:    audio,
+		Format:   format,
+		MimeType: format.MimeType(),
+	}, nil
+}
+
+// StreamSpeech generates speech with a Gemini TTS model, returning PCM
+// audio as it is generated. Only AudioFormatPCM can be streamed.
+func (p *Gemini) StreamSpeech(ctx context.Context, req *core.SpeechRequest) (*core.SpeechStream, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Format != "" && req.Format != core.AudioFormatPCM {
+		return nil, unsupportedFormatError(req.Format)
+	}
+
+	body, err := json.Marshal(buildSpeechRequest(req))
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	url := fmt.Sprintf("%s/v1beta/models/%s:streamGenerateContent?alt=sse", p.config.BaseURL, req.Model)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, normalizeError(resp.StatusCode, respBody)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer resp.Body.Close()
+		pw.CloseWithError(copySpeechStream(pw, resp.Body))
+	}()
+
+	return &core.SpeechStream{
+		ReadCloser: &speechStreamReader{PipeReader: pr, body: resp.Body},
+		Format:     core.AudioFormatPCM,
+		MimeType:   core.AudioFormatPCM.MimeType(),
+	}, nil
+}
+
+// copySpeechStream decodes the audio in each SSE event and writes it to w.
+func copySpeechStream(w io.Writer, body io.Reader) error {
+	reader := bufio.NewReader(body)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return newNetworkError(err)
+		}
+
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		var chunk geminiResponse
+		if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))), &chunk); err != nil {
+			return newDecodeError(err)
+		}
+
+		for _, c := range chunk.Candidates {
+			for _, part := range c.Content.Parts {
+				if part.InlineData == nil {
+					continue
+				}
+				data, err := base64.StdEncoding.DecodeString(part.InlineData.Data)
+				if err != nil {
+					return newDecodeError(err)
+				}
+				if _, err := w.Write(data); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// speechStreamReader reads streamed audio. Closing it also closes the
+// response body, so the decoding goroutine stops promptly.
+type speechStreamReader struct {
+	*io.PipeReader
+	body io.Closer
+}
+
+// Close closes the response body and the pipe.
+func (r *speechStreamReader) Close() error {
+	r.body.Close()
+	return r.PipeReader.Close()
+}
+
+// buildSpeechRequest creates a generateContent request for audio output.
+// Gemini takes delivery instructions as part of the prompt.
+func buildSpeechRequest(req *core.SpeechRequest) *geminiSpeechRequest {
+	text := req.Input
+	if req.Instructions != "" {
+		text = req.Instructions + ": " + req.Input
+	}
+
+	voice := req.Voice
+	if voice == "" {
+		voice = defaultVoice
+	}
+
+	return &geminiSpeechRequest{
+		Contents: []geminiContent{{
+			Role:  "user",
+			Parts: []geminiPart{{Text: text}},
+		}},
+		GenerationConfig: &geminiSpeechGenConfig{
+			ResponseModalities: []string{"AUDIO"},
+			SpeechConfig: geminiSpeechConfig{
+				VoiceConfig: geminiVoiceConfig{
+					PrebuiltVoiceConfig: geminiPrebuiltVoice{VoiceName: voice},
+				},
+			},
+		},
+	}
+}
+
+// doGenerate sends a generateContent request and decodes the response into out.
+func (p *Gemini) doGenerate(ctx context.Context, model string, payload, out any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return newDecodeError(err)
+	}
+
+	url := fmt.Sprintf("%s/v1beta/models/%s:generateContent", p.config.BaseURL, model)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return newNetworkError(err)
+	}
+
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return normalizeError(resp.StatusCode, respBody)
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return newDecodeError(err)
+	}
+	return nil
+}
+
+// pcmSampleRate reads the sample rate from a MIME type such as
+// audio/L16;codec=pcm;rate=24000.
+func pcmSampleRate(mimeType string) int {
+	_, params, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return defaultSampleRate
+	}
+	rate, err := strconv.Atoi(params["rate"])
+	if err != nil || rate <= 0 {
+		return defaultSampleRate
+	}
+	return rate
+}
+
+// wavHeader returns the 44-byte header of a 16-bit mono PCM WAV file.
+func wavHeader(dataLen, sampleRate int) []byte {
+	const (
+		channels      = 1
+		bitsPerSample = 16
+	)
+	blockAlign := channels * bitsPerSample / 8
+
+	h := make([]byte, 44)
+	copy(h[0:], "RIFF")
+	binary.LittleEndian.PutUint32(h[4:], uint32(36+dataLen))
+	copy(h[8:], "WAVE")
+	copy(h[12:], "fmt ")
+	binary.LittleEndian.PutUint32(h[16:], 16)
+	binary.LittleEndian.PutUint16(h[20:], 1) // PCM
+	binary.LittleEndian.PutUint16(h[22:], channels)
+	binary.LittleEndian.PutUint32(h[24:], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(h[28:], uint32(sampleRate*blockAlign))
+	binary.LittleEndian.PutUint16(h[32:], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(h[34:], bitsPerSample)
+	copy(h[36:], "data")
+	binary.LittleEndian.PutUint32(h[40:], uint32(dataLen))
+	return h
+}
+
+// unsupportedFormatError reports an audio format Gemini cannot produce.
+func unsupportedFormatError(format core.AudioFormat) error {
+	return &core.ProviderError{
+		Provider: "gemini",
+		Code:     "unsupported_format",
+		Message:  fmt.Sprintf("Gemini speech output is PCM; format %q is not supported", format),
+		Err:      core.ErrNotSupported,
+	}
+}
+
+// Compile-time check that Gemini implements Transcriber.
+var _ core.Transcriber = (*Gemini)(nil)
+
+// Compile-time check that Gemini implements SpeechSynthesizer.
+var _ core.SpeechSynthesizer = (*Gemini)(nil)
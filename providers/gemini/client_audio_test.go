@@ -0,0 +1,139 @@
+package gemini
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestTranscribe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1beta/models/gemini-2.5-flash:generateContent" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var req geminiRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		parts := req.Contents[0].Parts
+		if len(parts) != 2 {
+			t.Fatalf("parts = %+v", parts)
+		}
+		if !strings.Contains(parts[0].Text, "Translate") || !strings.Contains(parts[0].Text, `"de"`) {
+			t.Errorf("prompt = %q", parts[0].Text)
+		}
+		if parts[1].InlineData.MimeType != "audio/mp3" || parts[1].InlineData.Data != base64.StdEncoding.EncodeToString([]byte("audio")) {
+			t.Errorf("inline data = %+v", parts[1].InlineData)
+		}
+		io.WriteString(w, `{"candidates":[{"content":{"role":"model","parts":[{"text":"Good morning\n"}]}}]}`)
+	}))
+	defer server.Close()
+
+	var provider core.Transcriber = New("test-key", WithBaseURL(server.URL))
+	got, err := provider.Transcribe(context.Background(), &core.TranscriptionRequest{
+		Model:     ModelGemini25Flash,
+		Audio:     strings.NewReader("audio"),
+		Filename:  "memo.mp3",
+		Language:  "de",
+		Translate: true,
+	})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if got.Text != "Good morning" {
+		t.Errorf("Text = %q", got.Text)
+	}
+}
+
+func TestSynthesizeSpeechWAV(t *testing.T) {
+	pcm := []byte{1, 2, 3, 4}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req geminiSpeechRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if got := req.GenerationConfig.SpeechConfig.VoiceConfig.PrebuiltVoiceConfig.VoiceName; got != "Kore" {
+			t.Errorf("voice = %q, want Kore", got)
+		}
+		if got := req.GenerationConfig.ResponseModalities; len(got) != 1 || got[0] != "AUDIO" {
+			t.Errorf("responseModalities = %v", got)
+		}
+		if got := req.Contents[0].Parts[0].Text; got != "Say warmly: Hello" {
+			t.Errorf("text = %q", got)
+		}
+		fmt.Fprintf(w, `{"candidates":[{"content":{"role":"model","parts":[{"inlineData":{"mimeType":"audio/L16;codec=pcm;rate=16000","data":%q}}]}}]}`,
+			base64.StdEncoding.EncodeToString(pcm))
+	}))
+	defer server.Close()
+
+	var provider core.SpeechSynthesizer = New("test-key", WithBaseURL(server.URL))
+	resp, err := provider.SynthesizeSpeech(context.Background(), &core.SpeechRequest{
+		Model:        ModelGemini25FlashTTS,
+		Input:        "Hello",
+		Instructions: "Say warmly",
+		Format:       core.AudioFormatWAV,
+	})
+	if err != nil {
+		t.Fatalf("SynthesizeSpeech() error = %v", err)
+	}
+
+	if len(resp.Audio) != 44+len(pcm) || string(resp.Audio[:4]) != "RIFF" {
+		t.Fatalf("audio = %v", resp.Audio)
+	}
+	if rate := binary.LittleEndian.Uint32(resp.Audio[24:]); rate != 16000 {
+		t.Errorf("sample rate = %d, want 16000", rate)
+	}
+	if string(resp.Audio[44:]) != string(pcm) || resp.MimeType != "audio/wav" {
+		t.Errorf("SynthesizeSpeech() = %+v", resp)
+	}
+}
+
+func TestSynthesizeSpeechUnsupportedFormat(t *testing.T) {
+	provider := New("test-key", WithBaseURL("http://127.0.0.1:0"))
+	_, err := provider.SynthesizeSpeech(context.Background(), &core.SpeechRequest{
+		Model:  ModelGemini25FlashTTS,
+		Input:  "Hello",
+		Format: core.AudioFormatMP3,
+	})
+	if !errors.Is(err, core.ErrNotSupported) {
+		t.Errorf("SynthesizeSpeech() error = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestStreamSpeech(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1beta/models/gemini-2.5-flash-preview-tts:streamGenerateContent" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		for _, chunk := range []string{"abc", "def"} {
+			fmt.Fprintf(w, "data: {\"candidates\":[{\"content\":{\"parts\":[{\"inlineData\":{\"mimeType\":\"audio/L16;codec=pcm;rate=24000\",\"data\":%q}}]}}]}\n\n",
+				base64.StdEncoding.EncodeToString([]byte(chunk)))
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	stream, err := provider.StreamSpeech(context.Background(), &core.SpeechRequest{
+		Model: ModelGemini25FlashTTS,
+		Input: "Hello",
+	})
+	if err != nil {
+		t.Fatalf("StreamSpeech() error = %v", err)
+	}
+	defer stream.Close()
+
+	data, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if string(data) != "abcdef" || stream.Format != core.AudioFormatPCM {
+		t.Errorf("stream = %q, format %s", data, stream.Format)
+	}
+}
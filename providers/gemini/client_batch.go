@@ -0,0 +1,341 @@
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// batchDisplayName names batches created through CreateBatch.
+const batchDisplayName = "iris-batch"
+
+// CreateBatch submits the requests as a batch job. The input is written as
+// JSONL and streamed to the Files API, so large batches are never held in
+// memory as a whole. Gemini runs a batch against a single model, so every
+// item must use the same model. Metadata is not supported and is ignored.
+func (p *Gemini) CreateBatch(ctx context.Context, req *core.BatchRequest) (*core.BatchJob, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	model := req.Items[0].Request.Model
+	for _, item := range req.Items[1:] {
+		if item.Request.Model != model {
+			return nil, fmt.Errorf("%w: item %q uses model %s, but Gemini batches run a single model (%s)",
+				core.ErrInvalidBatchItem, item.CustomID, item.Request.Model, model)
+		}
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeBatchInput(pw, req.Items))
+	}()
+
+	file, err := p.UploadFile(ctx, &FileUploadRequest{
+		File:        pr,
+		DisplayName: "batch.jsonl",
+		MimeType:    "application/jsonl",
+	})
+	pr.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var op geminiBatchOperation
+	url := fmt.Sprintf("%s/v1beta/models/%s:batchGenerateContent", p.config.BaseURL, model)
+	err = p.doBatchRequest(ctx, http.MethodPost, url, &geminiBatchCreateRequest{
+		Batch: geminiBatchSpec{
+			DisplayName: batchDisplayName,
+			InputConfig: geminiBatchInputConfig{FileName: file.Name},
+		},
+	}, &op)
+	if err != nil {
+		return nil, err
+	}
+	return mapBatchJob(&op), nil
+}
+
+// writeBatchInput writes one JSONL line per batch item.
+func writeBatchInput(w io.Writer, items []core.BatchItem) error {
+	enc := json.NewEncoder(w)
+	for _, item := range items {
+		line := geminiBatchLine{
+			Key:     item.CustomID,
+			Request: buildRequest(item.Request),
+		}
+		if err := enc.Encode(&line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetBatch returns the current state of a batch job. id is the batch
+// resource name, such as "batches/abc123".
+func (p *Gemini) GetBatch(ctx context.Context, id string) (*core.BatchJob, error) {
+	op, err := p.getBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapBatchJob(op), nil
+}
+
+// CancelBatch requests cancellation of a batch job. The cancel call returns
+// no body, so the job is fetched again afterwards.
+func (p *Gemini) CancelBatch(ctx context.Context, id string) (*core.BatchJob, error) {
+	url := p.config.BaseURL + "/v1beta/" + batchName(id) + ":cancel"
+	if err := p.doBatchRequest(ctx, http.MethodPost, url, nil, nil); err != nil {
+		return nil, err
+	}
+	return p.GetBatch(ctx, id)
+}
+
+// BatchResults returns the results of a finished batch job, read from its
+// responses file.
+func (p *Gemini) BatchResults(ctx context.Context, id string) ([]core.BatchResult, error) {
+	op, err := p.getBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := batchState(op)
+	if !mapBatchStatus(batch.State).IsTerminal() {
+		return nil, &core.ProviderError{
+			Provider: "gemini",
+			Code:     "batch_not_finished",
+			Message:  fmt.Sprintf("batch %s is %s", batchName(id), batch.State),
+			Err:      core.ErrBadRequest,
+		}
+	}
+
+	responsesFile := batch.ResponsesFile
+	if batch.Output != nil && batch.Output.ResponsesFile != "" {
+		responsesFile = batch.Output.ResponsesFile
+	}
+	if responsesFile == "" {
+		return nil, nil
+	}
+
+	url := p.config.BaseURL + "/download/v1beta/" + responsesFile + ":download?alt=media"
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, normalizeError(resp.StatusCode, body)
+	}
+
+	model := strings.TrimPrefix(batch.Model, "models/")
+	var results []core.BatchResult
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var line geminiBatchResultLine
+		if err := dec.Decode(&line); err != nil {
+			if errors.Is(err, io.EOF) {
+				return results, nil
+			}
+			return nil, newDecodeError(err)
+		}
+		results = append(results, mapBatchResult(&line, model))
+	}
+}
+
+// mapBatchResult converts one responses file line to a core result.
+func mapBatchResult(line *geminiBatchResultLine, model string) core.BatchResult {
+	result := core.BatchResult{CustomID: line.Key}
+
+	switch {
+	case line.Error != nil:
+		result.Err = &core.ProviderError{
+			Provider: "gemini",
+			Code:     line.Error.Status,
+			Message:  line.Error.Message,
+			Err:      rpcStatusSentinel(line.Error.Status),
+		}
+	case line.Response == nil:
+		result.Err = newDecodeError(fmt.Errorf("batch result %q has no response", line.Key))
+	default:
+		result.Response, result.Err = mapResponse(line.Response, model)
+	}
+	return result
+}
+
+// rpcStatusSentinel maps a google.rpc status name to a core sentinel.
+func rpcStatusSentinel(status string) error {
+	switch status {
+	case "INVALID_ARGUMENT", "FAILED_PRECONDITION", "OUT_OF_RANGE", "NOT_FOUND":
+		return core.ErrBadRequest
+	case "UNAUTHENTICATED", "PERMISSION_DENIED":
+		return core.ErrUnauthorized
+	case "RESOURCE_EXHAUSTED":
+		return core.ErrRateLimited
+	case "CANCELLED":
+		return core.ErrBatchItemCancelled
+	default:
+		return core.ErrServer
+	}
+}
+
+// getBatch retrieves the raw batch operation.
+func (p *Gemini) getBatch(ctx context.Context, id string) (*geminiBatchOperation, error) {
+	var op geminiBatchOperation
+	url := p.config.BaseURL + "/v1beta/" + batchName(id)
+	if err := p.doBatchRequest(ctx, http.MethodGet, url, nil, &op); err != nil {
+		return nil, err
+	}
+	return &op, nil
+}
+
+// doBatchRequest sends a batch request and decodes the JSON response into
+// out, if out is non-nil.
+func (p *Gemini) doBatchRequest(ctx context.Context, method, url string, payload, out any) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return newDecodeError(err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return newNetworkError(err)
+	}
+
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return normalizeError(resp.StatusCode, respBody)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return newDecodeError(err)
+	}
+	return nil
+}
+
+// batchName converts a batch ID to its resource name, batches/{id}.
+func batchName(id string) string {
+	if strings.HasPrefix(id, "batches/") {
+		return id
+	}
+	return "batches/" + id
+}
+
+// batchState returns the batch described by an operation, preferring the
+// final response over the progress metadata.
+func batchState(op *geminiBatchOperation) *geminiBatch {
+	batch := &geminiBatch{}
+	if op.Metadata != nil {
+		*batch = *op.Metadata
+	}
+	if op.Response != nil {
+		if op.Response.State != "" {
+			batch.State = op.Response.State
+		}
+		if op.Response.BatchStats != nil {
+			batch.BatchStats = op.Response.BatchStats
+		}
+		if op.Response.Output != nil {
+			batch.Output = op.Response.Output
+		}
+		if op.Response.ResponsesFile != "" {
+			batch.ResponsesFile = op.Response.ResponsesFile
+		}
+	}
+	if batch.State == "" && op.Error != nil {
+		batch.State = "BATCH_STATE_FAILED"
+	}
+	return batch
+}
+
+// mapBatchJob converts a Gemini batch operation to core format.
+func mapBatchJob(op *geminiBatchOperation) *core.BatchJob {
+	batch := batchState(op)
+
+	job := &core.BatchJob{
+		ID:     op.Name,
+		Status: mapBatchStatus(batch.State),
+	}
+	if stats := batch.BatchStats; stats != nil {
+		// Counts are int64 strings; unparseable values are left zero.
+		job.Counts.Total, _ = strconv.Atoi(stats.RequestCount)
+		job.Counts.Succeeded, _ = strconv.Atoi(stats.SuccessfulRequestCount)
+		job.Counts.Failed, _ = strconv.Atoi(stats.FailedRequestCount)
+	}
+	job.CreatedAt, _ = time.Parse(time.RFC3339Nano, batch.CreateTime)
+	if ended, err := time.Parse(time.RFC3339Nano, batch.EndTime); err == nil {
+		job.EndedAt = &ended
+	}
+	if op.Error != nil {
+		job.Errors = append(job.Errors, op.Error.Message)
+	}
+	return job
+}
+
+// mapBatchStatus converts a Gemini batch state such as BATCH_STATE_RUNNING
+// or JOB_STATE_RUNNING to core format.
+func mapBatchStatus(state string) core.BatchStatus {
+	if i := strings.Index(state, "_STATE_"); i >= 0 {
+		state = state[i+len("_STATE_"):]
+	}
+
+	switch state {
+	case "RUNNING":
+		return core.BatchStatusInProgress
+	case "SUCCEEDED":
+		return core.BatchStatusCompleted
+	case "FAILED":
+		return core.BatchStatusFailed
+	case "CANCELLED":
+		return core.BatchStatusCancelled
+	case "EXPIRED":
+		return core.BatchStatusExpired
+	default:
+		return core.BatchStatusPending
+	}
+}
+
+// Compile-time check that Gemini implements BatchProvider.
+var _ core.BatchProvider = (*Gemini)(nil)
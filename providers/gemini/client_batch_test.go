@@ -0,0 +1,200 @@
+package gemini
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func batchItem(id string, model core.ModelID) core.BatchItem {
+	return core.BatchItem{CustomID: id, Request: &core.ChatRequest{
+		Model:    model,
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Hello " + id}},
+	}}
+}
+
+func TestCreateBatch(t *testing.T) {
+	var lines []geminiBatchLine
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/upload/v1beta/files":
+			if got := r.Header.Get("X-Goog-Upload-Header-Content-Type"); got != "application/jsonl" {
+				t.Errorf("upload content type = %q, want application/jsonl", got)
+			}
+			w.Header().Set("X-Goog-Upload-URL", "http://"+r.Host+"/upload-target")
+		case "/upload-target":
+			scanner := bufio.NewScanner(r.Body)
+			for scanner.Scan() {
+				var line geminiBatchLine
+				if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+					t.Fatalf("invalid JSONL line: %v", err)
+				}
+				lines = append(lines, line)
+			}
+			json.NewEncoder(w).Encode(fileUploadResponse{File: File{Name: "files/input", State: FileStateActive}})
+		case "/v1beta/models/gemini-2.5-flash:batchGenerateContent":
+			var req geminiBatchCreateRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Batch.InputConfig.FileName != "files/input" {
+				t.Errorf("input file = %q, want files/input", req.Batch.InputConfig.FileName)
+			}
+			fmt.Fprint(w, `{"name":"batches/abc","metadata":{"@type":"type.googleapis.com/google.ai.generativelanguage.v1main.GenerateContentBatch",
+				"model":"models/gemini-2.5-flash","state":"BATCH_STATE_PENDING","createTime":"2025-01-01T00:00:00.123Z",
+				"batchStats":{"requestCount":"2","pendingRequestCount":"2"}}}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var provider core.BatchProvider = New("test-key", WithBaseURL(server.URL))
+	job, err := provider.CreateBatch(context.Background(), &core.BatchRequest{
+		Items: []core.BatchItem{batchItem("a", "gemini-2.5-flash"), batchItem("b", "gemini-2.5-flash")},
+	})
+	if err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+
+	if len(lines) != 2 || lines[0].Key != "a" || lines[1].Key != "b" {
+		t.Fatalf("lines = %+v", lines)
+	}
+	if got := lines[0].Request.Contents[0].Parts[0].Text; got != "Hello a" {
+		t.Errorf("request text = %q", got)
+	}
+	if job.ID != "batches/abc" || job.Status != core.BatchStatusPending || job.Counts.Total != 2 {
+		t.Errorf("job = %+v", job)
+	}
+	if job.CreatedAt.Year() != 2025 {
+		t.Errorf("CreatedAt = %v", job.CreatedAt)
+	}
+}
+
+func TestCreateBatchRequiresSingleModel(t *testing.T) {
+	provider := New("test-key", WithBaseURL("http://127.0.0.1:0"))
+	_, err := provider.CreateBatch(context.Background(), &core.BatchRequest{
+		Items: []core.BatchItem{batchItem("a", "gemini-2.5-flash"), batchItem("b", "gemini-2.5-pro")},
+	})
+	if !errors.Is(err, core.ErrInvalidBatchItem) {
+		t.Errorf("CreateBatch() error = %v, want ErrInvalidBatchItem", err)
+	}
+}
+
+func TestBatchResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1beta/batches/abc":
+			fmt.Fprint(w, `{"name":"batches/abc","done":true,
+				"metadata":{"model":"models/gemini-2.5-flash","state":"BATCH_STATE_SUCCEEDED","createTime":"2025-01-01T00:00:00Z","endTime":"2025-01-01T01:00:00Z",
+					"batchStats":{"requestCount":"2","successfulRequestCount":"1","failedRequestCount":"1"}},
+				"response":{"responsesFile":"files/output"}}`)
+		case "/download/v1beta/files/output:download":
+			if r.URL.Query().Get("alt") != "media" {
+				t.Errorf("alt = %q, want media", r.URL.Query().Get("alt"))
+			}
+			fmt.Fprintln(w, `{"key":"a","response":{"candidates":[{"content":{"role":"model","parts":[{"text":"Hi a"}]},"finishReason":"STOP"}],"usageMetadata":{"promptTokenCount":3,"candidatesTokenCount":2}}}`)
+			fmt.Fprintln(w, `{"key":"b","error":{"code":3,"message":"bad request","status":"INVALID_ARGUMENT"}}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	results, err := provider.BatchResults(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("BatchResults() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("len(results) = %d, want 2", len(results))
+	}
+
+	if results[0].CustomID != "a" || results[0].Err != nil {
+		t.Fatalf("results[0] = %+v", results[0])
+	}
+	if results[0].Response.Output != "Hi a" || results[0].Response.Model != "gemini-2.5-flash" {
+		t.Errorf("results[0].Response = %+v", results[0].Response)
+	}
+	if results[1].CustomID != "b" || !errors.Is(results[1].Err, core.ErrBadRequest) {
+		t.Errorf("results[1] = %+v", results[1])
+	}
+}
+
+func TestBatchResultsNotFinished(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"batches/abc","metadata":{"state":"BATCH_STATE_RUNNING"}}`)
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	_, err := provider.BatchResults(context.Background(), "batches/abc")
+	if !errors.Is(err, core.ErrBadRequest) {
+		t.Errorf("BatchResults() error = %v, want ErrBadRequest", err)
+	}
+}
+
+func TestCancelBatch(t *testing.T) {
+	var cancelled bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1beta/batches/abc:cancel":
+			cancelled = true
+			io.WriteString(w, `{}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1beta/batches/abc":
+			fmt.Fprint(w, `{"name":"batches/abc","done":true,"metadata":{"state":"BATCH_STATE_CANCELLED"}}`)
+		default:
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	job, err := provider.CancelBatch(context.Background(), "batches/abc")
+	if err != nil {
+		t.Fatalf("CancelBatch() error = %v", err)
+	}
+	if !cancelled || job.Status != core.BatchStatusCancelled {
+		t.Errorf("cancelled = %v, Status = %s", cancelled, job.Status)
+	}
+}
+
+func TestMapBatchStatus(t *testing.T) {
+	tests := map[string]core.BatchStatus{
+		"BATCH_STATE_PENDING":   core.BatchStatusPending,
+		"BATCH_STATE_RUNNING":   core.BatchStatusInProgress,
+		"JOB_STATE_RUNNING":     core.BatchStatusInProgress,
+		"BATCH_STATE_SUCCEEDED": core.BatchStatusCompleted,
+		"BATCH_STATE_FAILED":    core.BatchStatusFailed,
+		"BATCH_STATE_CANCELLED": core.BatchStatusCancelled,
+		"BATCH_STATE_EXPIRED":   core.BatchStatusExpired,
+		"":                      core.BatchStatusPending,
+	}
+	for state, want := range tests {
+		if got := mapBatchStatus(state); got != want {
+			t.Errorf("mapBatchStatus(%q) = %s, want %s", state, got, want)
+		}
+	}
+}
+
+func TestMapBatchJobOperationError(t *testing.T) {
+	job := mapBatchJob(&geminiBatchOperation{
+		Name:  "batches/abc",
+		Done:  true,
+		Error: &geminiError{Code: 3, Message: "input file is invalid", Status: "INVALID_ARGUMENT"},
+	})
+	if job.Status != core.BatchStatusFailed {
+		t.Errorf("Status = %s, want failed", job.Status)
+	}
+	if !strings.Contains(strings.Join(job.Errors, ""), "input file is invalid") {
+		t.Errorf("Errors = %v", job.Errors)
+	}
+}
@@ -0,0 +1,174 @@
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// maxEmbedBatchSize is the maximum number of requests accepted by
+// batchEmbedContents. Larger inputs are split across several calls.
+const maxEmbedBatchSize = 100
+
+// Gemini-specific task types. core.InputTypeQuery and core.InputTypeDocument
+// map to RETRIEVAL_QUERY and RETRIEVAL_DOCUMENT.
+const (
+	// TaskTypeSemanticSimilarity optimizes embeddings for text similarity.
+	TaskTypeSemanticSimilarity core.InputType = "SEMANTIC_SIMILARITY"
+	// TaskTypeClassification optimizes embeddings for text classifiers.
+	TaskTypeClassification core.InputType = "CLASSIFICATION"
+	// TaskTypeClustering optimizes embeddings for clustering.
+	TaskTypeClustering core.InputType = "CLUSTERING"
+	// TaskTypeCodeRetrievalQuery optimizes query embeddings for code search.
+	TaskTypeCodeRetrievalQuery core.InputType = "CODE_RETRIEVAL_QUERY"
+	// TaskTypeQuestionAnswering optimizes embeddings for question answering.
+	TaskTypeQuestionAnswering core.InputType = "QUESTION_ANSWERING"
+	// TaskTypeFactVerification optimizes embeddings for fact verification.
+	TaskTypeFactVerification core.InputType = "FACT_VERIFICATION"
+)
+
+// CreateEmbeddings generates embeddings for the given input texts.
+// A single input uses embedContent; more inputs use batchEmbedContents,
+// split into batches of at most 100. The API does not report token usage.
+func (p *Gemini) CreateEmbeddings(ctx context.Context, req *core.EmbeddingRequest) (*core.EmbeddingResponse, error) {
+	model := strings.TrimPrefix(string(req.Model), "models/")
+
+	var values [][]float32
+	if len(req.Input) == 1 {
+		vec, err := p.embedContent(ctx, model, buildEmbedRequest(req, model, req.Input[0]))
+		if err != nil {
+			return nil, err
+		}
+		values = [][]float32{vec}
+	} else {
+		values = make([][]float32, 0, len(req.Input))
+		for start := 0; start < len(req.Input); start += maxEmbedBatchSize {
+			end := min(start+maxEmbedBatchSize, len(req.Input))
+
+			batch := &geminiBatchEmbedRequest{Requests: make([]geminiEmbedRequest, 0, end-start)}
+			for _, input := range req.Input[start:end] {
+				batch.Requests = append(batch.Requests, buildEmbedRequest(req, model, input))
+			}
+
+			vecs, err := p.batchEmbedContents(ctx, model, batch)
+			if err != nil {
+				return nil, err
+			}
+			if len(vecs) != end-start {
+				return nil, newDecodeError(fmt.Errorf("expected %d embeddings, got %d", end-start, len(vecs)))
+			}
+			values = append(values, vecs...)
+		}
+	}
+
+	vectors := make([]core.EmbeddingVector, len(values))
+	for i, v := range values {
+		vectors[i] = core.EmbeddingVector{
+			Index:    i,
+			ID:       req.Input[i].ID,
+			Vector:   v,
+			Metadata: req.Input[i].Metadata,
+		}
+	}
+
+	return &core.EmbeddingResponse{
+		Vectors: vectors,
+		Model:   req.Model,
+	}, nil
+}
+
+// embedContent embeds a single input.
+func (p *Gemini) embedContent(ctx context.Context, model string, embedReq geminiEmbedRequest) ([]float32, error) {
+	var resp geminiEmbedResponse
+	if err := p.doEmbed(ctx, model, "embedContent", embedReq, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Embedding.Values, nil
+}
+
+// batchEmbedContents embeds up to maxEmbedBatchSize inputs in one call.
+func (p *Gemini) batchEmbedContents(ctx context.Context, model string, batch *geminiBatchEmbedRequest) ([][]float32, error) {
+	var resp geminiBatchEmbedResponse
+	if err := p.doEmbed(ctx, model, "batchEmbedContents", batch, &resp); err != nil {
+		return nil, err
+	}
+
+	vecs := make([][]float32, len(resp.Embeddings))
+	for i, e := range resp.Embeddings {
+		vecs[i] = e.Values
+	}
+	return vecs, nil
+}
+
+// doEmbed posts an embedding request to models/{model}:{method} and decodes the response into out.
+func (p *Gemini) doEmbed(ctx context.Context, model, method string, payload, out any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return newDecodeError(err)
+	}
+
+	url := fmt.Sprintf("%s/v1beta/models/%s:%s", p.config.BaseURL, model, method)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return newNetworkError(err)
+	}
+
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return normalizeError(resp.StatusCode, respBody)
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return newDecodeError(err)
+	}
+
+	return nil
+}
+
+// buildEmbedRequest converts a single core input to Gemini format.
+func buildEmbedRequest(req *core.EmbeddingRequest, model string, input core.EmbeddingInput) geminiEmbedRequest {
+	return geminiEmbedRequest{
+		Model:                "models/" + model,
+		Content:              geminiContent{Parts: []geminiPart{{Text: input.Text}}},
+		TaskType:             mapTaskType(req.InputType),
+		OutputDimensionality: req.Dimensions,
+	}
+}
+
+// mapTaskType converts a core input type to a Gemini task type.
+// Values other than query and document are passed through unchanged.
+func mapTaskType(t core.InputType) string {
+	switch t {
+	case core.InputTypeQuery:
+		return "RETRIEVAL_QUERY"
+	case core.InputTypeDocument:
+		return "RETRIEVAL_DOCUMENT"
+	default:
+		return string(t)
+	}
+}
+
+// Compile-time check that Gemini implements EmbeddingProvider.
+var _ core.EmbeddingProvider = (*Gemini)(nil)
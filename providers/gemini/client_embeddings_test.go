@@ -0,0 +1,182 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestCreateEmbeddingsSingle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1beta/models/gemini-embedding-001:embedContent" {
+			t.Errorf("Path = %s, want embedContent", r.URL.Path)
+		}
+		if got := r.Header.Get("x-goog-api-key"); got != "test-key" {
+			t.Errorf("x-goog-api-key = %q, want test-key", got)
+		}
+
+		var req geminiEmbedRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if req.Model != "models/gemini-embedding-001" {
+			t.Errorf("model = %q", req.Model)
+		}
+		if req.TaskType != "RETRIEVAL_QUERY" {
+			t.Errorf("taskType = %q, want RETRIEVAL_QUERY", req.TaskType)
+		}
+		if req.OutputDimensionality == nil || *req.OutputDimensionality != 768 {
+			t.Errorf("outputDimensionality = %v, want 768", req.OutputDimensionality)
+		}
+		if len(req.Content.Parts) != 1 || req.Content.Parts[0].Text != "hello" {
+			t.Errorf("content = %+v", req.Content)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"embedding":{"values":[0.1,0.2,0.3]}}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	dims := 768
+	resp, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+		Model:      ModelGeminiEmbedding001,
+		Input:      []core.EmbeddingInput{{Text: "hello", ID: "doc-1"}},
+		InputType:  core.InputTypeQuery,
+		Dimensions: &dims,
+	})
+	if err != nil {
+		t.Fatalf("CreateEmbeddings() error = %v", err)
+	}
+
+	if len(resp.Vectors) != 1 {
+		t.Fatalf("len(Vectors) = %d, want 1", len(resp.Vectors))
+	}
+	if resp.Vectors[0].ID != "doc-1" {
+		t.Errorf("ID = %q, want doc-1", resp.Vectors[0].ID)
+	}
+	if len(resp.Vectors[0].Vector) != 3 {
+		t.Errorf("len(Vector) = %d, want 3", len(resp.Vectors[0].Vector))
+	}
+	if resp.Model != ModelGeminiEmbedding001 {
+		t.Errorf("Model = %q", resp.Model)
+	}
+}
+
+func TestCreateEmbeddingsBatching(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, ":batchEmbedContents") {
+			t.Errorf("Path = %s, want batchEmbedContents", r.URL.Path)
+		}
+		calls.Add(1)
+
+		var req geminiBatchEmbedRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if len(req.Requests) > maxEmbedBatchSize {
+			t.Errorf("batch size = %d, want <= %d", len(req.Requests), maxEmbedBatchSize)
+		}
+
+		// Echo the input number back as the vector so ordering can be checked.
+		var resp geminiBatchEmbedResponse
+		for _, r := range req.Requests {
+			if r.TaskType != "RETRIEVAL_DOCUMENT" {
+				t.Errorf("taskType = %q, want RETRIEVAL_DOCUMENT", r.TaskType)
+			}
+			var n int
+			fmt.Sscanf(r.Content.Parts[0].Text, "text %d", &n)
+			resp.Embeddings = append(resp.Embeddings, geminiEmbedding{Values: []float32{float32(n)}})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	const n = 250
+	input := make([]core.EmbeddingInput, n)
+	for i := range input {
+		input[i] = core.EmbeddingInput{Text: fmt.Sprintf("text %d", i), ID: fmt.Sprintf("id-%d", i)}
+	}
+
+	p := New("test-key", WithBaseURL(server.URL))
+	resp, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+		Model:     "models/" + ModelGeminiEmbedding001,
+		Input:     input,
+		InputType: core.InputTypeDocument,
+	})
+	if err != nil {
+		t.Fatalf("CreateEmbeddings() error = %v", err)
+	}
+
+	if got := calls.Load(); got != 3 {
+		t.Errorf("calls = %d, want 3", got)
+	}
+	if len(resp.Vectors) != n {
+		t.Fatalf("len(Vectors) = %d, want %d", len(resp.Vectors), n)
+	}
+	for i, v := range resp.Vectors {
+		if v.Index != i || v.ID != fmt.Sprintf("id-%d", i) || v.Vector[0] != float32(i) {
+			t.Fatalf("Vectors[%d] = {Index:%d ID:%s Vector:%v}", i, v.Index, v.ID, v.Vector)
+		}
+	}
+}
+
+func TestCreateEmbeddingsCountMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"embeddings":[{"values":[1]}]}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	_, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+		Model: ModelGeminiEmbedding001,
+		Input: []core.EmbeddingInput{{Text: "a"}, {Text: "b"}},
+	})
+	if !errors.Is(err, core.ErrDecode) {
+		t.Errorf("error = %v, want ErrDecode", err)
+	}
+}
+
+func TestCreateEmbeddingsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":400,"message":"bad task type","status":"INVALID_ARGUMENT"}}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	_, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+		Model:     ModelGeminiEmbedding001,
+		Input:     []core.EmbeddingInput{{Text: "a"}},
+		InputType: "BOGUS",
+	})
+	if !errors.Is(err, core.ErrBadRequest) {
+		t.Errorf("error = %v, want ErrBadRequest", err)
+	}
+}
+
+func TestMapTaskType(t *testing.T) {
+	tests := []struct {
+		in   core.InputType
+		want string
+	}{
+		{core.InputTypeNone, ""},
+		{core.InputTypeQuery, "RETRIEVAL_QUERY"},
+		{core.InputTypeDocument, "RETRIEVAL_DOCUMENT"},
+		{TaskTypeSemanticSimilarity, "SEMANTIC_SIMILARITY"},
+	}
+	for _, tt := range tests {
+		if got := mapTaskType(tt.in); got != tt.want {
+			t.Errorf("mapTaskType(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -19,6 +19,10 @@ const (
 	filesUploadPath = "/upload/v1beta/files"
 )
 
+// uploadChunkSize is the size of each resumable upload request. It is a
+// multiple of the 256 KiB granularity required by the upload protocol.
+const uploadChunkSize = 8 << 20
+
 // UploadFile uploads a file to Gemini using resumable upload protocol.
 // Files are stored for 48 hours before automatic deletion.
 func (p *Gemini) UploadFile(ctx context.Context, req *FileUploadRequest) (*File, error) {
@@ -77,21 +81,50 @@ func (p *Gemini) initiateResumableUpload(ctx context.Context, req *FileUploadReq
 	return uploadURL, nil
 }
 
+// uploadFileContent streams the file to the resumable upload URL in chunks
+// of uploadChunkSize, finalizing the upload with the last chunk.
 func (p *Gemini) uploadFileContent(ctx context.Context, uploadURL string, req *FileUploadRequest) (*File, error) {
-	// Read all content to determine size
-	content, err := io.ReadAll(req.File)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read file content: %w", err)
+	buf := make([]byte, uploadChunkSize)
+	var offset int64
+
+	for {
+		n, err := io.ReadFull(req.File, buf)
+		last := err == io.EOF || err == io.ErrUnexpectedEOF
+		if err != nil && !last {
+			return nil, fmt.Errorf("failed to read file content: %w", err)
+		}
+
+		command := "upload"
+		if last {
+			command = "upload, finalize"
+		}
+
+		body, err := p.uploadChunk(ctx, uploadURL, buf[:n], offset, command)
+		if err != nil {
+			return nil, err
+		}
+		offset += int64(n)
+
+		if last {
+			var result fileUploadResponse
+			if err := json.Unmarshal(body, &result); err != nil {
+				return nil, newDecodeError(err)
+			}
+			return &result.File, nil
+		}
 	}
+}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, bytes.NewReader(content))
+// uploadChunk sends one chunk of a resumable upload and returns the response body.
+func (p *Gemini) uploadChunk(ctx context.Context, uploadURL string, chunk []byte, offset int64, command string) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, bytes.NewReader(chunk))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create upload request: %w", err)
 	}
 
-	httpReq.Header.Set("Content-Length", strconv.Itoa(len(content)))
-	httpReq.Header.Set("X-Goog-Upload-Offset", "0")
-	httpReq.Header.Set("X-Goog-Upload-Command", "upload, finalize")
+	httpReq.Header.Set("Content-Length", strconv.Itoa(len(chunk)))
+	httpReq.Header.Set("X-Goog-Upload-Offset", strconv.FormatInt(offset, 10))
+	httpReq.Header.Set("X-Goog-Upload-Command", command)
 
 	resp, err := p.config.HTTPClient.Do(httpReq)
 	if err != nil {
@@ -108,12 +141,7 @@ func (p *Gemini) uploadFileContent(ctx context.Context, uploadURL string, req *F
 		return nil, normalizeError(resp.StatusCode, body)
 	}
 
-	var result fileUploadResponse
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, newDecodeError(err)
-	}
-
-	return &result.File, nil
+	return body, nil
 }
 
 // GetFile retrieves metadata for a specific file.
@@ -0,0 +1,151 @@
+package gemini
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// CreateFile uploads a file through the core.FileProvider interface.
+// The returned ID is the file URI, which can be passed to
+// MessageBuilder.FileID. Files may need processing before use;
+// see WaitForFile.
+func (p *Gemini) CreateFile(ctx context.Context, req *core.FileUploadRequest) (*core.File, error) {
+	mimeType := req.MimeType
+	if mimeType == "" {
+		mimeType = guessMimeType(req.Filename)
+	}
+
+	file, err := p.UploadFile(ctx, &FileUploadRequest{
+		File:        req.File,
+		DisplayName: req.Filename,
+		MimeType:    mimeType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapCoreFile(file), nil
+}
+
+// RetrieveFile returns metadata for a file. id may be a file URI or a
+// resource name such as "files/abc123".
+func (p *Gemini) RetrieveFile(ctx context.Context, id string) (*core.File, error) {
+	file, err := p.GetFile(ctx, fileName(id))
+	if err != nil {
+		return nil, err
+	}
+	return mapCoreFile(file), nil
+}
+
+// RetrieveFileContent is not supported: the Gemini API does not serve the
+// content of uploaded files.
+func (p *Gemini) RetrieveFileContent(ctx context.Context, id string) (io.ReadCloser, error) {
+	return nil, &core.ProviderError{
+		Provider: "gemini",
+		Code:     "not_supported",
+		Message:  "file content download is not supported by the Gemini API",
+		Err:      core.ErrNotSupported,
+	}
+}
+
+// EnumerateFiles lists files, following pagination.
+// Gemini files have no purpose, so req.Purpose is ignored.
+func (p *Gemini) EnumerateFiles(ctx context.Context, req *core.FileListRequest) ([]core.File, error) {
+	if req == nil {
+		req = &core.FileListRequest{}
+	}
+
+	listReq := &FileListRequest{}
+	var files []core.File
+	for {
+		listReq.PageSize = 100
+		if req.Limit > 0 {
+			listReq.PageSize = min(listReq.PageSize, req.Limit-len(files))
+		}
+
+		resp, err := p.ListFiles(ctx, listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range resp.Files {
+			files = append(files, *mapCoreFile(&resp.Files[i]))
+		}
+
+		if resp.NextPageToken == "" || (req.Limit > 0 && len(files) >= req.Limit) {
+			break
+		}
+		listReq.PageToken = resp.NextPageToken
+	}
+
+	return files, nil
+}
+
+// RemoveFile deletes a file. id may be a file URI or a resource name.
+func (p *Gemini) RemoveFile(ctx context.Context, id string) error {
+	return p.DeleteFile(ctx, fileName(id))
+}
+
+// WaitForFile polls until the file is ACTIVE. It returns ErrFileFailed if
+// processing fails.
+func (p *Gemini) WaitForFile(ctx context.Context, id string) (*core.File, error) {
+	file, err := p.WaitForFileActive(ctx, fileName(id))
+	if err != nil {
+		return nil, err
+	}
+	return mapCoreFile(file), nil
+}
+
+// fileName converts a file URI such as
+// https://generativelanguage.googleapis.com/v1beta/files/abc123 to its
+// resource name, files/abc123. Bare IDs are prefixed with "files/".
+func fileName(id string) string {
+	if i := strings.LastIndex(id, "files/"); i >= 0 {
+		return id[i:]
+	}
+	return "files/" + id
+}
+
+// mapCoreFile converts a Gemini file to core format.
+func mapCoreFile(f *File) *core.File {
+	id := f.URI
+	if id == "" {
+		id = f.Name
+	}
+
+	// Sizes are int64 strings and times RFC 3339; unparseable values are left zero.
+	size, _ := strconv.ParseInt(f.SizeBytes, 10, 64)
+	createdAt, _ := time.Parse(time.RFC3339Nano, f.CreateTime)
+
+	file := &core.File{
+		ID:        id,
+		Filename:  f.DisplayName,
+		MimeType:  f.MimeType,
+		Bytes:     size,
+		State:     mapFileState(f.State),
+		CreatedAt: createdAt,
+	}
+	if expires, err := time.Parse(time.RFC3339Nano, f.ExpirationTime); err == nil {
+		file.ExpiresAt = &expires
+	}
+	return file
+}
+
+// mapFileState converts a Gemini file state to core format.
+func mapFileState(s FileState) core.FileState {
+	switch s {
+	case FileStateActive:
+		return core.FileStateReady
+	case FileStateFailed:
+		return core.FileStateFailed
+	default:
+		return core.FileStateProcessing
+	}
+}
+
+// Compile-time check that Gemini implements FileProvider.
+var _ core.FileProvider = (*Gemini)(nil)
@@ -0,0 +1,157 @@
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestCreateFileChunkedUpload(t *testing.T) {
+	content := bytes.Repeat([]byte("x"), uploadChunkSize+10)
+	var commands, offsets []string
+	var received int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/upload/v1beta/files":
+			if got := r.Header.Get("X-Goog-Upload-Header-Content-Type"); got != "application/pdf" {
+				t.Errorf("upload content type = %q, want application/pdf", got)
+			}
+			w.Header().Set("X-Goog-Upload-URL", "http://"+r.Host+"/upload-target")
+		case "/upload-target":
+			commands = append(commands, r.Header.Get("X-Goog-Upload-Command"))
+			offsets = append(offsets, r.Header.Get("X-Goog-Upload-Offset"))
+			n, _ := io.Copy(io.Discard, r.Body)
+			received += int(n)
+			if r.Header.Get("X-Goog-Upload-Command") == "upload, finalize" {
+				json.NewEncoder(w).Encode(fileUploadResponse{File: File{
+					Name:           "files/abc",
+					URI:            "https://generativelanguage.googleapis.com/v1beta/files/abc",
+					DisplayName:    "big.pdf",
+					MimeType:       "application/pdf",
+					SizeBytes:      "8388618",
+					CreateTime:     "2025-01-01T00:00:00.123456Z",
+					ExpirationTime: "2025-01-03T00:00:00.123456Z",
+					State:          FileStateProcessing,
+				}})
+			}
+		}
+	}))
+	defer server.Close()
+
+	var provider core.FileProvider = New("test-key", WithBaseURL(server.URL))
+	file, err := provider.CreateFile(context.Background(), &core.FileUploadRequest{
+		File:     bytes.NewReader(content),
+		Filename: "big.pdf",
+	})
+	if err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+
+	if got := strings.Join(commands, "|"); got != "upload|upload, finalize" {
+		t.Errorf("commands = %q", got)
+	}
+	if got := strings.Join(offsets, "|"); got != "0|8388608" {
+		t.Errorf("offsets = %q", got)
+	}
+	if received != len(content) {
+		t.Errorf("received %d bytes, want %d", received, len(content))
+	}
+
+	if file.ID != "https://generativelanguage.googleapis.com/v1beta/files/abc" {
+		t.Errorf("ID = %q, want file URI", file.ID)
+	}
+	if file.Bytes != 8388618 || file.State != core.FileStateProcessing {
+		t.Errorf("CreateFile() = %+v", file)
+	}
+	if file.CreatedAt.IsZero() || file.ExpiresAt == nil {
+		t.Errorf("CreatedAt = %v, ExpiresAt = %v", file.CreatedAt, file.ExpiresAt)
+	}
+}
+
+func TestWaitForFileAcceptsURI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1beta/files/abc" {
+			t.Errorf("Path = %s, want /v1beta/files/abc", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(File{Name: "files/abc", URI: "https://example.com/v1beta/files/abc", State: FileStateActive})
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	file, err := provider.WaitForFile(context.Background(), "https://example.com/v1beta/files/abc")
+	if err != nil {
+		t.Fatalf("WaitForFile() error = %v", err)
+	}
+	if file.State != core.FileStateReady {
+		t.Errorf("State = %q, want ready", file.State)
+	}
+}
+
+func TestEnumerateFilesPaginates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := FileListResponse{}
+		if r.URL.Query().Get("pageToken") == "" {
+			resp.Files = []File{{Name: "files/1"}, {Name: "files/2"}}
+			resp.NextPageToken = "next"
+		} else {
+			resp.Files = []File{{Name: "files/3"}}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	files, err := provider.EnumerateFiles(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("EnumerateFiles() error = %v", err)
+	}
+	if len(files) != 3 || files[2].ID != "files/3" {
+		t.Errorf("files = %+v", files)
+	}
+}
+
+func TestRetrieveFileContentNotSupported(t *testing.T) {
+	provider := New("test-key")
+	_, err := provider.RetrieveFileContent(context.Background(), "files/abc")
+	if !errors.Is(err, core.ErrNotSupported) {
+		t.Errorf("error = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tests := map[string]string{
+		"https://generativelanguage.googleapis.com/v1beta/files/abc": "files/abc",
+		"files/abc": "files/abc",
+		"abc":       "files/abc",
+	}
+	for in, want := range tests {
+		if got := fileName(in); got != want {
+			t.Errorf("fileName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestMapMessagePartsPointers(t *testing.T) {
+	parts := mapMessageParts(core.Message{
+		Role: core.RoleUser,
+		Parts: []core.ContentPart{
+			&core.InputText{Text: "Summarize"},
+			&core.InputFile{FileID: "https://generativelanguage.googleapis.com/v1beta/files/abc"},
+		},
+	})
+	if len(parts) != 2 {
+		t.Fatalf("len(parts) = %d, want 2", len(parts))
+	}
+	if parts[1].FileData == nil || parts[1].FileData.FileURI != "https://generativelanguage.googleapis.com/v1beta/files/abc" {
+		t.Errorf("parts[1] = %+v", parts[1])
+	}
+}
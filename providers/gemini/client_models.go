@@ -0,0 +1,84 @@
+package gemini
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+)
+
+const modelsPath = "/v1beta/models"
+
+// modelsPageSize is the largest page size accepted by GET /v1beta/models.
+const modelsPageSize = "1000"
+
+// ListModels returns the models available to the API key using
+// GET /v1beta/models, following pagination. Models in the static list keep
+// its capabilities; others have capabilities derived from their supported
+// generation methods. Results are cached for the configured ModelCacheTTL.
+func (p *Gemini) ListModels(ctx context.Context) ([]core.ModelInfo, error) {
+	return p.modelCache.Get(ctx)
+}
+
+// fetchModels queries every page of GET /v1beta/models and merges the
+// result with the static list.
+func (p *Gemini) fetchModels(ctx context.Context) ([]core.ModelInfo, error) {
+	var live []core.ModelInfo
+	pageToken := ""
+	for {
+		params := url.Values{"pageSize": {modelsPageSize}}
+		if pageToken != "" {
+			params.Set("pageToken", pageToken)
+		}
+
+		var page geminiModelList
+		u := p.config.BaseURL + modelsPath + "?" + params.Encode()
+		if err := p.doBatchRequest(ctx, http.MethodGet, u, nil, &page); err != nil {
+			return nil, err
+		}
+
+		for _, m := range page.Models {
+			live = append(live, core.ModelInfo{
+				ID:           core.ModelID(strings.TrimPrefix(m.Name, "models/")),
+				DisplayName:  m.DisplayName,
+				Capabilities: modelCapabilities(&m),
+			})
+		}
+
+		if page.NextPageToken == "" {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+	return core.MergeModels(live, models), nil
+}
+
+// modelCapabilities derives Iris features from a model's supported
+// generation methods.
+func modelCapabilities(m *geminiModel) []core.Feature {
+	var features []core.Feature
+	for _, method := range m.SupportedGenerationMethods {
+		switch method {
+		case "generateContent":
+			switch {
+			case strings.HasSuffix(m.Name, "-tts"):
+				features = append(features, core.FeatureSpeechSynthesis)
+			case strings.Contains(m.Name, "-image"):
+				features = append(features, core.FeatureImageGeneration)
+			default:
+				features = append(features, core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling)
+				if m.Thinking {
+					features = append(features, core.FeatureReasoning)
+				}
+			}
+		case "embedContent":
+			features = append(features, core.FeatureEmbeddings)
+		}
+	}
+	return features
+}
+
+// Compile-time check that Gemini implements ModelLister.
+var _ core.ModelLister = (*Gemini)(nil)
@@ -0,0 +1,82 @@
+package gemini
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestListModels(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodGet || r.URL.Path != "/v1beta/models" {
+			t.Errorf("request = %s %s, want GET /v1beta/models", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("x-goog-api-key"); got != "test-key" {
+			t.Errorf("x-goog-api-key = %q", got)
+		}
+
+		switch r.URL.Query().Get("pageToken") {
+		case "":
+			io.WriteString(w, `{"models":[
+				{"name":"models/gemini-2.5-flash","displayName":"Gemini 2.5 Flash (live)","supportedGenerationMethods":["generateContent","countTokens"],"thinking":true},
+				{"name":"models/gemini-4-flash","displayName":"Gemini 4 Flash","supportedGenerationMethods":["generateContent","countTokens"],"thinking":true}],
+				"nextPageToken":"page-2"}`)
+		case "page-2":
+			io.WriteString(w, `{"models":[
+				{"name":"models/gemini-embedding-002","displayName":"Gemini Embedding 002","supportedGenerationMethods":["embedContent"]},
+				{"name":"models/gemini-4-flash-preview-tts","displayName":"Gemini 4 Flash TTS","supportedGenerationMethods":["generateContent"]}]}`)
+		default:
+			t.Errorf("unexpected pageToken %q", r.URL.Query().Get("pageToken"))
+		}
+	}))
+	defer server.Close()
+
+	var provider core.ModelLister = New("test-key", WithBaseURL(server.URL))
+	models, err := provider.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if len(models) != 4 {
+		t.Fatalf("ListModels() returned %d models, want 4", len(models))
+	}
+
+	if models[0].ID != ModelGemini25Flash || models[0].DisplayName != "Gemini 2.5 Flash" {
+		t.Errorf("models[0] = %+v, want static metadata", models[0])
+	}
+	if models[1].ID != "gemini-4-flash" || !models[1].HasCapability(core.FeatureChat) || !models[1].HasCapability(core.FeatureReasoning) {
+		t.Errorf("models[1] = %+v", models[1])
+	}
+	if !models[2].HasCapability(core.FeatureEmbeddings) || models[2].HasCapability(core.FeatureChat) {
+		t.Errorf("models[2] = %+v, want embeddings only", models[2])
+	}
+	if !models[3].HasCapability(core.FeatureSpeechSynthesis) || models[3].HasCapability(core.FeatureChat) {
+		t.Errorf("models[3] = %+v, want speech synthesis only", models[3])
+	}
+
+	if _, err := provider.ListModels(context.Background()); err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("API calls = %d, want 2 (one per page, then cached)", calls)
+	}
+}
+
+func TestListModelsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `{"error":{"code":403,"message":"API key not valid","status":"PERMISSION_DENIED"}}`)
+	}))
+	defer server.Close()
+
+	_, err := New("test-key", WithBaseURL(server.URL)).ListModels(context.Background())
+	if !errors.Is(err, core.ErrUnauthorized) {
+		t.Errorf("ListModels() error = %v, want ErrUnauthorized", err)
+	}
+}
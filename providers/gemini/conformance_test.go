@@ -0,0 +1,69 @@
+package gemini
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers/providertest"
+)
+
+// sseData renders each payload as an SSE data event.
+func sseData(payloads ...string) string {
+	var sb strings.Builder
+	for _, p := range payloads {
+		sb.WriteString("data: " + p + "\n\n")
+	}
+	return sb.String()
+}
+
+func TestConformance(t *testing.T) {
+	sse := http.Header{"Content-Type": {"text/event-stream"}}
+
+	suite := providertest.Suite{
+		NewProvider: func(baseURL string) core.Provider {
+			return New("test-key", WithBaseURL(baseURL))
+		},
+		Model: "gemini-2.5-flash",
+		Chat: &providertest.Fixture{
+			Body:   `{"candidates":[{"content":{"role":"model","parts":[{"text":"Hello there"}]},"finishReason":"STOP"}],"usageMetadata":{"promptTokenCount":5,"candidatesTokenCount":2}}`,
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		Stream: &providertest.Fixture{
+			Header: sse,
+			Body: sseData(
+				`{"candidates":[{"content":{"parts":[{"text":"Hello"}]}}]}`,
+				`{"candidates":[{"content":{"parts":[{"text":" there"}]},"finishReason":"STOP"}],"usageMetadata":{"promptTokenCount":5,"candidatesTokenCount":2}}`,
+			),
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		ToolCall: &providertest.Fixture{
+			Body: `{"candidates":[{"content":{"role":"model","parts":[{"functionCall":{"name":"get_weather","args":{"location": "NYC"}}}]},"finishReason":"STOP"}]}`,
+			ToolCalls: []core.ToolCall{
+				{Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		StreamToolCall: &providertest.Fixture{
+			Header: sse,
+			Body: sseData(
+				`{"candidates":[{"content":{"parts":[{"functionCall":{"name":"get_weather","args":{"location": "NYC"}}}]},"finishReason":"STOP"}]}`,
+			),
+			ToolCalls: []core.ToolCall{
+				{Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		Cancel: &providertest.Fixture{
+			Header: sse,
+			Body:   sseData(`{"candidates":[{"content":{"parts":[{"text":"Hello"}]}}]}`),
+		},
+		ErrorBody: func(status int) string {
+			return `{"error":{"code":400,"message":"request failed","status":"TEST_ERROR"}}`
+		},
+	}
+
+	suite.Run(t)
+}
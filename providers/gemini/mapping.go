@@ -107,10 +107,16 @@ func mapMessageParts(msg core.Message) []geminiPart {
 		switch p := part.(type) {
 		case core.InputText:
 			parts = append(parts, geminiPart{Text: p.Text})
+		case *core.InputText:
+			parts = append(parts, geminiPart{Text: p.Text})
 		case core.InputImage:
 			parts = append(parts, mapInputImage(p))
+		case *core.InputImage:
+			parts = append(parts, mapInputImage(*p))
 		case core.InputFile:
 			parts = append(parts, mapInputFile(p))
+		case *core.InputFile:
+			parts = append(parts, mapInputFile(*p))
 		}
 	}
 	return parts
@@ -219,6 +225,18 @@ func guessMimeType(filename string) string {
 		return "text/plain"
 	case strings.HasSuffix(lower, ".json"):
 		return "application/json"
+	case strings.HasSuffix(lower, ".mp3"):
+		return "audio/mp3"
+	case strings.HasSuffix(lower, ".wav"):
+		return "audio/wav"
+	case strings.HasSuffix(lower, ".flac"):
+		return "audio/flac"
+	case strings.HasSuffix(lower, ".ogg"), strings.HasSuffix(lower, ".opus"):
+		return "audio/ogg"
+	case strings.HasSuffix(lower, ".aac"):
+		return "audio/aac"
+	case strings.HasSuffix(lower, ".aiff"):
+		return "audio/aiff"
 	default:
 		return "application/octet-stream"
 	}
@@ -340,6 +340,8 @@ func TestGuessMimeType(t *testing.T) {
 		{"document.pdf", "application/pdf"},
 		{"notes.txt", "text/plain"},
 		{"data.json", "application/json"},
+		{"memo.mp3", "audio/mp3"},
+		{"call.WAV", "audio/wav"},
 		{"unknown.xyz", "application/octet-stream"},
 		{"", "application/octet-stream"},
 	}
@@ -17,6 +17,14 @@ const (
 	// Image generation models (Nano Banana)
 	ModelGemini25FlashImage core.ModelID = "gemini-2.5-flash-image"     // Nano Banana - fast/efficient
 	ModelGemini3ProImage    core.ModelID = "gemini-3-pro-image-preview" // Nano Banana Pro - professional with reasoning
+
+	// Text-to-speech models
+	ModelGemini25FlashTTS core.ModelID = "gemini-2.5-flash-preview-tts"
+	ModelGemini25ProTTS   core.ModelID = "gemini-2.5-pro-preview-tts"
+
+	// Embedding models
+	ModelGeminiEmbedding001 core.ModelID = "gemini-embedding-001"
+	ModelTextEmbedding004   core.ModelID = "text-embedding-004"
 )
 
 // models is the static list of supported models.
@@ -86,6 +94,28 @@ var models = []core.ModelInfo{
 			core.FeatureImageGeneration,
 		},
 	},
+	// Text-to-speech models
+	{
+		ID:           ModelGemini25FlashTTS,
+		DisplayName:  "Gemini 2.5 Flash Preview TTS",
+		Capabilities: []core.Feature{core.FeatureSpeechSynthesis},
+	},
+	{
+		ID:           ModelGemini25ProTTS,
+		DisplayName:  "Gemini 2.5 Pro Preview TTS",
+		Capabilities: []core.Feature{core.FeatureSpeechSynthesis},
+	},
+	// Embedding models
+	{
+		ID:           ModelGeminiEmbedding001,
+		DisplayName:  "Gemini Embedding 001",
+		Capabilities: []core.Feature{core.FeatureEmbeddings},
+	},
+	{
+		ID:           ModelTextEmbedding004,
+		DisplayName:  "Text Embedding 004",
+		Capabilities: []core.Feature{core.FeatureEmbeddings},
+	},
 }
 
 // modelRegistry is a map for quick model lookup by ID.
@@ -11,21 +11,26 @@ import (
 // Gemini is safe for concurrent use.
 type Gemini struct {
 	config Config
+
+	modelCache *core.ModelCache // caches ListModels results
 }
 
 // New creates a new Gemini provider with the given API key and options.
 func New(apiKey string, opts ...Option) *Gemini {
 	cfg := Config{
-		APIKey:     apiKey,
-		BaseURL:    DefaultBaseURL,
-		HTTPClient: http.DefaultClient,
+		APIKey:        apiKey,
+		BaseURL:       DefaultBaseURL,
+		HTTPClient:    http.DefaultClient,
+		ModelCacheTTL: core.DefaultModelCacheTTL,
 	}
 
 	for _, opt := range opts {
 		opt(&cfg)
 	}
 
-	return &Gemini{config: cfg}
+	p := &Gemini{config: cfg}
+	p.modelCache = core.NewModelCache(cfg.ModelCacheTTL, p.fetchModels)
+	return p
 }
 
 // ID returns the provider identifier.
@@ -44,7 +49,8 @@ func (p *Gemini) Models() []core.ModelInfo {
 // Supports reports whether the provider supports the given feature.
 func (p *Gemini) Supports(feature core.Feature) bool {
 	switch feature {
-	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureReasoning, core.FeatureImageGeneration:
+	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureReasoning, core.FeatureImageGeneration,
+		core.FeatureEmbeddings, core.FeatureFiles, core.FeatureBatch, core.FeatureTranscription, core.FeatureSpeechSynthesis, core.FeatureModelListing:
 		return true
 	default:
 		return false
@@ -63,8 +63,8 @@ func TestModels(t *testing.T) {
 	p := New("test-key")
 	models := p.Models()
 
-	if len(models) != 7 {
-		t.Errorf("Models() count = %d, want 7", len(models))
+	if len(models) != 11 {
+		t.Errorf("Models() count = %d, want 11", len(models))
 	}
 
 	// Verify model IDs
@@ -81,6 +81,8 @@ func TestModels(t *testing.T) {
 		ModelGemini25Pro,
 		ModelGemini25FlashImage,
 		ModelGemini3ProImage,
+		ModelGemini25FlashTTS,
+		ModelGemini25ProTTS,
 	}
 
 	for _, id := range expected {
@@ -117,6 +119,8 @@ func TestSupports(t *testing.T) {
 		{core.FeatureToolCalling, true},
 		{core.FeatureReasoning, true},
 		{core.FeatureImageGeneration, true},
+		{core.FeatureEmbeddings, true},
+		{core.FeatureFiles, true},
 		{core.FeatureBuiltInTools, false},
 		{core.FeatureResponseChain, false},
 		{core.Feature("unknown"), false},
@@ -0,0 +1,28 @@
+package gemini
+
+// geminiSpeechRequest is a generateContent request for audio output.
+type geminiSpeechRequest struct {
+	Contents         []geminiContent        `json:"contents"`
+	GenerationConfig *geminiSpeechGenConfig `json:"generationConfig"`
+}
+
+// geminiSpeechGenConfig requests audio output with a voice.
+type geminiSpeechGenConfig struct {
+	ResponseModalities []string           `json:"responseModalities"`
+	SpeechConfig       geminiSpeechConfig `json:"speechConfig"`
+}
+
+// geminiSpeechConfig selects the voice of generated speech.
+type geminiSpeechConfig struct {
+	VoiceConfig geminiVoiceConfig `json:"voiceConfig"`
+}
+
+// geminiVoiceConfig selects a prebuilt voice.
+type geminiVoiceConfig struct {
+	PrebuiltVoiceConfig geminiPrebuiltVoice `json:"prebuiltVoiceConfig"`
+}
+
+// geminiPrebuiltVoice names a prebuilt voice.
+type geminiPrebuiltVoice struct {
+	VoiceName string `json:"voiceName"`
+}
@@ -0,0 +1,65 @@
+package gemini
+
+// geminiBatchLine is one line of a batch input file.
+type geminiBatchLine struct {
+	Key     string         `json:"key"`
+	Request *geminiRequest `json:"request"`
+}
+
+// geminiBatchCreateRequest is the request body for models/{model}:batchGenerateContent.
+type geminiBatchCreateRequest struct {
+	Batch geminiBatchSpec `json:"batch"`
+}
+
+// geminiBatchSpec describes the batch to create.
+type geminiBatchSpec struct {
+	DisplayName string                 `json:"displayName"`
+	InputConfig geminiBatchInputConfig `json:"inputConfig"`
+}
+
+// geminiBatchInputConfig points a batch at its uploaded input file.
+type geminiBatchInputConfig struct {
+	FileName string `json:"fileName"`
+}
+
+// geminiBatchOperation is the long-running operation wrapping a batch.
+type geminiBatchOperation struct {
+	Name     string       `json:"name"`
+	Metadata *geminiBatch `json:"metadata,omitempty"`
+	Done     bool         `json:"done,omitempty"`
+	Error    *geminiError `json:"error,omitempty"`
+	Response *geminiBatch `json:"response,omitempty"`
+}
+
+// geminiBatch is the state of a batch job.
+type geminiBatch struct {
+	Model       string             `json:"model,omitempty"`
+	DisplayName string             `json:"displayName,omitempty"`
+	State       string             `json:"state,omitempty"`
+	CreateTime  string             `json:"createTime,omitempty"`
+	EndTime     string             `json:"endTime,omitempty"`
+	BatchStats  *geminiBatchStats  `json:"batchStats,omitempty"`
+	Output      *geminiBatchOutput `json:"output,omitempty"`
+	// ResponsesFile is set on the operation response once the batch succeeds.
+	ResponsesFile string `json:"responsesFile,omitempty"`
+}
+
+// geminiBatchStats reports per-request progress. Counts are int64 strings.
+type geminiBatchStats struct {
+	RequestCount           string `json:"requestCount,omitempty"`
+	SuccessfulRequestCount string `json:"successfulRequestCount,omitempty"`
+	FailedRequestCount     string `json:"failedRequestCount,omitempty"`
+	PendingRequestCount    string `json:"pendingRequestCount,omitempty"`
+}
+
+// geminiBatchOutput points at the results of a finished batch.
+type geminiBatchOutput struct {
+	ResponsesFile string `json:"responsesFile,omitempty"`
+}
+
+// geminiBatchResultLine is one line of a batch responses file.
+type geminiBatchResultLine struct {
+	Key      string          `json:"key"`
+	Response *geminiResponse `json:"response,omitempty"`
+	Error    *geminiError    `json:"error,omitempty"`
+}
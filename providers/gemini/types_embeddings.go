@@ -0,0 +1,30 @@
+package gemini
+
+// geminiEmbedRequest is the request body for models/{model}:embedContent and
+// a single entry of a batchEmbedContents request.
+type geminiEmbedRequest struct {
+	Model                string        `json:"model,omitempty"`
+	Content              geminiContent `json:"content"`
+	TaskType             string        `json:"taskType,omitempty"`
+	OutputDimensionality *int          `json:"outputDimensionality,omitempty"`
+}
+
+// geminiBatchEmbedRequest is the request body for models/{model}:batchEmbedContents.
+type geminiBatchEmbedRequest struct {
+	Requests []geminiEmbedRequest `json:"requests"`
+}
+
+// geminiEmbedding holds a single embedding vector.
+type geminiEmbedding struct {
+	Values []float32 `json:"values"`
+}
+
+// geminiEmbedResponse is the response from models/{model}:embedContent.
+type geminiEmbedResponse struct {
+	Embedding geminiEmbedding `json:"embedding"`
+}
+
+// geminiBatchEmbedResponse is the response from models/{model}:batchEmbedContents.
+type geminiBatchEmbedResponse struct {
+	Embeddings []geminiEmbedding `json:"embeddings"`
+}
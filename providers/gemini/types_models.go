@@ -0,0 +1,17 @@
+package gemini
+
+// geminiModelList is a page of models from GET /v1beta/models.
+type geminiModelList struct {
+	Models        []geminiModel `json:"models"`
+	NextPageToken string        `json:"nextPageToken"`
+}
+
+// geminiModel is a model entry from GET /v1beta/models.
+type geminiModel struct {
+	Name                       string   `json:"name"` // "models/{id}"
+	DisplayName                string   `json:"displayName"`
+	InputTokenLimit            int      `json:"inputTokenLimit"`
+	OutputTokenLimit           int      `json:"outputTokenLimit"`
+	SupportedGenerationMethods []string `json:"supportedGenerationMethods"`
+	Thinking                   bool     `json:"thinking"`
+}
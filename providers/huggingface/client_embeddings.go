@@ -0,0 +1,109 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// maxEmbedBatchSize is the number of inputs sent per feature-extraction call.
+// It matches the default client batch limit of Text Embeddings Inference.
+const maxEmbedBatchSize = 32
+
+// featureExtractionURL returns the router URL for a model's feature-extraction pipeline.
+func (p *HuggingFace) featureExtractionURL(model core.ModelID) string {
+	return fmt.Sprintf("%s/hf-inference/models/%s/pipeline/feature-extraction", p.config.BaseURL, model)
+}
+
+// CreateEmbeddings generates embeddings with the feature-extraction pipeline
+// of HF Inference. Inputs are sent in batches of at most 32. The model must
+// produce sentence embeddings (one vector per input), as sentence-transformers
+// models do. Dimensions and InputType are not supported and are ignored;
+// the API does not report token usage.
+func (p *HuggingFace) CreateEmbeddings(ctx context.Context, req *core.EmbeddingRequest) (*core.EmbeddingResponse, error) {
+	vectors := make([]core.EmbeddingVector, 0, len(req.Input))
+
+	for start := 0; start < len(req.Input); start += maxEmbedBatchSize {
+		end := min(start+maxEmbedBatchSize, len(req.Input))
+
+		hfReq := &hfFeatureExtractionRequest{
+			Inputs:   make([]string, 0, end-start),
+			Truncate: req.Truncation,
+		}
+		for _, input := range req.Input[start:end] {
+			hfReq.Inputs = append(hfReq.Inputs, input.Text)
+		}
+
+		values, err := p.featureExtraction(ctx, req.Model, hfReq)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != end-start {
+			return nil, newDecodeError(fmt.Errorf("expected %d embeddings, got %d", end-start, len(values)))
+		}
+
+		for i, v := range values {
+			input := req.Input[start+i]
+			vectors = append(vectors, core.EmbeddingVector{
+				Index:    start + i,
+				ID:       input.ID,
+				Vector:   v,
+				Metadata: input.Metadata,
+			})
+		}
+	}
+
+	return &core.EmbeddingResponse{
+		Vectors: vectors,
+		Model:   req.Model,
+	}, nil
+}
+
+// featureExtraction sends a single batch to the feature-extraction pipeline.
+func (p *HuggingFace) featureExtraction(ctx context.Context, model core.ModelID, hfReq *hfFeatureExtractionRequest) ([][]float32, error) {
+	body, err := json.Marshal(hfReq)
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.featureExtractionURL(model), bytes.NewReader(body))
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, normalizeError(resp.StatusCode, respBody, resp.Header.Get("x-request-id"))
+	}
+
+	var values [][]float32
+	if err := json.Unmarshal(respBody, &values); err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	return values, nil
+}
+
+// Compile-time check that HuggingFace implements EmbeddingProvider.
+var _ core.EmbeddingProvider = (*HuggingFace)(nil)
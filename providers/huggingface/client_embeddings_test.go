@@ -0,0 +1,119 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestCreateEmbeddingsBatching(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		want := "/hf-inference/models/BAAI/bge-small-en-v1.5/pipeline/feature-extraction"
+		if r.URL.Path != want {
+			t.Errorf("Path = %s, want %s", r.URL.Path, want)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer hf_test" {
+			t.Errorf("Authorization = %q", got)
+		}
+
+		var req hfFeatureExtractionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if len(req.Inputs) > maxEmbedBatchSize {
+			t.Errorf("batch size = %d, want <= %d", len(req.Inputs), maxEmbedBatchSize)
+		}
+		if req.Truncate == nil || !*req.Truncate {
+			t.Errorf("truncate = %v, want true", req.Truncate)
+		}
+
+		values := make([][]float32, len(req.Inputs))
+		for i, text := range req.Inputs {
+			var n int
+			fmt.Sscanf(text, "text %d", &n)
+			values[i] = []float32{float32(n), 0.5}
+		}
+		json.NewEncoder(w).Encode(values)
+	}))
+	defer server.Close()
+
+	const n = 70
+	input := make([]core.EmbeddingInput, n)
+	for i := range input {
+		input[i] = core.EmbeddingInput{
+			Text:     fmt.Sprintf("text %d", i),
+			ID:       fmt.Sprintf("id-%d", i),
+			Metadata: map[string]string{"n": fmt.Sprint(i)},
+		}
+	}
+
+	truncate := true
+	p := New("hf_test", WithBaseURL(server.URL))
+	resp, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+		Model:      "BAAI/bge-small-en-v1.5",
+		Input:      input,
+		Truncation: &truncate,
+	})
+	if err != nil {
+		t.Fatalf("CreateEmbeddings() error = %v", err)
+	}
+
+	if got := calls.Load(); got != 3 {
+		t.Errorf("calls = %d, want 3", got)
+	}
+	if len(resp.Vectors) != n {
+		t.Fatalf("len(Vectors) = %d, want %d", len(resp.Vectors), n)
+	}
+	for i, v := range resp.Vectors {
+		if v.Index != i || v.ID != fmt.Sprintf("id-%d", i) || v.Vector[0] != float32(i) || v.Metadata["n"] != fmt.Sprint(i) {
+			t.Fatalf("Vectors[%d] = %+v", i, v)
+		}
+	}
+}
+
+func TestCreateEmbeddingsTokenEmbeddings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Models without pooling return one vector per token.
+		w.Write([]byte(`[[[0.1,0.2],[0.3,0.4]]]`))
+	}))
+	defer server.Close()
+
+	p := New("hf_test", WithBaseURL(server.URL))
+	_, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+		Model: "bert-base-uncased",
+		Input: []core.EmbeddingInput{{Text: "hello"}},
+	})
+	if !errors.Is(err, core.ErrDecode) {
+		t.Errorf("error = %v, want ErrDecode", err)
+	}
+}
+
+func TestCreateEmbeddingsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error":"Model is currently loading","estimated_time":20}`))
+	}))
+	defer server.Close()
+
+	p := New("hf_test", WithBaseURL(server.URL))
+	_, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+		Model: "BAAI/bge-small-en-v1.5",
+		Input: []core.EmbeddingInput{{Text: "hello"}},
+	})
+	if !errors.Is(err, core.ErrServer) {
+		t.Fatalf("error = %v, want ErrServer", err)
+	}
+	var perr *core.ProviderError
+	if !errors.As(err, &perr) || perr.Message != "Model is currently loading" {
+		t.Errorf("Message = %q", perr.Message)
+	}
+}
@@ -0,0 +1,73 @@
+package huggingface
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers/providertest"
+)
+
+// sseData renders each payload as an SSE data event.
+func sseData(payloads ...string) string {
+	var sb strings.Builder
+	for _, p := range payloads {
+		fmt.Fprintf(&sb, "data: %s\n\n", p)
+	}
+	return sb.String()
+}
+
+func TestConformance(t *testing.T) {
+	sse := http.Header{"Content-Type": {"text/event-stream"}}
+
+	suite := providertest.Suite{
+		NewProvider: func(baseURL string) core.Provider {
+			return New("test-key", WithBaseURL(baseURL))
+		},
+		Model: "meta-llama/Llama-3.1-8B-Instruct",
+		Chat: &providertest.Fixture{
+			Body:   `{"id":"resp-1","model":"meta-llama/Llama-3.1-8B-Instruct","choices":[{"index":0,"message":{"role":"assistant","content":"Hello there"},"finish_reason":"stop"}],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`,
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		Stream: &providertest.Fixture{
+			Header: sse,
+			Body: sseData(
+				`{"id":"resp-2","model":"meta-llama/Llama-3.1-8B-Instruct","choices":[{"index":0,"delta":{"content":"Hello"}}]}`,
+				`{"id":"resp-2","model":"meta-llama/Llama-3.1-8B-Instruct","choices":[{"index":0,"delta":{"content":" there"}}],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`,
+				"[DONE]",
+			),
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		ToolCall: &providertest.Fixture{
+			Body: `{"id":"resp-3","model":"meta-llama/Llama-3.1-8B-Instruct","choices":[{"index":0,"message":{"role":"assistant","content":"","tool_calls":[{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"location\": \"NYC\"}"}}]},"finish_reason":"tool_calls"}]}`,
+			ToolCalls: []core.ToolCall{
+				{ID: "call_1", Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		StreamToolCall: &providertest.Fixture{
+			Header: sse,
+			Body: sseData(
+				`{"id":"resp-4","model":"meta-llama/Llama-3.1-8B-Instruct","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_2","type":"function","function":{"name":"get_weather","arguments":"{\"location\":"}}]}}]}`,
+				`{"id":"resp-4","model":"meta-llama/Llama-3.1-8B-Instruct","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":" \"NYC\"}"}}]}}]}`,
+				"[DONE]",
+			),
+			ToolCalls: []core.ToolCall{
+				{ID: "call_2", Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		Cancel: &providertest.Fixture{
+			Header: sse,
+			Body:   sseData(`{"id":"resp-5","model":"meta-llama/Llama-3.1-8B-Instruct","choices":[{"index":0,"delta":{"content":"Hello"}}]}`),
+		},
+		ErrorBody: func(status int) string {
+			return `{"error":{"message":"request failed","type":"test_error","code":"test_code"}}`
+		},
+	}
+
+	suite.Run(t)
+}
@@ -24,6 +24,17 @@
 //
 //	provider := huggingface.New("hf_xxxx", huggingface.WithProviderPolicy("fastest"))
 //
+// # Embeddings
+//
+// CreateEmbeddings uses the HF Inference feature-extraction pipeline and
+// works with sentence embedding models such as BAAI/bge-small-en-v1.5.
+// Inputs are sent in batches of 32:
+//
+//	resp, err := provider.CreateEmbeddings(ctx, &core.EmbeddingRequest{
+//	    Model: "BAAI/bge-small-en-v1.5",
+//	    Input: []core.EmbeddingInput{{Text: "Hello"}},
+//	})
+//
 // # Discovery API
 //
 // The provider includes methods to query the Hugging Face Hub API:
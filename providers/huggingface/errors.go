@@ -27,6 +27,15 @@ func normalizeError(status int, body []byte, requestID string) error {
 	_ = json.Unmarshal(body, &errResp)
 
 	message := errResp.Error.Message
+	if message == "" {
+		// Task endpoints such as feature-extraction report {"error": "..."}.
+		var plain struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(body, &plain) == nil {
+			message = plain.Error
+		}
+	}
 	if message == "" {
 		message = http.StatusText(status)
 	}
@@ -43,7 +43,7 @@ func (p *HuggingFace) Models() []core.ModelInfo {
 // Supports reports whether the provider supports the given feature.
 func (p *HuggingFace) Supports(feature core.Feature) bool {
 	switch feature {
-	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling:
+	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureEmbeddings:
 		return true
 	default:
 		return false
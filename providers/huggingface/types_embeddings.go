@@ -0,0 +1,7 @@
+package huggingface
+
+// hfFeatureExtractionRequest is the request body for the feature-extraction pipeline.
+type hfFeatureExtractionRequest struct {
+	Inputs   []string `json:"inputs"`
+	Truncate *bool    `json:"truncate,omitempty"`
+}
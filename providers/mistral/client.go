@@ -0,0 +1,82 @@
+package mistral
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// chatCompletionsPath is the API endpoint for chat completions.
+const chatCompletionsPath = "/chat/completions"
+
+// newRequest builds a POST request to the given API path.
+func (p *Mistral) newRequest(ctx context.Context, path string, body []byte) (*http.Request, error) {
+	url := p.config.BaseURL + path
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	// Set headers
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	return httpReq, nil
+}
+
+// requestID extracts the request ID from response headers.
+// Mistral reports it as a correlation ID on some endpoints.
+func requestID(h http.Header) string {
+	if id := h.Get("x-request-id"); id != "" {
+		return id
+	}
+	return h.Get("mistral-correlation-id")
+}
+
+// doChat performs a non-streaming chat completion request.
+func (p *Mistral) doChat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	// Marshal request body
+	body, err := json.Marshal(p.buildRequest(req, false))
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	httpReq, err := p.newRequest(ctx, chatCompletionsPath, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute request
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	// Check for error status
+	if resp.StatusCode >= 400 {
+		return nil, normalizeError(resp.StatusCode, respBody, requestID(resp.Header))
+	}
+
+	// Parse response
+	var mResp mistralResponse
+	if err := json.Unmarshal(respBody, &mResp); err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	// Map to Iris response
+	return mapResponse(&mResp)
+}
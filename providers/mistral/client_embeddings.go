@@ -0,0 +1,103 @@
+package mistral
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/erikhoward/iris/core"
+)
+
+const embeddingsPath = "/embeddings"
+
+// CreateEmbeddings generates embeddings for the given input texts.
+// Dimensions and OutputDType apply to codestral-embed only. Vectors are
+// always returned as floats, and InputType is ignored since Mistral has no
+// input types.
+func (p *Mistral) CreateEmbeddings(ctx context.Context, req *core.EmbeddingRequest) (*core.EmbeddingResponse, error) {
+	body, err := json.Marshal(buildEmbeddingRequest(req))
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	httpReq, err := p.newRequest(ctx, embeddingsPath, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, normalizeError(resp.StatusCode, respBody, requestID(resp.Header))
+	}
+
+	var mResp mistralEmbeddingResponse
+	if err := json.Unmarshal(respBody, &mResp); err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	return mapEmbeddingResponse(&mResp, req), nil
+}
+
+// buildEmbeddingRequest converts core request to Mistral format.
+func buildEmbeddingRequest(req *core.EmbeddingRequest) *mistralEmbeddingRequest {
+	inputs := make([]string, len(req.Input))
+	for i, input := range req.Input {
+		inputs[i] = input.Text
+	}
+
+	mReq := &mistralEmbeddingRequest{
+		Model: string(req.Model),
+		Input: inputs,
+	}
+
+	if req.Dimensions != nil {
+		mReq.OutputDimension = req.Dimensions
+	}
+	if req.OutputDType != "" {
+		mReq.OutputDType = string(req.OutputDType)
+	}
+
+	return mReq
+}
+
+// mapEmbeddingResponse converts Mistral response to core format.
+func mapEmbeddingResponse(resp *mistralEmbeddingResponse, req *core.EmbeddingRequest) *core.EmbeddingResponse {
+	vectors := make([]core.EmbeddingVector, len(resp.Data))
+
+	for i, data := range resp.Data {
+		vec := core.EmbeddingVector{
+			Index:  data.Index,
+			Vector: data.Embedding,
+		}
+
+		// Copy ID and Metadata from input if index is valid
+		if data.Index >= 0 && data.Index < len(req.Input) {
+			vec.ID = req.Input[data.Index].ID
+			vec.Metadata = req.Input[data.Index].Metadata
+		}
+
+		vectors[i] = vec
+	}
+
+	return &core.EmbeddingResponse{
+		Vectors: vectors,
+		Model:   core.ModelID(resp.Model),
+		Usage: core.EmbeddingUsage{
+			PromptTokens: resp.Usage.PromptTokens,
+			TotalTokens:  resp.Usage.TotalTokens,
+		},
+	}
+}
+
+// Compile-time check that Mistral implements EmbeddingProvider.
+var _ core.EmbeddingProvider = (*Mistral)(nil)
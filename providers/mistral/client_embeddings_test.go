@@ -0,0 +1,86 @@
+package mistral
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestCreateEmbeddings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/embeddings" {
+			t.Errorf("Path = %q, want /embeddings", r.URL.Path)
+		}
+
+		var req mistralEmbeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if req.Model != "codestral-embed" {
+			t.Errorf("Model = %q, want codestral-embed", req.Model)
+		}
+		if len(req.Input) != 2 || req.Input[1] != "world" {
+			t.Errorf("Input = %v", req.Input)
+		}
+		if req.OutputDimension == nil || *req.OutputDimension != 256 {
+			t.Errorf("OutputDimension = %v, want 256", req.OutputDimension)
+		}
+		if req.OutputDType != "int8" {
+			t.Errorf("OutputDType = %q, want int8", req.OutputDType)
+		}
+
+		w.Write([]byte(`{"id":"e1","object":"list","model":"codestral-embed","data":[{"object":"embedding","index":1,"embedding":[0.3,0.4]},{"object":"embedding","index":0,"embedding":[0.1,0.2]}],"usage":{"prompt_tokens":4,"total_tokens":4}}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	dims := 256
+	resp, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+		Model: ModelCodestralEmbed,
+		Input: []core.EmbeddingInput{
+			{Text: "hello", ID: "doc-1"},
+			{Text: "world", ID: "doc-2", Metadata: map[string]string{"k": "v"}},
+		},
+		Dimensions:  &dims,
+		OutputDType: core.OutputDTypeInt8,
+	})
+	if err != nil {
+		t.Fatalf("CreateEmbeddings() error = %v", err)
+	}
+
+	if len(resp.Vectors) != 2 {
+		t.Fatalf("len(Vectors) = %d, want 2", len(resp.Vectors))
+	}
+	if v := resp.Vectors[0]; v.Index != 1 || v.ID != "doc-2" || v.Metadata["k"] != "v" || v.Vector[1] != 0.4 {
+		t.Errorf("Vectors[0] = %+v", v)
+	}
+	if v := resp.Vectors[1]; v.Index != 0 || v.ID != "doc-1" {
+		t.Errorf("Vectors[1] = %+v", v)
+	}
+	if resp.Usage.PromptTokens != 4 || resp.Usage.TotalTokens != 4 {
+		t.Errorf("Usage = %+v", resp.Usage)
+	}
+}
+
+func TestCreateEmbeddingsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"Unauthorized","request_id":"abc"}`))
+	}))
+	defer server.Close()
+
+	p := New("bad-key", WithBaseURL(server.URL))
+	_, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+		Model: ModelMistralEmbed,
+		Input: []core.EmbeddingInput{{Text: "hello"}},
+	})
+
+	if !errors.Is(err, core.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package mistral
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// modelsPath is the API endpoint for listing models.
+const modelsPath = "/models"
+
+// ListModels returns the models available to the API key using GET /models.
+// Models in the static list keep its capabilities; others have capabilities
+// derived from those the API reports. Results are cached for the configured
+// ModelCacheTTL.
+func (p *Mistral) ListModels(ctx context.Context) ([]core.ModelInfo, error) {
+	return p.modelCache.Get(ctx)
+}
+
+// fetchModels queries GET /models and merges the result with the static list.
+func (p *Mistral) fetchModels(ctx context.Context) ([]core.ModelInfo, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.BaseURL+modelsPath, nil)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, normalizeError(resp.StatusCode, respBody, requestID(resp.Header))
+	}
+
+	var list mistralModelList
+	if err := json.Unmarshal(respBody, &list); err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	live := make([]core.ModelInfo, len(list.Data))
+	for i, m := range list.Data {
+		live[i] = core.ModelInfo{
+			ID:           core.ModelID(m.ID),
+			DisplayName:  m.Name,
+			Capabilities: modelCapabilities(&m),
+		}
+	}
+	return core.MergeModels(live, models), nil
+}
+
+// modelCapabilities converts the capabilities reported by the API to Iris features.
+func modelCapabilities(m *mistralModel) []core.Feature {
+	var features []core.Feature
+	if m.Capabilities.CompletionChat {
+		features = append(features, core.FeatureChat, core.FeatureChatStreaming)
+	}
+	if m.Capabilities.FunctionCalling {
+		features = append(features, core.FeatureToolCalling)
+	}
+	if strings.Contains(m.ID, "embed") {
+		features = append(features, core.FeatureEmbeddings)
+	}
+	return features
+}
+
+// Compile-time check that Mistral implements ModelLister.
+var _ core.ModelLister = (*Mistral)(nil)
@@ -0,0 +1,66 @@
+package mistral
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestListModels(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodGet || r.URL.Path != "/models" {
+			t.Errorf("request = %s %s, want GET /models", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("Authorization = %q", got)
+		}
+		io.WriteString(w, `{"object":"list","data":[
+			{"id":"mistral-large-latest","name":"mistral-large-2411","capabilities":{"completion_chat":true,"function_calling":true},"type":"base"},
+			{"id":"mistral-xl-2610","name":"mistral-xl-2610","capabilities":{"completion_chat":true,"function_calling":true,"vision":true},"type":"base"},
+			{"id":"mistral-embed-2","name":"mistral-embed-2","capabilities":{"completion_chat":false},"type":"base"}]}`)
+	}))
+	defer server.Close()
+
+	var provider core.ModelLister = New("test-key", WithBaseURL(server.URL))
+	models, err := provider.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if len(models) != 3 {
+		t.Fatalf("ListModels() returned %d models, want 3", len(models))
+	}
+	if models[0].ID != ModelMistralLarge || models[0].DisplayName != "Mistral Large" {
+		t.Errorf("models[0] = %+v, want static metadata", models[0])
+	}
+	if !models[1].HasCapability(core.FeatureChat) || !models[1].HasCapability(core.FeatureToolCalling) {
+		t.Errorf("models[1] = %+v", models[1])
+	}
+	if !models[2].HasCapability(core.FeatureEmbeddings) || models[2].HasCapability(core.FeatureChat) {
+		t.Errorf("models[2] = %+v, want embeddings only", models[2])
+	}
+
+	provider.ListModels(context.Background())
+	if calls != 1 {
+		t.Errorf("API calls = %d, want 1 (cached)", calls)
+	}
+}
+
+func TestListModelsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		io.WriteString(w, `{"message":"Unauthorized","request_id":"abc"}`)
+	}))
+	defer server.Close()
+
+	_, err := New("test-key", WithBaseURL(server.URL)).ListModels(context.Background())
+	if !errors.Is(err, core.ErrUnauthorized) {
+		t.Errorf("ListModels() error = %v, want ErrUnauthorized", err)
+	}
+}
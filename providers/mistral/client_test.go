@@ -0,0 +1,114 @@
+package mistral
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestChatJSONMode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat/completions" {
+			t.Errorf("Path = %q, want /chat/completions", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("invalid request body: %v", err)
+		}
+		format, _ := req["response_format"].(map[string]any)
+		if format["type"] != "json_object" {
+			t.Errorf("response_format = %v, want json_object", req["response_format"])
+		}
+		messages, _ := req["messages"].([]any)
+		if first, _ := messages[0].(map[string]any); first["role"] != "system" || first["content"] != "Answer in JSON." {
+			t.Errorf("messages[0] = %v, want instructions as system message", messages[0])
+		}
+
+		w.Write([]byte(`{"id":"r1","model":"mistral-small-latest","choices":[{"index":0,"message":{"role":"assistant","content":"{\"ok\":true}"}}],"usage":{"prompt_tokens":3,"completion_tokens":4,"total_tokens":7}}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL), WithJSONMode())
+	resp, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:        ModelMistralSmall,
+		Instructions: "Answer in JSON.",
+		Messages:     []core.Message{{Role: core.RoleUser, Content: "Status?"}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.Output != `{"ok":true}` {
+		t.Errorf("Output = %q", resp.Output)
+	}
+}
+
+func TestChatReasoningContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req mistralRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("invalid request body: %v", err)
+		}
+		if req.PromptMode != "reasoning" {
+			t.Errorf("PromptMode = %q, want reasoning", req.PromptMode)
+		}
+		if req.ResponseFormat != nil {
+			t.Errorf("ResponseFormat = %+v, want nil without JSON mode", req.ResponseFormat)
+		}
+
+		w.Write([]byte(`{"id":"r2","model":"magistral-medium-latest","choices":[{"index":0,"message":{"role":"assistant","content":[{"type":"thinking","thinking":[{"type":"text","text":"2+2 is 4."}]},{"type":"text","text":"4"}]}}]}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	resp, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:           ModelMagistralMedium,
+		ReasoningEffort: core.ReasoningEffortHigh,
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.Output != "4" {
+		t.Errorf("Output = %q, want 4", resp.Output)
+	}
+	if resp.Reasoning == nil || resp.Reasoning.Summary[0] != "2+2 is 4." {
+		t.Errorf("Reasoning = %+v", resp.Reasoning)
+	}
+}
+
+func TestChatValidationError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("mistral-correlation-id", "corr-1")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"object":"error","message":{"detail":[{"loc":["body","model"],"msg":"Field required"}]},"type":"invalid_request_error","code":null}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	_, err := p.Chat(context.Background(), &core.ChatRequest{})
+
+	var pErr *core.ProviderError
+	if !errors.As(err, &pErr) {
+		t.Fatalf("expected ProviderError, got %T", err)
+	}
+	if pErr.RequestID != "corr-1" {
+		t.Errorf("RequestID = %q, want corr-1", pErr.RequestID)
+	}
+	if pErr.Code != "invalid_request_error" {
+		t.Errorf("Code = %q, want invalid_request_error", pErr.Code)
+	}
+	if pErr.Message != `{"detail":[{"loc":["body","model"],"msg":"Field required"}]}` {
+		t.Errorf("Message = %q", pErr.Message)
+	}
+	if !errors.Is(err, core.ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package mistral
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers/providertest"
+)
+
+func TestConformance(t *testing.T) {
+	sse := http.Header{"Content-Type": {"text/event-stream"}}
+
+	suite := providertest.Suite{
+		NewProvider: func(baseURL string) core.Provider {
+			return New("test-key", WithBaseURL(baseURL))
+		},
+		Model: ModelMistralLarge,
+		Chat: &providertest.Fixture{
+			Body:   `{"id":"resp-1","model":"mistral-large-latest","choices":[{"index":0,"message":{"role":"assistant","content":"Hello there"},"finish_reason":"stop"}],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`,
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		Stream: &providertest.Fixture{
+			Header: sse,
+			Body: sseResponse(
+				`{"id":"resp-2","model":"mistral-large-latest","choices":[{"index":0,"delta":{"role":"assistant","content":"Hello"}}]}`,
+				`{"id":"resp-2","model":"mistral-large-latest","choices":[{"index":0,"delta":{"content":" there"},"finish_reason":"stop"}],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`,
+				"[DONE]",
+			),
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		ToolCall: &providertest.Fixture{
+			Body: `{"id":"resp-3","model":"mistral-large-latest","choices":[{"index":0,"message":{"role":"assistant","content":"","tool_calls":[{"id":"call_1","function":{"name":"get_weather","arguments":"{\"location\": \"NYC\"}"}}]},"finish_reason":"tool_calls"}]}`,
+			ToolCalls: []core.ToolCall{
+				{ID: "call_1", Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		StreamToolCall: &providertest.Fixture{
+			Header: sse,
+			Body: sseResponse(
+				`{"id":"resp-4","model":"mistral-large-latest","choices":[{"index":0,"delta":{"tool_calls":[{"id":"call_2","index":0,"function":{"name":"get_weather","arguments":"{\"location\":"}}]}}]}`,
+				`{"id":"resp-4","model":"mistral-large-latest","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":" \"NYC\"}"}}]},"finish_reason":"tool_calls"}]}`,
+				"[DONE]",
+			),
+			ToolCalls: []core.ToolCall{
+				{ID: "call_2", Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		Cancel: &providertest.Fixture{
+			Header: sse,
+			Body:   sseResponse(`{"id":"resp-5","model":"mistral-large-latest","choices":[{"index":0,"delta":{"content":"Hello"}}]}`),
+		},
+		ErrorBody: func(status int) string {
+			return `{"object":"error","message":"request failed","type":"test_error","code":"test_code"}`
+		},
+		ErrorStatuses: map[int]error{
+			http.StatusBadRequest:          core.ErrBadRequest,
+			http.StatusUnauthorized:        core.ErrUnauthorized,
+			http.StatusForbidden:           core.ErrUnauthorized,
+			http.StatusNotFound:            core.ErrNotFound,
+			http.StatusUnprocessableEntity: core.ErrBadRequest,
+			http.StatusTooManyRequests:     core.ErrRateLimited,
+			http.StatusInternalServerError: core.ErrServer,
+			http.StatusServiceUnavailable:  core.ErrServer,
+		},
+	}
+
+	suite.Run(t)
+}
@@ -0,0 +1,100 @@
+package mistral
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// ErrToolArgsInvalidJSON is returned when tool call arguments contain invalid JSON.
+var ErrToolArgsInvalidJSON = errors.New("tool args invalid json")
+
+// mistralErrorResponse represents an error response from the Mistral API.
+// Validation failures carry a structured message or a detail list
+// instead of a plain string.
+type mistralErrorResponse struct {
+	Message json.RawMessage `json:"message"`
+	Type    string          `json:"type"`
+	Code    any             `json:"code"`
+	Detail  json.RawMessage `json:"detail"`
+}
+
+// errorMessage extracts a readable message from a Mistral error body.
+func (e *mistralErrorResponse) errorMessage() string {
+	for _, raw := range []json.RawMessage{e.Message, e.Detail} {
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			if s != "" {
+				return s
+			}
+			continue
+		}
+		return string(raw)
+	}
+	return ""
+}
+
+// normalizeError converts an HTTP error response to a ProviderError with the appropriate sentinel.
+func normalizeError(status int, body []byte, requestID string) error {
+	// Parse error response if possible
+	var errResp mistralErrorResponse
+	_ = json.Unmarshal(body, &errResp)
+
+	message := errResp.errorMessage()
+	if message == "" {
+		message = http.StatusText(status)
+	}
+
+	code := errResp.Type
+	if errResp.Code != nil {
+		code = fmt.Sprint(errResp.Code)
+	}
+
+	// Determine sentinel error based on status
+	var sentinel error
+	switch {
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		sentinel = core.ErrBadRequest
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		sentinel = core.ErrUnauthorized
+	case status == http.StatusNotFound:
+		sentinel = core.ErrNotFound
+	case status == http.StatusTooManyRequests:
+		sentinel = core.ErrRateLimited
+	default:
+		sentinel = core.ErrServer
+	}
+
+	return &core.ProviderError{
+		Provider:  "mistral",
+		Status:    status,
+		RequestID: requestID,
+		Code:      code,
+		Message:   message,
+		Err:       sentinel,
+	}
+}
+
+// newNetworkError creates a ProviderError for network-related failures.
+func newNetworkError(err error) error {
+	return &core.ProviderError{
+		Provider: "mistral",
+		Message:  err.Error(),
+		Err:      core.ErrNetwork,
+	}
+}
+
+// newDecodeError creates a ProviderError for JSON decode failures.
+func newDecodeError(err error) error {
+	return &core.ProviderError{
+		Provider: "mistral",
+		Message:  err.Error(),
+		Err:      core.ErrDecode,
+	}
+}
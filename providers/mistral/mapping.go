@@ -0,0 +1,215 @@
+package mistral
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/tools"
+)
+
+// schemaProvider is an interface for tools that provide a JSON schema.
+// This allows us to check if a core.Tool also implements the full tools.Tool interface.
+type schemaProvider interface {
+	Schema() tools.ToolSchema
+}
+
+// mapMessages converts Iris messages to Mistral message format.
+// Instructions, if set, are sent as a leading system message.
+func mapMessages(instructions string, msgs []core.Message) []mistralMessage {
+	result := make([]mistralMessage, 0, len(msgs)+1)
+	if instructions != "" {
+		result = append(result, mistralMessage{Role: string(core.RoleSystem), Content: instructions})
+	}
+	for _, msg := range msgs {
+		result = append(result, mistralMessage{
+			Role:    string(msg.Role),
+			Content: msg.Content,
+		})
+	}
+	return result
+}
+
+// mapTools converts Iris tools to Mistral tool format.
+// Tools that implement schemaProvider will have their schema included.
+func mapTools(irisTools []core.Tool) []mistralTool {
+	if len(irisTools) == 0 {
+		return nil
+	}
+
+	result := make([]mistralTool, len(irisTools))
+	for i, t := range irisTools {
+		var params json.RawMessage
+
+		// Check if the tool provides a schema
+		if sp, ok := t.(schemaProvider); ok {
+			params = sp.Schema().JSONSchema
+		}
+
+		// Mistral rejects an empty schema, so default to an empty object schema
+		if params == nil {
+			params = json.RawMessage(`{"type":"object","properties":{}}`)
+		}
+
+		result[i] = mistralTool{
+			Type: "function",
+			Function: mistralFunction{
+				Name:        t.Name(),
+				Description: t.Description(),
+				Parameters:  params,
+			},
+		}
+	}
+	return result
+}
+
+// buildRequest creates a Mistral API request from an Iris ChatRequest.
+func (p *Mistral) buildRequest(req *core.ChatRequest, stream bool) *mistralRequest {
+	mReq := &mistralRequest{
+		Model:    string(req.Model),
+		Messages: mapMessages(req.Instructions, req.Messages),
+		Stream:   stream,
+	}
+
+	// Only set optional fields if provided
+	if req.Temperature != nil {
+		mReq.Temperature = req.Temperature
+	}
+
+	if req.MaxTokens != nil {
+		mReq.MaxTokens = req.MaxTokens
+	}
+
+	// Map tools if present
+	if len(req.Tools) > 0 {
+		mReq.Tools = mapTools(req.Tools)
+		mReq.ToolChoice = "auto"
+	}
+
+	if p.config.JSONMode {
+		mReq.ResponseFormat = &mistralResponseFormat{Type: "json_object"}
+	}
+
+	// Reasoning models only think when asked for the reasoning system prompt
+	if req.ReasoningEffort != "" && req.ReasoningEffort != core.ReasoningEffortNone {
+		mReq.PromptMode = "reasoning"
+	}
+
+	return mReq
+}
+
+// parseContent splits message content into answer text and reasoning text.
+// Content is a plain string, or an array of text and thinking chunks.
+func parseContent(raw json.RawMessage) (text, thinking string, err error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", "", nil
+	}
+
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s, "", nil
+	}
+
+	var chunks []mistralContentChunk
+	if err := json.Unmarshal(raw, &chunks); err != nil {
+		return "", "", err
+	}
+
+	var textBuf, thinkBuf strings.Builder
+	for _, c := range chunks {
+		switch c.Type {
+		case "text":
+			textBuf.WriteString(c.Text)
+		case "thinking":
+			for _, t := range c.Thinking {
+				thinkBuf.WriteString(t.Text)
+			}
+		}
+	}
+	return textBuf.String(), thinkBuf.String(), nil
+}
+
+// mapResponse converts a Mistral response to an Iris ChatResponse.
+func mapResponse(resp *mistralResponse) (*core.ChatResponse, error) {
+	result := &core.ChatResponse{
+		ID:    resp.ID,
+		Model: core.ModelID(resp.Model),
+		Usage: mapUsage(&resp.Usage),
+	}
+
+	// Extract content from first choice
+	if len(resp.Choices) > 0 {
+		choice := resp.Choices[0]
+
+		text, thinking, err := parseContent(choice.Message.Content)
+		if err != nil {
+			return nil, newDecodeError(err)
+		}
+		result.Output = text
+
+		// Map reasoning content if present (Magistral)
+		if thinking != "" {
+			result.Reasoning = &core.ReasoningOutput{
+				Summary: []string{thinking},
+			}
+		}
+
+		// Map tool calls if present
+		if len(choice.Message.ToolCalls) > 0 {
+			toolCalls, err := mapToolCalls(choice.Message.ToolCalls)
+			if err != nil {
+				return nil, err
+			}
+			result.ToolCalls = toolCalls
+		}
+	}
+
+	return result, nil
+}
+
+// toolArguments returns the raw JSON arguments of a tool call.
+// String-encoded arguments are unwrapped; object arguments are kept as-is.
+func toolArguments(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// mapToolCalls converts Mistral tool calls to Iris ToolCalls.
+func mapToolCalls(calls []mistralToolCall) ([]core.ToolCall, error) {
+	result := make([]core.ToolCall, len(calls))
+
+	for i, call := range calls {
+		args := toolArguments(call.Function.Arguments)
+		if args == "" {
+			args = "{}"
+		}
+
+		// Validate that arguments is valid JSON
+		if !json.Valid([]byte(args)) {
+			return nil, ErrToolArgsInvalidJSON
+		}
+
+		result[i] = core.ToolCall{
+			ID:        call.ID,
+			Name:      call.Function.Name,
+			Arguments: json.RawMessage(args),
+		}
+	}
+
+	return result, nil
+}
+
+// mapUsage converts Mistral usage to Iris TokenUsage.
+func mapUsage(u *mistralUsage) core.TokenUsage {
+	return core.TokenUsage{
+		PromptTokens:     u.PromptTokens,
+		CompletionTokens: u.CompletionTokens,
+		TotalTokens:      u.TotalTokens,
+	}
+}
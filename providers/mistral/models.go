@@ -0,0 +1,132 @@
+// Package mistral provides a Mistral AI API provider implementation for Iris.
+package mistral
+
+import "github.com/erikhoward/iris/core"
+
+// Model constants for Mistral models.
+const (
+	// Chat models
+	ModelMistralLarge  core.ModelID = "mistral-large-latest"
+	ModelMistralMedium core.ModelID = "mistral-medium-latest"
+	ModelMistralSmall  core.ModelID = "mistral-small-latest"
+	ModelMinistral8B   core.ModelID = "ministral-8b-latest"
+	ModelCodestral     core.ModelID = "codestral-latest"
+
+	// Reasoning models
+	ModelMagistralMedium core.ModelID = "magistral-medium-latest"
+	ModelMagistralSmall  core.ModelID = "magistral-small-latest"
+
+	// Embedding models
+	ModelMistralEmbed   core.ModelID = "mistral-embed"
+	ModelCodestralEmbed core.ModelID = "codestral-embed"
+)
+
+// models is the static list of supported models.
+var models = []core.ModelInfo{
+	// Chat models
+	{
+		ID:          ModelMistralLarge,
+		DisplayName: "Mistral Large",
+		APIEndpoint: core.APIEndpointCompletions,
+		Capabilities: []core.Feature{
+			core.FeatureChat,
+			core.FeatureChatStreaming,
+			core.FeatureToolCalling,
+		},
+	},
+	{
+		ID:          ModelMistralMedium,
+		DisplayName: "Mistral Medium",
+		APIEndpoint: core.APIEndpointCompletions,
+		Capabilities: []core.Feature{
+			core.FeatureChat,
+			core.FeatureChatStreaming,
+			core.FeatureToolCalling,
+		},
+	},
+	{
+		ID:          ModelMistralSmall,
+		DisplayName: "Mistral Small",
+		APIEndpoint: core.APIEndpointCompletions,
+		Capabilities: []core.Feature{
+			core.FeatureChat,
+			core.FeatureChatStreaming,
+			core.FeatureToolCalling,
+		},
+	},
+	{
+		ID:          ModelMinistral8B,
+		DisplayName: "Ministral 8B",
+		APIEndpoint: core.APIEndpointCompletions,
+		Capabilities: []core.Feature{
+			core.FeatureChat,
+			core.FeatureChatStreaming,
+			core.FeatureToolCalling,
+		},
+	},
+	{
+		ID:          ModelCodestral,
+		DisplayName: "Codestral",
+		APIEndpoint: core.APIEndpointCompletions,
+		Capabilities: []core.Feature{
+			core.FeatureChat,
+			core.FeatureChatStreaming,
+			core.FeatureToolCalling,
+		},
+	},
+	// Reasoning models
+	{
+		ID:          ModelMagistralMedium,
+		DisplayName: "Magistral Medium",
+		APIEndpoint: core.APIEndpointCompletions,
+		Capabilities: []core.Feature{
+			core.FeatureChat,
+			core.FeatureChatStreaming,
+			core.FeatureToolCalling,
+			core.FeatureReasoning,
+		},
+	},
+	{
+		ID:          ModelMagistralSmall,
+		DisplayName: "Magistral Small",
+		APIEndpoint: core.APIEndpointCompletions,
+		Capabilities: []core.Feature{
+			core.FeatureChat,
+			core.FeatureChatStreaming,
+			core.FeatureToolCalling,
+			core.FeatureReasoning,
+		},
+	},
+	// Embedding models
+	{
+		ID:          ModelMistralEmbed,
+		DisplayName: "Mistral Embed",
+		Capabilities: []core.Feature{
+			core.FeatureEmbeddings,
+		},
+	},
+	{
+		ID:          ModelCodestralEmbed,
+		DisplayName: "Codestral Embed",
+		Capabilities: []core.Feature{
+			core.FeatureEmbeddings,
+		},
+	},
+}
+
+// modelRegistry is a map for quick model lookup by ID.
+var modelRegistry = buildModelRegistry()
+
+// buildModelRegistry creates a map from model ID to ModelInfo.
+func buildModelRegistry() map[core.ModelID]*core.ModelInfo {
+	registry := make(map[core.ModelID]*core.ModelInfo, len(models))
+	for i := range models {
+		registry[models[i].ID] = &models[i]
+	}
+	return registry
+}
+
+// GetModelInfo returns the ModelInfo for a given model ID, or nil if not found.
+func GetModelInfo(id core.ModelID) *core.ModelInfo {
+	return modelRegistry[id]
+}
@@ -0,0 +1,84 @@
+package mistral
+
+import (
+	"net/http"
+	"time"
+)
+
+// Config holds configuration for the Mistral provider.
+type Config struct {
+	// APIKey is the Mistral API key (required).
+	APIKey string
+
+	// BaseURL is the API base URL. Defaults to https://api.mistral.ai/v1
+	BaseURL string
+
+	// HTTPClient is the HTTP client to use. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Headers contains optional extra headers to include in requests.
+	Headers http.Header
+
+	// Timeout is the optional request timeout.
+	Timeout time.Duration
+
+	// ModelCacheTTL is how long ListModels results are cached.
+	// Defaults to core.DefaultModelCacheTTL; zero or less disables caching.
+	ModelCacheTTL time.Duration
+
+	// JSONMode constrains chat output to a valid JSON object.
+	JSONMode bool
+}
+
+// DefaultBaseURL is the default Mistral API base URL.
+const DefaultBaseURL = "https://api.mistral.ai/v1"
+
+// Option configures the Mistral provider.
+type Option func(*Config)
+
+// WithBaseURL sets the API base URL.
+func WithBaseURL(url string) Option {
+	return func(c *Config) {
+		c.BaseURL = url
+	}
+}
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Config) {
+		c.HTTPClient = client
+	}
+}
+
+// WithHeader adds an extra header to include in requests.
+func WithHeader(key, value string) Option {
+	return func(c *Config) {
+		if c.Headers == nil {
+			c.Headers = make(http.Header)
+		}
+		c.Headers.Set(key, value)
+	}
+}
+
+// WithTimeout sets the request timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Config) {
+		c.Timeout = d
+	}
+}
+
+// WithJSONMode requests JSON object output for every chat request.
+// The prompt should still ask the model to answer in JSON.
+func WithJSONMode() Option {
+	return func(c *Config) {
+		c.JSONMode = true
+	}
+}
+
+// WithModelCacheTTL sets how long ListModels results are cached.
+// A duration of zero or less disables caching.
+func WithModelCacheTTL(d time.Duration) Option {
+	return func(c *Config) {
+		c.ModelCacheTTL = d
+	}
+}
@@ -0,0 +1,89 @@
+package mistral
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// Mistral is an LLM provider implementation for the Mistral AI API.
+// Mistral is safe for concurrent use.
+type Mistral struct {
+	config Config
+
+	modelCache *core.ModelCache // caches ListModels results
+}
+
+// New creates a new Mistral provider with the given API key and options.
+func New(apiKey string, opts ...Option) *Mistral {
+	cfg := Config{
+		APIKey:        apiKey,
+		BaseURL:       DefaultBaseURL,
+		HTTPClient:    http.DefaultClient,
+		ModelCacheTTL: core.DefaultModelCacheTTL,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	p := &Mistral{config: cfg}
+	p.modelCache = core.NewModelCache(cfg.ModelCacheTTL, p.fetchModels)
+	return p
+}
+
+// ID returns the provider identifier.
+func (p *Mistral) ID() string {
+	return "mistral"
+}
+
+// Models returns the list of available models.
+func (p *Mistral) Models() []core.ModelInfo {
+	// Return a copy to prevent mutation
+	result := make([]core.ModelInfo, len(models))
+	copy(result, models)
+	return result
+}
+
+// Supports reports whether the provider supports the given feature.
+func (p *Mistral) Supports(feature core.Feature) bool {
+	switch feature {
+	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling,
+		core.FeatureReasoning, core.FeatureEmbeddings, core.FeatureModelListing:
+		return true
+	default:
+		return false
+	}
+}
+
+// buildHeaders constructs the HTTP headers for an API request.
+func (p *Mistral) buildHeaders() http.Header {
+	headers := make(http.Header)
+
+	// Required headers
+	headers.Set("Authorization", "Bearer "+p.config.APIKey)
+	headers.Set("Content-Type", "application/json")
+
+	// Copy any extra headers
+	for key, values := range p.config.Headers {
+		for _, v := range values {
+			headers.Add(key, v)
+		}
+	}
+
+	return headers
+}
+
+// Chat sends a non-streaming chat request.
+func (p *Mistral) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	return p.doChat(ctx, req)
+}
+
+// StreamChat sends a streaming chat request.
+func (p *Mistral) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	return p.doStreamChat(ctx, req)
+}
+
+// Compile-time check that Mistral implements Provider.
+var _ core.Provider = (*Mistral)(nil)
@@ -0,0 +1,44 @@
+package mistral
+
+import (
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers"
+)
+
+func TestSupports(t *testing.T) {
+	p := New("test-key")
+
+	for _, f := range []core.Feature{core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureReasoning, core.FeatureEmbeddings} {
+		if !p.Supports(f) {
+			t.Errorf("Supports(%q) = false, want true", f)
+		}
+	}
+	if p.Supports(core.FeatureReranking) {
+		t.Error("Supports(reranking) = true, want false")
+	}
+}
+
+func TestModels(t *testing.T) {
+	p := New("test-key")
+	models := p.Models()
+	models[0].DisplayName = "mutated"
+
+	if p.Models()[0].DisplayName == "mutated" {
+		t.Error("Models() should return a copy")
+	}
+	if info := GetModelInfo(ModelMistralEmbed); info == nil || info.Capabilities[0] != core.FeatureEmbeddings {
+		t.Errorf("GetModelInfo(%q) = %+v", ModelMistralEmbed, info)
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	p, err := providers.Create("mistral", "test-key")
+	if err != nil {
+		t.Fatalf("providers.Create() error = %v", err)
+	}
+	if p.ID() != "mistral" {
+		t.Errorf("ID() = %q, want mistral", p.ID())
+	}
+}
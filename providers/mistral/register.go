@@ -0,0 +1,12 @@
+package mistral
+
+import (
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers"
+)
+
+func init() {
+	providers.Register("mistral", func(apiKey string) core.Provider {
+		return New(apiKey)
+	})
+}
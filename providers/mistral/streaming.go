@@ -0,0 +1,269 @@
+package mistral
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// toolCallAssembler accumulates streaming tool call fragments.
+// Mistral usually sends each tool call whole in a single delta, but
+// fragments are merged in case arguments are split.
+type toolCallAssembler struct {
+	calls map[int]*assemblingToolCall
+	order []int
+}
+
+type assemblingToolCall struct {
+	ID        string
+	Name      string
+	Arguments strings.Builder
+}
+
+func newToolCallAssembler() *toolCallAssembler {
+	return &toolCallAssembler{
+		calls: make(map[int]*assemblingToolCall),
+	}
+}
+
+// addFragment processes a streaming tool call fragment.
+// When the index is omitted, a fragment with an unseen ID starts a new call
+// and any other fragment continues the matching or most recent call.
+func (a *toolCallAssembler) addFragment(tc mistralToolCall) {
+	idx := -1
+	switch {
+	case tc.Index != nil:
+		idx = *tc.Index
+	case tc.ID != "":
+		for i, c := range a.calls {
+			if c.ID == tc.ID {
+				idx = i
+			}
+		}
+		if idx < 0 {
+			idx = len(a.order)
+			for a.calls[idx] != nil {
+				idx++
+			}
+		}
+	case len(a.order) > 0:
+		idx = a.order[len(a.order)-1]
+	default:
+		idx = 0
+	}
+
+	call, exists := a.calls[idx]
+	if !exists {
+		call = &assemblingToolCall{}
+		a.calls[idx] = call
+		a.order = append(a.order, idx)
+	}
+
+	if tc.ID != "" {
+		call.ID = tc.ID
+	}
+	if tc.Function.Name != "" {
+		call.Name = tc.Function.Name
+	}
+	call.Arguments.WriteString(toolArguments(tc.Function.Arguments))
+}
+
+// finalize validates and returns the assembled tool calls in arrival order.
+func (a *toolCallAssembler) finalize() ([]core.ToolCall, error) {
+	if len(a.calls) == 0 {
+		return nil, nil
+	}
+
+	result := make([]core.ToolCall, 0, len(a.calls))
+	for _, idx := range a.order {
+		call := a.calls[idx]
+
+		args := call.Arguments.String()
+		if args == "" {
+			args = "{}"
+		}
+		if !json.Valid([]byte(args)) {
+			return nil, ErrToolArgsInvalidJSON
+		}
+
+		result = append(result, core.ToolCall{
+			ID:        call.ID,
+			Name:      call.Name,
+			Arguments: json.RawMessage(args),
+		})
+	}
+
+	return result, nil
+}
+
+// doStreamChat performs a streaming chat completion request.
+func (p *Mistral) doStreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	// Marshal request body with stream=true
+	body, err := json.Marshal(p.buildRequest(req, true))
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	httpReq, err := p.newRequest(ctx, chatCompletionsPath, body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	// Execute request
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	// Check for error status
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, normalizeError(resp.StatusCode, respBody, requestID(resp.Header))
+	}
+
+	// Create channels
+	chunkCh := make(chan core.ChatChunk, 100)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *core.ChatResponse, 1)
+
+	// Start goroutine to process SSE stream
+	go p.processSSEStream(ctx, resp.Body, chunkCh, errCh, finalCh)
+
+	return &core.ChatStream{
+		Ch:    chunkCh,
+		Err:   errCh,
+		Final: finalCh,
+	}, nil
+}
+
+// processSSEStream reads the SSE stream and emits chunks.
+func (p *Mistral) processSSEStream(
+	ctx context.Context,
+	body io.ReadCloser,
+	chunkCh chan<- core.ChatChunk,
+	errCh chan<- error,
+	finalCh chan<- *core.ChatResponse,
+) {
+	defer body.Close()
+	defer close(chunkCh)
+	defer close(errCh)
+	defer close(finalCh)
+
+	reader := bufio.NewReader(body)
+	assembler := newToolCallAssembler()
+
+	var responseID string
+	var responseModel string
+	var usage *mistralUsage
+	var reasoning strings.Builder
+
+	for {
+		// Check for context cancellation
+		select {
+		case <-ctx.Done():
+			errCh <- ctx.Err()
+			return
+		default:
+		}
+
+		// Read line
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			errCh <- newNetworkError(err)
+			return
+		}
+
+		// Trim whitespace
+		line = strings.TrimSpace(line)
+
+		// Skip empty lines, comments, and non-data fields
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		payload := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+
+		// Check for done signal
+		if payload == "[DONE]" {
+			break
+		}
+
+		// Parse chunk
+		var chunk mistralStreamChunk
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			errCh <- newDecodeError(err)
+			return
+		}
+
+		// Capture metadata
+		if chunk.ID != "" {
+			responseID = chunk.ID
+		}
+		if chunk.Model != "" {
+			responseModel = chunk.Model
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+
+		// Process choices
+		for _, choice := range chunk.Choices {
+			text, thinking, err := parseContent(choice.Delta.Content)
+			if err != nil {
+				errCh <- newDecodeError(err)
+				return
+			}
+			reasoning.WriteString(thinking)
+
+			// Emit content delta
+			if text != "" {
+				select {
+				case chunkCh <- core.ChatChunk{Delta: text}:
+				case <-ctx.Done():
+					errCh <- ctx.Err()
+					return
+				}
+			}
+
+			// Accumulate tool calls
+			for _, tc := range choice.Delta.ToolCalls {
+				assembler.addFragment(tc)
+			}
+		}
+	}
+
+	// Finalize tool calls
+	toolCalls, err := assembler.finalize()
+	if err != nil {
+		errCh <- err
+		return
+	}
+
+	// Build final response
+	finalResp := &core.ChatResponse{
+		ID:        responseID,
+		Model:     core.ModelID(responseModel),
+		ToolCalls: toolCalls,
+	}
+
+	if reasoning.Len() > 0 {
+		finalResp.Reasoning = &core.ReasoningOutput{
+			Summary: []string{reasoning.String()},
+		}
+	}
+
+	if usage != nil {
+		finalResp.Usage = mapUsage(usage)
+	}
+
+	finalCh <- finalResp
+}
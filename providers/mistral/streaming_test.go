@@ -0,0 +1,102 @@
+package mistral
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers/providertest"
+)
+
+// sseResponse formats events as an SSE response body.
+func sseResponse(events ...string) string {
+	var sb strings.Builder
+	for _, e := range events {
+		sb.WriteString("data: ")
+		sb.WriteString(e)
+		sb.WriteString("\n\n")
+	}
+	return sb.String()
+}
+
+// streamChat serves body as a streaming response and collects the result.
+func streamChat(t *testing.T, body string) *providertest.StreamResult {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	p := New("test-key", WithBaseURL(server.URL))
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{Model: ModelMagistralMedium})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	res, err := providertest.CollectStream(stream, time.Second)
+	if err != nil {
+		t.Fatalf("CollectStream() error = %v", err)
+	}
+	if v := res.Violations(); len(v) > 0 {
+		t.Fatalf("contract violations: %v", v)
+	}
+	return res
+}
+
+func TestStreamThinkingChunks(t *testing.T) {
+	res := streamChat(t, sseResponse(
+		`{"id":"r1","model":"magistral-medium-latest","choices":[{"index":0,"delta":{"content":[{"type":"thinking","thinking":[{"type":"text","text":"Let me "}]}]}}]}`,
+		`{"id":"r1","model":"magistral-medium-latest","choices":[{"index":0,"delta":{"content":[{"type":"thinking","thinking":[{"type":"text","text":"think."}]}]}}]}`,
+		`{"id":"r1","model":"magistral-medium-latest","choices":[{"index":0,"delta":{"content":"42"}}]}`,
+		"[DONE]",
+	))
+
+	if res.Err() != nil {
+		t.Fatalf("stream error = %v", res.Err())
+	}
+	if res.Output() != "42" {
+		t.Errorf("Output() = %q, want 42", res.Output())
+	}
+	final := res.Final()
+	if final.Reasoning == nil || final.Reasoning.Summary[0] != "Let me think." {
+		t.Errorf("Reasoning = %+v", final.Reasoning)
+	}
+}
+
+func TestStreamWholeToolCallsWithoutIndex(t *testing.T) {
+	res := streamChat(t, sseResponse(
+		`{"id":"r2","model":"mistral-large-latest","choices":[{"index":0,"delta":{"tool_calls":[{"id":"a","function":{"name":"first","arguments":"{\"x\":1}"}},{"id":"b","function":{"name":"second","arguments":{"y":2}}}]}}]}`,
+		"[DONE]",
+	))
+
+	if res.Err() != nil {
+		t.Fatalf("stream error = %v", res.Err())
+	}
+	calls := res.Final().ToolCalls
+	if len(calls) != 2 {
+		t.Fatalf("len(ToolCalls) = %d, want 2", len(calls))
+	}
+	if calls[0].ID != "a" || string(calls[0].Arguments) != `{"x":1}` {
+		t.Errorf("ToolCalls[0] = %+v", calls[0])
+	}
+	if calls[1].ID != "b" || string(calls[1].Arguments) != `{"y":2}` {
+		t.Errorf("ToolCalls[1] = %+v", calls[1])
+	}
+}
+
+func TestStreamInvalidToolArgs(t *testing.T) {
+	res := streamChat(t, sseResponse(
+		`{"id":"r3","choices":[{"index":0,"delta":{"tool_calls":[{"id":"a","index":0,"function":{"name":"f","arguments":"{\"x\":"}}]}}]}`,
+		"[DONE]",
+	))
+
+	if !errors.Is(res.Err(), ErrToolArgsInvalidJSON) {
+		t.Errorf("expected ErrToolArgsInvalidJSON, got %v", res.Err())
+	}
+}
@@ -0,0 +1,119 @@
+package mistral
+
+import "encoding/json"
+
+// mistralRequest represents a request to the Mistral chat completions API.
+type mistralRequest struct {
+	Model          string                 `json:"model"`
+	Messages       []mistralMessage       `json:"messages"`
+	Temperature    *float32               `json:"temperature,omitempty"`
+	MaxTokens      *int                   `json:"max_tokens,omitempty"`
+	Stream         bool                   `json:"stream"`
+	Tools          []mistralTool          `json:"tools,omitempty"`
+	ToolChoice     any                    `json:"tool_choice,omitempty"`
+	ResponseFormat *mistralResponseFormat `json:"response_format,omitempty"`
+	PromptMode     string                 `json:"prompt_mode,omitempty"`
+}
+
+// mistralMessage represents a message in the Mistral format.
+type mistralMessage struct {
+	Role       string `json:"role"`
+	Content    string `json:"content"`
+	ToolCallID string `json:"tool_call_id,omitempty"`
+}
+
+// mistralResponseFormat specifies the response format.
+type mistralResponseFormat struct {
+	Type string `json:"type"` // "text" or "json_object"
+}
+
+// mistralTool represents a tool definition in the Mistral format.
+type mistralTool struct {
+	Type     string          `json:"type"`
+	Function mistralFunction `json:"function"`
+}
+
+// mistralFunction represents a function definition for Mistral tools.
+type mistralFunction struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Parameters  json.RawMessage `json:"parameters"`
+}
+
+// mistralResponse represents a response from the Mistral chat completions API.
+type mistralResponse struct {
+	ID      string          `json:"id"`
+	Object  string          `json:"object"`
+	Created int64           `json:"created"`
+	Model   string          `json:"model"`
+	Choices []mistralChoice `json:"choices"`
+	Usage   mistralUsage    `json:"usage"`
+}
+
+// mistralChoice represents a single choice in a Mistral response.
+type mistralChoice struct {
+	Index        int            `json:"index"`
+	Message      mistralRespMsg `json:"message"`
+	FinishReason string         `json:"finish_reason"`
+}
+
+// mistralRespMsg represents the assistant message in a response.
+// Content is a string, or an array of chunks for reasoning models.
+type mistralRespMsg struct {
+	Role      string            `json:"role"`
+	Content   json.RawMessage   `json:"content"`
+	ToolCalls []mistralToolCall `json:"tool_calls,omitempty"`
+}
+
+// mistralContentChunk is one element of an array-valued message content.
+type mistralContentChunk struct {
+	Type     string                `json:"type"` // "text" or "thinking"
+	Text     string                `json:"text,omitempty"`
+	Thinking []mistralContentChunk `json:"thinking,omitempty"`
+}
+
+// mistralToolCall represents a tool call in a Mistral response.
+type mistralToolCall struct {
+	ID       string              `json:"id"`
+	Type     string              `json:"type,omitempty"`
+	Index    *int                `json:"index,omitempty"`
+	Function mistralFunctionCall `json:"function"`
+}
+
+// mistralFunctionCall represents the function details in a tool call.
+// Arguments is normally a JSON-encoded string but may be an object.
+type mistralFunctionCall struct {
+	Name      string          `json:"name,omitempty"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// mistralUsage represents token usage in a Mistral response.
+type mistralUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// Streaming response types for Mistral SSE protocol.
+
+// mistralStreamChunk represents a single chunk in a Mistral streaming response.
+type mistralStreamChunk struct {
+	ID      string                `json:"id"`
+	Model   string                `json:"model"`
+	Choices []mistralStreamChoice `json:"choices"`
+	Usage   *mistralUsage         `json:"usage,omitempty"`
+}
+
+// mistralStreamChoice represents a single choice in a streaming chunk.
+type mistralStreamChoice struct {
+	Index        int                `json:"index"`
+	Delta        mistralStreamDelta `json:"delta"`
+	FinishReason *string            `json:"finish_reason,omitempty"`
+}
+
+// mistralStreamDelta represents the delta content in a streaming chunk.
+type mistralStreamDelta struct {
+	Role      string            `json:"role,omitempty"`
+	Content   json.RawMessage   `json:"content,omitempty"`
+	ToolCalls []mistralToolCall `json:"tool_calls,omitempty"`
+}
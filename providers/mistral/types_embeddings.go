@@ -0,0 +1,31 @@
+package mistral
+
+// mistralEmbeddingRequest is the request body for POST /v1/embeddings.
+type mistralEmbeddingRequest struct {
+	Model           string   `json:"model"`
+	Input           []string `json:"input"`
+	OutputDimension *int     `json:"output_dimension,omitempty"`
+	OutputDType     string   `json:"output_dtype,omitempty"`
+}
+
+// mistralEmbeddingResponse is the response from POST /v1/embeddings.
+type mistralEmbeddingResponse struct {
+	ID     string                 `json:"id"`
+	Object string                 `json:"object"`
+	Data   []mistralEmbeddingData `json:"data"`
+	Model  string                 `json:"model"`
+	Usage  mistralEmbeddingUsage  `json:"usage"`
+}
+
+// mistralEmbeddingData represents a single embedding in the response.
+type mistralEmbeddingData struct {
+	Object    string    `json:"object"`
+	Index     int       `json:"index"`
+	Embedding []float32 `json:"embedding"`
+}
+
+// mistralEmbeddingUsage contains token usage for the embedding request.
+type mistralEmbeddingUsage struct {
+	PromptTokens int `json:"prompt_tokens"`
+	TotalTokens  int `json:"total_tokens"`
+}
@@ -0,0 +1,22 @@
+package mistral
+
+// mistralModelList is the response from GET /models.
+type mistralModelList struct {
+	Data []mistralModel `json:"data"`
+}
+
+// mistralModel is a model entry from GET /models.
+type mistralModel struct {
+	ID           string                   `json:"id"`
+	Name         string                   `json:"name"`
+	Capabilities mistralModelCapabilities `json:"capabilities"`
+	Type         string                   `json:"type"`
+}
+
+// mistralModelCapabilities reports what a model supports.
+type mistralModelCapabilities struct {
+	CompletionChat  bool `json:"completion_chat"`
+	CompletionFIM   bool `json:"completion_fim"`
+	FunctionCalling bool `json:"function_calling"`
+	Vision          bool `json:"vision"`
+}
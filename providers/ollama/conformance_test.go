@@ -0,0 +1,67 @@
+package ollama
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers/providertest"
+)
+
+// ndjson joins payloads into a newline-delimited JSON body.
+func ndjson(lines ...string) string {
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func TestConformance(t *testing.T) {
+	nd := http.Header{"Content-Type": {"application/x-ndjson"}}
+
+	suite := providertest.Suite{
+		NewProvider: func(baseURL string) core.Provider {
+			return New(WithBaseURL(baseURL))
+		},
+		Model: "llama3.2",
+		Chat: &providertest.Fixture{
+			Body:   `{"model":"llama3.2","message":{"role":"assistant","content":"Hello there"},"done":true,"prompt_eval_count":5,"eval_count":2}`,
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		Stream: &providertest.Fixture{
+			Header: nd,
+			Body: ndjson(
+				`{"model":"llama3.2","message":{"role":"assistant","content":"Hello"},"done":false}`,
+				`{"model":"llama3.2","message":{"role":"assistant","content":" there"},"done":false}`,
+				`{"model":"llama3.2","message":{"role":"assistant","content":""},"done":true,"prompt_eval_count":5,"eval_count":2}`,
+			),
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		ToolCall: &providertest.Fixture{
+			Body: `{"model":"llama3.2","message":{"role":"assistant","content":"","tool_calls":[{"function":{"name":"get_weather","arguments":{"location": "NYC"}}}]},"done":true}`,
+			ToolCalls: []core.ToolCall{
+				{Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		StreamToolCall: &providertest.Fixture{
+			Header: nd,
+			Body: ndjson(
+				`{"model":"llama3.2","message":{"role":"assistant","content":"","tool_calls":[{"function":{"name":"get_weather","arguments":{"location": "NYC"}}}]},"done":false}`,
+				`{"model":"llama3.2","message":{"role":"assistant","content":""},"done":true}`,
+			),
+			ToolCalls: []core.ToolCall{
+				{Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		Cancel: &providertest.Fixture{
+			Header: nd,
+			Body:   ndjson(`{"model":"llama3.2","message":{"role":"assistant","content":"Hello"},"done":false}`),
+		},
+		ErrorBody: func(status int) string {
+			return `{"error":"request failed"}`
+		},
+	}
+
+	suite.Run(t)
+}
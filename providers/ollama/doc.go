@@ -38,6 +38,22 @@
 //   - Streaming responses
 //   - Tool/function calling (for supported models)
 //   - Thinking/reasoning mode (for supported models like qwen3)
+//   - Embeddings via /api/embed (for embedding models like nomic-embed-text)
+//
+// # Model Management
+//
+// The provider can list, inspect, pull, and delete local models:
+//
+//	err := provider.PullModel(ctx, "nomic-embed-text", func(p ollama.PullProgress) {
+//		fmt.Printf("%s %d/%d\n", p.Status, p.Completed, p.Total)
+//	})
+//
+//	installed, err := provider.ListLocalModels(ctx)
+//	running, err := provider.ListRunningModels(ctx)
+//
+// ListModels returns the installed models as core.ModelInfo with
+// capabilities from /api/show, caching the result. After it or
+// RefreshModels is called, Models reports the same list.
 //
 // # Models
 //
@@ -0,0 +1,67 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// CreateEmbeddings generates embeddings for the given input texts using /api/embed.
+// Ollama returns float vectors only, so EncodingFormat, InputType, and
+// OutputDType are ignored.
+func (p *Ollama) CreateEmbeddings(ctx context.Context, req *core.EmbeddingRequest) (*core.EmbeddingResponse, error) {
+	inputs := make([]string, len(req.Input))
+	for i, input := range req.Input {
+		inputs[i] = input.Text
+	}
+
+	resp, err := p.doJSON(ctx, http.MethodPost, "/api/embed", &ollamaEmbedRequest{
+		Model:      string(req.Model),
+		Input:      inputs,
+		Truncate:   req.Truncation,
+		Dimensions: req.Dimensions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var embedResp ollamaEmbedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&embedResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	vectors := make([]core.EmbeddingVector, len(embedResp.Embeddings))
+	for i, embedding := range embedResp.Embeddings {
+		vectors[i] = core.EmbeddingVector{
+			Index:  i,
+			Vector: embedding,
+		}
+
+		// Copy ID and Metadata from input
+		if i < len(req.Input) {
+			vectors[i].ID = req.Input[i].ID
+			vectors[i].Metadata = req.Input[i].Metadata
+		}
+	}
+
+	model := core.ModelID(embedResp.Model)
+	if model == "" {
+		model = req.Model
+	}
+
+	return &core.EmbeddingResponse{
+		Vectors: vectors,
+		Model:   model,
+		Usage: core.EmbeddingUsage{
+			PromptTokens: embedResp.PromptEvalCount,
+			TotalTokens:  embedResp.PromptEvalCount,
+		},
+	}, nil
+}
+
+// Compile-time check that Ollama implements EmbeddingProvider.
+var _ core.EmbeddingProvider = (*Ollama)(nil)
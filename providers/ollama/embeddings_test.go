@@ -0,0 +1,86 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// TestCreateEmbeddings tests embedding generation via /api/embed.
+func TestCreateEmbeddings(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				t.Errorf("Method = %q, want POST", r.Method)
+			}
+			if r.URL.Path != "/api/embed" {
+				t.Errorf("Path = %q, want /api/embed", r.URL.Path)
+			}
+
+			var req ollamaEmbedRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("Failed to decode request: %v", err)
+			}
+			if req.Model != "nomic-embed-text" {
+				t.Errorf("Model = %q, want nomic-embed-text", req.Model)
+			}
+			if len(req.Input) != 2 || req.Input[0] != "hello" {
+				t.Errorf("Input = %v", req.Input)
+			}
+			if req.Truncate == nil || *req.Truncate {
+				t.Errorf("Truncate = %v, want false", req.Truncate)
+			}
+
+			w.Write([]byte(`{"model":"nomic-embed-text","embeddings":[[0.1,0.2],[0.3,0.4]],"prompt_eval_count":6}`))
+		}))
+		defer server.Close()
+
+		truncate := false
+		p := New(WithBaseURL(server.URL))
+		resp, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+			Model:      "nomic-embed-text",
+			Input:      []core.EmbeddingInput{{Text: "hello", ID: "a"}, {Text: "world", ID: "b"}},
+			Truncation: &truncate,
+		})
+		if err != nil {
+			t.Fatalf("CreateEmbeddings() error = %v", err)
+		}
+
+		if len(resp.Vectors) != 2 {
+			t.Fatalf("len(Vectors) = %d, want 2", len(resp.Vectors))
+		}
+		if v := resp.Vectors[1]; v.Index != 1 || v.ID != "b" || v.Vector[0] != 0.3 {
+			t.Errorf("Vectors[1] = %+v", v)
+		}
+		if resp.Usage.PromptTokens != 6 {
+			t.Errorf("PromptTokens = %d, want 6", resp.Usage.PromptTokens)
+		}
+	})
+
+	t.Run("model not found", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"model \"missing\" not found, try pulling it first"}`))
+		}))
+		defer server.Close()
+
+		p := New(WithBaseURL(server.URL))
+		_, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+			Model: "missing",
+			Input: []core.EmbeddingInput{{Text: "hello"}},
+		})
+
+		var pErr *core.ProviderError
+		if !errors.As(err, &pErr) {
+			t.Fatalf("expected ProviderError, got %T", err)
+		}
+		if pErr.Code != "model_not_found" {
+			t.Errorf("Code = %q, want model_not_found", pErr.Code)
+		}
+	})
+}
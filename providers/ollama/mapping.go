@@ -163,9 +163,9 @@ func mapToolCalls(toolCalls []ollamaToolCall) []core.ToolCall {
 		// Ollama doesn't provide tool call IDs, generate one
 		callID := fmt.Sprintf("call_%d", i)
 
-		// Convert arguments map to JSON
-		argsJSON, err := json.Marshal(tc.Function.Arguments)
-		if err != nil {
+		// Preserve the raw arguments, defaulting to an empty object
+		argsJSON := tc.Function.Arguments
+		if len(argsJSON) == 0 || string(argsJSON) == "null" || !json.Valid(argsJSON) {
 			argsJSON = json.RawMessage(`{}`)
 		}
 
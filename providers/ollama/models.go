@@ -0,0 +1,304 @@
+package ollama
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// Model capabilities reported by /api/show.
+const (
+	CapabilityCompletion = "completion"
+	CapabilityTools      = "tools"
+	CapabilityThinking   = "thinking"
+	CapabilityEmbedding  = "embedding"
+	CapabilityVision     = "vision"
+	CapabilityInsert     = "insert"
+)
+
+// LocalModel is a model installed on the Ollama server.
+type LocalModel struct {
+	Name       string       `json:"name"`
+	Model      string       `json:"model"`
+	ModifiedAt time.Time    `json:"modified_at"`
+	Size       int64        `json:"size"`
+	Digest     string       `json:"digest"`
+	Details    ModelDetails `json:"details"`
+}
+
+// ModelDetails describes a model's format, family, and quantization.
+type ModelDetails struct {
+	ParentModel       string   `json:"parent_model,omitempty"`
+	Format            string   `json:"format,omitempty"`
+	Family            string   `json:"family,omitempty"`
+	Families          []string `json:"families,omitempty"`
+	ParameterSize     string   `json:"parameter_size,omitempty"`
+	QuantizationLevel string   `json:"quantization_level,omitempty"`
+}
+
+// ShowResponse contains a model's details and capabilities.
+type ShowResponse struct {
+	Modelfile    string         `json:"modelfile,omitempty"`
+	Parameters   string         `json:"parameters,omitempty"`
+	Template     string         `json:"template,omitempty"`
+	Details      ModelDetails   `json:"details"`
+	ModelInfo    map[string]any `json:"model_info,omitempty"`
+	Capabilities []string       `json:"capabilities,omitempty"`
+	ModifiedAt   time.Time      `json:"modified_at"`
+}
+
+// HasCapability reports whether the model has the given capability.
+func (s *ShowResponse) HasCapability(capability string) bool {
+	for _, c := range s.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// RunningModel is a model currently loaded into memory.
+type RunningModel struct {
+	Name      string       `json:"name"`
+	Model     string       `json:"model"`
+	Size      int64        `json:"size"`
+	SizeVRAM  int64        `json:"size_vram"`
+	Digest    string       `json:"digest"`
+	Details   ModelDetails `json:"details"`
+	ExpiresAt time.Time    `json:"expires_at"`
+}
+
+// PullProgress reports the progress of a model pull.
+// Total and Completed are set while a layer is downloading.
+type PullProgress struct {
+	Status    string `json:"status"`
+	Digest    string `json:"digest,omitempty"`
+	Total     int64  `json:"total,omitempty"`
+	Completed int64  `json:"completed,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// doJSON sends a request with a JSON body and returns the response.
+// Non-200 responses are converted to errors and their bodies closed.
+func (p *Ollama) doJSON(ctx context.Context, method, path string, payload any) (*http.Response, error) {
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, p.config.BaseURL+path, &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, &core.ProviderError{
+			Provider: "ollama",
+			Code:     "network_error",
+			Message:  err.Error(),
+			Err:      core.ErrNetwork,
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, parseErrorResponse(resp)
+	}
+
+	return resp, nil
+}
+
+// getJSON sends a request and decodes the JSON response into out.
+func (p *Ollama) getJSON(ctx context.Context, method, path string, payload, out any) error {
+	resp, err := p.doJSON(ctx, method, path, payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// ListLocalModels returns the models installed on the server using /api/tags.
+func (p *Ollama) ListLocalModels(ctx context.Context) ([]LocalModel, error) {
+	var tags ollamaTagsResponse
+	if err := p.getJSON(ctx, http.MethodGet, "/api/tags", nil, &tags); err != nil {
+		return nil, err
+	}
+	return tags.Models, nil
+}
+
+// ShowModel returns details and capabilities for a model using /api/show.
+func (p *Ollama) ShowModel(ctx context.Context, name string) (*ShowResponse, error) {
+	var show ShowResponse
+	if err := p.getJSON(ctx, http.MethodPost, "/api/show", &ollamaModelRequest{Model: name}, &show); err != nil {
+		return nil, err
+	}
+	return &show, nil
+}
+
+// ListRunningModels returns the models loaded into memory using /api/ps.
+func (p *Ollama) ListRunningModels(ctx context.Context) ([]RunningModel, error) {
+	var ps ollamaPSResponse
+	if err := p.getJSON(ctx, http.MethodGet, "/api/ps", nil, &ps); err != nil {
+		return nil, err
+	}
+	return ps.Models, nil
+}
+
+// DeleteModel removes a model from the server using /api/delete.
+func (p *Ollama) DeleteModel(ctx context.Context, name string) error {
+	resp, err := p.doJSON(ctx, http.MethodDelete, "/api/delete", &ollamaModelRequest{Model: name})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	p.forgetModel(name)
+	p.modelCache.Invalidate()
+	return nil
+}
+
+// PullModel downloads a model using /api/pull.
+// progress, if non-nil, is called for each progress update as it streams in.
+// PullModel returns when the pull completes, fails, or ctx is canceled.
+func (p *Ollama) PullModel(ctx context.Context, name string, progress func(PullProgress)) error {
+	stream := true
+	resp, err := p.doJSON(ctx, http.MethodPost, "/api/pull", &ollamaModelRequest{Model: name, Stream: &stream})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var update PullProgress
+		if err := json.Unmarshal(line, &update); err != nil {
+			return fmt.Errorf("failed to parse pull progress: %w", err)
+		}
+
+		// Check for inline error
+		if update.Error != "" {
+			return newStreamError(update.Error)
+		}
+
+		if progress != nil {
+			progress(update)
+		}
+		if update.Status == "success" {
+			p.modelCache.Invalidate()
+			return nil
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("stream read error: %w", err)
+	}
+	return newStreamError("pull ended before completing")
+}
+
+// ListModels returns the models installed on the server with capabilities
+// from /api/show, sorted by name. Results are cached for the configured
+// ModelCacheTTL; pulling or deleting a model through the provider clears
+// the cache.
+func (p *Ollama) ListModels(ctx context.Context) ([]core.ModelInfo, error) {
+	return p.modelCache.Get(ctx)
+}
+
+// RefreshModels replaces the model list returned by Models with the
+// models installed on the server and their capabilities, bypassing the
+// ListModels cache.
+func (p *Ollama) RefreshModels(ctx context.Context) error {
+	_, err := p.modelCache.Refresh(ctx)
+	return err
+}
+
+// fetchModels queries the installed models and their capabilities and
+// stores the result for Models.
+func (p *Ollama) fetchModels(ctx context.Context) ([]core.ModelInfo, error) {
+	installed, err := p.ListLocalModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]core.ModelInfo, 0, len(installed))
+	for _, m := range installed {
+		show, err := p.ShowModel(ctx, m.Name)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, core.ModelInfo{
+			ID:           core.ModelID(m.Name),
+			DisplayName:  m.Name,
+			Capabilities: mapCapabilities(show.Capabilities),
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+
+	p.mu.Lock()
+	p.installed = infos
+	p.mu.Unlock()
+	return infos, nil
+}
+
+// forgetModel drops a deleted model from the refreshed model list.
+func (p *Ollama) forgetModel(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, m := range p.installed {
+		if string(m.ID) == name {
+			p.installed = append(p.installed[:i:i], p.installed[i+1:]...)
+			return
+		}
+	}
+}
+
+// mapCapabilities converts Ollama capabilities to Iris features.
+func mapCapabilities(capabilities []string) []core.Feature {
+	var features []core.Feature
+	for _, c := range capabilities {
+		switch c {
+		case CapabilityCompletion:
+			features = append(features, core.FeatureChat, core.FeatureChatStreaming)
+		case CapabilityTools:
+			features = append(features, core.FeatureToolCalling)
+		case CapabilityThinking:
+			features = append(features, core.FeatureReasoning)
+		case CapabilityEmbedding:
+			features = append(features, core.FeatureEmbeddings)
+		}
+	}
+	return features
+}
+
+// Compile-time check that Ollama implements ModelLister.
+var _ core.ModelLister = (*Ollama)(nil)
@@ -0,0 +1,238 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// newModelServer serves a fake Ollama model management API.
+func newModelServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(modelHandler(t))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// modelHandler handles the fake Ollama model management API.
+func modelHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/tags":
+			w.Write([]byte(`{"models":[
+				{"name":"qwen3:8b","model":"qwen3:8b","size":5200000000,"digest":"abc","details":{"family":"qwen3","parameter_size":"8.2B","quantization_level":"Q4_K_M"}},
+				{"name":"nomic-embed-text:latest","model":"nomic-embed-text:latest","size":274000000,"digest":"def","details":{"family":"nomic-bert"}}
+			]}`))
+		case "POST /api/show":
+			var req ollamaModelRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			switch req.Model {
+			case "qwen3:8b":
+				w.Write([]byte(`{"details":{"family":"qwen3"},"capabilities":["completion","tools","thinking"]}`))
+			case "nomic-embed-text:latest":
+				w.Write([]byte(`{"details":{"family":"nomic-bert"},"capabilities":["embedding"]}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error":"model not found"}`))
+			}
+		case "GET /api/ps":
+			w.Write([]byte(`{"models":[{"name":"qwen3:8b","model":"qwen3:8b","size":6000000000,"size_vram":6000000000,"expires_at":"2026-01-01T00:05:00Z"}]}`))
+		case "DELETE /api/delete":
+			var req ollamaModelRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Model != "qwen3:8b" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error":"model not found"}`))
+			}
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+// TestListLocalModels tests listing installed models.
+func TestListLocalModels(t *testing.T) {
+	p := New(WithBaseURL(newModelServer(t).URL))
+
+	models, err := p.ListLocalModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListLocalModels() error = %v", err)
+	}
+	if len(models) != 2 {
+		t.Fatalf("len(models) = %d, want 2", len(models))
+	}
+	if models[0].Name != "qwen3:8b" || models[0].Details.QuantizationLevel != "Q4_K_M" {
+		t.Errorf("models[0] = %+v", models[0])
+	}
+}
+
+// TestShowModel tests fetching model details.
+func TestShowModel(t *testing.T) {
+	p := New(WithBaseURL(newModelServer(t).URL))
+
+	show, err := p.ShowModel(context.Background(), "qwen3:8b")
+	if err != nil {
+		t.Fatalf("ShowModel() error = %v", err)
+	}
+	if !show.HasCapability(CapabilityTools) || show.HasCapability(CapabilityEmbedding) {
+		t.Errorf("Capabilities = %v", show.Capabilities)
+	}
+
+	if _, err := p.ShowModel(context.Background(), "missing"); !errors.Is(err, core.ErrBadRequest) {
+		t.Errorf("ShowModel(missing) error = %v, want ErrBadRequest", err)
+	}
+}
+
+// TestListRunningModels tests listing loaded models.
+func TestListRunningModels(t *testing.T) {
+	p := New(WithBaseURL(newModelServer(t).URL))
+
+	running, err := p.ListRunningModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListRunningModels() error = %v", err)
+	}
+	if len(running) != 1 || running[0].SizeVRAM != 6000000000 || running[0].ExpiresAt.IsZero() {
+		t.Errorf("running = %+v", running)
+	}
+}
+
+// TestRefreshModels tests that Models reflects the server after a refresh.
+func TestRefreshModels(t *testing.T) {
+	p := New(WithBaseURL(newModelServer(t).URL))
+
+	if err := p.RefreshModels(context.Background()); err != nil {
+		t.Fatalf("RefreshModels() error = %v", err)
+	}
+
+	models := p.Models()
+	if len(models) != 2 {
+		t.Fatalf("len(Models()) = %d, want 2", len(models))
+	}
+	if models[0].ID != "nomic-embed-text:latest" || !models[0].HasCapability(core.FeatureEmbeddings) {
+		t.Errorf("models[0] = %+v", models[0])
+	}
+	if models[1].ID != "qwen3:8b" || !models[1].HasCapability(core.FeatureToolCalling) || !models[1].HasCapability(core.FeatureReasoning) {
+		t.Errorf("models[1] = %+v", models[1])
+	}
+
+	// Deleting a model removes it from the refreshed list
+	if err := p.DeleteModel(context.Background(), "qwen3:8b"); err != nil {
+		t.Fatalf("DeleteModel() error = %v", err)
+	}
+	if models := p.Models(); len(models) != 1 || models[0].ID != "nomic-embed-text:latest" {
+		t.Errorf("Models() after delete = %+v", models)
+	}
+
+	if err := p.DeleteModel(context.Background(), "missing"); err == nil {
+		t.Error("DeleteModel(missing) should fail")
+	}
+}
+
+// TestListModels tests that ListModels reports installed models with
+// capabilities and caches them until a model is deleted.
+func TestListModels(t *testing.T) {
+	var tagCalls int
+	handler := modelHandler(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/tags" {
+			tagCalls++
+		}
+		handler(w, r)
+	}))
+	defer server.Close()
+
+	var p core.ModelLister = New(WithBaseURL(server.URL))
+	models, err := p.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if len(models) != 2 || models[1].ID != "qwen3:8b" || !models[1].HasCapability(core.FeatureReasoning) {
+		t.Fatalf("ListModels() = %+v", models)
+	}
+
+	p.ListModels(context.Background())
+	if tagCalls != 1 {
+		t.Errorf("tag calls = %d, want 1 (cached)", tagCalls)
+	}
+
+	if err := p.(*Ollama).DeleteModel(context.Background(), "qwen3:8b"); err != nil {
+		t.Fatalf("DeleteModel() error = %v", err)
+	}
+	p.ListModels(context.Background())
+	if tagCalls != 2 {
+		t.Errorf("tag calls after delete = %d, want 2", tagCalls)
+	}
+}
+
+// TestPullModel tests pulling a model with streamed progress.
+func TestPullModel(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/pull" {
+				t.Errorf("Path = %q, want /api/pull", r.URL.Path)
+			}
+			var req ollamaModelRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Model != "llama3.2" || req.Stream == nil || !*req.Stream {
+				t.Errorf("req = %+v", req)
+			}
+
+			w.Write([]byte(`{"status":"pulling manifest"}
+{"status":"pulling abc","digest":"abc","total":100,"completed":40}
+{"status":"pulling abc","digest":"abc","total":100,"completed":100}
+{"status":"verifying sha256 digest"}
+{"status":"success"}
+`))
+		}))
+		defer server.Close()
+
+		p := New(WithBaseURL(server.URL))
+		var updates []PullProgress
+		err := p.PullModel(context.Background(), "llama3.2", func(pp PullProgress) {
+			updates = append(updates, pp)
+		})
+		if err != nil {
+			t.Fatalf("PullModel() error = %v", err)
+		}
+
+		if len(updates) != 5 {
+			t.Fatalf("len(updates) = %d, want 5", len(updates))
+		}
+		if updates[1].Completed != 40 || updates[1].Total != 100 {
+			t.Errorf("updates[1] = %+v", updates[1])
+		}
+	})
+
+	t.Run("inline error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"status":"pulling manifest"}
+{"error":"pull model manifest: file does not exist"}
+`))
+		}))
+		defer server.Close()
+
+		p := New(WithBaseURL(server.URL))
+		err := p.PullModel(context.Background(), "nope", nil)
+		if !errors.Is(err, core.ErrServer) {
+			t.Errorf("PullModel() error = %v, want ErrServer", err)
+		}
+	})
+
+	t.Run("incomplete", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"status":"pulling manifest"}` + "\n"))
+		}))
+		defer server.Close()
+
+		p := New(WithBaseURL(server.URL))
+		if err := p.PullModel(context.Background(), "llama3.2", nil); err == nil {
+			t.Error("PullModel() should fail when the stream ends before success")
+		}
+	})
+}
@@ -32,6 +32,10 @@ type Config struct {
 
 	// Timeout is the request timeout. Zero means no timeout.
 	Timeout time.Duration
+
+	// ModelCacheTTL is how long ListModels results are cached.
+	// Defaults to core.DefaultModelCacheTTL; zero or less disables caching.
+	ModelCacheTTL time.Duration
 }
 
 // Option is a function that configures the Ollama provider.
@@ -81,3 +85,11 @@ func WithTimeout(timeout time.Duration) Option {
 		c.Timeout = timeout
 	}
 }
+
+// WithModelCacheTTL sets how long ListModels results are cached.
+// A duration of zero or less disables caching.
+func WithModelCacheTTL(d time.Duration) Option {
+	return func(c *Config) {
+		c.ModelCacheTTL = d
+	}
+}
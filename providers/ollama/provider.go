@@ -3,6 +3,7 @@ package ollama
 import (
 	"context"
 	"net/http"
+	"sync"
 
 	"github.com/erikhoward/iris/core"
 )
@@ -11,6 +12,11 @@ import (
 // Ollama is safe for concurrent use.
 type Ollama struct {
 	config Config
+
+	mu        sync.RWMutex
+	installed []core.ModelInfo // set by ListModels and RefreshModels
+
+	modelCache *core.ModelCache // caches ListModels results
 }
 
 // New creates a new Ollama provider with the given options.
@@ -18,15 +24,18 @@ type Ollama struct {
 // For Ollama Cloud, use WithCloud() and WithAPIKey().
 func New(opts ...Option) *Ollama {
 	cfg := Config{
-		BaseURL:    DefaultLocalURL,
-		HTTPClient: http.DefaultClient,
+		BaseURL:       DefaultLocalURL,
+		HTTPClient:    http.DefaultClient,
+		ModelCacheTTL: core.DefaultModelCacheTTL,
 	}
 
 	for _, opt := range opts {
 		opt(&cfg)
 	}
 
-	return &Ollama{config: cfg}
+	p := &Ollama{config: cfg}
+	p.modelCache = core.NewModelCache(cfg.ModelCacheTTL, p.fetchModels)
+	return p
 }
 
 // ID returns the provider identifier.
@@ -34,9 +43,19 @@ func (p *Ollama) ID() string {
 	return "ollama"
 }
 
-// Models returns example models available through Ollama.
-// Note: Ollama models are dynamic - you can use any model you have pulled locally.
+// Models returns the models installed on the server as of the last
+// ListModels or RefreshModels call. Before the first refresh it returns common example
+// models, since any model that has been pulled locally can be used.
 func (p *Ollama) Models() []core.ModelInfo {
+	p.mu.RLock()
+	installed := p.installed
+	p.mu.RUnlock()
+	if installed != nil {
+		result := make([]core.ModelInfo, len(installed))
+		copy(result, installed)
+		return result
+	}
+
 	// Return common example models for documentation purposes
 	// Users can use any model they have pulled
 	return []core.ModelInfo{
@@ -54,7 +73,7 @@ func (p *Ollama) Models() []core.ModelInfo {
 // Supports reports whether the provider supports the given feature.
 func (p *Ollama) Supports(feature core.Feature) bool {
 	switch feature {
-	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureReasoning:
+	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureReasoning, core.FeatureEmbeddings, core.FeatureModelListing:
 		return true
 	default:
 		return false
@@ -110,6 +110,7 @@ func TestProviderSupports(t *testing.T) {
 		{core.FeatureChatStreaming, true},
 		{core.FeatureToolCalling, true},
 		{core.FeatureReasoning, true},
+		{core.FeatureEmbeddings, true},
 		{core.Feature("unknown"), false},
 	}
 
@@ -243,10 +244,8 @@ func TestChat(t *testing.T) {
 					ToolCalls: []ollamaToolCall{
 						{
 							Function: ollamaFunctionCall{
-								Name: "get_weather",
-								Arguments: map[string]interface{}{
-									"city": "Tokyo",
-								},
+								Name:      "get_weather",
+								Arguments: json.RawMessage(`{"city":"Tokyo"}`),
 							},
 						},
 					},
@@ -616,7 +615,7 @@ func TestMapResponse(t *testing.T) {
 			Message: ollamaMessage{
 				Role: "assistant",
 				ToolCalls: []ollamaToolCall{
-					{Function: ollamaFunctionCall{Name: "weather", Arguments: map[string]interface{}{"city": "NYC"}}},
+					{Function: ollamaFunctionCall{Name: "weather", Arguments: json.RawMessage(`{"city":"NYC"}`)}},
 				},
 			},
 			Done: true,
@@ -660,8 +659,8 @@ func TestMapResponse(t *testing.T) {
 // TestMapToolCalls tests tool call mapping.
 func TestMapToolCalls(t *testing.T) {
 	calls := []ollamaToolCall{
-		{Function: ollamaFunctionCall{Name: "func1", Arguments: map[string]interface{}{"a": "1"}}},
-		{Function: ollamaFunctionCall{Name: "func2", Arguments: map[string]interface{}{"b": "2"}}},
+		{Function: ollamaFunctionCall{Name: "func1", Arguments: json.RawMessage(`{"a":"1"}`)}},
+		{Function: ollamaFunctionCall{Name: "func2", Arguments: json.RawMessage(`{"b":"2"}`)}},
 	}
 
 	result := mapToolCalls(calls)
@@ -58,7 +58,7 @@ func (p *Ollama) doStreamChat(ctx context.Context, req *core.ChatRequest) (*core
 	finalCh := make(chan *core.ChatResponse, 1)
 
 	// Start goroutine to read stream
-	go p.processNDJSONStream(ctx, resp, chunkCh, errCh, finalCh)
+	go p.processNDJSONStream(ctx, resp, req.Model, chunkCh, errCh, finalCh)
 
 	return &core.ChatStream{
 		Ch:    chunkCh,
@@ -71,6 +71,7 @@ func (p *Ollama) doStreamChat(ctx context.Context, req *core.ChatRequest) (*core
 func (p *Ollama) processNDJSONStream(
 	ctx context.Context,
 	resp *http.Response,
+	model core.ModelID,
 	chunkCh chan<- core.ChatChunk,
 	errCh chan<- error,
 	finalCh chan<- *core.ChatResponse,
@@ -149,8 +150,19 @@ func (p *Ollama) processNDJSONStream(
 		return
 	}
 
-	// Send final response
-	if finalResp != nil {
-		finalCh <- mapResponse(finalResp)
+	// The stream ended without a done marker; report what was received
+	if finalResp == nil {
+		finalResp = &ollamaResponse{
+			Model: string(model),
+			Message: ollamaMessage{
+				Role:      "assistant",
+				Content:   accumulatedContent,
+				Thinking:  accumulatedThinking,
+				ToolCalls: accumulatedToolCalls,
+			},
+		}
 	}
+
+	// Send final response
+	finalCh <- mapResponse(finalResp)
 }
@@ -1,5 +1,7 @@
 package ollama
 
+import "encoding/json"
+
 // ollamaRequest is the request body for the Ollama chat API.
 type ollamaRequest struct {
 	Model     string          `json:"model"`
@@ -55,8 +57,8 @@ type ollamaToolCall struct {
 
 // ollamaFunctionCall contains the function name and arguments.
 type ollamaFunctionCall struct {
-	Name      string                 `json:"name"`
-	Arguments map[string]interface{} `json:"arguments"`
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
 }
 
 // ollamaTool represents a tool definition for the Ollama API.
@@ -76,3 +78,34 @@ type ollamaToolFunction struct {
 type ollamaErrorResponse struct {
 	Error string `json:"error"`
 }
+
+// ollamaEmbedRequest is the request body for the Ollama embed API.
+type ollamaEmbedRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Truncate   *bool    `json:"truncate,omitempty"`
+	Dimensions *int     `json:"dimensions,omitempty"`
+}
+
+// ollamaEmbedResponse is the response from the Ollama embed API.
+type ollamaEmbedResponse struct {
+	Model           string      `json:"model"`
+	Embeddings      [][]float32 `json:"embeddings"`
+	PromptEvalCount int         `json:"prompt_eval_count,omitempty"`
+}
+
+// ollamaModelRequest names a model for the show, pull, and delete APIs.
+type ollamaModelRequest struct {
+	Model  string `json:"model"`
+	Stream *bool  `json:"stream,omitempty"`
+}
+
+// ollamaTagsResponse is the response from the Ollama tags API.
+type ollamaTagsResponse struct {
+	Models []LocalModel `json:"models"`
+}
+
+// ollamaPSResponse is the response from the Ollama ps API.
+type ollamaPSResponse struct {
+	Models []RunningModel `json:"models"`
+}
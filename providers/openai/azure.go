@@ -0,0 +1,239 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// Azure is an LLM provider implementation for Azure OpenAI.
+// It shares request mapping and stream handling with OpenAI, but addresses
+// models through deployments, sends the api-version query parameter, and
+// reports content filter rejections as ContentFilterError.
+// Azure is safe for concurrent use.
+type Azure struct {
+	client *OpenAI
+}
+
+// NewAzure creates a new Azure OpenAI provider for the given resource
+// endpoint, key, and options.
+//
+// Requests for a model are sent to the deployment of the same name unless
+// mapped otherwise with WithAzureDeployment:
+//
+//	provider := openai.NewAzure("https://my-resource.openai.azure.com", key,
+//		openai.WithAzureDeployment(openai.ModelGPT4o, "gpt4o-prod"),
+//	)
+func NewAzure(endpoint, apiKey string, opts ...AzureOption) *Azure {
+	cfg := AzureConfig{
+		Endpoint:   endpoint,
+		APIKey:     apiKey,
+		APIVersion: DefaultAzureAPIVersion,
+		AuthStyle:  AzureAuthAPIKey,
+		HTTPClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &Azure{
+		client: &OpenAI{
+			config: Config{
+				APIKey:     cfg.APIKey,
+				HTTPClient: cfg.HTTPClient,
+				Headers:    cfg.Headers,
+				Timeout:    cfg.Timeout,
+			},
+			azure: &cfg,
+		},
+	}
+}
+
+// ID returns the provider identifier.
+func (a *Azure) ID() string {
+	return azureProviderID
+}
+
+// Models returns the models with a configured deployment. Known OpenAI
+// models report their capabilities; other deployments list none, so
+// requests to them are checked against Supports instead.
+func (a *Azure) Models() []core.ModelInfo {
+	ids := make([]string, 0, len(a.client.azure.Deployments))
+	for id := range a.client.azure.Deployments {
+		ids = append(ids, string(id))
+	}
+	sort.Strings(ids)
+
+	result := make([]core.ModelInfo, 0, len(ids))
+	for _, id := range ids {
+		info := core.ModelInfo{ID: core.ModelID(id), DisplayName: id}
+		if known := GetModelInfo(core.ModelID(id)); known != nil {
+			info = *known
+		}
+		if a.client.azure.ResponsesAPI {
+			info.APIEndpoint = core.APIEndpointResponses
+		}
+		result = append(result, info)
+	}
+	return result
+}
+
+// Supports reports whether the provider supports the given feature on
+// some deployment. Reasoning, built-in tools, and response chaining are
+// available on deployments of models served by the Responses API.
+func (a *Azure) Supports(feature core.Feature) bool {
+	switch feature {
+	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureEmbeddings,
+		core.FeatureReasoning, core.FeatureBuiltInTools, core.FeatureResponseChain:
+		return true
+	default:
+		return false
+	}
+}
+
+// Chat sends a non-streaming chat request.
+// Routes to either the Chat Completions API or Responses API based on the model.
+func (a *Azure) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	var (
+		resp *core.ChatResponse
+		err  error
+	)
+	if a.useResponsesAPI(req.Model) {
+		resp, err = a.client.doResponsesChat(ctx, a.toDeployment(req))
+	} else {
+		resp, err = a.client.doChat(ctx, a.toDeployment(req))
+	}
+	if err != nil {
+		return nil, relabelAzureError(err)
+	}
+	return resp, nil
+}
+
+// StreamChat sends a streaming chat request.
+// Routes to either the Chat Completions API or Responses API based on the model.
+func (a *Azure) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	var (
+		stream *core.ChatStream
+		err    error
+	)
+	if a.useResponsesAPI(req.Model) {
+		stream, err = a.client.doResponsesStreamChat(ctx, a.toDeployment(req))
+	} else {
+		stream, err = a.client.doStreamChat(ctx, a.toDeployment(req))
+	}
+	if err != nil {
+		return nil, relabelAzureError(err)
+	}
+	return relabelAzureStream(ctx, stream), nil
+}
+
+// CreateEmbeddings generates embeddings for the given input texts.
+func (a *Azure) CreateEmbeddings(ctx context.Context, req *core.EmbeddingRequest) (*core.EmbeddingResponse, error) {
+	deployed := *req
+	deployed.Model = core.ModelID(a.client.azure.deployment(req.Model))
+
+	resp, err := a.client.CreateEmbeddings(ctx, &deployed)
+	if err != nil {
+		return nil, relabelAzureError(err)
+	}
+	return resp, nil
+}
+
+// RetrieveResponse returns a stored response in its current state.
+func (a *Azure) RetrieveResponse(ctx context.Context, responseID string) (*core.ChatResponse, error) {
+	resp, err := a.client.RetrieveResponse(ctx, responseID)
+	return resp, relabelAzureError(err)
+}
+
+// WaitForResponse polls a background response until it is no longer queued
+// or in progress. See OpenAI.WaitForResponse.
+func (a *Azure) WaitForResponse(ctx context.Context, responseID string, interval time.Duration) (*core.ChatResponse, error) {
+	resp, err := a.client.WaitForResponse(ctx, responseID, interval)
+	return resp, relabelAzureError(err)
+}
+
+// CancelResponse cancels a background response.
+func (a *Azure) CancelResponse(ctx context.Context, responseID string) (*core.ChatResponse, error) {
+	resp, err := a.client.CancelResponse(ctx, responseID)
+	return resp, relabelAzureError(err)
+}
+
+// DeleteResponse deletes a stored response.
+func (a *Azure) DeleteResponse(ctx context.Context, responseID string) error {
+	return relabelAzureError(a.client.DeleteResponse(ctx, responseID))
+}
+
+// ListResponseInputItems returns the input items of a stored response.
+func (a *Azure) ListResponseInputItems(ctx context.Context, responseID string, req *ResponseInputItemsRequest) (*ResponseInputItemsResponse, error) {
+	list, err := a.client.ListResponseInputItems(ctx, responseID, req)
+	return list, relabelAzureError(err)
+}
+
+// StreamResponse streams a background response, starting after the event
+// with the given sequence number. See OpenAI.StreamResponse.
+func (a *Azure) StreamResponse(ctx context.Context, responseID string, startingAfter int) (*core.ChatStream, error) {
+	stream, err := a.client.StreamResponse(ctx, responseID, startingAfter)
+	if err != nil {
+		return nil, relabelAzureError(err)
+	}
+	return relabelAzureStream(ctx, stream), nil
+}
+
+// useResponsesAPI determines if a model should use the Responses API.
+func (a *Azure) useResponsesAPI(model core.ModelID) bool {
+	return a.client.azure.ResponsesAPI || a.client.shouldUseResponsesAPI(model)
+}
+
+// toDeployment returns a copy of req addressed to the model's deployment.
+func (a *Azure) toDeployment(req *core.ChatRequest) *core.ChatRequest {
+	deployed := *req
+	deployed.Model = core.ModelID(a.client.azure.deployment(req.Model))
+	return &deployed
+}
+
+// relabelAzureStream forwards a stream, attributing its error to Azure.
+// Chunks, the error, and the final response keep their order, and channels
+// are closed in the same order as the source so non-blocking reads of Err
+// and Final after Ch closes still observe them.
+func relabelAzureStream(ctx context.Context, src *core.ChatStream) *core.ChatStream {
+	chunkCh := make(chan core.ChatChunk, 100)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *core.ChatResponse, 1)
+
+	go func() {
+		defer close(chunkCh)
+		defer close(errCh)
+		defer close(finalCh)
+
+		for chunk := range src.Ch {
+			select {
+			case chunkCh <- chunk:
+			case <-ctx.Done():
+				// Keep draining; the source reports the cancellation
+			}
+		}
+
+		if err, ok := <-src.Err; ok && err != nil {
+			errCh <- relabelAzureError(err)
+		}
+		if final, ok := <-src.Final; ok && final != nil {
+			finalCh <- final
+		}
+	}()
+
+	return &core.ChatStream{
+		Ch:    chunkCh,
+		Err:   errCh,
+		Final: finalCh,
+	}
+}
+
+// Compile-time check that Azure implements Provider.
+var _ core.Provider = (*Azure)(nil)
+
+// Compile-time check that Azure implements EmbeddingProvider.
+var _ core.EmbeddingProvider = (*Azure)(nil)
@@ -0,0 +1,128 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// ErrContentFiltered is returned when Azure's content filter blocks a prompt
+// or completion. It wraps core.ErrBadRequest.
+var ErrContentFiltered = fmt.Errorf("content filtered: %w", core.ErrBadRequest)
+
+// ContentFilterResult is the verdict of one content filter category.
+type ContentFilterResult struct {
+	Filtered bool   `json:"filtered"`
+	Severity string `json:"severity,omitempty"`
+	Detected bool   `json:"detected,omitempty"`
+}
+
+// ContentFilterError is returned when Azure's content filter rejects a request.
+// It unwraps to a ProviderError whose Err is ErrContentFiltered.
+type ContentFilterError struct {
+	// Err is the underlying provider error.
+	Err *core.ProviderError
+
+	// Results holds the verdict per category, e.g. "hate", "violence", "jailbreak".
+	Results map[string]ContentFilterResult
+}
+
+// Error implements the error interface.
+func (e *ContentFilterError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying ProviderError.
+func (e *ContentFilterError) Unwrap() error {
+	return e.Err
+}
+
+// azureProviderID is the provider identifier for Azure OpenAI.
+const azureProviderID = "azure"
+
+// azureErrorResponse represents an error response from Azure OpenAI.
+type azureErrorResponse struct {
+	Error struct {
+		Message    string          `json:"message"`
+		Type       string          `json:"type"`
+		Code       json.RawMessage `json:"code"`
+		InnerError struct {
+			Code                string                         `json:"code"`
+			ContentFilterResult map[string]ContentFilterResult `json:"content_filter_result"`
+		} `json:"innererror"`
+	} `json:"error"`
+}
+
+// normalizeAzureError converts an Azure error response to a ProviderError,
+// or to a ContentFilterError when the content filter rejected the request.
+func normalizeAzureError(status int, body []byte, requestID string) error {
+	var errResp azureErrorResponse
+	_ = json.Unmarshal(body, &errResp)
+
+	message := errResp.Error.Message
+	if message == "" {
+		message = http.StatusText(status)
+	}
+
+	// Codes are usually strings, but some gateways send numbers
+	var code string
+	if json.Unmarshal(errResp.Error.Code, &code) != nil {
+		code = string(errResp.Error.Code)
+	}
+	if code == "" {
+		code = errResp.Error.Type
+	}
+
+	// Determine sentinel error based on status
+	var sentinel error
+	switch {
+	case status == http.StatusBadRequest:
+		sentinel = core.ErrBadRequest
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		sentinel = core.ErrUnauthorized
+	case status == http.StatusNotFound:
+		sentinel = core.ErrNotFound
+	case status == http.StatusTooManyRequests:
+		sentinel = core.ErrRateLimited
+	case status >= 500:
+		sentinel = core.ErrServer
+	default:
+		sentinel = core.ErrServer
+	}
+
+	filtered := code == "content_filter" || errResp.Error.InnerError.Code == "ResponsibleAIPolicyViolation"
+	if filtered {
+		sentinel = ErrContentFiltered
+	}
+
+	pErr := &core.ProviderError{
+		Provider:  azureProviderID,
+		Status:    status,
+		RequestID: requestID,
+		Code:      code,
+		Message:   message,
+		Err:       sentinel,
+	}
+
+	if filtered {
+		return &ContentFilterError{
+			Err:     pErr,
+			Results: errResp.Error.InnerError.ContentFilterResult,
+		}
+	}
+	return pErr
+}
+
+// relabelAzureError attributes errors created by shared OpenAI code to Azure.
+func relabelAzureError(err error) error {
+	var pErr *core.ProviderError
+	if errors.As(err, &pErr) && pErr.Provider != azureProviderID {
+		relabeled := *pErr
+		relabeled.Provider = azureProviderID
+		return &relabeled
+	}
+	return err
+}
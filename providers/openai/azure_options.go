@@ -0,0 +1,136 @@
+package openai
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// DefaultAzureAPIVersion is the default Azure OpenAI api-version.
+// It is the first version that serves both Chat Completions and the Responses API.
+const DefaultAzureAPIVersion = "2025-04-01-preview"
+
+// AzureAuthStyle determines how credentials are sent to Azure OpenAI.
+type AzureAuthStyle string
+
+const (
+	// AzureAuthAPIKey sends a resource key in the api-key header (default).
+	AzureAuthAPIKey AzureAuthStyle = "api-key"
+
+	// AzureAuthBearer sends a Microsoft Entra ID token as a bearer token.
+	AzureAuthBearer AzureAuthStyle = "bearer"
+)
+
+// AzureConfig holds configuration for the Azure OpenAI provider.
+type AzureConfig struct {
+	// Endpoint is the resource endpoint, e.g. https://my-resource.openai.azure.com (required).
+	Endpoint string
+
+	// APIKey is the resource key or Entra ID token (required).
+	APIKey string
+
+	// APIVersion is the api-version query parameter. Defaults to DefaultAzureAPIVersion.
+	APIVersion string
+
+	// AuthStyle determines how APIKey is sent. Defaults to AzureAuthAPIKey.
+	AuthStyle AzureAuthStyle
+
+	// Deployments maps model IDs to deployment names.
+	// Models without an entry are assumed to be deployed under their own ID.
+	Deployments map[core.ModelID]string
+
+	// ResponsesAPI sends every chat request to the Responses API.
+	// Otherwise only models the openai package routes there use it.
+	ResponsesAPI bool
+
+	// HTTPClient is the HTTP client to use. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Headers contains optional extra headers to include in requests.
+	Headers http.Header
+
+	// Timeout is the optional request timeout.
+	Timeout time.Duration
+}
+
+// deployment returns the deployment name for a model.
+func (c *AzureConfig) deployment(model core.ModelID) string {
+	if d, ok := c.Deployments[model]; ok {
+		return d
+	}
+	return string(model)
+}
+
+// url builds a request URL for an API path.
+// Chat Completions and embeddings are addressed through the deployment;
+// the Responses API takes the deployment as the model in the request body.
+func (c *AzureConfig) url(path string, deployment core.ModelID) string {
+	base := strings.TrimRight(c.Endpoint, "/") + "/openai"
+	// Responses are not addressed by deployment
+	if path != responsesPath && !strings.HasPrefix(path, responsesPath+"/") {
+		base += "/deployments/" + url.PathEscape(string(deployment))
+	}
+	return base + path + "?api-version=" + url.QueryEscape(c.APIVersion)
+}
+
+// AzureOption configures the Azure OpenAI provider.
+type AzureOption func(*AzureConfig)
+
+// WithAzureAPIVersion sets the api-version query parameter.
+func WithAzureAPIVersion(version string) AzureOption {
+	return func(c *AzureConfig) {
+		c.APIVersion = version
+	}
+}
+
+// WithAzureDeployment maps a model ID to the name of its deployment.
+func WithAzureDeployment(model core.ModelID, deployment string) AzureOption {
+	return func(c *AzureConfig) {
+		if c.Deployments == nil {
+			c.Deployments = make(map[core.ModelID]string)
+		}
+		c.Deployments[model] = deployment
+	}
+}
+
+// WithAzureBearerAuth sends the key as a bearer token, for Microsoft Entra ID
+// access tokens, instead of in the api-key header.
+func WithAzureBearerAuth() AzureOption {
+	return func(c *AzureConfig) {
+		c.AuthStyle = AzureAuthBearer
+	}
+}
+
+// WithAzureResponsesAPI sends every chat request to the Responses API.
+func WithAzureResponsesAPI() AzureOption {
+	return func(c *AzureConfig) {
+		c.ResponsesAPI = true
+	}
+}
+
+// WithAzureHTTPClient sets a custom HTTP client.
+func WithAzureHTTPClient(client *http.Client) AzureOption {
+	return func(c *AzureConfig) {
+		c.HTTPClient = client
+	}
+}
+
+// WithAzureHeader adds an extra header to include in requests.
+func WithAzureHeader(key, value string) AzureOption {
+	return func(c *AzureConfig) {
+		if c.Headers == nil {
+			c.Headers = make(http.Header)
+		}
+		c.Headers.Set(key, value)
+	}
+}
+
+// WithAzureTimeout sets the request timeout.
+func WithAzureTimeout(d time.Duration) AzureOption {
+	return func(c *AzureConfig) {
+		c.Timeout = d
+	}
+}
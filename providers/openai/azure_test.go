@@ -0,0 +1,372 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers/providertest"
+)
+
+func TestAzureConformance(t *testing.T) {
+	sse := http.Header{"Content-Type": {"text/event-stream"}}
+
+	suite := providertest.Suite{
+		NewProvider: func(baseURL string) core.Provider {
+			return NewAzure(baseURL, "test-key")
+		},
+		Model: "gpt-4o",
+		Chat: &providertest.Fixture{
+			Body:   `{"id":"chatcmpl-1","model":"gpt-4o","choices":[{"index":0,"message":{"role":"assistant","content":"Hello there"},"finish_reason":"stop"}],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`,
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		Stream: &providertest.Fixture{
+			Header: sse,
+			Body: sseResponse(
+				`{"id":"","model":"","choices":[],"prompt_filter_results":[{"prompt_index":0,"content_filter_results":{}}]}`,
+				`{"id":"chatcmpl-2","model":"gpt-4o","choices":[{"index":0,"delta":{"content":"Hello"}}]}`,
+				`{"id":"chatcmpl-2","model":"gpt-4o","choices":[{"index":0,"delta":{"content":" there"}}],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`,
+				"[DONE]",
+			),
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		ToolCall: &providertest.Fixture{
+			Body: `{"id":"chatcmpl-3","model":"gpt-4o","choices":[{"index":0,"message":{"role":"assistant","content":"","tool_calls":[{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"location\": \"NYC\"}"}}]},"finish_reason":"tool_calls"}]}`,
+			ToolCalls: []core.ToolCall{
+				{ID: "call_1", Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		StreamToolCall: &providertest.Fixture{
+			Header: sse,
+			Body: sseResponse(
+				`{"id":"chatcmpl-4","model":"gpt-4o","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_2","type":"function","function":{"name":"get_weather","arguments":"{\"location\":"}}]}}]}`,
+				`{"id":"chatcmpl-4","model":"gpt-4o","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":" \"NYC\"}"}}]}}]}`,
+				"[DONE]",
+			),
+			ToolCalls: []core.ToolCall{
+				{ID: "call_2", Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		Cancel: &providertest.Fixture{
+			Header: sse,
+			Body:   sseResponse(`{"id":"chatcmpl-5","model":"gpt-4o","choices":[{"index":0,"delta":{"content":"Hello"}}]}`),
+		},
+		ErrorBody: func(status int) string {
+			return `{"error":{"message":"request failed","code":"test_code"}}`
+		},
+	}
+
+	suite.Run(t)
+}
+
+func TestAzureChatURLAndAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/openai/deployments/gpt4o-prod/chat/completions" {
+			t.Errorf("Path = %q, want deployment path", r.URL.Path)
+		}
+		if v := r.URL.Query().Get("api-version"); v != "2024-10-21" {
+			t.Errorf("api-version = %q, want %q", v, "2024-10-21")
+		}
+		if r.Header.Get("api-key") != "test-key" {
+			t.Errorf("api-key = %q, want %q", r.Header.Get("api-key"), "test-key")
+		}
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("Authorization = %q, want empty", r.Header.Get("Authorization"))
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var req openAIRequest
+		json.Unmarshal(body, &req)
+		if req.Model != "gpt4o-prod" {
+			t.Errorf("model = %q, want deployment name", req.Model)
+		}
+
+		w.Write([]byte(`{"id":"chatcmpl-1","model":"gpt-4o","choices":[{"message":{"role":"assistant","content":"hi"}}]}`))
+	}))
+	defer server.Close()
+
+	p := NewAzure(server.URL+"/", "test-key",
+		WithAzureAPIVersion("2024-10-21"),
+		WithAzureDeployment(ModelGPT4o, "gpt4o-prod"),
+	)
+
+	resp, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:    ModelGPT4o,
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Hello"}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.Output != "hi" {
+		t.Errorf("Output = %q, want %q", resp.Output, "hi")
+	}
+}
+
+func TestAzureBearerAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer entra-token" {
+			t.Errorf("Authorization = %q, want bearer token", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("api-key") != "" {
+			t.Errorf("api-key = %q, want empty", r.Header.Get("api-key"))
+		}
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"hi"}}]}`))
+	}))
+	defer server.Close()
+
+	p := NewAzure(server.URL, "entra-token", WithAzureBearerAuth())
+	if _, err := p.Chat(context.Background(), &core.ChatRequest{Model: "my-deployment"}); err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+}
+
+func TestAzureResponsesAPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/openai/responses" {
+			t.Errorf("Path = %q, want /openai/responses", r.URL.Path)
+		}
+		if r.URL.Query().Get("api-version") != DefaultAzureAPIVersion {
+			t.Errorf("api-version = %q, want default", r.URL.Query().Get("api-version"))
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var req responsesRequest
+		json.Unmarshal(body, &req)
+		if req.Model != "gpt5-eastus" {
+			t.Errorf("model = %q, want deployment name", req.Model)
+		}
+
+		json.NewEncoder(w).Encode(responsesResponse{
+			ID:         "resp-1",
+			Model:      "gpt-5",
+			Status:     "completed",
+			OutputText: "done",
+		})
+	}))
+	defer server.Close()
+
+	p := NewAzure(server.URL, "test-key", WithAzureDeployment(ModelGPT5, "gpt5-eastus"))
+	resp, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:    ModelGPT5,
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Hello"}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.Output != "done" {
+		t.Errorf("Output = %q, want %q", resp.Output, "done")
+	}
+}
+
+func TestAzureCancelResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/openai/responses/resp_1/cancel" {
+			t.Errorf("Path = %q, want /openai/responses/resp_1/cancel", r.URL.Path)
+		}
+		if r.URL.Query().Get("api-version") != DefaultAzureAPIVersion {
+			t.Errorf("api-version = %q, want default", r.URL.Query().Get("api-version"))
+		}
+		json.NewEncoder(w).Encode(responsesResponse{ID: "resp_1", Status: ResponseStatusCancelled})
+	}))
+	defer server.Close()
+
+	p := NewAzure(server.URL, "test-key")
+	if _, err := p.CancelResponse(context.Background(), "resp_1"); err != nil {
+		t.Fatalf("CancelResponse() error = %v", err)
+	}
+}
+
+func TestAzureForcedResponsesAPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/openai/responses" {
+			t.Errorf("Path = %q, want /openai/responses", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(responsesResponse{ID: "resp-1", Status: "completed", OutputText: "ok"})
+	}))
+	defer server.Close()
+
+	p := NewAzure(server.URL, "test-key", WithAzureResponsesAPI())
+	if _, err := p.Chat(context.Background(), &core.ChatRequest{Model: "custom-deployment"}); err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+}
+
+func TestAzureEmbeddings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/openai/deployments/embed-small/embeddings" {
+			t.Errorf("Path = %q, want deployment embeddings path", r.URL.Path)
+		}
+		w.Write([]byte(`{"data":[{"index":0,"embedding":[0.1,0.2]}],"model":"text-embedding-3-small","usage":{"prompt_tokens":2,"total_tokens":2}}`))
+	}))
+	defer server.Close()
+
+	p := NewAzure(server.URL, "test-key", WithAzureDeployment("text-embedding-3-small", "embed-small"))
+	resp, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+		Model: "text-embedding-3-small",
+		Input: []core.EmbeddingInput{{Text: "hello"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateEmbeddings() error = %v", err)
+	}
+	if len(resp.Vectors) != 1 || len(resp.Vectors[0].Vector) != 2 {
+		t.Errorf("Vectors = %+v", resp.Vectors)
+	}
+}
+
+func TestAzureContentFilterError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-request-id", "req-cf")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"message":"The response was filtered","param":"prompt","code":"content_filter","status":400,
+			"innererror":{"code":"ResponsibleAIPolicyViolation","content_filter_result":{
+				"hate":{"filtered":false,"severity":"safe"},
+				"violence":{"filtered":true,"severity":"high"},
+				"jailbreak":{"filtered":false,"detected":false}}}}}`))
+	}))
+	defer server.Close()
+
+	p := NewAzure(server.URL, "test-key")
+	_, err := p.Chat(context.Background(), &core.ChatRequest{Model: "gpt-4o"})
+
+	var cfErr *ContentFilterError
+	if !errors.As(err, &cfErr) {
+		t.Fatalf("expected ContentFilterError, got %T: %v", err, err)
+	}
+	if !cfErr.Results["violence"].Filtered || cfErr.Results["violence"].Severity != "high" {
+		t.Errorf("Results[violence] = %+v", cfErr.Results["violence"])
+	}
+	if cfErr.Results["hate"].Filtered {
+		t.Error("Results[hate].Filtered = true, want false")
+	}
+	if !errors.Is(err, ErrContentFiltered) {
+		t.Error("expected ErrContentFiltered")
+	}
+	if !errors.Is(err, core.ErrBadRequest) {
+		t.Error("expected ErrContentFiltered to wrap ErrBadRequest")
+	}
+
+	var pErr *core.ProviderError
+	if !errors.As(err, &pErr) {
+		t.Fatal("expected ProviderError in chain")
+	}
+	if pErr.Provider != "azure" || pErr.RequestID != "req-cf" || pErr.Code != "content_filter" {
+		t.Errorf("ProviderError = %+v", pErr)
+	}
+}
+
+func TestAzureDeploymentNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"code":"DeploymentNotFound","message":"The API deployment for this resource does not exist."}}`))
+	}))
+	defer server.Close()
+
+	p := NewAzure(server.URL, "test-key")
+	_, err := p.Chat(context.Background(), &core.ChatRequest{Model: "missing"})
+
+	if !errors.Is(err, core.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	var pErr *core.ProviderError
+	if errors.As(err, &pErr) && pErr.Code != "DeploymentNotFound" {
+		t.Errorf("Code = %q, want DeploymentNotFound", pErr.Code)
+	}
+}
+
+func TestAzureStreamErrorsAttributedToAzure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(sseResponse(`{"choices":[{"index":0,"delta":{"content":"Hi"}}]}`, `{not json`)))
+	}))
+	defer server.Close()
+
+	p := NewAzure(server.URL, "test-key")
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{Model: "gpt-4o"})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	res, err := providertest.CollectStream(stream, time.Second)
+	if err != nil {
+		t.Fatalf("CollectStream() error = %v", err)
+	}
+	if v := res.Violations(); len(v) > 0 {
+		t.Fatalf("contract violations: %v", v)
+	}
+	if res.Output() != "Hi" {
+		t.Errorf("Output() = %q, want %q", res.Output(), "Hi")
+	}
+
+	var pErr *core.ProviderError
+	if !errors.As(res.Err(), &pErr) {
+		t.Fatalf("expected ProviderError, got %v", res.Err())
+	}
+	if pErr.Provider != "azure" {
+		t.Errorf("Provider = %q, want azure", pErr.Provider)
+	}
+	if !errors.Is(res.Err(), core.ErrDecode) {
+		t.Errorf("expected ErrDecode, got %v", res.Err())
+	}
+}
+
+func TestAzureModels(t *testing.T) {
+	p := NewAzure("https://example.openai.azure.com", "key",
+		WithAzureDeployment(ModelGPT4o, "a"),
+		WithAzureDeployment("my-finetune", "b"),
+	)
+
+	models := p.Models()
+	if len(models) != 2 {
+		t.Fatalf("len(Models()) = %d, want 2", len(models))
+	}
+	if models[0].ID != ModelGPT4o || models[1].ID != "my-finetune" {
+		t.Errorf("Models() = %v", models)
+	}
+	if p.ID() != "azure" {
+		t.Errorf("ID() = %q, want azure", p.ID())
+	}
+	if !p.Supports(core.FeatureEmbeddings) || p.Supports(core.FeatureImageGeneration) {
+		t.Error("unexpected Supports() result")
+	}
+}
+
+func TestAzureCapabilityCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(responsesResponse{ID: "resp-2", Model: "gpt-5", Status: "completed", OutputText: "ok"})
+	}))
+	defer server.Close()
+
+	client := core.NewClient(NewAzure(server.URL, "test-key",
+		WithAzureDeployment(ModelGPT4o, "gpt4o-prod"),
+		WithAzureDeployment("reasoner", "custom"),
+	))
+	ctx := context.Background()
+
+	// A deployment named after the model, and one mapped to an unknown model
+	for _, model := range []core.ModelID{ModelGPT5, "reasoner"} {
+		_, err := client.Chat(model).
+			User("Hello").
+			ReasoningEffort(core.ReasoningEffortHigh).
+			WebSearch().
+			ContinueFrom("resp-1").
+			GetResponse(ctx)
+		if err != nil {
+			t.Errorf("%s: GetResponse() error = %v", model, err)
+		}
+	}
+
+	// Known models are still checked against their capabilities
+	_, err := client.Chat(ModelGPT4o).User("Hello").ReasoningEffort(core.ReasoningEffortHigh).GetResponse(ctx)
+	var unsupported *core.UnsupportedFeatureError
+	if !errors.As(err, &unsupported) || unsupported.Feature != core.FeatureReasoning {
+		t.Errorf("GetResponse() for %s error = %v, want UnsupportedFeatureError", ModelGPT4o, err)
+	}
+}
@@ -15,6 +15,10 @@ const chatCompletionsPath = "/chat/completions"
 
 // doChat performs a non-streaming chat completion request.
 func (p *OpenAI) doChat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	if err := checkCompletionsRequest(req); err != nil {
+		return nil, err
+	}
+
 	// Build OpenAI request
 	oaiReq := buildRequest(req, false)
 
@@ -25,7 +29,7 @@ func (p *OpenAI) doChat(ctx context.Context, req *core.ChatRequest) (*core.ChatR
 	}
 
 	// Create HTTP request
-	url := p.config.BaseURL + chatCompletionsPath
+	url := p.endpoint(chatCompletionsPath, req.Model)
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		return nil, newNetworkError(err)
@@ -56,7 +60,7 @@ func (p *OpenAI) doChat(ctx context.Context, req *core.ChatRequest) (*core.ChatR
 
 	// Check for error status
 	if resp.StatusCode >= 400 {
-		return nil, normalizeError(resp.StatusCode, respBody, requestID)
+		return nil, p.responseError(resp.StatusCode, respBody, requestID)
 	}
 
 	// Parse response
@@ -0,0 +1,269 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+const (
+	transcriptionsPath = "/audio/transcriptions"
+	translationsPath   = "/audio/translations"
+	speechPath         = "/audio/speech"
+
+	// defaultVoice is used when a speech request names no voice.
+	defaultVoice = "alloy"
+)
+
+// Transcribe converts speech to text with the Audio API. The audio is
+// streamed to the API rather than buffered in memory.
+//
+// Segment and word timestamps are only available from whisper-1; other
+// models return the text alone. Translation into English is also only
+// available from whisper-1.
+func (p *OpenAI) Transcribe(ctx context.Context, req *core.TranscriptionRequest) (*core.Transcription, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	path := transcriptionsPath
+	if req.Translate {
+		path = translationsPath
+	}
+
+	pr, pw := io.Pipe()
+	w := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeTranscriptionForm(w, req))
+	}()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.BaseURL+path, pr)
+	if err != nil {
+		pr.Close()
+		return nil, newNetworkError(err)
+	}
+
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+	httpReq.Header.Set("Content-Type", w.FormDataContentType())
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	pr.Close()
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, p.responseError(resp.StatusCode, body, resp.Header.Get("x-request-id"))
+	}
+
+	var transcription openAITranscription
+	if err := json.Unmarshal(body, &transcription); err != nil {
+		return nil, newDecodeError(err)
+	}
+	return mapTranscription(&transcription), nil
+}
+
+// writeTranscriptionForm writes the multipart form for a transcription or
+// translation request.
+func writeTranscriptionForm(w *multipart.Writer, req *core.TranscriptionRequest) error {
+	verbose := supportsVerboseTranscription(req.Model)
+
+	fields := [][2]string{{"model", string(req.Model)}}
+	if req.Language != "" && !req.Translate {
+		fields = append(fields, [2]string{"language", req.Language})
+	}
+	if req.Prompt != "" {
+		fields = append(fields, [2]string{"prompt", req.Prompt})
+	}
+	if req.Temperature != nil {
+		fields = append(fields, [2]string{"temperature", strconv.FormatFloat(float64(*req.Temperature), 'f', -1, 32)})
+	}
+	if verbose {
+		fields = append(fields, [2]string{"response_format", "verbose_json"})
+		if !req.Translate {
+			for _, g := range req.Timestamps {
+				fields = append(fields, [2]string{"timestamp_granularities[]", string(g)})
+			}
+		}
+	} else {
+		fields = append(fields, [2]string{"response_format", "json"})
+	}
+
+	for _, f := range fields {
+		if err := w.WriteField(f[0], f[1]); err != nil {
+			return fmt.Errorf("failed to write %s field: %w", f[0], err)
+		}
+	}
+
+	part, err := w.CreateFormFile("file", audioFilename(req))
+	if err != nil {
+		return fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := io.Copy(part, req.Audio); err != nil {
+		return fmt.Errorf("failed to copy audio: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+	return nil
+}
+
+// supportsVerboseTranscription reports whether a model returns
+// verbose_json with segments and timestamps.
+func supportsVerboseTranscription(model core.ModelID) bool {
+	return strings.HasPrefix(string(model), "whisper")
+}
+
+// audioFilename returns the filename to upload audio as. The API detects the
+// format from the extension, so one is derived from the MIME type when the
+// request has no filename.
+func audioFilename(req *core.TranscriptionRequest) string {
+	if req.Filename != "" {
+		return req.Filename
+	}
+	if req.MimeType != "" {
+		if exts, err := mime.ExtensionsByType(req.MimeType); err == nil && len(exts) > 0 {
+			return "audio" + exts[0]
+		}
+	}
+	return "audio"
+}
+
+// mapTranscription converts an OpenAI transcription to core format.
+func mapTranscription(t *openAITranscription) *core.Transcription {
+	result := &core.Transcription{
+		Text:     t.Text,
+		Language: t.Language,
+		Duration: seconds(t.Duration),
+	}
+	for _, s := range t.Segments {
+		result.Segments = append(result.Segments, core.TranscriptionSegment{
+			Start: seconds(s.Start),
+			End:   seconds(s.End),
+			Text:  s.Text,
+		})
+	}
+	for _, w := range t.Words {
+		result.Words = append(result.Words, core.TranscriptionWord{
+			Word:  w.Word,
+			Start: seconds(w.Start),
+			End:   seconds(w.End),
+		})
+	}
+	return result
+}
+
+// seconds converts fractional seconds to a duration.
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// SynthesizeSpeech generates speech with the Audio API. The voice defaults
+// to "alloy" and the format to MP3.
+func (p *OpenAI) SynthesizeSpeech(ctx context.Context, req *core.SpeechRequest) (*core.SpeechResponse, error) {
+	stream, err := p.StreamSpeech(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	audio, err := io.ReadAll(stream)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	return &core.SpeechResponse{
+		Audio:    audio,
+		Format:   stream.Format,
+		MimeType: stream.MimeType,
+	}, nil
+}
+
+// StreamSpeech generates speech with the Audio API, returning the audio as
+// it is generated. PCM output has the lowest latency.
+func (p *OpenAI) StreamSpeech(ctx context.Context, req *core.SpeechRequest) (*core.SpeechStream, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	format := req.Format
+	if format == "" {
+		format = core.AudioFormatMP3
+	}
+	voice := req.Voice
+	if voice == "" {
+		voice = defaultVoice
+	}
+
+	body, err := json.Marshal(&openAISpeechRequest{
+		Model:          string(req.Model),
+		Input:          req.Input,
+		Voice:          voice,
+		ResponseFormat: string(format),
+		Speed:          req.Speed,
+		Instructions:   req.Instructions,
+	})
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.BaseURL+speechPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, p.responseError(resp.StatusCode, respBody, resp.Header.Get("x-request-id"))
+	}
+
+	mimeType := resp.Header.Get("Content-Type")
+	if mimeType == "" {
+		mimeType = format.MimeType()
+	}
+
+	return &core.SpeechStream{
+		ReadCloser: resp.Body,
+		Format:     format,
+		MimeType:   mimeType,
+	}, nil
+}
+
+// Compile-time check that OpenAI implements Transcriber.
+var _ core.Transcriber = (*OpenAI)(nil)
+
+// Compile-time check that OpenAI implements SpeechSynthesizer.
+var _ core.SpeechSynthesizer = (*OpenAI)(nil)
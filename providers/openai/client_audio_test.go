@@ -0,0 +1,223 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestTranscribeVerbose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/audio/transcriptions" {
+			t.Errorf("path = %s, want /audio/transcriptions", r.URL.Path)
+		}
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+		if got := r.FormValue("model"); got != "whisper-1" {
+			t.Errorf("model = %q", got)
+		}
+		if got := r.FormValue("response_format"); got != "verbose_json" {
+			t.Errorf("response_format = %q, want verbose_json", got)
+		}
+		if got := r.MultipartForm.Value["timestamp_granularities[]"]; len(got) != 2 {
+			t.Errorf("timestamp_granularities[] = %v", got)
+		}
+		if got := r.FormValue("language"); got != "en" {
+			t.Errorf("language = %q, want en", got)
+		}
+		f, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("missing file: %v", err)
+		}
+		data, _ := io.ReadAll(f)
+		if header.Filename != "memo.mp3" || string(data) != "audio-bytes" {
+			t.Errorf("file = %q %q", header.Filename, data)
+		}
+
+		io.WriteString(w, `{"task":"transcribe","language":"english","duration":2.5,"text":"Hello world",
+			"segments":[{"id":0,"start":0.0,"end":2.5,"text":"Hello world"}],
+			"words":[{"word":"Hello","start":0.0,"end":1.0},{"word":"world","start":1.2,"end":2.5}]}`)
+	}))
+	defer server.Close()
+
+	var provider core.Transcriber = New("test-key", WithBaseURL(server.URL))
+	got, err := provider.Transcribe(context.Background(), &core.TranscriptionRequest{
+		Model:      ModelWhisper1,
+		Audio:      strings.NewReader("audio-bytes"),
+		Filename:   "memo.mp3",
+		Language:   "en",
+		Timestamps: []core.TimestampGranularity{core.TimestampGranularitySegment, core.TimestampGranularityWord},
+	})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+
+	if got.Text != "Hello world" || got.Language != "english" || got.Duration != 2500*time.Millisecond {
+		t.Errorf("Transcribe() = %+v", got)
+	}
+	if len(got.Segments) != 1 || got.Segments[0].End != 2500*time.Millisecond {
+		t.Errorf("Segments = %+v", got.Segments)
+	}
+	if len(got.Words) != 2 || got.Words[1].Word != "world" || got.Words[1].Start != 1200*time.Millisecond {
+		t.Errorf("Words = %+v", got.Words)
+	}
+}
+
+func TestTranscribeTranslate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/audio/translations" {
+			t.Errorf("path = %s, want /audio/translations", r.URL.Path)
+		}
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+		if _, ok := r.MultipartForm.Value["language"]; ok {
+			t.Error("language must not be sent to translations")
+		}
+		io.WriteString(w, `{"text":"Good morning"}`)
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	got, err := provider.Transcribe(context.Background(), &core.TranscriptionRequest{
+		Model:     ModelWhisper1,
+		Audio:     strings.NewReader("audio"),
+		MimeType:  "audio/wav",
+		Language:  "de",
+		Translate: true,
+	})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if got.Text != "Good morning" {
+		t.Errorf("Text = %q", got.Text)
+	}
+}
+
+func TestTranscribeJSONModel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+		if got := r.FormValue("response_format"); got != "json" {
+			t.Errorf("response_format = %q, want json", got)
+		}
+		io.WriteString(w, `{"text":"Hi"}`)
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	got, err := provider.Transcribe(context.Background(), &core.TranscriptionRequest{
+		Model:    ModelGPT4oTranscribe,
+		Audio:    strings.NewReader("audio"),
+		Filename: "a.wav",
+	})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if got.Text != "Hi" || got.Segments != nil {
+		t.Errorf("Transcribe() = %+v", got)
+	}
+}
+
+func TestTranscribeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"error":{"message":"Invalid file format.","type":"invalid_request_error"}}`)
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	_, err := provider.Transcribe(context.Background(), &core.TranscriptionRequest{
+		Model: ModelWhisper1,
+		Audio: strings.NewReader("audio"),
+	})
+	if !errors.Is(err, core.ErrBadRequest) {
+		t.Errorf("Transcribe() error = %v, want ErrBadRequest", err)
+	}
+}
+
+func TestSynthesizeSpeech(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/audio/speech" {
+			t.Errorf("path = %s, want /audio/speech", r.URL.Path)
+		}
+		var req openAISpeechRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Model != "gpt-4o-mini-tts" || req.Input != "Hello" || req.Voice != "alloy" {
+			t.Errorf("request = %+v", req)
+		}
+		if req.ResponseFormat != "mp3" || req.Speed != 1.25 || req.Instructions != "cheerful" {
+			t.Errorf("request options = %+v", req)
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Write([]byte("ID3-audio"))
+	}))
+	defer server.Close()
+
+	var provider core.SpeechSynthesizer = New("test-key", WithBaseURL(server.URL))
+	resp, err := provider.SynthesizeSpeech(context.Background(), &core.SpeechRequest{
+		Model:        ModelGPT4oMiniTTS,
+		Input:        "Hello",
+		Speed:        1.25,
+		Instructions: "cheerful",
+	})
+	if err != nil {
+		t.Fatalf("SynthesizeSpeech() error = %v", err)
+	}
+	if string(resp.Audio) != "ID3-audio" || resp.Format != core.AudioFormatMP3 || resp.MimeType != "audio/mpeg" {
+		t.Errorf("SynthesizeSpeech() = %+v", resp)
+	}
+}
+
+func TestStreamSpeech(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req openAISpeechRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.ResponseFormat != "pcm" || req.Voice != "nova" {
+			t.Errorf("request = %+v", req)
+		}
+		w.Write([]byte("chunk1"))
+		w.(http.Flusher).Flush()
+		w.Write([]byte("chunk2"))
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	stream, err := provider.StreamSpeech(context.Background(), &core.SpeechRequest{
+		Model:  ModelTTS1,
+		Input:  "Hello",
+		Voice:  "nova",
+		Format: core.AudioFormatPCM,
+	})
+	if err != nil {
+		t.Fatalf("StreamSpeech() error = %v", err)
+	}
+	defer stream.Close()
+
+	data, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if string(data) != "chunk1chunk2" || stream.Format != core.AudioFormatPCM {
+		t.Errorf("stream = %q, format %s", data, stream.Format)
+	}
+}
+
+func TestSynthesizeSpeechValidates(t *testing.T) {
+	provider := New("test-key", WithBaseURL("http://127.0.0.1:0"))
+	_, err := provider.SynthesizeSpeech(context.Background(), &core.SpeechRequest{Model: ModelTTS1})
+	if !errors.Is(err, core.ErrNoSpeechInput) {
+		t.Errorf("SynthesizeSpeech() error = %v, want ErrNoSpeechInput", err)
+	}
+}
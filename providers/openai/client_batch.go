@@ -0,0 +1,299 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+const (
+	batchesPath = "/batches"
+
+	// batchEndpoint is the endpoint batch requests are run against.
+	batchEndpoint = "/v1/chat/completions"
+
+	// batchCompletionWindow is the only completion window the Batch API accepts.
+	batchCompletionWindow = "24h"
+)
+
+// CreateBatch submits the requests as a batch job against the Chat
+// Completions endpoint. The input is written as JSONL and streamed to the
+// Files API with purpose "batch", so large batches are never held in memory
+// as a whole.
+func (p *OpenAI) CreateBatch(ctx context.Context, req *core.BatchRequest) (*core.BatchJob, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeBatchInput(pw, req.Items))
+	}()
+
+	file, err := p.UploadFile(ctx, &FileUploadRequest{
+		File:     pr,
+		Filename: "batch.jsonl",
+		Purpose:  FilePurposeBatch,
+	})
+	pr.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var batch openAIBatch
+	err = p.doBatchRequest(ctx, http.MethodPost, batchesPath, &openAIBatchCreateRequest{
+		InputFileID:      file.ID,
+		Endpoint:         batchEndpoint,
+		CompletionWindow: batchCompletionWindow,
+		Metadata:         req.Metadata,
+	}, &batch)
+	if err != nil {
+		return nil, err
+	}
+	return mapBatchJob(&batch), nil
+}
+
+// writeBatchInput writes one JSONL line per batch item.
+func writeBatchInput(w io.Writer, items []core.BatchItem) error {
+	enc := json.NewEncoder(w)
+	for _, item := range items {
+		line := openAIBatchLine{
+			CustomID: item.CustomID,
+			Method:   http.MethodPost,
+			URL:      batchEndpoint,
+			Body:     buildRequest(item.Request, false),
+		}
+		if err := enc.Encode(&line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetBatch returns the current state of a batch job.
+func (p *OpenAI) GetBatch(ctx context.Context, id string) (*core.BatchJob, error) {
+	batch, err := p.getBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapBatchJob(batch), nil
+}
+
+// CancelBatch requests cancellation of a batch job.
+func (p *OpenAI) CancelBatch(ctx context.Context, id string) (*core.BatchJob, error) {
+	var batch openAIBatch
+	if err := p.doBatchRequest(ctx, http.MethodPost, batchesPath+"/"+id+"/cancel", nil, &batch); err != nil {
+		return nil, err
+	}
+	return mapBatchJob(&batch), nil
+}
+
+// BatchResults returns the results of a finished batch job, read from its
+// output file and error file.
+func (p *OpenAI) BatchResults(ctx context.Context, id string) ([]core.BatchResult, error) {
+	batch, err := p.getBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !mapBatchStatus(batch.Status).IsTerminal() {
+		return nil, &core.ProviderError{
+			Provider: "openai",
+			Code:     "batch_not_finished",
+			Message:  fmt.Sprintf("batch %s is %s", id, batch.Status),
+			Err:      core.ErrBadRequest,
+		}
+	}
+
+	var results []core.BatchResult
+	for _, fileID := range []string{batch.OutputFileID, batch.ErrorFileID} {
+		if fileID == "" {
+			continue
+		}
+		results, err = p.readBatchOutput(ctx, fileID, results)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// readBatchOutput decodes a batch output or error file and appends its results.
+func (p *OpenAI) readBatchOutput(ctx context.Context, fileID string, results []core.BatchResult) ([]core.BatchResult, error) {
+	content, err := p.DownloadFile(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	dec := json.NewDecoder(content)
+	for {
+		var line openAIBatchOutputLine
+		if err := dec.Decode(&line); err != nil {
+			if errors.Is(err, io.EOF) {
+				return results, nil
+			}
+			return nil, newDecodeError(err)
+		}
+		results = append(results, p.mapBatchResult(&line))
+	}
+}
+
+// mapBatchResult converts one output line to a core result.
+func (p *OpenAI) mapBatchResult(line *openAIBatchOutputLine) core.BatchResult {
+	result := core.BatchResult{CustomID: line.CustomID}
+
+	switch {
+	case line.Error != nil:
+		result.Err = &core.ProviderError{
+			Provider: "openai",
+			Code:     line.Error.Code,
+			Message:  line.Error.Message,
+			Err:      batchErrorSentinel(line.Error.Code),
+		}
+	case line.Response == nil:
+		result.Err = newDecodeError(fmt.Errorf("batch result %q has no response", line.CustomID))
+	case line.Response.StatusCode >= 400:
+		result.Err = p.responseError(line.Response.StatusCode, line.Response.Body, line.Response.RequestID)
+	default:
+		var oaiResp openAIResponse
+		if err := json.Unmarshal(line.Response.Body, &oaiResp); err != nil {
+			result.Err = newDecodeError(err)
+			break
+		}
+		result.Response, result.Err = mapResponse(&oaiResp)
+	}
+	return result
+}
+
+// batchErrorSentinel maps a per-request batch error code to a core sentinel.
+func batchErrorSentinel(code string) error {
+	switch code {
+	case "batch_cancelled":
+		return core.ErrBatchItemCancelled
+	case "batch_expired":
+		return core.ErrBatchItemExpired
+	default:
+		return core.ErrBadRequest
+	}
+}
+
+// getBatch retrieves the raw batch object.
+func (p *OpenAI) getBatch(ctx context.Context, id string) (*openAIBatch, error) {
+	var batch openAIBatch
+	if err := p.doBatchRequest(ctx, http.MethodGet, batchesPath+"/"+id, nil, &batch); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// doBatchRequest sends a Batch API request and decodes the JSON response into out.
+func (p *OpenAI) doBatchRequest(ctx context.Context, method, path string, payload, out any) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return newDecodeError(err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, p.config.BaseURL+path, body)
+	if err != nil {
+		return newNetworkError(err)
+	}
+
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return p.responseError(resp.StatusCode, respBody, resp.Header.Get("x-request-id"))
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return newDecodeError(err)
+	}
+	return nil
+}
+
+// mapBatchJob converts an OpenAI batch to core format.
+func mapBatchJob(b *openAIBatch) *core.BatchJob {
+	job := &core.BatchJob{
+		ID:     b.ID,
+		Status: mapBatchStatus(b.Status),
+		Counts: core.BatchCounts{
+			Total:     b.RequestCounts.Total,
+			Succeeded: b.RequestCounts.Completed,
+			Failed:    b.RequestCounts.Failed,
+		},
+		CreatedAt: time.Unix(b.CreatedAt, 0),
+		ExpiresAt: unixTime(b.ExpiresAt),
+		Metadata:  b.Metadata,
+	}
+	if b.Errors != nil {
+		for _, e := range b.Errors.Data {
+			job.Errors = append(job.Errors, e.Message)
+		}
+	}
+	for _, ended := range []*int64{b.CompletedAt, b.FailedAt, b.CancelledAt, b.ExpiredAt} {
+		if ended != nil {
+			job.EndedAt = unixTime(ended)
+			break
+		}
+	}
+	return job
+}
+
+// mapBatchStatus converts an OpenAI batch status to core format.
+func mapBatchStatus(status string) core.BatchStatus {
+	switch status {
+	case "validating":
+		return core.BatchStatusPending
+	case "in_progress", "finalizing":
+		return core.BatchStatusInProgress
+	case "cancelling":
+		return core.BatchStatusCancelling
+	case "completed":
+		return core.BatchStatusCompleted
+	case "failed":
+		return core.BatchStatusFailed
+	case "cancelled":
+		return core.BatchStatusCancelled
+	case "expired":
+		return core.BatchStatusExpired
+	default:
+		return core.BatchStatusPending
+	}
+}
+
+// unixTime converts an optional Unix timestamp to a time.
+func unixTime(ts *int64) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := time.Unix(*ts, 0)
+	return &t
+}
+
+// Compile-time check that OpenAI implements BatchProvider.
+var _ core.BatchProvider = (*OpenAI)(nil)
@@ -0,0 +1,192 @@
+package openai
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func batchItem(id string) core.BatchItem {
+	return core.BatchItem{CustomID: id, Request: &core.ChatRequest{
+		Model:    ModelGPT4o,
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Hello " + id}},
+	}}
+}
+
+func TestCreateBatch(t *testing.T) {
+	var lines []openAIBatchLine
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files":
+			if err := r.ParseMultipartForm(10 << 20); err != nil {
+				t.Fatalf("failed to parse form: %v", err)
+			}
+			if got := r.FormValue("purpose"); got != "batch" {
+				t.Errorf("purpose = %q, want batch", got)
+			}
+			f, _, err := r.FormFile("file")
+			if err != nil {
+				t.Fatalf("missing file: %v", err)
+			}
+			scanner := bufio.NewScanner(f)
+			for scanner.Scan() {
+				var line openAIBatchLine
+				if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+					t.Fatalf("invalid JSONL line: %v", err)
+				}
+				lines = append(lines, line)
+			}
+			json.NewEncoder(w).Encode(File{ID: "file-input", Purpose: FilePurposeBatch})
+		case "/batches":
+			var req openAIBatchCreateRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.InputFileID != "file-input" || req.Endpoint != "/v1/chat/completions" || req.CompletionWindow != "24h" {
+				t.Errorf("create request = %+v", req)
+			}
+			if req.Metadata["job"] != "nightly" {
+				t.Errorf("metadata = %v", req.Metadata)
+			}
+			fmt.Fprint(w, `{"id":"batch_abc","status":"validating","created_at":1700000000,"expires_at":1700086400,
+				"request_counts":{"total":0,"completed":0,"failed":0},"metadata":{"job":"nightly"}}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var provider core.BatchProvider = New("test-key", WithBaseURL(server.URL))
+	job, err := provider.CreateBatch(context.Background(), &core.BatchRequest{
+		Items:    []core.BatchItem{batchItem("a"), batchItem("b")},
+		Metadata: map[string]string{"job": "nightly"},
+	})
+	if err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+
+	if len(lines) != 2 || lines[0].CustomID != "a" || lines[1].CustomID != "b" {
+		t.Fatalf("lines = %+v", lines)
+	}
+	if lines[0].Method != "POST" || lines[0].URL != "/v1/chat/completions" || lines[0].Body.Model != string(ModelGPT4o) {
+		t.Errorf("line = %+v", lines[0])
+	}
+	if job.ID != "batch_abc" || job.Status != core.BatchStatusPending {
+		t.Errorf("job = %+v", job)
+	}
+	if job.ExpiresAt == nil || job.ExpiresAt.Unix() != 1700086400 {
+		t.Errorf("ExpiresAt = %v", job.ExpiresAt)
+	}
+}
+
+func TestCreateBatchValidates(t *testing.T) {
+	provider := New("test-key", WithBaseURL("http://127.0.0.1:0"))
+	_, err := provider.CreateBatch(context.Background(), &core.BatchRequest{
+		Items: []core.BatchItem{batchItem("a"), batchItem("a")},
+	})
+	if !errors.Is(err, core.ErrInvalidBatchItem) {
+		t.Errorf("CreateBatch() error = %v, want ErrInvalidBatchItem", err)
+	}
+}
+
+func TestBatchResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/batches/batch_abc":
+			fmt.Fprint(w, `{"id":"batch_abc","status":"completed","created_at":1700000000,"completed_at":1700000600,
+				"output_file_id":"file-out","error_file_id":"file-err",
+				"request_counts":{"total":4,"completed":1,"failed":3}}`)
+		case "/files/file-out/content":
+			fmt.Fprintln(w, `{"id":"r1","custom_id":"a","response":{"status_code":200,"request_id":"req_1","body":{"id":"chatcmpl-1","model":"gpt-4o","choices":[{"index":0,"message":{"role":"assistant","content":"Hi a"},"finish_reason":"stop"}],"usage":{"prompt_tokens":3,"completion_tokens":2,"total_tokens":5}}},"error":null}`)
+			fmt.Fprintln(w, `{"id":"r2","custom_id":"b","response":{"status_code":429,"request_id":"req_2","body":{"error":{"message":"slow down","type":"rate_limit_error","code":"rate_limit_exceeded"}}},"error":null}`)
+		case "/files/file-err/content":
+			fmt.Fprintln(w, `{"id":"r3","custom_id":"c","response":null,"error":{"code":"batch_expired","message":"expired"}}`)
+			fmt.Fprintln(w, `{"id":"r4","custom_id":"d","response":null,"error":{"code":"batch_cancelled","message":"cancelled"}}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	results, err := provider.BatchResults(context.Background(), "batch_abc")
+	if err != nil {
+		t.Fatalf("BatchResults() error = %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("len(results) = %d, want 4", len(results))
+	}
+
+	if results[0].CustomID != "a" || results[0].Err != nil || results[0].Response.Output != "Hi a" {
+		t.Errorf("results[0] = %+v", results[0])
+	}
+	if results[1].CustomID != "b" || !errors.Is(results[1].Err, core.ErrRateLimited) {
+		t.Errorf("results[1] = %+v", results[1])
+	}
+	if results[2].CustomID != "c" || !errors.Is(results[2].Err, core.ErrBatchItemExpired) {
+		t.Errorf("results[2] = %+v", results[2])
+	}
+	if results[3].CustomID != "d" || !errors.Is(results[3].Err, core.ErrBatchItemCancelled) {
+		t.Errorf("results[3] = %+v", results[3])
+	}
+}
+
+func TestBatchResultsNotFinished(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"batch_abc","status":"in_progress","created_at":1700000000,"request_counts":{"total":2,"completed":1,"failed":0}}`)
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	_, err := provider.BatchResults(context.Background(), "batch_abc")
+	if !errors.Is(err, core.ErrBadRequest) {
+		t.Errorf("BatchResults() error = %v, want ErrBadRequest", err)
+	}
+}
+
+func TestMapBatchJob(t *testing.T) {
+	failedAt := int64(1700000100)
+	job := mapBatchJob(&openAIBatch{
+		ID:            "batch_abc",
+		Status:        "failed",
+		CreatedAt:     1700000000,
+		FailedAt:      &failedAt,
+		RequestCounts: openAIBatchCounts{Total: 2},
+		Errors:        &openAIBatchErrors{Data: []openAIBatchError{{Code: "invalid_json_line", Message: "line 2 is not valid JSON"}}},
+	})
+
+	if job.Status != core.BatchStatusFailed || job.Counts.Total != 2 {
+		t.Errorf("job = %+v", job)
+	}
+	if job.EndedAt == nil || job.EndedAt.Unix() != failedAt {
+		t.Errorf("EndedAt = %v", job.EndedAt)
+	}
+	if len(job.Errors) != 1 || job.Errors[0] != "line 2 is not valid JSON" {
+		t.Errorf("Errors = %v", job.Errors)
+	}
+}
+
+func TestCancelBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/batches/batch_abc/cancel" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		fmt.Fprint(w, `{"id":"batch_abc","status":"cancelling","created_at":1700000000,"request_counts":{"total":2,"completed":1,"failed":0}}`)
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	job, err := provider.CancelBatch(context.Background(), "batch_abc")
+	if err != nil {
+		t.Fatalf("CancelBatch() error = %v", err)
+	}
+	if job.Status != core.BatchStatusCancelling {
+		t.Errorf("Status = %s, want cancelling", job.Status)
+	}
+}
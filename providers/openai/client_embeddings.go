@@ -22,7 +22,7 @@ func (p *OpenAI) CreateEmbeddings(ctx context.Context, req *core.EmbeddingReques
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	url := p.config.BaseURL + embeddingsPath
+	url := p.endpoint(embeddingsPath, req.Model)
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -48,7 +48,7 @@ func (p *OpenAI) CreateEmbeddings(ctx context.Context, req *core.EmbeddingReques
 	requestID := resp.Header.Get("x-request-id")
 
 	if resp.StatusCode >= 400 {
-		return nil, normalizeError(resp.StatusCode, respBody, requestID)
+		return nil, p.responseError(resp.StatusCode, respBody, requestID)
 	}
 
 	var oaiResp openAIEmbeddingResponse
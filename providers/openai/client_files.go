@@ -1,7 +1,6 @@
 package openai
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -15,41 +14,18 @@ import (
 )
 
 // UploadFile uploads a file to OpenAI.
+// The file content is streamed from req.File rather than buffered in memory.
 func (p *OpenAI) UploadFile(ctx context.Context, req *FileUploadRequest) (*File, error) {
-	var buf bytes.Buffer
-	w := multipart.NewWriter(&buf)
-
-	// Add purpose field
-	if err := w.WriteField("purpose", string(req.Purpose)); err != nil {
-		return nil, fmt.Errorf("failed to write purpose field: %w", err)
-	}
-
-	// Add expires_after fields if provided
-	if req.ExpiresAfter != nil {
-		if err := w.WriteField("expires_after[anchor]", req.ExpiresAfter.Anchor); err != nil {
-			return nil, fmt.Errorf("failed to write expires_after[anchor] field: %w", err)
-		}
-		if err := w.WriteField("expires_after[seconds]", strconv.Itoa(req.ExpiresAfter.Seconds)); err != nil {
-			return nil, fmt.Errorf("failed to write expires_after[seconds] field: %w", err)
-		}
-	}
-
-	// Add file field
-	part, err := w.CreateFormFile("file", req.Filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
-	}
-	if _, err := io.Copy(part, req.File); err != nil {
-		return nil, fmt.Errorf("failed to copy file content: %w", err)
-	}
-
-	if err := w.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
-	}
+	pr, pw := io.Pipe()
+	w := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeUploadForm(w, req))
+	}()
 
 	url := p.config.BaseURL + "/files"
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &buf)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, pr)
 	if err != nil {
+		pr.Close()
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
@@ -89,6 +65,38 @@ func (p *OpenAI) UploadFile(ctx context.Context, req *FileUploadRequest) (*File,
 	return &file, nil
 }
 
+// writeUploadForm writes the multipart form for an upload and closes w.
+func writeUploadForm(w *multipart.Writer, req *FileUploadRequest) error {
+	// Add purpose field
+	if err := w.WriteField("purpose", string(req.Purpose)); err != nil {
+		return fmt.Errorf("failed to write purpose field: %w", err)
+	}
+
+	// Add expires_after fields if provided
+	if req.ExpiresAfter != nil {
+		if err := w.WriteField("expires_after[anchor]", req.ExpiresAfter.Anchor); err != nil {
+			return fmt.Errorf("failed to write expires_after[anchor] field: %w", err)
+		}
+		if err := w.WriteField("expires_after[seconds]", strconv.Itoa(req.ExpiresAfter.Seconds)); err != nil {
+			return fmt.Errorf("failed to write expires_after[seconds] field: %w", err)
+		}
+	}
+
+	// Add file field
+	part, err := w.CreateFormFile("file", req.Filename)
+	if err != nil {
+		return fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := io.Copy(part, req.File); err != nil {
+		return fmt.Errorf("failed to copy file content: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+	return nil
+}
+
 // parseFileError parses an error response from the Files API.
 func (p *OpenAI) parseFileError(resp *http.Response) error {
 	body, _ := io.ReadAll(resp.Body)
@@ -0,0 +1,116 @@
+package openai
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// maxFileListLimit is the largest page size accepted by GET /files.
+const maxFileListLimit = 10000
+
+// CreateFile uploads a file through the core.FileProvider interface.
+// Files without a purpose are uploaded as user_data.
+func (p *OpenAI) CreateFile(ctx context.Context, req *core.FileUploadRequest) (*core.File, error) {
+	purpose := FilePurpose(req.Purpose)
+	if purpose == "" {
+		purpose = FilePurposeUserData
+	}
+
+	file, err := p.UploadFile(ctx, &FileUploadRequest{
+		File:     req.File,
+		Filename: req.Filename,
+		Purpose:  purpose,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapCoreFile(file), nil
+}
+
+// RetrieveFile returns metadata for a file.
+func (p *OpenAI) RetrieveFile(ctx context.Context, id string) (*core.File, error) {
+	file, err := p.GetFile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapCoreFile(file), nil
+}
+
+// RetrieveFileContent returns the content of a file.
+// The caller is responsible for closing the returned ReadCloser.
+func (p *OpenAI) RetrieveFileContent(ctx context.Context, id string) (io.ReadCloser, error) {
+	return p.DownloadFile(ctx, id)
+}
+
+// EnumerateFiles lists files, following pagination.
+func (p *OpenAI) EnumerateFiles(ctx context.Context, req *core.FileListRequest) ([]core.File, error) {
+	if req == nil {
+		req = &core.FileListRequest{}
+	}
+
+	listReq := &FileListRequest{}
+	if req.Purpose != "" {
+		purpose := FilePurpose(req.Purpose)
+		listReq.Purpose = &purpose
+	}
+
+	var files []core.File
+	for {
+		limit := maxFileListLimit
+		if req.Limit > 0 {
+			limit = min(limit, req.Limit-len(files))
+		}
+		listReq.Limit = &limit
+
+		resp, err := p.ListFiles(ctx, listReq)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range resp.Data {
+			files = append(files, *mapCoreFile(&resp.Data[i]))
+		}
+
+		if !resp.HasMore || len(resp.Data) == 0 || (req.Limit > 0 && len(files) >= req.Limit) {
+			break
+		}
+		after := resp.Data[len(resp.Data)-1].ID
+		listReq.After = &after
+	}
+
+	return files, nil
+}
+
+// RemoveFile deletes a file.
+func (p *OpenAI) RemoveFile(ctx context.Context, id string) error {
+	return p.DeleteFile(ctx, id)
+}
+
+// WaitForFile returns the file's metadata. OpenAI files can be referenced
+// as soon as the upload completes, so there is nothing to wait for.
+func (p *OpenAI) WaitForFile(ctx context.Context, id string) (*core.File, error) {
+	return p.RetrieveFile(ctx, id)
+}
+
+// mapCoreFile converts an OpenAI file to core format.
+func mapCoreFile(f *File) *core.File {
+	file := &core.File{
+		ID:        f.ID,
+		Filename:  f.Filename,
+		Bytes:     f.Bytes,
+		Purpose:   string(f.Purpose),
+		State:     core.FileStateReady,
+		CreatedAt: time.Unix(f.CreatedAt, 0),
+	}
+	if f.ExpiresAt != nil {
+		expires := time.Unix(*f.ExpiresAt, 0)
+		file.ExpiresAt = &expires
+	}
+	return file
+}
+
+// Compile-time check that OpenAI implements FileProvider.
+var _ core.FileProvider = (*OpenAI)(nil)
@@ -0,0 +1,115 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestCreateFileDefaultsPurpose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(10 << 20); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+		if got := r.FormValue("purpose"); got != "user_data" {
+			t.Errorf("purpose = %q, want user_data", got)
+		}
+		expiresAt := int64(1700003600)
+		json.NewEncoder(w).Encode(File{
+			ID:        "file-abc",
+			Bytes:     5,
+			CreatedAt: 1700000000,
+			ExpiresAt: &expiresAt,
+			Filename:  "notes.txt",
+			Purpose:   FilePurposeUserData,
+		})
+	}))
+	defer server.Close()
+
+	var provider core.FileProvider = New("test-key", WithBaseURL(server.URL))
+	file, err := provider.CreateFile(context.Background(), &core.FileUploadRequest{
+		File:     strings.NewReader("hello"),
+		Filename: "notes.txt",
+	})
+	if err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+
+	if file.ID != "file-abc" || file.Filename != "notes.txt" || file.Bytes != 5 {
+		t.Errorf("CreateFile() = %+v", file)
+	}
+	if file.State != core.FileStateReady {
+		t.Errorf("State = %q, want ready", file.State)
+	}
+	if file.CreatedAt.Unix() != 1700000000 {
+		t.Errorf("CreatedAt = %v", file.CreatedAt)
+	}
+	if file.ExpiresAt == nil || file.ExpiresAt.Unix() != 1700003600 {
+		t.Errorf("ExpiresAt = %v", file.ExpiresAt)
+	}
+}
+
+func TestEnumerateFilesPaginates(t *testing.T) {
+	var afters []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		after := r.URL.Query().Get("after")
+		afters = append(afters, after)
+		if got := r.URL.Query().Get("purpose"); got != "batch" {
+			t.Errorf("purpose = %q, want batch", got)
+		}
+
+		page := FileListResponse{Object: "list"}
+		switch after {
+		case "":
+			page.Data = []File{{ID: "file-1"}, {ID: "file-2"}}
+			page.HasMore = true
+		case "file-2":
+			page.Data = []File{{ID: "file-3"}}
+		default:
+			t.Errorf("unexpected after = %q", after)
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	files, err := provider.EnumerateFiles(context.Background(), &core.FileListRequest{Purpose: "batch"})
+	if err != nil {
+		t.Fatalf("EnumerateFiles() error = %v", err)
+	}
+
+	if len(files) != 3 || files[2].ID != "file-3" {
+		t.Errorf("files = %+v", files)
+	}
+	if fmt.Sprint(afters) != "[ file-2]" {
+		t.Errorf("after cursors = %q", afters)
+	}
+}
+
+func TestEnumerateFilesLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("limit"); got != "2" {
+			t.Errorf("limit = %q, want 2", got)
+		}
+		json.NewEncoder(w).Encode(FileListResponse{
+			Data:    []File{{ID: "file-1"}, {ID: "file-2"}},
+			HasMore: true,
+		})
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	files, err := provider.EnumerateFiles(context.Background(), &core.FileListRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("EnumerateFiles() error = %v", err)
+	}
+	if len(files) != 2 {
+		t.Errorf("len(files) = %d, want 2", len(files))
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/erikhoward/iris/core"
 )
@@ -84,6 +85,40 @@ func TestUploadFile(t *testing.T) {
 	}
 }
 
+func TestUploadFileStreams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A buffered body would have a known length
+		if r.ContentLength != -1 {
+			t.Errorf("expected streamed body, got ContentLength %d", r.ContentLength)
+		}
+		io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(File{ID: "file-abc123"})
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL+"/v1"))
+
+	if _, err := provider.UploadFile(context.Background(), &FileUploadRequest{
+		File:     strings.NewReader("hello world"),
+		Filename: "test.txt",
+		Purpose:  FilePurposeUserData,
+	}); err != nil {
+		t.Fatalf("UploadFile failed: %v", err)
+	}
+
+	// A failing reader aborts the upload
+	readErr := errors.New("read failed")
+	_, err := provider.UploadFile(context.Background(), &FileUploadRequest{
+		File:     io.MultiReader(strings.NewReader("hello"), iotest.ErrReader(readErr)),
+		Filename: "test.txt",
+		Purpose:  FilePurposeUserData,
+	})
+	if !errors.Is(err, core.ErrNetwork) || !strings.Contains(err.Error(), readErr.Error()) {
+		t.Errorf("expected network error from reader, got %v", err)
+	}
+}
+
 func TestUploadFileWithExpiration(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if err := r.ParseMultipartForm(10 << 20); err != nil {
@@ -0,0 +1,58 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+)
+
+const modelsPath = "/models"
+
+// ListModels returns the models available to the API key using GET /models.
+// Models in the static list keep its capabilities; others have capabilities
+// inferred from their ID. Results are cached for the configured
+// ModelCacheTTL.
+func (p *OpenAI) ListModels(ctx context.Context) ([]core.ModelInfo, error) {
+	return p.modelCache.Get(ctx)
+}
+
+// fetchModels queries GET /models and merges the result with the static list.
+func (p *OpenAI) fetchModels(ctx context.Context) ([]core.ModelInfo, error) {
+	var list openAIModelList
+	if err := p.doBatchRequest(ctx, http.MethodGet, modelsPath, nil, &list); err != nil {
+		return nil, err
+	}
+
+	live := make([]core.ModelInfo, len(list.Data))
+	for i, m := range list.Data {
+		live[i] = core.ModelInfo{
+			ID:           core.ModelID(m.ID),
+			Capabilities: inferCapabilities(m.ID),
+		}
+	}
+	return core.MergeModels(live, models), nil
+}
+
+// inferCapabilities guesses the capabilities of a model missing from the
+// static list from its ID.
+func inferCapabilities(id string) []core.Feature {
+	switch {
+	case strings.Contains(id, "embedding"):
+		return []core.Feature{core.FeatureEmbeddings}
+	case strings.Contains(id, "moderation"):
+		return []core.Feature{core.FeatureModeration}
+	case strings.HasPrefix(id, "whisper") || strings.Contains(id, "transcribe"):
+		return []core.Feature{core.FeatureTranscription}
+	case strings.HasPrefix(id, "tts") || strings.Contains(id, "-tts"):
+		return []core.Feature{core.FeatureSpeechSynthesis}
+	case strings.HasPrefix(id, "dall-e") || strings.HasPrefix(id, "gpt-image"):
+		return []core.Feature{core.FeatureImageGeneration}
+	default:
+		return []core.Feature{core.FeatureChat, core.FeatureChatStreaming}
+	}
+}
+
+// Compile-time check that OpenAI implements ModelLister.
+var _ core.ModelLister = (*OpenAI)(nil)
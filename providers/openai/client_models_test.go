@@ -0,0 +1,112 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestListModels(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodGet || r.URL.Path != "/models" {
+			t.Errorf("request = %s %s, want GET /models", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("Authorization = %q", got)
+		}
+		io.WriteString(w, `{"object":"list","data":[
+			{"id":"gpt-5.2","object":"model","created":1700000000,"owned_by":"system"},
+			{"id":"gpt-9-preview","object":"model","created":1800000000,"owned_by":"system"},
+			{"id":"text-embedding-4-large","object":"model","created":1800000000,"owned_by":"system"}]}`)
+	}))
+	defer server.Close()
+
+	var provider core.ModelLister = New("test-key", WithBaseURL(server.URL))
+	models, err := provider.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if len(models) != 3 {
+		t.Fatalf("ListModels() returned %d models, want 3", len(models))
+	}
+
+	static := GetModelInfo(ModelGPT52)
+	if models[0].DisplayName != static.DisplayName || models[0].GetAPIEndpoint() != core.APIEndpointResponses {
+		t.Errorf("models[0] = %+v, want static metadata", models[0])
+	}
+	if models[1].ID != "gpt-9-preview" || !models[1].HasCapability(core.FeatureChat) {
+		t.Errorf("models[1] = %+v", models[1])
+	}
+	if !models[2].HasCapability(core.FeatureEmbeddings) || models[2].HasCapability(core.FeatureChat) {
+		t.Errorf("models[2] = %+v, want embeddings only", models[2])
+	}
+
+	if _, err := provider.ListModels(context.Background()); err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("API calls = %d, want 1 (cached)", calls)
+	}
+}
+
+func TestListModelsCacheDisabled(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		io.WriteString(w, `{"object":"list","data":[]}`)
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL), WithModelCacheTTL(0))
+	provider.ListModels(context.Background())
+	provider.ListModels(context.Background())
+	if calls != 2 {
+		t.Errorf("API calls = %d, want 2", calls)
+	}
+}
+
+func TestListModelsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		io.WriteString(w, `{"error":{"message":"bad key","type":"invalid_request_error"}}`)
+	}))
+	defer server.Close()
+
+	_, err := New("test-key", WithBaseURL(server.URL)).ListModels(context.Background())
+	if !errors.Is(err, core.ErrUnauthorized) {
+		t.Errorf("ListModels() error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestInferCapabilities(t *testing.T) {
+	tests := []struct {
+		id   string
+		want core.Feature
+	}{
+		{"gpt-9", core.FeatureChat},
+		{"text-embedding-9", core.FeatureEmbeddings},
+		{"omni-moderation-2026", core.FeatureModeration},
+		{"whisper-2", core.FeatureTranscription},
+		{"gpt-5-transcribe", core.FeatureTranscription},
+		{"gpt-5-mini-tts", core.FeatureSpeechSynthesis},
+		{"tts-2", core.FeatureSpeechSynthesis},
+		{"gpt-image-2", core.FeatureImageGeneration},
+		{"dall-e-4", core.FeatureImageGeneration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got := inferCapabilities(tt.id)
+			if len(got) == 0 || got[0] != tt.want {
+				t.Errorf("inferCapabilities(%q) = %v, want %v first", tt.id, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+)
+
+const moderationsPath = "/moderations"
+
+// Moderate classifies text and images with the Moderation API. The model
+// defaults to omni-moderation-latest. Text-only inputs are sent in one
+// request; when any input has an image, each input is sent separately so
+// every input gets its own result.
+func (p *OpenAI) Moderate(ctx context.Context, req *core.ModerationRequest) (*core.ModerationResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	model := req.Model
+	if model == "" {
+		model = ModelOmniModerationLatest
+	}
+
+	if !hasModerationImages(req.Input) {
+		texts := make([]string, len(req.Input))
+		for i, in := range req.Input {
+			texts[i] = in.Text
+		}
+		return p.doModeration(ctx, &openAIModerationRequest{Model: string(model), Input: texts})
+	}
+
+	result := &core.ModerationResponse{Model: model}
+	for _, in := range req.Input {
+		resp, err := p.doModeration(ctx, &openAIModerationRequest{
+			Model: string(model),
+			Input: moderationParts(in),
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.Model = resp.Model
+		result.Results = append(result.Results, resp.Results...)
+	}
+	return result, nil
+}
+
+// hasModerationImages reports whether any input has an image.
+func hasModerationImages(inputs []core.ModerationInput) bool {
+	for _, in := range inputs {
+		if in.ImageURL != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// moderationParts converts an input to multimodal content parts.
+func moderationParts(in core.ModerationInput) []openAIModerationPart {
+	var parts []openAIModerationPart
+	if in.Text != "" {
+		parts = append(parts, openAIModerationPart{Type: "text", Text: in.Text})
+	}
+	if in.ImageURL != "" {
+		parts = append(parts, openAIModerationPart{
+			Type:     "image_url",
+			ImageURL: &openAIModerationImageURL{URL: in.ImageURL},
+		})
+	}
+	return parts
+}
+
+// doModeration sends a moderation request and maps the response.
+func (p *OpenAI) doModeration(ctx context.Context, modReq *openAIModerationRequest) (*core.ModerationResponse, error) {
+	body, err := json.Marshal(modReq)
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.BaseURL+moderationsPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, p.responseError(resp.StatusCode, respBody, resp.Header.Get("x-request-id"))
+	}
+
+	var modResp openAIModerationResponse
+	if err := json.Unmarshal(respBody, &modResp); err != nil {
+		return nil, newDecodeError(err)
+	}
+	return mapModerationResponse(&modResp), nil
+}
+
+// mapModerationResponse converts a moderation response to core format.
+func mapModerationResponse(resp *openAIModerationResponse) *core.ModerationResponse {
+	result := &core.ModerationResponse{
+		Model:   core.ModelID(resp.Model),
+		Results: make([]core.ModerationResult, len(resp.Results)),
+	}
+	for i, r := range resp.Results {
+		res := core.ModerationResult{
+			Flagged:    r.Flagged,
+			Categories: make(map[core.ModerationCategory]bool, len(r.Categories)),
+			Scores:     make(map[core.ModerationCategory]float64, len(r.CategoryScores)),
+		}
+		for name, flagged := range r.Categories {
+			res.Categories[moderationCategory(name)] = flagged
+		}
+		for name, score := range r.CategoryScores {
+			res.Scores[moderationCategory(name)] = score
+		}
+		result.Results[i] = res
+	}
+	return result
+}
+
+// moderationCategory normalizes an OpenAI category name such as
+// "self-harm/intent" to a core category.
+func moderationCategory(name string) core.ModerationCategory {
+	return core.ModerationCategory(strings.NewReplacer("/", "_", "-", "_").Replace(name))
+}
+
+// Compile-time check that OpenAI implements Moderator.
+var _ core.Moderator = (*OpenAI)(nil)
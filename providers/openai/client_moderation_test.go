@@ -0,0 +1,96 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestModerateText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/moderations" {
+			t.Errorf("path = %s, want /moderations", r.URL.Path)
+		}
+		var req struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("invalid body: %v", err)
+		}
+		if req.Model != "omni-moderation-latest" || len(req.Input) != 2 {
+			t.Errorf("request = %+v", req)
+		}
+		io.WriteString(w, `{"id":"modr-1","model":"omni-moderation-latest","results":[
+			{"flagged":false,"categories":{"self-harm/intent":false,"harassment":false},"category_scores":{"self-harm/intent":0.01,"harassment":0.02}},
+			{"flagged":true,"categories":{"self-harm/intent":true,"harassment":false},"category_scores":{"self-harm/intent":0.91,"harassment":0.03}}]}`)
+	}))
+	defer server.Close()
+
+	var provider core.Moderator = New("test-key", WithBaseURL(server.URL))
+	resp, err := provider.Moderate(context.Background(), &core.ModerationRequest{
+		Input: []core.ModerationInput{{Text: "hello"}, {Text: "something worrying"}},
+	})
+	if err != nil {
+		t.Fatalf("Moderate() error = %v", err)
+	}
+
+	if len(resp.Results) != 2 || !resp.Flagged() || resp.Results[0].Flagged {
+		t.Fatalf("Moderate() = %+v", resp)
+	}
+	if !resp.Results[1].Categories[core.ModerationSelfHarmIntent] {
+		t.Errorf("Categories = %v", resp.Results[1].Categories)
+	}
+	if got := resp.Results[1].Scores[core.ModerationSelfHarmIntent]; got != 0.91 {
+		t.Errorf("Scores[self_harm_intent] = %v, want 0.91", got)
+	}
+}
+
+func TestModerateImages(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var req struct {
+			Input []openAIModerationPart `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("invalid body: %v", err)
+		}
+		if calls == 1 && (len(req.Input) != 2 || req.Input[1].Type != "image_url" || req.Input[1].ImageURL.URL != "https://example.com/a.png") {
+			t.Errorf("input = %+v", req.Input)
+		}
+		io.WriteString(w, `{"model":"omni-moderation-latest","results":[{"flagged":false,"categories":{"violence/graphic":false},"category_scores":{"violence/graphic":0.1}}]}`)
+	}))
+	defer server.Close()
+
+	provider := New("test-key", WithBaseURL(server.URL))
+	resp, err := provider.Moderate(context.Background(), &core.ModerationRequest{
+		Input: []core.ModerationInput{
+			{Text: "caption", ImageURL: "https://example.com/a.png"},
+			{Text: "plain text"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Moderate() error = %v", err)
+	}
+	if calls != 2 || len(resp.Results) != 2 {
+		t.Errorf("calls = %d, results = %d, want 2 and 2", calls, len(resp.Results))
+	}
+	if _, ok := resp.Results[0].Scores[core.ModerationViolenceGraphic]; !ok {
+		t.Errorf("Scores = %v", resp.Results[0].Scores)
+	}
+}
+
+func TestModerateValidates(t *testing.T) {
+	provider := New("test-key", WithBaseURL("http://127.0.0.1:0"))
+	_, err := provider.Moderate(context.Background(), &core.ModerationRequest{})
+	if !errors.Is(err, core.ErrNoModerationInput) {
+		t.Errorf("Moderate() error = %v, want ErrNoModerationInput", err)
+	}
+}
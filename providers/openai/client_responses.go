@@ -6,6 +6,10 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/erikhoward/iris/core"
 )
@@ -25,7 +29,7 @@ func (p *OpenAI) doResponsesChat(ctx context.Context, req *core.ChatRequest) (*c
 	}
 
 	// Create HTTP request
-	url := p.config.BaseURL + responsesPath
+	url := p.endpoint(responsesPath, req.Model)
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		return nil, newNetworkError(err)
@@ -56,7 +60,7 @@ func (p *OpenAI) doResponsesChat(ctx context.Context, req *core.ChatRequest) (*c
 
 	// Check for error status
 	if resp.StatusCode >= 400 {
-		return nil, normalizeError(resp.StatusCode, respBody, requestID)
+		return nil, p.responseError(resp.StatusCode, respBody, requestID)
 	}
 
 	// Parse response
@@ -66,5 +70,189 @@ func (p *OpenAI) doResponsesChat(ctx context.Context, req *core.ChatRequest) (*c
 	}
 
 	// Map to Iris response
-	return mapResponsesResponse(&respResp)
+	result, err := mapResponsesResponse(&respResp)
+	if err != nil {
+		return nil, err
+	}
+	if isStateless(req) {
+		result.Items = mapResponsesItems(respResp.Output)
+	}
+	return result, nil
+}
+
+// RetrieveResponse returns a stored response, such as one created with
+// ChatBuilder.Background, in its current state.
+func (p *OpenAI) RetrieveResponse(ctx context.Context, responseID string) (*core.ChatResponse, error) {
+	var resp responsesResponse
+	if err := p.doResponsesRequest(ctx, http.MethodGet, p.responseURL(responseID, "", nil), &resp); err != nil {
+		return nil, err
+	}
+	return mapResponsesResponse(&resp)
+}
+
+// WaitForResponse polls a background response every interval until it is
+// no longer queued or in progress. An interval of zero or less polls every
+// 2 seconds. A failed response is returned with an error describing the
+// failure.
+func (p *OpenAI) WaitForResponse(ctx context.Context, responseID string, interval time.Duration) (*core.ChatResponse, error) {
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		var resp responsesResponse
+		if err := p.doResponsesRequest(ctx, http.MethodGet, p.responseURL(responseID, "", nil), &resp); err != nil {
+			return nil, err
+		}
+
+		switch resp.Status {
+		case ResponseStatusQueued, ResponseStatusInProgress:
+		case ResponseStatusFailed:
+			result, err := mapResponsesResponse(&resp)
+			if err != nil {
+				return nil, err
+			}
+			return result, p.responseFailedError(&resp)
+		default:
+			return mapResponsesResponse(&resp)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// CancelResponse cancels a background response and returns it with its
+// updated status.
+func (p *OpenAI) CancelResponse(ctx context.Context, responseID string) (*core.ChatResponse, error) {
+	var resp responsesResponse
+	if err := p.doResponsesRequest(ctx, http.MethodPost, p.responseURL(responseID, "/cancel", nil), &resp); err != nil {
+		return nil, err
+	}
+	return mapResponsesResponse(&resp)
+}
+
+// DeleteResponse deletes a stored response.
+func (p *OpenAI) DeleteResponse(ctx context.Context, responseID string) error {
+	var resp struct {
+		ID      string `json:"id"`
+		Deleted bool   `json:"deleted"`
+	}
+	return p.doResponsesRequest(ctx, http.MethodDelete, p.responseURL(responseID, "", nil), &resp)
+}
+
+// ListResponseInputItems returns the input items of a stored response.
+func (p *OpenAI) ListResponseInputItems(ctx context.Context, responseID string, req *ResponseInputItemsRequest) (*ResponseInputItemsResponse, error) {
+	query := url.Values{}
+	if req != nil {
+		if req.Limit != nil {
+			query.Set("limit", strconv.Itoa(*req.Limit))
+		}
+		if req.After != nil {
+			query.Set("after", *req.After)
+		}
+		if req.Before != nil {
+			query.Set("before", *req.Before)
+		}
+		if req.Order != nil {
+			query.Set("order", *req.Order)
+		}
+	}
+
+	var list ResponseInputItemsResponse
+	if err := p.doResponsesRequest(ctx, http.MethodGet, p.responseURL(responseID, "/input_items", query), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// StreamResponse streams a background response, starting after the event
+// with the given sequence number. Pass 0 to stream from the beginning, or
+// the Sequence of the last chunk received to resume an interrupted stream.
+func (p *OpenAI) StreamResponse(ctx context.Context, responseID string, startingAfter int) (*core.ChatStream, error) {
+	query := url.Values{"stream": {"true"}}
+	if startingAfter > 0 {
+		query.Set("starting_after", strconv.Itoa(startingAfter))
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, p.responseURL(responseID, "", query), nil)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	return p.startResponsesStream(ctx, httpReq, false)
+}
+
+// responseURL returns the URL of a stored response, or of one of its
+// sub-resources when suffix is set.
+func (p *OpenAI) responseURL(responseID, suffix string, query url.Values) string {
+	u := p.endpoint(responsesPath+"/"+url.PathEscape(responseID)+suffix, "")
+	if len(query) == 0 {
+		return u
+	}
+	if strings.Contains(u, "?") {
+		return u + "&" + query.Encode()
+	}
+	return u + "?" + query.Encode()
+}
+
+// doResponsesRequest sends a request without a body to the Responses API
+// and decodes the JSON result into out.
+func (p *OpenAI) doResponsesRequest(ctx context.Context, method, fullURL string, out any) error {
+	httpReq, err := http.NewRequestWithContext(ctx, method, fullURL, nil)
+	if err != nil {
+		return newNetworkError(err)
+	}
+
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return p.responseError(resp.StatusCode, respBody, resp.Header.Get("x-request-id"))
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return newDecodeError(err)
+	}
+	return nil
+}
+
+// responseFailedError describes why a response failed or was cancelled.
+func (p *OpenAI) responseFailedError(resp *responsesResponse) error {
+	code, message := "response_failed", "response failed"
+	if resp.Status == ResponseStatusCancelled {
+		code, message = "response_cancelled", "response cancelled"
+	}
+	if resp.Error != nil {
+		if resp.Error.Code != "" {
+			code = resp.Error.Code
+		}
+		if resp.Error.Message != "" {
+			message = resp.Error.Message
+		}
+	}
+	return &core.ProviderError{
+		Provider: p.ID(),
+		Code:     code,
+		Message:  message,
+		Err:      core.ErrServer,
+	}
 }
@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/erikhoward/iris/core"
 )
@@ -808,3 +810,404 @@ func TestResponsesAPIMultimodalFileWithID(t *testing.T) {
 		t.Errorf("Output = %q, want expected text", resp.Output)
 	}
 }
+
+func TestResponsesAPIBackgroundRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqBody map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if reqBody["background"] != true {
+			t.Errorf("background = %v, want true", reqBody["background"])
+		}
+		if reqBody["store"] != true {
+			t.Errorf("store = %v, want true", reqBody["store"])
+		}
+
+		json.NewEncoder(w).Encode(responsesResponse{ID: "resp_1", Model: "gpt-5.2", Status: ResponseStatusQueued})
+	}))
+	defer server.Close()
+
+	store := true
+	p := New("test-key", WithBaseURL(server.URL))
+	resp, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:      ModelGPT52,
+		Messages:   []core.Message{{Role: core.RoleUser, Content: "Write a long report"}},
+		Background: true,
+		Store:      &store,
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.ID != "resp_1" || resp.Status != ResponseStatusQueued {
+		t.Errorf("response = %+v, want queued resp_1", resp)
+	}
+}
+
+func TestRetrieveResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Method = %q, want GET", r.Method)
+		}
+		if r.URL.Path != "/responses/resp_1" {
+			t.Errorf("Path = %q, want /responses/resp_1", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(responsesResponse{ID: "resp_1", Status: ResponseStatusCompleted, OutputText: "done"})
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	resp, err := p.RetrieveResponse(context.Background(), "resp_1")
+	if err != nil {
+		t.Fatalf("RetrieveResponse() error = %v", err)
+	}
+	if resp.Output != "done" {
+		t.Errorf("Output = %q, want %q", resp.Output, "done")
+	}
+}
+
+func TestRetrieveResponseNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"message":"No response found","type":"invalid_request_error"}}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	if _, err := p.RetrieveResponse(context.Background(), "resp_missing"); err == nil {
+		t.Fatal("RetrieveResponse() error = nil, want error")
+	}
+}
+
+func TestBackgroundRejectedOnChatCompletions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	store := false
+	for name, req := range map[string]*core.ChatRequest{
+		"background": {Model: "custom-model", Messages: []core.Message{{Role: core.RoleUser, Content: "Hi"}}, Background: true},
+		"store":      {Model: ModelGPT4o, Messages: []core.Message{{Role: core.RoleUser, Content: "Hi"}}, Store: &store},
+	} {
+		if _, err := p.Chat(context.Background(), req); !errors.Is(err, core.ErrResponsesAPIRequired) {
+			t.Errorf("%s: Chat() error = %v, want ErrResponsesAPIRequired", name, err)
+		}
+		if _, err := p.StreamChat(context.Background(), req); !errors.Is(err, core.ErrResponsesAPIRequired) {
+			t.Errorf("%s: StreamChat() error = %v, want ErrResponsesAPIRequired", name, err)
+		}
+	}
+}
+
+func TestWaitForResponse(t *testing.T) {
+	statuses := []string{ResponseStatusQueued, ResponseStatusInProgress, ResponseStatusCompleted}
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[min(calls, len(statuses)-1)]
+		calls++
+		json.NewEncoder(w).Encode(responsesResponse{ID: "resp_1", Status: status, OutputText: "done"})
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	resp, err := p.WaitForResponse(context.Background(), "resp_1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitForResponse() error = %v", err)
+	}
+	if resp.Status != ResponseStatusCompleted || calls != 3 {
+		t.Errorf("Status = %q after %d calls, want completed after 3", resp.Status, calls)
+	}
+}
+
+func TestWaitForResponseDefaultInterval(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(responsesResponse{ID: "resp_1", Status: ResponseStatusCompleted, OutputText: "done"})
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	resp, err := p.WaitForResponse(context.Background(), "resp_1", 0)
+	if err != nil {
+		t.Fatalf("WaitForResponse() error = %v", err)
+	}
+	if resp.Output != "done" {
+		t.Errorf("Output = %q, want %q", resp.Output, "done")
+	}
+}
+
+func TestWaitForResponseFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(responsesResponse{
+			ID:     "resp_1",
+			Status: ResponseStatusFailed,
+			Error:  &responsesError{Code: "server_error", Message: "The model crashed"},
+		})
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	resp, err := p.WaitForResponse(context.Background(), "resp_1", time.Millisecond)
+	if !errors.Is(err, core.ErrServer) {
+		t.Fatalf("WaitForResponse() error = %v, want ErrServer", err)
+	}
+	var provErr *core.ProviderError
+	if !errors.As(err, &provErr) || provErr.Code != "server_error" || provErr.Message != "The model crashed" {
+		t.Errorf("error = %+v, want the response's error", err)
+	}
+	if resp == nil || resp.Status != ResponseStatusFailed {
+		t.Errorf("response = %+v, want the failed response", resp)
+	}
+}
+
+func TestWaitForResponseContextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(responsesResponse{ID: "resp_1", Status: ResponseStatusInProgress})
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	if _, err := p.WaitForResponse(ctx, "resp_1", 100*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForResponse() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestCancelResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Method = %q, want POST", r.Method)
+		}
+		if r.URL.Path != "/responses/resp_1/cancel" {
+			t.Errorf("Path = %q, want /responses/resp_1/cancel", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(responsesResponse{ID: "resp_1", Status: ResponseStatusCancelled})
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	resp, err := p.CancelResponse(context.Background(), "resp_1")
+	if err != nil {
+		t.Fatalf("CancelResponse() error = %v", err)
+	}
+	if resp.Status != ResponseStatusCancelled {
+		t.Errorf("Status = %q, want %q", resp.Status, ResponseStatusCancelled)
+	}
+}
+
+func TestDeleteResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("Method = %q, want DELETE", r.Method)
+		}
+		if r.URL.Path != "/responses/resp_1" {
+			t.Errorf("Path = %q, want /responses/resp_1", r.URL.Path)
+		}
+		w.Write([]byte(`{"id":"resp_1","object":"response","deleted":true}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	if err := p.DeleteResponse(context.Background(), "resp_1"); err != nil {
+		t.Fatalf("DeleteResponse() error = %v", err)
+	}
+}
+
+func TestListResponseInputItems(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/responses/resp_1/input_items" {
+			t.Errorf("Path = %q, want /responses/resp_1/input_items", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("limit") != "2" || q.Get("after") != "msg_0" || q.Get("order") != "asc" {
+			t.Errorf("query = %v, want limit, after and order", q)
+		}
+		w.Write([]byte(`{
+			"object": "list",
+			"data": [
+				{"id": "msg_1", "type": "message", "role": "user", "content": [{"type": "input_text", "text": "Hello"}]},
+				{"id": "fco_1", "type": "function_call_output", "call_id": "call_1", "output": "72F"}
+			],
+			"has_more": true,
+			"first_id": "msg_1",
+			"last_id": "fco_1"
+		}`))
+	}))
+	defer server.Close()
+
+	limit, after, order := 2, "msg_0", "asc"
+	p := New("test-key", WithBaseURL(server.URL))
+	list, err := p.ListResponseInputItems(context.Background(), "resp_1", &ResponseInputItemsRequest{
+		Limit: &limit,
+		After: &after,
+		Order: &order,
+	})
+	if err != nil {
+		t.Fatalf("ListResponseInputItems() error = %v", err)
+	}
+	if len(list.Data) != 2 || !list.HasMore || list.LastID != "fco_1" {
+		t.Fatalf("list = %+v", list)
+	}
+	if list.Data[0].Content[0].Text != "Hello" || list.Data[1].CallID != "call_1" {
+		t.Errorf("Data = %+v", list.Data)
+	}
+}
+
+func TestStreamResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Method = %q, want GET", r.Method)
+		}
+		if r.URL.Path != "/responses/resp_1" {
+			t.Errorf("Path = %q, want /responses/resp_1", r.URL.Path)
+		}
+		if q := r.URL.Query(); q.Get("stream") != "true" || q.Get("starting_after") != "5" {
+			t.Errorf("query = %v, want stream=true&starting_after=5", q)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		events := []string{
+			`data: {"type":"response.output_text.delta","delta":"lo","sequence_number":6}`,
+			`data: {"type":"response.output_text.delta","delta":" World","sequence_number":7}`,
+			`data: {"type":"response.completed","sequence_number":8,"response":{"id":"resp_1","status":"completed"}}`,
+		}
+		for _, event := range events {
+			fmt.Fprintf(w, "%s\n\n", event)
+		}
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	stream, err := p.StreamResponse(context.Background(), "resp_1", 5)
+	if err != nil {
+		t.Fatalf("StreamResponse() error = %v", err)
+	}
+
+	var chunks []core.ChatChunk
+	for chunk := range stream.Ch {
+		chunks = append(chunks, chunk)
+	}
+	if len(chunks) != 2 || chunks[0].Delta != "lo" || chunks[0].Sequence != 6 || chunks[1].Sequence != 7 {
+		t.Errorf("chunks = %+v, want deltas with sequence numbers", chunks)
+	}
+
+	select {
+	case resp := <-stream.Final:
+		if resp == nil || resp.Status != ResponseStatusCompleted {
+			t.Errorf("Final = %+v, want completed", resp)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for final response")
+	}
+}
+
+func TestStreamResponseFailed(t *testing.T) {
+	tests := []struct {
+		event string
+		code  string
+	}{
+		{`{"type":"response.failed","response":{"id":"resp_1","status":"failed","error":{"code":"server_error","message":"The model crashed"}}}`, "server_error"},
+		{`{"type":"response.cancelled","response":{"id":"resp_1","status":"cancelled"}}`, "response_cancelled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				fmt.Fprintf(w, "data: %s\n\n", `{"type":"response.output_text.delta","delta":"Hel","sequence_number":1}`)
+				fmt.Fprintf(w, "data: %s\n\n", tt.event)
+			}))
+			defer server.Close()
+
+			p := New("test-key", WithBaseURL(server.URL))
+			stream, err := p.StreamResponse(context.Background(), "resp_1", 0)
+			if err != nil {
+				t.Fatalf("StreamResponse() error = %v", err)
+			}
+			for range stream.Ch {
+			}
+
+			select {
+			case err := <-stream.Err:
+				var provErr *core.ProviderError
+				if !errors.As(err, &provErr) || provErr.Code != tt.code || !errors.Is(err, core.ErrServer) {
+					t.Errorf("Err = %v, want ProviderError with code %q", err, tt.code)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("Timeout waiting for error")
+			}
+			if resp, ok := <-stream.Final; ok && resp != nil {
+				t.Errorf("Final = %+v, want none", resp)
+			}
+		})
+	}
+}
+
+func TestResponsesAPIStatelessConversation(t *testing.T) {
+	var requests []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqBody map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		requests = append(requests, reqBody)
+
+		w.Write([]byte(`{
+			"id": "resp_` + fmt.Sprint(len(requests)) + `",
+			"status": "completed",
+			"output": [
+				{"type":"reasoning","id":"rs_1","summary":[],"encrypted_content":"gAAAA"},
+				{"type":"message","id":"msg_1","role":"assistant","content":[{"type":"output_text","text":"4"}]}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := core.NewClient(New("test-key", WithBaseURL(server.URL)))
+	first, err := client.Chat(ModelGPT52).User("What is 2+2?").Store(false).GetResponse(context.Background())
+	if err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if len(first.Items) != 2 || first.Items[0].Type != "reasoning" ||
+		!strings.Contains(string(first.Items[0].Raw), `"encrypted_content":"gAAAA"`) {
+		t.Fatalf("Items = %+v, want reasoning and message items", first.Items)
+	}
+
+	_, err = client.Chat(ModelGPT52).
+		User("What is 2+2?").
+		ContinueWith(first).
+		User("And times 3?").
+		Store(false).
+		GetResponse(context.Background())
+	if err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+
+	second := requests[1]
+	if _, ok := second["previous_response_id"]; ok {
+		t.Error("stateless continuation sent previous_response_id")
+	}
+	input, _ := second["input"].([]any)
+	if len(input) != 4 {
+		t.Fatalf("len(input) = %d, want 4", len(input))
+	}
+	if item, _ := input[1].(map[string]any); item["encrypted_content"] != "gAAAA" {
+		t.Errorf("input[1] = %v, want the replayed reasoning item", input[1])
+	}
+
+	// Stored responses have no items and chain by ID
+	stored, err := client.Chat(ModelGPT52).User("Hi").GetResponse(context.Background())
+	if err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if len(stored.Items) != 0 {
+		t.Errorf("Items = %+v, want none for a stored response", stored.Items)
+	}
+	if _, err := client.Chat(ModelGPT52).ContinueWith(stored).User("More").GetResponse(context.Background()); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if requests[3]["previous_response_id"] != stored.ID {
+		t.Errorf("previous_response_id = %v, want %q", requests[3]["previous_response_id"], stored.ID)
+	}
+}
@@ -0,0 +1,62 @@
+package openai
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers/providertest"
+)
+
+func TestConformance(t *testing.T) {
+	sse := http.Header{"Content-Type": {"text/event-stream"}}
+
+	suite := providertest.Suite{
+		NewProvider: func(baseURL string) core.Provider {
+			return New("test-key", WithBaseURL(baseURL))
+		},
+		Model: "gpt-4o",
+		Chat: &providertest.Fixture{
+			Body:   `{"id":"chatcmpl-1","model":"gpt-4o","choices":[{"index":0,"message":{"role":"assistant","content":"Hello there"},"finish_reason":"stop"}],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`,
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		Stream: &providertest.Fixture{
+			Header: sse,
+			Body: sseResponse(
+				`{"id":"chatcmpl-2","model":"gpt-4o","choices":[{"index":0,"delta":{"content":"Hello"}}]}`,
+				`{"id":"chatcmpl-2","model":"gpt-4o","choices":[{"index":0,"delta":{"content":" there"}}],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`,
+				"[DONE]",
+			),
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		ToolCall: &providertest.Fixture{
+			Body: `{"id":"chatcmpl-3","model":"gpt-4o","choices":[{"index":0,"message":{"role":"assistant","content":"","tool_calls":[{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"location\": \"NYC\"}"}}]},"finish_reason":"tool_calls"}]}`,
+			ToolCalls: []core.ToolCall{
+				{ID: "call_1", Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		StreamToolCall: &providertest.Fixture{
+			Header: sse,
+			Body: sseResponse(
+				`{"id":"chatcmpl-4","model":"gpt-4o","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_2","type":"function","function":{"name":"get_weather","arguments":"{\"location\":"}}]}}]}`,
+				`{"id":"chatcmpl-4","model":"gpt-4o","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":" \"NYC\"}"}}]}}]}`,
+				"[DONE]",
+			),
+			ToolCalls: []core.ToolCall{
+				{ID: "call_2", Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		Cancel: &providertest.Fixture{
+			Header: sse,
+			Body:   sseResponse(`{"id":"chatcmpl-5","model":"gpt-4o","choices":[{"index":0,"delta":{"content":"Hello"}}]}`),
+		},
+		ErrorBody: func(status int) string {
+			return `{"error":{"message":"request failed","type":"test_error","code":"test_code"}}`
+		},
+	}
+
+	suite.Run(t)
+}
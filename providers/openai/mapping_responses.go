@@ -47,6 +47,15 @@ func buildResponsesRequest(req *core.ChatRequest, stream bool) *responsesRequest
 		respReq.Truncation = req.Truncation
 	}
 
+	respReq.Background = req.Background
+	respReq.Store = req.Store
+
+	// Stateless requests carry reasoning forward in the input instead of
+	// through stored responses
+	if isStateless(req) {
+		respReq.Include = []string{"reasoning.encrypted_content"}
+	}
+
 	// Map tools (both custom and built-in)
 	respReq.Tools = mapResponsesTools(req.Tools, req.BuiltInTools)
 
@@ -93,6 +102,14 @@ func buildResponsesInput(msgs []core.Message, instructions string) responsesInpu
 			continue
 		}
 
+		// Replay the output items of a stateless response as is
+		if len(msg.Items) > 0 {
+			for _, item := range msg.Items {
+				messages = append(messages, responsesInputMessage{Item: item.Raw})
+			}
+			continue
+		}
+
 		role := string(msg.Role)
 		// Responses API uses "developer" instead of "system" for system messages
 		if msg.Role == core.RoleSystem {
@@ -266,3 +283,21 @@ func mapResponsesResponse(resp *responsesResponse) (*core.ChatResponse, error) {
 
 	return result, nil
 }
+
+// isStateless reports whether the request asks the provider not to store
+// the response.
+func isStateless(req *core.ChatRequest) bool {
+	return req.Store != nil && !*req.Store
+}
+
+// mapResponsesItems returns the output items for replaying a stateless
+// response.
+func mapResponsesItems(output []responsesOutput) []core.ResponseItem {
+	items := make([]core.ResponseItem, 0, len(output))
+	for _, item := range output {
+		if len(item.raw) > 0 {
+			items = append(items, core.ResponseItem{Type: item.Type, Raw: item.raw})
+		}
+	}
+	return items
+}
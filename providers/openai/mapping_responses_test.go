@@ -177,6 +177,57 @@ func TestBuildResponsesInputWithInstructions(t *testing.T) {
 	}
 }
 
+func TestBuildResponsesRequestStateless(t *testing.T) {
+	store := false
+	req := &core.ChatRequest{
+		Model: ModelGPT52,
+		Messages: []core.Message{
+			{Role: core.RoleUser, Content: "What is 2+2?"},
+			{Role: core.RoleAssistant, Content: "4", Items: []core.ResponseItem{
+				{Type: "reasoning", Raw: json.RawMessage(`{"type":"reasoning","id":"rs_1","encrypted_content":"gAAA"}`)},
+				{Type: "message", Raw: json.RawMessage(`{"type":"message","id":"msg_1","role":"assistant","content":[{"type":"output_text","text":"4"}]}`)},
+			}},
+			{Role: core.RoleUser, Content: "And times 3?"},
+		},
+		Store: &store,
+	}
+
+	body, err := json.Marshal(buildResponsesRequest(req, false))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var got struct {
+		Store   *bool             `json:"store"`
+		Include []string          `json:"include"`
+		Input   []json.RawMessage `json:"input"`
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got.Store == nil || *got.Store {
+		t.Errorf("store = %v, want false", got.Store)
+	}
+	if len(got.Include) != 1 || got.Include[0] != "reasoning.encrypted_content" {
+		t.Errorf("include = %v, want reasoning.encrypted_content", got.Include)
+	}
+	if len(got.Input) != 4 {
+		t.Fatalf("len(input) = %d, want 4", len(got.Input))
+	}
+	if string(got.Input[1]) != `{"type":"reasoning","id":"rs_1","encrypted_content":"gAAA"}` {
+		t.Errorf("input[1] = %s, want the reasoning item unchanged", got.Input[1])
+	}
+	if string(got.Input[3]) != `{"role":"user","content":"And times 3?"}` {
+		t.Errorf("input[3] = %s", got.Input[3])
+	}
+
+	// Stored requests do not ask for encrypted reasoning
+	store = true
+	if include := buildResponsesRequest(req, false).Include; include != nil {
+		t.Errorf("Include = %v, want nil when stored", include)
+	}
+}
+
 func TestMapResponsesToolsBuiltIn(t *testing.T) {
 	builtIn := []core.BuiltInTool{
 		{Type: "web_search"},
@@ -56,6 +56,18 @@ const (
 	ModelDALLE3             core.ModelID = "dall-e-3"
 	ModelDALLE2             core.ModelID = "dall-e-2"
 	ModelChatGPTImageLatest core.ModelID = "chatgpt-image-latest"
+
+	// Audio models
+	ModelWhisper1            core.ModelID = "whisper-1"
+	ModelGPT4oTranscribe     core.ModelID = "gpt-4o-transcribe"
+	ModelGPT4oMiniTranscribe core.ModelID = "gpt-4o-mini-transcribe"
+	ModelGPT4oMiniTTS        core.ModelID = "gpt-4o-mini-tts"
+	ModelTTS1                core.ModelID = "tts-1"
+	ModelTTS1HD              core.ModelID = "tts-1-hd"
+
+	// Moderation models
+	ModelOmniModerationLatest core.ModelID = "omni-moderation-latest"
+	ModelTextModerationLatest core.ModelID = "text-moderation-latest"
 )
 
 // models is the static list of supported models.
@@ -447,6 +459,64 @@ var models = []core.ModelInfo{
 			core.FeatureImageGeneration,
 		},
 	},
+	// Audio models
+	{
+		ID:          ModelWhisper1,
+		DisplayName: "Whisper",
+		Capabilities: []core.Feature{
+			core.FeatureTranscription,
+		},
+	},
+	{
+		ID:          ModelGPT4oTranscribe,
+		DisplayName: "GPT-4o Transcribe",
+		Capabilities: []core.Feature{
+			core.FeatureTranscription,
+		},
+	},
+	{
+		ID:          ModelGPT4oMiniTranscribe,
+		DisplayName: "GPT-4o Mini Transcribe",
+		Capabilities: []core.Feature{
+			core.FeatureTranscription,
+		},
+	},
+	{
+		ID:          ModelGPT4oMiniTTS,
+		DisplayName: "GPT-4o Mini TTS",
+		Capabilities: []core.Feature{
+			core.FeatureSpeechSynthesis,
+		},
+	},
+	{
+		ID:          ModelTTS1,
+		DisplayName: "TTS 1",
+		Capabilities: []core.Feature{
+			core.FeatureSpeechSynthesis,
+		},
+	},
+	{
+		ID:          ModelTTS1HD,
+		DisplayName: "TTS 1 HD",
+		Capabilities: []core.Feature{
+			core.FeatureSpeechSynthesis,
+		},
+	},
+	// Moderation models
+	{
+		ID:          ModelOmniModerationLatest,
+		DisplayName: "Omni Moderation",
+		Capabilities: []core.Feature{
+			core.FeatureModeration,
+		},
+	},
+	{
+		ID:          ModelTextModerationLatest,
+		DisplayName: "Text Moderation",
+		Capabilities: []core.Feature{
+			core.FeatureModeration,
+		},
+	},
 }
 
 // modelRegistry is a map for quick model lookup by ID.
@@ -27,6 +27,10 @@ type Config struct {
 
 	// Timeout is the optional request timeout.
 	Timeout time.Duration
+
+	// ModelCacheTTL is how long ListModels results are cached.
+	// Defaults to core.DefaultModelCacheTTL; zero or less disables caching.
+	ModelCacheTTL time.Duration
 }
 
 // DefaultBaseURL is the default OpenAI API base URL.
@@ -79,3 +83,11 @@ func WithTimeout(d time.Duration) Option {
 		c.Timeout = d
 	}
 }
+
+// WithModelCacheTTL sets how long ListModels results are cached.
+// A duration of zero or less disables caching.
+func WithModelCacheTTL(d time.Duration) Option {
+	return func(c *Config) {
+		c.ModelCacheTTL = d
+	}
+}
@@ -2,6 +2,7 @@ package openai
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/erikhoward/iris/core"
@@ -11,21 +12,29 @@ import (
 // OpenAI is safe for concurrent use.
 type OpenAI struct {
 	config Config
+
+	// azure routes requests to an Azure OpenAI resource when set (see NewAzure).
+	azure *AzureConfig
+
+	modelCache *core.ModelCache // caches ListModels results
 }
 
 // New creates a new OpenAI provider with the given API key and options.
 func New(apiKey string, opts ...Option) *OpenAI {
 	cfg := Config{
-		APIKey:     apiKey,
-		BaseURL:    DefaultBaseURL,
-		HTTPClient: http.DefaultClient,
+		APIKey:        apiKey,
+		BaseURL:       DefaultBaseURL,
+		HTTPClient:    http.DefaultClient,
+		ModelCacheTTL: core.DefaultModelCacheTTL,
 	}
 
 	for _, opt := range opts {
 		opt(&cfg)
 	}
 
-	return &OpenAI{config: cfg}
+	p := &OpenAI{config: cfg}
+	p.modelCache = core.NewModelCache(cfg.ModelCacheTTL, p.fetchModels)
+	return p
 }
 
 // ID returns the provider identifier.
@@ -44,7 +53,8 @@ func (p *OpenAI) Models() []core.ModelInfo {
 // Supports reports whether the provider supports the given feature.
 func (p *OpenAI) Supports(feature core.Feature) bool {
 	switch feature {
-	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureImageGeneration, core.FeatureEmbeddings:
+	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureImageGeneration, core.FeatureEmbeddings,
+		core.FeatureFiles, core.FeatureBatch, core.FeatureTranscription, core.FeatureSpeechSynthesis, core.FeatureModeration, core.FeatureModelListing:
 		return true
 	default:
 		return false
@@ -56,7 +66,11 @@ func (p *OpenAI) buildHeaders() http.Header {
 	headers := make(http.Header)
 
 	// Required headers
-	headers.Set("Authorization", "Bearer "+p.config.APIKey)
+	if p.azure != nil && p.azure.AuthStyle == AzureAuthAPIKey {
+		headers.Set("api-key", p.config.APIKey)
+	} else {
+		headers.Set("Authorization", "Bearer "+p.config.APIKey)
+	}
 	headers.Set("Content-Type", "application/json")
 
 	// Optional organization header
@@ -79,6 +93,24 @@ func (p *OpenAI) buildHeaders() http.Header {
 	return headers
 }
 
+// endpoint returns the URL for an API path.
+// Azure resources address chat and embeddings through the model's deployment
+// and require an api-version query parameter.
+func (p *OpenAI) endpoint(path string, model core.ModelID) string {
+	if p.azure != nil {
+		return p.azure.url(path, model)
+	}
+	return p.config.BaseURL + path
+}
+
+// responseError converts an HTTP error response to a ProviderError.
+func (p *OpenAI) responseError(status int, body []byte, requestID string) error {
+	if p.azure != nil {
+		return normalizeAzureError(status, body, requestID)
+	}
+	return normalizeError(status, body, requestID)
+}
+
 // Chat sends a non-streaming chat request.
 // Routes to either the Chat Completions API or Responses API based on the model.
 func (p *OpenAI) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
@@ -109,6 +141,15 @@ func (p *OpenAI) shouldUseResponsesAPI(model core.ModelID) bool {
 	return info.GetAPIEndpoint() == core.APIEndpointResponses
 }
 
+// checkCompletionsRequest rejects options that only the Responses API
+// supports, rather than sending a synchronous, stored request instead.
+func checkCompletionsRequest(req *core.ChatRequest) error {
+	if req.Background || req.Store != nil {
+		return fmt.Errorf("%w: %s", core.ErrResponsesAPIRequired, req.Model)
+	}
+	return nil
+}
+
 // Compile-time check that OpenAI implements Provider.
 var _ core.Provider = (*OpenAI)(nil)
 
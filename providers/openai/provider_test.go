@@ -65,19 +65,20 @@ func TestModelsHaveCapabilities(t *testing.T) {
 			t.Errorf("Model %s has no capabilities", m.ID)
 		}
 
-		// All chat models should support chat (image-only models are exempt)
+		// All chat models should support chat (image, audio, and moderation models are exempt)
 		hasChat := false
-		hasImageGen := false
+		specialized := false
 		for _, cap := range m.Capabilities {
 			if cap == core.FeatureChat {
 				hasChat = true
 			}
-			if cap == core.FeatureImageGeneration {
-				hasImageGen = true
+			if cap == core.FeatureImageGeneration || cap == core.FeatureTranscription || cap == core.FeatureSpeechSynthesis ||
+				cap == core.FeatureModeration {
+				specialized = true
 			}
 		}
-		// Only require FeatureChat if it's not an image-only model
-		if !hasChat && !hasImageGen {
+		// Only require FeatureChat if it's not an image, audio, or moderation model
+		if !hasChat && !specialized {
 			t.Errorf("Model %s missing FeatureChat capability", m.ID)
 		}
 	}
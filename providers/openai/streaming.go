@@ -119,6 +119,10 @@ func (a *toolCallAssembler) finalize() ([]core.ToolCall, error) {
 
 // doStreamChat performs a streaming chat completion request.
 func (p *OpenAI) doStreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	if err := checkCompletionsRequest(req); err != nil {
+		return nil, err
+	}
+
 	// Build OpenAI request with stream=true
 	oaiReq := buildRequest(req, true)
 
@@ -129,7 +133,7 @@ func (p *OpenAI) doStreamChat(ctx context.Context, req *core.ChatRequest) (*core
 	}
 
 	// Create HTTP request
-	url := p.config.BaseURL + chatCompletionsPath
+	url := p.endpoint(chatCompletionsPath, req.Model)
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		return nil, newNetworkError(err)
@@ -155,7 +159,7 @@ func (p *OpenAI) doStreamChat(ctx context.Context, req *core.ChatRequest) (*core
 	if resp.StatusCode >= 400 {
 		defer resp.Body.Close()
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, normalizeError(resp.StatusCode, respBody, requestID)
+		return nil, p.responseError(resp.StatusCode, respBody, requestID)
 	}
 
 	// Create channels
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/erikhoward/iris/core"
@@ -24,12 +25,19 @@ func (p *OpenAI) doResponsesStreamChat(ctx context.Context, req *core.ChatReques
 	}
 
 	// Create HTTP request
-	url := p.config.BaseURL + responsesPath
+	url := p.endpoint(responsesPath, req.Model)
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		return nil, newNetworkError(err)
 	}
 
+	return p.startResponsesStream(ctx, httpReq, isStateless(req))
+}
+
+// startResponsesStream sends a request that returns Responses API events
+// and streams them. When stateless is set, the final response carries the
+// output items.
+func (p *OpenAI) startResponsesStream(ctx context.Context, httpReq *http.Request, stateless bool) (*core.ChatStream, error) {
 	// Set headers
 	for key, values := range p.buildHeaders() {
 		for _, v := range values {
@@ -50,7 +58,7 @@ func (p *OpenAI) doResponsesStreamChat(ctx context.Context, req *core.ChatReques
 	if resp.StatusCode >= 400 {
 		defer resp.Body.Close()
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, normalizeError(resp.StatusCode, respBody, requestID)
+		return nil, p.responseError(resp.StatusCode, respBody, requestID)
 	}
 
 	// Create channels
@@ -59,7 +67,7 @@ func (p *OpenAI) doResponsesStreamChat(ctx context.Context, req *core.ChatReques
 	finalCh := make(chan *core.ChatResponse, 1)
 
 	// Start goroutine to process SSE stream
-	go p.processResponsesStream(ctx, resp.Body, chunkCh, errCh, finalCh)
+	go p.processResponsesStream(ctx, resp.Body, stateless, chunkCh, errCh, finalCh)
 
 	return &core.ChatStream{
 		Ch:    chunkCh,
@@ -76,11 +84,15 @@ type responsesStreamState struct {
 	usage         *responsesUsage
 	toolCalls     map[int]*assemblingToolCall // index -> tool call being assembled
 	reasoning     []string                    // reasoning summaries
+	stateless     bool                        // whether to collect output items
+	items         map[int]responsesOutput     // index -> completed output item
 }
 
-func newResponsesStreamState() *responsesStreamState {
+func newResponsesStreamState(stateless bool) *responsesStreamState {
 	return &responsesStreamState{
 		toolCalls: make(map[int]*assemblingToolCall),
+		stateless: stateless,
+		items:     make(map[int]responsesOutput),
 	}
 }
 
@@ -88,6 +100,7 @@ func newResponsesStreamState() *responsesStreamState {
 func (p *OpenAI) processResponsesStream(
 	ctx context.Context,
 	body io.ReadCloser,
+	stateless bool,
 	chunkCh chan<- core.ChatChunk,
 	errCh chan<- error,
 	finalCh chan<- *core.ChatResponse,
@@ -98,7 +111,7 @@ func (p *OpenAI) processResponsesStream(
 	defer close(finalCh)
 
 	reader := bufio.NewReader(body)
-	state := newResponsesStreamState()
+	state := newResponsesStreamState(stateless)
 
 	for {
 		// Check for context cancellation
@@ -191,6 +204,20 @@ func (p *OpenAI) processResponsesStream(
 		}
 	}
 
+	// Collect output items in order for stateless replay
+	if state.stateless && len(state.items) > 0 {
+		indexes := make([]int, 0, len(state.items))
+		for idx := range state.items {
+			indexes = append(indexes, idx)
+		}
+		sort.Ints(indexes)
+		output := make([]responsesOutput, 0, len(indexes))
+		for _, idx := range indexes {
+			output = append(output, state.items[idx])
+		}
+		finalResp.Items = mapResponsesItems(output)
+	}
+
 	finalCh <- finalResp
 }
 
@@ -213,7 +240,7 @@ func (p *OpenAI) handleResponsesStreamEvent(
 			}
 		}
 
-	case "response.completed":
+	case "response.completed", "response.incomplete":
 		// Final response with usage
 		if len(event.Response) > 0 {
 			var resp responsesResponse
@@ -225,6 +252,14 @@ func (p *OpenAI) handleResponsesStreamEvent(
 			}
 		}
 
+	case "response.failed", "response.cancelled":
+		// A failed or cancelled response has no answer to deliver
+		resp := responsesResponse{Status: strings.TrimPrefix(event.Type, "response.")}
+		if len(event.Response) > 0 {
+			json.Unmarshal(event.Response, &resp)
+		}
+		return p.responseFailedError(&resp)
+
 	case "response.output_item.added":
 		// New output item - could be reasoning, message, or function_call
 		// We'll handle the content in the delta events
@@ -235,7 +270,7 @@ func (p *OpenAI) handleResponsesStreamEvent(
 			var delta responsesContentDelta
 			if err := json.Unmarshal(event.Delta, &delta); err == nil && delta.Text != "" {
 				select {
-				case chunkCh <- core.ChatChunk{Delta: delta.Text}:
+				case chunkCh <- core.ChatChunk{Delta: delta.Text, Sequence: event.SequenceNumber}:
 				case <-ctx.Done():
 					return ctx.Err()
 				}
@@ -248,7 +283,20 @@ func (p *OpenAI) handleResponsesStreamEvent(
 			var delta responsesContentDelta
 			if err := json.Unmarshal(event.Delta, &delta); err == nil && delta.Text != "" {
 				select {
-				case chunkCh <- core.ChatChunk{Delta: delta.Text}:
+				case chunkCh <- core.ChatChunk{Delta: delta.Text, Sequence: event.SequenceNumber}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+
+	case "response.reasoning_summary_text.delta":
+		// Reasoning summary delta
+		if len(event.Delta) > 0 {
+			var delta responsesContentDelta
+			if err := json.Unmarshal(event.Delta, &delta); err == nil && delta.Text != "" {
+				select {
+				case chunkCh <- core.ChatChunk{Reasoning: delta.Text, Sequence: event.SequenceNumber}:
 				case <-ctx.Done():
 					return ctx.Err()
 				}
@@ -276,6 +324,9 @@ func (p *OpenAI) handleResponsesStreamEvent(
 		if len(event.Item) > 0 {
 			var item responsesOutput
 			if err := json.Unmarshal(event.Item, &item); err == nil {
+				if state.stateless {
+					state.items[event.OutputIndex] = item
+				}
 				switch item.Type {
 				case "function_call":
 					// Store the completed function call info
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -259,6 +260,66 @@ func TestResponsesAPIStreamChatWithReasoning(t *testing.T) {
 	}
 }
 
+func TestResponsesAPIStreamChatStatelessReasoning(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqBody map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if include, _ := reqBody["include"].([]any); len(include) != 1 || include[0] != "reasoning.encrypted_content" {
+			t.Errorf("include = %v, want reasoning.encrypted_content", reqBody["include"])
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		events := []string{
+			`data: {"type":"response.created","response":{"id":"resp_1","status":"in_progress"}}`,
+			`data: {"type":"response.reasoning_summary_text.delta","item_id":"rs_1","delta":"Adding "}`,
+			`data: {"type":"response.reasoning_summary_text.delta","item_id":"rs_1","delta":"numbers"}`,
+			`data: {"type":"response.output_item.done","output_index":0,"item":{"type":"reasoning","id":"rs_1","summary":[{"type":"summary_text","text":"Adding numbers"}],"encrypted_content":"gAAAA"}}`,
+			`data: {"type":"response.output_text.delta","delta":"4"}`,
+			`data: {"type":"response.output_item.done","output_index":1,"item":{"type":"message","id":"msg_1","role":"assistant","content":[{"type":"output_text","text":"4"}]}}`,
+			`data: {"type":"response.completed","response":{"id":"resp_1","status":"completed"}}`,
+		}
+		for _, event := range events {
+			fmt.Fprintf(w, "%s\n\n", event)
+		}
+	}))
+	defer server.Close()
+
+	store := false
+	p := New("test-key", WithBaseURL(server.URL))
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{
+		Model:           ModelGPT52,
+		Messages:        []core.Message{{Role: core.RoleUser, Content: "What is 2+2?"}},
+		ReasoningEffort: core.ReasoningEffortLow,
+		Store:           &store,
+	})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	var reasoning, output string
+	for chunk := range stream.Ch {
+		reasoning += chunk.Reasoning
+		output += chunk.Delta
+	}
+	if reasoning != "Adding numbers" || output != "4" {
+		t.Errorf("reasoning = %q, output = %q", reasoning, output)
+	}
+
+	select {
+	case resp := <-stream.Final:
+		if resp == nil || len(resp.Items) != 2 || resp.Items[0].Type != "reasoning" || resp.Items[1].Type != "message" {
+			t.Fatalf("Final = %+v, want reasoning and message items in order", resp)
+		}
+		if !strings.Contains(string(resp.Items[0].Raw), `"encrypted_content":"gAAAA"`) {
+			t.Errorf("Items[0].Raw = %s, want encrypted content", resp.Items[0].Raw)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for final response")
+	}
+}
+
 func TestResponsesAPIStreamChatError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("x-request-id", "req-stream-err")
@@ -0,0 +1,37 @@
+package openai
+
+// openAISpeechRequest is the request body for /audio/speech.
+type openAISpeechRequest struct {
+	Model          string  `json:"model"`
+	Input          string  `json:"input"`
+	Voice          string  `json:"voice"`
+	ResponseFormat string  `json:"response_format,omitempty"`
+	Speed          float64 `json:"speed,omitempty"`
+	Instructions   string  `json:"instructions,omitempty"`
+}
+
+// openAITranscription is a response from /audio/transcriptions or
+// /audio/translations. Segments and words are only present in
+// verbose_json responses; times are in seconds.
+type openAITranscription struct {
+	Text     string                       `json:"text"`
+	Language string                       `json:"language,omitempty"`
+	Duration float64                      `json:"duration,omitempty"`
+	Segments []openAITranscriptionSegment `json:"segments,omitempty"`
+	Words    []openAITranscriptionWord    `json:"words,omitempty"`
+}
+
+// openAITranscriptionSegment is a timed segment of a verbose transcription.
+type openAITranscriptionSegment struct {
+	ID    int     `json:"id"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Text  string  `json:"text"`
+}
+
+// openAITranscriptionWord is a timed word of a verbose transcription.
+type openAITranscriptionWord struct {
+	Word  string  `json:"word"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
@@ -0,0 +1,72 @@
+package openai
+
+import "encoding/json"
+
+// openAIBatchLine is one line of a batch input file.
+type openAIBatchLine struct {
+	CustomID string         `json:"custom_id"`
+	Method   string         `json:"method"`
+	URL      string         `json:"url"`
+	Body     *openAIRequest `json:"body"`
+}
+
+// openAIBatchCreateRequest is the request body for POST /batches.
+type openAIBatchCreateRequest struct {
+	InputFileID      string            `json:"input_file_id"`
+	Endpoint         string            `json:"endpoint"`
+	CompletionWindow string            `json:"completion_window"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+// openAIBatch is a batch object returned by the Batch API.
+type openAIBatch struct {
+	ID            string             `json:"id"`
+	Object        string             `json:"object"`
+	Endpoint      string             `json:"endpoint"`
+	Status        string             `json:"status"`
+	InputFileID   string             `json:"input_file_id"`
+	OutputFileID  string             `json:"output_file_id,omitempty"`
+	ErrorFileID   string             `json:"error_file_id,omitempty"`
+	CreatedAt     int64              `json:"created_at"`
+	CompletedAt   *int64             `json:"completed_at,omitempty"`
+	FailedAt      *int64             `json:"failed_at,omitempty"`
+	ExpiredAt     *int64             `json:"expired_at,omitempty"`
+	CancelledAt   *int64             `json:"cancelled_at,omitempty"`
+	ExpiresAt     *int64             `json:"expires_at,omitempty"`
+	RequestCounts openAIBatchCounts  `json:"request_counts"`
+	Metadata      map[string]string  `json:"metadata,omitempty"`
+	Errors        *openAIBatchErrors `json:"errors,omitempty"`
+}
+
+// openAIBatchCounts reports per-request progress of a batch.
+type openAIBatchCounts struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+}
+
+// openAIBatchErrors lists validation errors of a failed batch.
+type openAIBatchErrors struct {
+	Data []openAIBatchError `json:"data"`
+}
+
+// openAIBatchError is a batch-level or per-request error.
+type openAIBatchError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// openAIBatchOutputLine is one line of a batch output or error file.
+type openAIBatchOutputLine struct {
+	ID       string            `json:"id"`
+	CustomID string            `json:"custom_id"`
+	Response *openAIBatchReply `json:"response"`
+	Error    *openAIBatchError `json:"error"`
+}
+
+// openAIBatchReply is the HTTP response recorded for a batch request.
+type openAIBatchReply struct {
+	StatusCode int             `json:"status_code"`
+	RequestID  string          `json:"request_id"`
+	Body       json.RawMessage `json:"body"`
+}
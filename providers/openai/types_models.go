@@ -0,0 +1,13 @@
+package openai
+
+// openAIModelList is the response from GET /models.
+type openAIModelList struct {
+	Data []openAIModel `json:"data"`
+}
+
+// openAIModel is a model entry from GET /models.
+type openAIModel struct {
+	ID      string `json:"id"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+}
@@ -0,0 +1,34 @@
+package openai
+
+// openAIModerationRequest is the request body for /moderations. Input is a
+// string array for text, or an array of content parts for multimodal input.
+type openAIModerationRequest struct {
+	Model string `json:"model"`
+	Input any    `json:"input"`
+}
+
+// openAIModerationPart is a multimodal moderation input part.
+type openAIModerationPart struct {
+	Type     string                    `json:"type"`
+	Text     string                    `json:"text,omitempty"`
+	ImageURL *openAIModerationImageURL `json:"image_url,omitempty"`
+}
+
+// openAIModerationImageURL references an image to moderate.
+type openAIModerationImageURL struct {
+	URL string `json:"url"`
+}
+
+// openAIModerationResponse is a response from /moderations.
+type openAIModerationResponse struct {
+	ID      string                   `json:"id"`
+	Model   string                   `json:"model"`
+	Results []openAIModerationResult `json:"results"`
+}
+
+// openAIModerationResult is the classification of one input.
+type openAIModerationResult struct {
+	Flagged        bool               `json:"flagged"`
+	Categories     map[string]bool    `json:"categories"`
+	CategoryScores map[string]float64 `json:"category_scores"`
+}
@@ -16,6 +16,9 @@ type responsesRequest struct {
 	Reasoning          *responsesReasoningParam `json:"reasoning,omitempty"`
 	PreviousResponseID string                   `json:"previous_response_id,omitempty"`
 	Truncation         string                   `json:"truncation,omitempty"`
+	Background         bool                     `json:"background,omitempty"`
+	Store              *bool                    `json:"store,omitempty"`
+	Include            []string                 `json:"include,omitempty"`
 	Stream             bool                     `json:"stream,omitempty"`
 	StreamOptions      *streamOptions           `json:"stream_options,omitempty"`
 }
@@ -99,6 +102,19 @@ func (c *responsesContent) UnmarshalJSON(data []byte) error {
 type responsesInputMessage struct {
 	Role    string           `json:"role"`
 	Content responsesContent `json:"content"`
+
+	// Item is a replayed output item, sent as is in place of the message.
+	Item json.RawMessage `json:"-"`
+}
+
+// MarshalJSON implements custom marshaling for responsesInputMessage.
+// If Item is set, marshals it unchanged. Otherwise marshals the message.
+func (m responsesInputMessage) MarshalJSON() ([]byte, error) {
+	if len(m.Item) > 0 {
+		return m.Item, nil
+	}
+	type plain responsesInputMessage
+	return json.Marshal(plain(m))
 }
 
 // responsesContentPart represents a content part in a Responses API input message.
@@ -158,7 +174,8 @@ type responsesOutput struct {
 	Role   string `json:"role,omitempty"`
 
 	// For reasoning type
-	Summary []responsesReasoningSummary `json:"summary,omitempty"`
+	Summary          []responsesReasoningSummary `json:"summary,omitempty"`
+	EncryptedContent string                      `json:"encrypted_content,omitempty"`
 
 	// For message type
 	Content []responsesMessageContent `json:"content,omitempty"`
@@ -167,6 +184,19 @@ type responsesOutput struct {
 	CallID    string `json:"call_id,omitempty"`
 	Name      string `json:"name,omitempty"`
 	Arguments string `json:"arguments,omitempty"`
+
+	// raw is the item as received, for replaying stateless responses
+	raw json.RawMessage
+}
+
+// UnmarshalJSON decodes the item and keeps a copy of its raw JSON.
+func (o *responsesOutput) UnmarshalJSON(data []byte) error {
+	type plain responsesOutput
+	if err := json.Unmarshal(data, (*plain)(o)); err != nil {
+		return err
+	}
+	o.raw = append(json.RawMessage(nil), data...)
+	return nil
 }
 
 // responsesReasoningSummary contains a summary of reasoning.
@@ -199,10 +229,11 @@ type responsesError struct {
 
 // responsesStreamEvent represents a streaming event from the Responses API.
 type responsesStreamEvent struct {
-	Type     string          `json:"type"`
-	Response json.RawMessage `json:"response,omitempty"`
-	Item     json.RawMessage `json:"item,omitempty"`
-	Delta    json.RawMessage `json:"delta,omitempty"`
+	Type           string          `json:"type"`
+	SequenceNumber int             `json:"sequence_number,omitempty"`
+	Response       json.RawMessage `json:"response,omitempty"`
+	Item           json.RawMessage `json:"item,omitempty"`
+	Delta          json.RawMessage `json:"delta,omitempty"`
 	// For content delta
 	ContentIndex int    `json:"content_index,omitempty"`
 	OutputIndex  int    `json:"output_index,omitempty"`
@@ -215,7 +246,71 @@ type responsesContentDelta struct {
 	Text string `json:"text,omitempty"`
 }
 
+// UnmarshalJSON accepts the delta either as a plain string, as the API
+// sends it, or as an object with a text field.
+func (d *responsesContentDelta) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		d.Type = "text"
+		return json.Unmarshal(data, &d.Text)
+	}
+	type plain responsesContentDelta
+	return json.Unmarshal(data, (*plain)(d))
+}
+
 // responsesFunctionCallDelta represents a function call delta in streaming.
 type responsesFunctionCallDelta struct {
 	Arguments string `json:"arguments,omitempty"`
 }
+
+// Response status values reported by the Responses API.
+const (
+	ResponseStatusQueued     = "queued"
+	ResponseStatusInProgress = "in_progress"
+	ResponseStatusCompleted  = "completed"
+	ResponseStatusIncomplete = "incomplete"
+	ResponseStatusFailed     = "failed"
+	ResponseStatusCancelled  = "cancelled"
+)
+
+// ResponseInputItemsRequest contains pagination options for listing the
+// input items of a response.
+type ResponseInputItemsRequest struct {
+	Limit  *int
+	After  *string
+	Before *string
+	Order  *string // "asc" or "desc"
+}
+
+// ResponseInputItemsResponse contains paginated input items.
+type ResponseInputItemsResponse struct {
+	Object  string              `json:"object"`
+	Data    []ResponseInputItem `json:"data"`
+	HasMore bool                `json:"has_more"`
+	FirstID string              `json:"first_id,omitempty"`
+	LastID  string              `json:"last_id,omitempty"`
+}
+
+// ResponseInputItem is an item of a response's input, such as a message
+// or a function call output.
+type ResponseInputItem struct {
+	ID      string                 `json:"id"`
+	Type    string                 `json:"type"`
+	Role    string                 `json:"role,omitempty"`
+	Status  string                 `json:"status,omitempty"`
+	Content []ResponseInputContent `json:"content,omitempty"`
+
+	// For function_call and function_call_output items
+	CallID    string `json:"call_id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+	Output    string `json:"output,omitempty"`
+}
+
+// ResponseInputContent is a content part of an input message.
+type ResponseInputContent struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	ImageURL string `json:"image_url,omitempty"`
+	FileID   string `json:"file_id,omitempty"`
+	Filename string `json:"filename,omitempty"`
+}
@@ -0,0 +1,84 @@
+package openaicompat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// chatCompletionsPath is the API endpoint for chat completions.
+const chatCompletionsPath = "/chat/completions"
+
+// newRequest builds a POST request to the chat completions endpoint.
+func (p *OpenAICompat) newRequest(ctx context.Context, body []byte) (*http.Request, error) {
+	url := strings.TrimRight(p.config.BaseURL, "/") + chatCompletionsPath
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, p.newNetworkError(err)
+	}
+
+	// Set headers
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	return httpReq, nil
+}
+
+// doChat performs a non-streaming chat completion request.
+func (p *OpenAICompat) doChat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	// Marshal request body
+	body, err := json.Marshal(p.buildRequest(req, false))
+	if err != nil {
+		return nil, p.newDecodeError(err)
+	}
+
+	httpReq, err := p.newRequest(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute request
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, p.newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, p.newNetworkError(err)
+	}
+
+	// Extract request ID from response headers
+	requestID := resp.Header.Get("x-request-id")
+
+	// Check for error status
+	if resp.StatusCode >= 400 {
+		return nil, p.normalizeError(resp.StatusCode, respBody, requestID)
+	}
+
+	// Parse response
+	var cResp compatResponse
+	if err := json.Unmarshal(respBody, &cResp); err != nil {
+		return nil, p.newDecodeError(err)
+	}
+
+	// Map to Iris response
+	result, err := mapResponse(&cResp)
+	if err != nil {
+		return nil, err
+	}
+	if result.Model == "" {
+		result.Model = req.Model
+	}
+	return result, nil
+}
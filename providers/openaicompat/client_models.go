@@ -0,0 +1,69 @@
+package openaicompat
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// modelsPath is the API endpoint for listing models.
+const modelsPath = "/models"
+
+// ListModels returns the models served by the endpoint using GET /models.
+// Models configured with WithModels keep their metadata; others are given
+// the provider's features as capabilities. Results are cached for the
+// configured ModelCacheTTL.
+//
+// Most OpenAI-compatible servers implement /models, but not all; Supports
+// reports FeatureModelListing only when it is among the configured features.
+func (p *OpenAICompat) ListModels(ctx context.Context) ([]core.ModelInfo, error) {
+	return p.modelCache.Get(ctx)
+}
+
+// fetchModels queries GET /models and merges the result with the configured models.
+func (p *OpenAICompat) fetchModels(ctx context.Context) ([]core.ModelInfo, error) {
+	url := strings.TrimRight(p.config.BaseURL, "/") + modelsPath
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, p.newNetworkError(err)
+	}
+
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, p.newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, p.newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, p.normalizeError(resp.StatusCode, respBody, resp.Header.Get("x-request-id"))
+	}
+
+	var list compatModelList
+	if err := json.Unmarshal(respBody, &list); err != nil {
+		return nil, p.newDecodeError(err)
+	}
+
+	live := make([]core.ModelInfo, len(list.Data))
+	for i, m := range list.Data {
+		live[i] = core.ModelInfo{ID: core.ModelID(m.ID), Capabilities: p.config.Features}
+	}
+	return core.MergeModels(live, p.config.Models), nil
+}
+
+// Compile-time check that OpenAICompat implements ModelLister.
+var _ core.ModelLister = (*OpenAICompat)(nil)
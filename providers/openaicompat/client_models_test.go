@@ -0,0 +1,61 @@
+package openaicompat
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestListModels(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/models" {
+			t.Errorf("request = %s %s, want GET /v1/models", r.Method, r.URL.Path)
+		}
+		io.WriteString(w, `{"object":"list","data":[
+			{"id":"meta-llama/Llama-3.1-8B-Instruct","object":"model","owned_by":"vllm"},
+			{"id":"Qwen/Qwen3-8B","object":"model","owned_by":"vllm"}]}`)
+	}))
+	defer server.Close()
+
+	provider := New("vllm", server.URL+"/v1/", "",
+		WithModels(core.ModelInfo{ID: "Qwen/Qwen3-8B", DisplayName: "Qwen 3 8B", Capabilities: []core.Feature{core.FeatureChat, core.FeatureReasoning}}),
+	)
+	models, err := provider.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if len(models) != 2 {
+		t.Fatalf("ListModels() returned %d models, want 2", len(models))
+	}
+	if models[0].DisplayName != "meta-llama/Llama-3.1-8B-Instruct" || !models[0].HasCapability(core.FeatureToolCalling) {
+		t.Errorf("models[0] = %+v, want default features", models[0])
+	}
+	if models[1].DisplayName != "Qwen 3 8B" || !models[1].HasCapability(core.FeatureReasoning) {
+		t.Errorf("models[1] = %+v, want configured metadata", models[1])
+	}
+
+	provider.ListModels(context.Background())
+	if calls != 1 {
+		t.Errorf("API calls = %d, want 1 (cached)", calls)
+	}
+}
+
+func TestListModelsNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `404 page not found`)
+	}))
+	defer server.Close()
+
+	_, err := New("local", server.URL, "").ListModels(context.Background())
+	if !errors.Is(err, core.ErrNotFound) {
+		t.Errorf("ListModels() error = %v, want ErrNotFound", err)
+	}
+}
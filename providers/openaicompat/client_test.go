@@ -0,0 +1,136 @@
+package openaicompat
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestChatRequestFormat(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("Path = %q, want %q", r.URL.Path, "/v1/chat/completions")
+		}
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("Authorization = %q, want none", auth)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("invalid request body: %v", err)
+		}
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"ok"}}]}`))
+	}))
+	defer server.Close()
+
+	p := New("llamacpp", server.URL+"/v1/", "")
+	resp, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:        "local",
+		Instructions: "Be brief.",
+		Messages:     []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	// Missing ID and model are tolerated
+	if resp.ID != "" || resp.Model != "local" || resp.Output != "ok" {
+		t.Errorf("resp = %+v", resp)
+	}
+
+	msgs, _ := got["messages"].([]any)
+	if len(msgs) != 2 {
+		t.Fatalf("len(messages) = %d, want 2", len(msgs))
+	}
+	first, _ := msgs[0].(map[string]any)
+	if first["role"] != "system" || first["content"] != "Be brief." {
+		t.Errorf("first message = %v, want system instructions", first)
+	}
+	if _, ok := got["stream_options"]; ok {
+		t.Error("stream_options should be omitted for non-streaming requests")
+	}
+	if _, ok := got["reasoning_effort"]; ok {
+		t.Error("reasoning_effort should be omitted when not set")
+	}
+
+	if _, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:           "local",
+		Messages:        []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+		ReasoningEffort: core.ReasoningEffortHigh,
+	}); err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if got["reasoning_effort"] != "high" {
+		t.Errorf("reasoning_effort = %v, want high", got["reasoning_effort"])
+	}
+}
+
+func TestChatReasoningContent(t *testing.T) {
+	for _, field := range []string{"reasoning_content", "reasoning"} {
+		t.Run(field, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"id":"r1","choices":[{"message":{"role":"assistant","content":"42","` + field + `":"thinking..."}}]}`))
+			}))
+			defer server.Close()
+
+			p := New("vllm", server.URL, "")
+			resp, err := p.Chat(context.Background(), &core.ChatRequest{Model: "m"})
+			if err != nil {
+				t.Fatalf("Chat() error = %v", err)
+			}
+			if resp.Reasoning == nil || len(resp.Reasoning.Summary) != 1 || resp.Reasoning.Summary[0] != "thinking..." {
+				t.Errorf("Reasoning = %+v, want summary %q", resp.Reasoning, "thinking...")
+			}
+		})
+	}
+}
+
+func TestChatToolCallDeviations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","tool_calls":[
+			{"type":"function","function":{"name":"a","arguments":{"x":1}}},
+			{"id":"call_b","type":"function","function":{"name":"b","arguments":""}}
+		]}}],"usage":{"prompt_tokens":3,"completion_tokens":4}}`))
+	}))
+	defer server.Close()
+
+	p := New("vllm", server.URL, "")
+	resp, err := p.Chat(context.Background(), &core.ChatRequest{Model: "m"})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	if len(resp.ToolCalls) != 2 {
+		t.Fatalf("len(ToolCalls) = %d, want 2", len(resp.ToolCalls))
+	}
+	if resp.ToolCalls[0].ID != "call_0" {
+		t.Errorf("ToolCalls[0].ID = %q, want generated %q", resp.ToolCalls[0].ID, "call_0")
+	}
+	if string(resp.ToolCalls[0].Arguments) != `{"x":1}` {
+		t.Errorf("ToolCalls[0].Arguments = %s, want object passed through", resp.ToolCalls[0].Arguments)
+	}
+	if string(resp.ToolCalls[1].Arguments) != `{}` {
+		t.Errorf("ToolCalls[1].Arguments = %s, want {}", resp.ToolCalls[1].Arguments)
+	}
+	if resp.Usage.TotalTokens != 7 {
+		t.Errorf("TotalTokens = %d, want derived 7", resp.Usage.TotalTokens)
+	}
+}
+
+func TestChatInvalidToolArguments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","tool_calls":[{"id":"c","type":"function","function":{"name":"a","arguments":"{not json"}}]}}]}`))
+	}))
+	defer server.Close()
+
+	p := New("vllm", server.URL, "")
+	_, err := p.Chat(context.Background(), &core.ChatRequest{Model: "m"})
+	if err != ErrToolArgsInvalidJSON {
+		t.Errorf("err = %v, want ErrToolArgsInvalidJSON", err)
+	}
+}
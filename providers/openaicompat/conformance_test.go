@@ -0,0 +1,84 @@
+package openaicompat
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers/providertest"
+)
+
+// sseData formats payloads as an SSE body.
+func sseData(payloads ...string) string {
+	var b strings.Builder
+	for _, p := range payloads {
+		b.WriteString("data: ")
+		b.WriteString(p)
+		b.WriteString("\n\n")
+	}
+	return b.String()
+}
+
+func TestConformance(t *testing.T) {
+	sse := http.Header{"Content-Type": {"text/event-stream"}}
+
+	suite := providertest.Suite{
+		NewProvider: func(baseURL string) core.Provider {
+			return New("vllm", baseURL, "test-key")
+		},
+		Model: "llama-3.1-8b",
+		Chat: &providertest.Fixture{
+			Body:   `{"id":"cmpl-1","model":"llama-3.1-8b","choices":[{"index":0,"message":{"role":"assistant","content":"Hello there"},"finish_reason":"stop"}],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`,
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		Stream: &providertest.Fixture{
+			Header: sse,
+			Body: sseData(
+				`{"id":"cmpl-2","model":"llama-3.1-8b","choices":[{"index":0,"delta":{"content":"Hello"}}]}`,
+				`{"id":"cmpl-2","model":"llama-3.1-8b","choices":[{"index":0,"delta":{"content":" there"}}],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`,
+				"[DONE]",
+			),
+			Output: "Hello there",
+			Usage:  core.TokenUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		ToolCall: &providertest.Fixture{
+			Body: `{"id":"cmpl-3","model":"llama-3.1-8b","choices":[{"index":0,"message":{"role":"assistant","content":"","tool_calls":[{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"location\": \"NYC\"}"}}]},"finish_reason":"tool_calls"}]}`,
+			ToolCalls: []core.ToolCall{
+				{ID: "call_1", Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		StreamToolCall: &providertest.Fixture{
+			Header: sse,
+			Body: sseData(
+				`{"id":"cmpl-4","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_2","type":"function","function":{"name":"get_weather","arguments":"{\"location\":"}}]}}]}`,
+				`{"id":"cmpl-4","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":" \"NYC\"}"}}]}}]}`,
+				"[DONE]",
+			),
+			ToolCalls: []core.ToolCall{
+				{ID: "call_2", Name: "get_weather", Arguments: json.RawMessage(`{"location": "NYC"}`)},
+			},
+		},
+		Cancel: &providertest.Fixture{
+			Header: sse,
+			Body:   sseData(`{"id":"cmpl-5","choices":[{"index":0,"delta":{"content":"Hello"}}]}`),
+		},
+		ErrorBody: func(status int) string {
+			return `{"error":"request failed"}`
+		},
+		ErrorStatuses: map[int]error{
+			http.StatusBadRequest:          core.ErrBadRequest,
+			http.StatusUnauthorized:        core.ErrUnauthorized,
+			http.StatusForbidden:           core.ErrUnauthorized,
+			http.StatusNotFound:            core.ErrNotFound,
+			http.StatusUnprocessableEntity: core.ErrBadRequest,
+			http.StatusTooManyRequests:     core.ErrRateLimited,
+			http.StatusInternalServerError: core.ErrServer,
+			http.StatusServiceUnavailable:  core.ErrServer,
+		},
+	}
+
+	suite.Run(t)
+}
@@ -0,0 +1,168 @@
+package openaicompat
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// ErrToolArgsInvalidJSON is returned when tool call arguments contain invalid JSON.
+var ErrToolArgsInvalidJSON = errors.New("tool args invalid json")
+
+// maxErrorText caps how much of a non-JSON error body is kept as the message.
+const maxErrorText = 512
+
+// parseErrorBody extracts a message and code from an error payload.
+// Servers disagree on the shape, so the following are all accepted:
+//
+//	{"error": {"message": "...", "type": "...", "code": "..."}}  (OpenAI, Groq, vLLM)
+//	{"error": "..."}                                             (llama.cpp, LM Studio)
+//	{"message": "...", "code": ...}                              (Together)
+//	{"detail": "..."}                                            (FastAPI servers)
+//	plain text
+func parseErrorBody(body []byte) (message, code string) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return "", ""
+	}
+
+	var envelope struct {
+		Error   json.RawMessage `json:"error"`
+		Message string          `json:"message"`
+		Detail  json.RawMessage `json:"detail"`
+		Code    json.RawMessage `json:"code"`
+		Type    string          `json:"type"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return truncate(string(body)), ""
+	}
+
+	if len(envelope.Error) > 0 {
+		var text string
+		if json.Unmarshal(envelope.Error, &text) == nil {
+			return text, rawCode(envelope.Code)
+		}
+
+		var obj struct {
+			Message string          `json:"message"`
+			Type    string          `json:"type"`
+			Code    json.RawMessage `json:"code"`
+		}
+		if json.Unmarshal(envelope.Error, &obj) == nil {
+			code := rawCode(obj.Code)
+			if code == "" {
+				code = obj.Type
+			}
+			return obj.Message, code
+		}
+	}
+
+	if envelope.Message != "" {
+		code := rawCode(envelope.Code)
+		if code == "" {
+			code = envelope.Type
+		}
+		return envelope.Message, code
+	}
+
+	if len(envelope.Detail) > 0 {
+		var text string
+		if json.Unmarshal(envelope.Detail, &text) == nil {
+			return text, ""
+		}
+		return truncate(string(envelope.Detail)), ""
+	}
+
+	return "", ""
+}
+
+// rawCode renders a code that may be a JSON string or number.
+func rawCode(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// truncate shortens text to maxErrorText bytes.
+func truncate(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > maxErrorText {
+		return s[:maxErrorText] + "..."
+	}
+	return s
+}
+
+// normalizeError converts an HTTP error response to a ProviderError with the appropriate sentinel.
+func (p *OpenAICompat) normalizeError(status int, body []byte, requestID string) error {
+	message, code := parseErrorBody(body)
+	if message == "" {
+		message = http.StatusText(status)
+	}
+
+	// Determine sentinel error based on status
+	var sentinel error
+	switch {
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		sentinel = core.ErrBadRequest
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		sentinel = core.ErrUnauthorized
+	case status == http.StatusNotFound:
+		sentinel = core.ErrNotFound
+	case status == http.StatusTooManyRequests:
+		sentinel = core.ErrRateLimited
+	case status >= 500:
+		sentinel = core.ErrServer
+	default:
+		sentinel = core.ErrServer
+	}
+
+	return &core.ProviderError{
+		Provider:  p.config.ID,
+		Status:    status,
+		RequestID: requestID,
+		Code:      code,
+		Message:   message,
+		Err:       sentinel,
+	}
+}
+
+// newStreamError creates a ProviderError for an error event sent mid-stream.
+func (p *OpenAICompat) newStreamError(raw json.RawMessage) error {
+	message, code := parseErrorBody([]byte(`{"error":` + string(raw) + `}`))
+	if message == "" {
+		message = "stream error"
+	}
+	return &core.ProviderError{
+		Provider: p.config.ID,
+		Code:     code,
+		Message:  message,
+		Err:      core.ErrServer,
+	}
+}
+
+// newNetworkError creates a ProviderError for network-related failures.
+func (p *OpenAICompat) newNetworkError(err error) error {
+	return &core.ProviderError{
+		Provider: p.config.ID,
+		Message:  err.Error(),
+		Err:      core.ErrNetwork,
+	}
+}
+
+// newDecodeError creates a ProviderError for JSON decode failures.
+func (p *OpenAICompat) newDecodeError(err error) error {
+	return &core.ProviderError{
+		Provider: p.config.ID,
+		Message:  err.Error(),
+		Err:      core.ErrDecode,
+	}
+}
@@ -0,0 +1,69 @@
+package openaicompat
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestParseErrorBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantMessage string
+		wantCode    string
+	}{
+		{"openai", `{"error":{"message":"bad model","type":"invalid_request_error","code":"model_not_found"}}`, "bad model", "model_not_found"},
+		{"openai type only", `{"error":{"message":"bad","type":"invalid_request_error"}}`, "bad", "invalid_request_error"},
+		{"string error", `{"error":"context too long"}`, "context too long", ""},
+		{"top-level message", `{"message":"slow down","code":429}`, "slow down", "429"},
+		{"detail", `{"detail":"Not Found"}`, "Not Found", ""},
+		{"detail object", `{"detail":[{"msg":"field required"}]}`, `[{"msg":"field required"}]`, ""},
+		{"plain text", "Internal Server Error\n", "Internal Server Error", ""},
+		{"empty", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, code := parseErrorBody([]byte(tt.body))
+			if message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", message, tt.wantMessage)
+			}
+			if code != tt.wantCode {
+				t.Errorf("code = %q, want %q", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestParseErrorBodyTruncatesText(t *testing.T) {
+	message, _ := parseErrorBody([]byte(strings.Repeat("x", 2*maxErrorText)))
+	if len(message) != maxErrorText+len("...") {
+		t.Errorf("len(message) = %d, want %d", len(message), maxErrorText+3)
+	}
+}
+
+func TestNormalizeError(t *testing.T) {
+	p := New("groq", GroqBaseURL, "key")
+
+	err := p.normalizeError(502, nil, "req-1")
+
+	var pErr *core.ProviderError
+	if !errors.As(err, &pErr) {
+		t.Fatalf("expected ProviderError, got %T", err)
+	}
+	if pErr.Provider != "groq" {
+		t.Errorf("Provider = %q, want %q", pErr.Provider, "groq")
+	}
+	if pErr.Message != "Bad Gateway" {
+		t.Errorf("Message = %q, want status text", pErr.Message)
+	}
+	if pErr.RequestID != "req-1" {
+		t.Errorf("RequestID = %q, want %q", pErr.RequestID, "req-1")
+	}
+	if !errors.Is(err, core.ErrServer) {
+		t.Errorf("expected ErrServer, got %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package openaicompat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/tools"
+)
+
+// schemaProvider is an interface for tools that provide a JSON schema.
+// This allows us to check if a core.Tool also implements the full tools.Tool interface.
+type schemaProvider interface {
+	Schema() tools.ToolSchema
+}
+
+// mapMessages converts Iris messages to the chat completions message format.
+// Instructions, when set, are sent as a leading system message.
+func mapMessages(instructions string, msgs []core.Message) []compatMessage {
+	result := make([]compatMessage, 0, len(msgs)+1)
+	if instructions != "" {
+		result = append(result, compatMessage{Role: string(core.RoleSystem), Content: instructions})
+	}
+	for _, msg := range msgs {
+		result = append(result, compatMessage{
+			Role:    string(msg.Role),
+			Content: msg.Content,
+		})
+	}
+	return result
+}
+
+// mapTools converts Iris tools to the chat completions tool format.
+// Tools that implement schemaProvider will have their schema included.
+func mapTools(irisTools []core.Tool) []compatTool {
+	if len(irisTools) == 0 {
+		return nil
+	}
+
+	result := make([]compatTool, len(irisTools))
+	for i, t := range irisTools {
+		var params json.RawMessage
+
+		// Check if the tool provides a schema
+		if sp, ok := t.(schemaProvider); ok {
+			params = sp.Schema().JSONSchema
+		}
+
+		// Default to empty object if no schema
+		if params == nil {
+			params = json.RawMessage(`{}`)
+		}
+
+		result[i] = compatTool{
+			Type: "function",
+			Function: compatFunction{
+				Name:        t.Name(),
+				Description: t.Description(),
+				Parameters:  params,
+			},
+		}
+	}
+	return result
+}
+
+// buildRequest creates a chat completions request from an Iris ChatRequest.
+func (p *OpenAICompat) buildRequest(req *core.ChatRequest, stream bool) *compatRequest {
+	cReq := &compatRequest{
+		Model:       string(req.Model),
+		Messages:    mapMessages(req.Instructions, req.Messages),
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+		Stream:      stream,
+	}
+
+	// Passed through as is, since servers differ in the levels they accept
+	if req.ReasoningEffort != "" && req.ReasoningEffort != core.ReasoningEffortNone {
+		cReq.ReasoningEffort = string(req.ReasoningEffort)
+	}
+
+	if stream && p.config.StreamUsage {
+		cReq.StreamOptions = &compatStreamOptions{IncludeUsage: true}
+	}
+
+	// Map tools if present
+	if len(req.Tools) > 0 {
+		cReq.Tools = mapTools(req.Tools)
+		cReq.ToolChoice = "auto"
+	}
+
+	return cReq
+}
+
+// mapResponse converts a chat completions response to an Iris ChatResponse.
+func mapResponse(resp *compatResponse) (*core.ChatResponse, error) {
+	result := &core.ChatResponse{
+		ID:    resp.ID,
+		Model: core.ModelID(resp.Model),
+	}
+
+	if resp.Usage != nil {
+		result.Usage = mapUsage(resp.Usage)
+	}
+
+	// Extract content from first choice
+	if len(resp.Choices) > 0 {
+		msg := resp.Choices[0].Message
+		result.Output = msg.Content
+
+		if reasoning := firstNonEmpty(msg.ReasoningContent, msg.Reasoning); reasoning != "" {
+			result.Reasoning = &core.ReasoningOutput{
+				Summary: []string{reasoning},
+			}
+		}
+
+		if len(msg.ToolCalls) > 0 {
+			toolCalls, err := mapToolCalls(msg.ToolCalls)
+			if err != nil {
+				return nil, err
+			}
+			result.ToolCalls = toolCalls
+		}
+	}
+
+	return result, nil
+}
+
+// mapToolCalls converts tool calls to Iris ToolCalls.
+// Missing IDs are replaced with generated ones.
+func mapToolCalls(calls []compatToolCall) ([]core.ToolCall, error) {
+	result := make([]core.ToolCall, len(calls))
+
+	for i, call := range calls {
+		args, err := decodeArguments(call.Function.Arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		id := call.ID
+		if id == "" {
+			id = fmt.Sprintf("call_%d", i)
+		}
+
+		result[i] = core.ToolCall{
+			ID:        id,
+			Name:      call.Function.Name,
+			Arguments: args,
+		}
+	}
+
+	return result, nil
+}
+
+// decodeArguments returns tool call arguments as raw JSON.
+// The OpenAI format encodes arguments as a JSON string, which is unquoted
+// without reformatting; an inline object is passed through as-is.
+// Missing or empty arguments become an empty object.
+func decodeArguments(raw json.RawMessage) (json.RawMessage, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return json.RawMessage(`{}`), nil
+	}
+
+	if raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, ErrToolArgsInvalidJSON
+		}
+		if s == "" {
+			return json.RawMessage(`{}`), nil
+		}
+		raw = json.RawMessage(s)
+	}
+
+	if !json.Valid(raw) {
+		return nil, ErrToolArgsInvalidJSON
+	}
+	return raw, nil
+}
+
+// mapUsage converts token usage, deriving the total when a server omits it.
+func mapUsage(u *compatUsage) core.TokenUsage {
+	total := u.TotalTokens
+	if total == 0 {
+		total = u.PromptTokens + u.CompletionTokens
+	}
+	return core.TokenUsage{
+		PromptTokens:     u.PromptTokens,
+		CompletionTokens: u.CompletionTokens,
+		TotalTokens:      total,
+	}
+}
+
+// firstNonEmpty returns the first non-empty string.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,176 @@
+package openaicompat
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// Base URLs for common OpenAI-compatible servers.
+const (
+	// VLLMBaseURL is the default base URL of a local vLLM server.
+	VLLMBaseURL = "http://localhost:8000/v1"
+
+	// LMStudioBaseURL is the default base URL of the LM Studio local server.
+	LMStudioBaseURL = "http://localhost:1234/v1"
+
+	// LlamaCppBaseURL is the default base URL of a local llama.cpp server.
+	LlamaCppBaseURL = "http://localhost:8080/v1"
+
+	// GroqBaseURL is the base URL of the Groq OpenAI-compatible API.
+	GroqBaseURL = "https://api.groq.com/openai/v1"
+
+	// TogetherBaseURL is the base URL of the Together AI API.
+	TogetherBaseURL = "https://api.together.xyz/v1"
+)
+
+// AuthStyle determines how the API key is sent to the server.
+type AuthStyle string
+
+const (
+	// AuthBearer sends the key as "Authorization: Bearer <key>" (default).
+	AuthBearer AuthStyle = "bearer"
+
+	// AuthHeader sends the key verbatim in a custom header (see Config.AuthHeader).
+	AuthHeader AuthStyle = "header"
+
+	// AuthNone sends no credentials.
+	AuthNone AuthStyle = "none"
+)
+
+// Config holds configuration for an OpenAI-compatible provider.
+type Config struct {
+	// ID is the provider identifier reported by ID() and in errors (required).
+	ID string
+
+	// BaseURL is the API base URL including any version prefix,
+	// e.g. http://localhost:8000/v1 (required).
+	BaseURL string
+
+	// APIKey is the optional API key. Local servers usually need none.
+	APIKey string
+
+	// AuthStyle determines how APIKey is sent. Defaults to AuthBearer.
+	AuthStyle AuthStyle
+
+	// AuthHeader is the header name used with AuthHeader, e.g. "api-key".
+	AuthHeader string
+
+	// HTTPClient is the HTTP client to use. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Headers contains optional extra headers to include in requests.
+	Headers http.Header
+
+	// Models is the list of models returned by Models().
+	Models []core.ModelInfo
+
+	// Features is the list of features reported by Supports().
+	// Defaults to chat, streaming, and tool calling.
+	Features []core.Feature
+
+	// StreamUsage requests a usage chunk at the end of streams via
+	// stream_options.include_usage. Not every server accepts the field.
+	StreamUsage bool
+
+	// Timeout is the optional request timeout.
+	Timeout time.Duration
+
+	// ModelCacheTTL is how long ListModels results are cached.
+	// Defaults to core.DefaultModelCacheTTL; zero or less disables caching.
+	ModelCacheTTL time.Duration
+}
+
+// defaultFeatures are the features assumed for an unknown server.
+var defaultFeatures = []core.Feature{
+	core.FeatureChat,
+	core.FeatureChatStreaming,
+	core.FeatureToolCalling,
+}
+
+// Option configures the OpenAI-compatible provider.
+type Option func(*Config)
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Config) {
+		c.HTTPClient = client
+	}
+}
+
+// WithHeader adds an extra header to include in requests.
+func WithHeader(key, value string) Option {
+	return func(c *Config) {
+		if c.Headers == nil {
+			c.Headers = make(http.Header)
+		}
+		c.Headers.Set(key, value)
+	}
+}
+
+// WithAuthHeader sends the API key verbatim in the named header
+// instead of as a bearer token.
+func WithAuthHeader(name string) Option {
+	return func(c *Config) {
+		c.AuthStyle = AuthHeader
+		c.AuthHeader = name
+	}
+}
+
+// WithNoAuth disables sending credentials.
+func WithNoAuth() Option {
+	return func(c *Config) {
+		c.AuthStyle = AuthNone
+	}
+}
+
+// WithModels sets the models returned by Models().
+func WithModels(models ...core.ModelInfo) Option {
+	return func(c *Config) {
+		c.Models = models
+	}
+}
+
+// WithModelIDs sets the models returned by Models() from bare IDs.
+// Each model is given the provider's features as capabilities.
+func WithModelIDs(ids ...string) Option {
+	return func(c *Config) {
+		c.Models = make([]core.ModelInfo, len(ids))
+		for i, id := range ids {
+			c.Models[i] = core.ModelInfo{
+				ID:          core.ModelID(id),
+				DisplayName: id,
+			}
+		}
+	}
+}
+
+// WithFeatures sets the features reported by Supports().
+func WithFeatures(features ...core.Feature) Option {
+	return func(c *Config) {
+		c.Features = features
+	}
+}
+
+// WithStreamUsage requests token usage at the end of streams.
+func WithStreamUsage() Option {
+	return func(c *Config) {
+		c.StreamUsage = true
+	}
+}
+
+// WithTimeout sets the request timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Config) {
+		c.Timeout = d
+	}
+}
+
+// WithModelCacheTTL sets how long ListModels results are cached.
+// A duration of zero or less disables caching.
+func WithModelCacheTTL(d time.Duration) Option {
+	return func(c *Config) {
+		c.ModelCacheTTL = d
+	}
+}
@@ -0,0 +1,133 @@
+// Package openaicompat provides a generic provider for servers that implement
+// the OpenAI Chat Completions API, such as vLLM, LM Studio, llama.cpp,
+// Groq, and Together AI.
+//
+// Unlike the openai package, no model routing is performed: every request is
+// sent to {BaseURL}/chat/completions. Common deviations from the OpenAI
+// format are tolerated, including streams without usage, responses without
+// IDs, reasoning_content fields, tool call arguments sent as objects, and
+// non-standard error bodies.
+//
+// # Usage
+//
+//	provider := openaicompat.New("vllm", openaicompat.VLLMBaseURL, "",
+//		openaicompat.WithModelIDs("meta-llama/Llama-3.1-8B-Instruct"),
+//	)
+//	client := core.NewClient(provider)
+//
+// # Registry
+//
+// Register makes an endpoint available through the providers registry under
+// its own ID, which is how the CLI exposes endpoints defined in its config:
+//
+//	openaicompat.Register("groq", openaicompat.GroqBaseURL)
+//	p, err := providers.Create("groq", os.Getenv("GROQ_API_KEY"))
+package openaicompat
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// OpenAICompat is an LLM provider for OpenAI-compatible APIs.
+// OpenAICompat is safe for concurrent use.
+type OpenAICompat struct {
+	config Config
+
+	modelCache *core.ModelCache // caches ListModels results
+}
+
+// New creates a provider with the given ID, base URL, optional API key, and options.
+func New(id, baseURL, apiKey string, opts ...Option) *OpenAICompat {
+	cfg := Config{
+		ID:            id,
+		BaseURL:       baseURL,
+		APIKey:        apiKey,
+		AuthStyle:     AuthBearer,
+		HTTPClient:    http.DefaultClient,
+		ModelCacheTTL: core.DefaultModelCacheTTL,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if len(cfg.Features) == 0 {
+		cfg.Features = defaultFeatures
+	}
+	for i := range cfg.Models {
+		if len(cfg.Models[i].Capabilities) == 0 {
+			cfg.Models[i].Capabilities = cfg.Features
+		}
+	}
+
+	p := &OpenAICompat{config: cfg}
+	p.modelCache = core.NewModelCache(cfg.ModelCacheTTL, p.fetchModels)
+	return p
+}
+
+// ID returns the configured provider identifier.
+func (p *OpenAICompat) ID() string {
+	return p.config.ID
+}
+
+// Models returns the configured list of models.
+func (p *OpenAICompat) Models() []core.ModelInfo {
+	// Return a copy to prevent mutation
+	result := make([]core.ModelInfo, len(p.config.Models))
+	copy(result, p.config.Models)
+	return result
+}
+
+// Supports reports whether the provider supports the given feature.
+func (p *OpenAICompat) Supports(feature core.Feature) bool {
+	for _, f := range p.config.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// buildHeaders constructs the HTTP headers for an API request.
+func (p *OpenAICompat) buildHeaders() http.Header {
+	headers := make(http.Header)
+	headers.Set("Content-Type", "application/json")
+
+	// Credentials, if any
+	if p.config.APIKey != "" {
+		switch p.config.AuthStyle {
+		case AuthHeader:
+			if p.config.AuthHeader != "" {
+				headers.Set(p.config.AuthHeader, p.config.APIKey)
+			}
+		case AuthNone:
+		default:
+			headers.Set("Authorization", "Bearer "+p.config.APIKey)
+		}
+	}
+
+	// Copy any extra headers
+	for key, values := range p.config.Headers {
+		for _, v := range values {
+			headers.Add(key, v)
+		}
+	}
+
+	return headers
+}
+
+// Chat sends a non-streaming chat request.
+func (p *OpenAICompat) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	return p.doChat(ctx, req)
+}
+
+// StreamChat sends a streaming chat request.
+func (p *OpenAICompat) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	return p.doStreamChat(ctx, req)
+}
+
+// Compile-time check that OpenAICompat implements Provider.
+var _ core.Provider = (*OpenAICompat)(nil)
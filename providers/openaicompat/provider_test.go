@@ -0,0 +1,103 @@
+package openaicompat
+
+import (
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers"
+)
+
+func TestOpenAICompatImplementsProvider(t *testing.T) {
+	p := New("vllm", VLLMBaseURL, "")
+	var _ core.Provider = p
+}
+
+func TestID(t *testing.T) {
+	p := New("groq", GroqBaseURL, "key")
+
+	if p.ID() != "groq" {
+		t.Errorf("ID() = %q, want %q", p.ID(), "groq")
+	}
+}
+
+func TestModelIDs(t *testing.T) {
+	p := New("vllm", VLLMBaseURL, "", WithModelIDs("llama", "qwen"))
+	models := p.Models()
+
+	if len(models) != 2 {
+		t.Fatalf("Models() returned %d models, want 2", len(models))
+	}
+	if models[0].ID != "llama" || models[1].ID != "qwen" {
+		t.Errorf("Models() = %v", models)
+	}
+	if len(models[0].Capabilities) != len(defaultFeatures) {
+		t.Errorf("Capabilities = %v, want %v", models[0].Capabilities, defaultFeatures)
+	}
+}
+
+func TestModelsReturnsCopy(t *testing.T) {
+	p := New("vllm", VLLMBaseURL, "", WithModelIDs("llama"))
+	models1 := p.Models()
+	models1[0].DisplayName = "modified"
+
+	if p.Models()[0].DisplayName == "modified" {
+		t.Error("Models() did not return a copy")
+	}
+}
+
+func TestSupports(t *testing.T) {
+	p := New("vllm", VLLMBaseURL, "")
+
+	if !p.Supports(core.FeatureChat) || !p.Supports(core.FeatureChatStreaming) || !p.Supports(core.FeatureToolCalling) {
+		t.Error("default features should include chat, streaming, and tool calling")
+	}
+	if p.Supports(core.FeatureReasoning) {
+		t.Error("Supports(reasoning) should be false by default")
+	}
+
+	p = New("vllm", VLLMBaseURL, "", WithFeatures(core.FeatureChat))
+	if p.Supports(core.FeatureToolCalling) {
+		t.Error("WithFeatures should replace the defaults")
+	}
+}
+
+func TestBuildHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		apiKey string
+		opts   []Option
+		header string
+		want   string
+	}{
+		{"bearer", "key", nil, "Authorization", "Bearer key"},
+		{"no key", "", nil, "Authorization", ""},
+		{"custom header", "key", []Option{WithAuthHeader("api-key")}, "api-key", "key"},
+		{"custom header omits bearer", "key", []Option{WithAuthHeader("api-key")}, "Authorization", ""},
+		{"no auth", "key", []Option{WithNoAuth()}, "Authorization", ""},
+		{"extra header", "", []Option{WithHeader("X-Custom", "v")}, "X-Custom", "v"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New("test", VLLMBaseURL, tt.apiKey, tt.opts...)
+			if got := p.buildHeaders().Get(tt.header); got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("compat-test", LMStudioBaseURL, WithModelIDs("local-model"))
+
+	p, err := providers.Create("compat-test", "")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if p.ID() != "compat-test" {
+		t.Errorf("ID() = %q, want %q", p.ID(), "compat-test")
+	}
+	if len(p.Models()) != 1 {
+		t.Errorf("Models() returned %d models, want 1", len(p.Models()))
+	}
+}
@@ -0,0 +1,15 @@
+package openaicompat
+
+import (
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers"
+)
+
+// Register adds an OpenAI-compatible endpoint to the providers registry
+// under id. Unlike the built-in providers there is no init() registration,
+// since each endpoint needs its own ID and base URL.
+func Register(id, baseURL string, opts ...Option) {
+	providers.Register(id, func(apiKey string) core.Provider {
+		return New(id, baseURL, apiKey, opts...)
+	})
+}
@@ -0,0 +1,287 @@
+package openaicompat
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// toolCallAssembler accumulates streaming tool call fragments.
+type toolCallAssembler struct {
+	calls []*assemblingToolCall
+}
+
+type assemblingToolCall struct {
+	ID        string
+	Name      string
+	Arguments strings.Builder
+}
+
+func newToolCallAssembler() *toolCallAssembler {
+	return &toolCallAssembler{}
+}
+
+// addFragment processes a streaming tool call fragment.
+// When a server omits the index, a fragment carrying a new ID starts a new
+// call and any other fragment continues the most recent one.
+func (a *toolCallAssembler) addFragment(tc compatStreamToolCall) {
+	idx := len(a.calls) - 1
+	switch {
+	case tc.Index != nil:
+		idx = *tc.Index
+	case idx < 0 || (tc.ID != "" && tc.ID != a.calls[idx].ID):
+		idx = len(a.calls)
+	}
+
+	for len(a.calls) <= idx {
+		a.calls = append(a.calls, nil)
+	}
+	call := a.calls[idx]
+	if call == nil {
+		call = &assemblingToolCall{}
+		a.calls[idx] = call
+	}
+
+	if tc.ID != "" {
+		call.ID = tc.ID
+	}
+	if tc.Function.Name != "" {
+		call.Name = tc.Function.Name
+	}
+
+	// Fragments are normally JSON strings; some servers send a whole object.
+	raw := tc.Function.Arguments
+	if len(raw) == 0 || string(raw) == "null" {
+		return
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		call.Arguments.WriteString(s)
+	} else {
+		call.Arguments.Write(raw)
+	}
+}
+
+// finalize validates and returns the assembled tool calls.
+func (a *toolCallAssembler) finalize() ([]core.ToolCall, error) {
+	if len(a.calls) == 0 {
+		return nil, nil
+	}
+
+	result := make([]core.ToolCall, 0, len(a.calls))
+	for i, call := range a.calls {
+		if call == nil {
+			continue
+		}
+
+		args := call.Arguments.String()
+		if args == "" {
+			args = "{}"
+		}
+		if !json.Valid([]byte(args)) {
+			return nil, ErrToolArgsInvalidJSON
+		}
+
+		id := call.ID
+		if id == "" {
+			id = fmt.Sprintf("call_%d", i)
+		}
+
+		result = append(result, core.ToolCall{
+			ID:        id,
+			Name:      call.Name,
+			Arguments: json.RawMessage(args),
+		})
+	}
+
+	return result, nil
+}
+
+// doStreamChat performs a streaming chat completion request.
+func (p *OpenAICompat) doStreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	// Marshal request body with stream=true
+	body, err := json.Marshal(p.buildRequest(req, true))
+	if err != nil {
+		return nil, p.newDecodeError(err)
+	}
+
+	httpReq, err := p.newRequest(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	// Execute request
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, p.newNetworkError(err)
+	}
+
+	// Extract request ID from response headers
+	requestID := resp.Header.Get("x-request-id")
+
+	// Check for error status
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, p.normalizeError(resp.StatusCode, respBody, requestID)
+	}
+
+	// Create channels
+	chunkCh := make(chan core.ChatChunk, 100)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *core.ChatResponse, 1)
+
+	// Start goroutine to process SSE stream
+	go p.processSSEStream(ctx, resp.Body, req.Model, chunkCh, errCh, finalCh)
+
+	return &core.ChatStream{
+		Ch:    chunkCh,
+		Err:   errCh,
+		Final: finalCh,
+	}, nil
+}
+
+// processSSEStream reads the SSE stream and emits chunks.
+// A stream that ends without [DONE] is treated as complete.
+func (p *OpenAICompat) processSSEStream(
+	ctx context.Context,
+	body io.ReadCloser,
+	model core.ModelID,
+	chunkCh chan<- core.ChatChunk,
+	errCh chan<- error,
+	finalCh chan<- *core.ChatResponse,
+) {
+	defer body.Close()
+	defer close(chunkCh)
+	defer close(errCh)
+	defer close(finalCh)
+
+	reader := bufio.NewReader(body)
+	assembler := newToolCallAssembler()
+
+	var responseID string
+	var responseModel string
+	var usage *compatUsage
+	var reasoning strings.Builder
+
+	for {
+		// Check for context cancellation
+		select {
+		case <-ctx.Done():
+			errCh <- ctx.Err()
+			return
+		default:
+		}
+
+		// Read line
+		line, err := reader.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			if err == io.EOF {
+				break
+			}
+			errCh <- p.newNetworkError(err)
+			return
+		}
+
+		// Trim whitespace
+		line = strings.TrimSpace(line)
+
+		// Skip empty lines, comments, and non-data fields
+		if !strings.HasPrefix(line, "data:") {
+			if err == io.EOF {
+				break
+			}
+			continue
+		}
+
+		payload := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+
+		// Check for done signal
+		if payload == "[DONE]" {
+			break
+		}
+
+		// Parse chunk
+		var chunk compatStreamChunk
+		if jerr := json.Unmarshal([]byte(payload), &chunk); jerr != nil {
+			errCh <- p.newDecodeError(jerr)
+			return
+		}
+
+		// Some servers report failures as an in-band error event
+		if len(chunk.Error) > 0 && string(chunk.Error) != "null" {
+			errCh <- p.newStreamError(chunk.Error)
+			return
+		}
+
+		// Capture metadata
+		if chunk.ID != "" {
+			responseID = chunk.ID
+		}
+		if chunk.Model != "" {
+			responseModel = chunk.Model
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+
+		// Process choices
+		for _, choice := range chunk.Choices {
+			reasoning.WriteString(firstNonEmpty(choice.Delta.ReasoningContent, choice.Delta.Reasoning))
+
+			// Emit content delta
+			if choice.Delta.Content != "" {
+				select {
+				case chunkCh <- core.ChatChunk{Delta: choice.Delta.Content}:
+				case <-ctx.Done():
+					errCh <- ctx.Err()
+					return
+				}
+			}
+
+			// Accumulate tool calls
+			for _, tc := range choice.Delta.ToolCalls {
+				assembler.addFragment(tc)
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	// Finalize tool calls
+	toolCalls, err := assembler.finalize()
+	if err != nil {
+		errCh <- err
+		return
+	}
+
+	// Build final response
+	if responseModel == "" {
+		responseModel = string(model)
+	}
+	finalResp := &core.ChatResponse{
+		ID:        responseID,
+		Model:     core.ModelID(responseModel),
+		ToolCalls: toolCalls,
+	}
+
+	if reasoning.Len() > 0 {
+		finalResp.Reasoning = &core.ReasoningOutput{
+			Summary: []string{reasoning.String()},
+		}
+	}
+
+	if usage != nil {
+		finalResp.Usage = mapUsage(usage)
+	}
+
+	finalCh <- finalResp
+}
@@ -0,0 +1,155 @@
+package openaicompat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/providers/providertest"
+)
+
+// streamServer serves body as an SSE stream and records the request body.
+func streamServer(t *testing.T, body string, got *map[string]any) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got != nil {
+			data, _ := io.ReadAll(r.Body)
+			json.Unmarshal(data, got)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(body))
+	}))
+}
+
+func TestStreamWithoutDoneOrUsage(t *testing.T) {
+	server := streamServer(t, sseData(
+		`{"choices":[{"delta":{"reasoning_content":"hmm"}}]}`,
+		`{"choices":[{"delta":{"content":"Hi"}}]}`,
+	), nil)
+	defer server.Close()
+
+	p := New("lmstudio", server.URL, "")
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{Model: "local"})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	res, err := providertest.CollectStream(stream, time.Second)
+	if err != nil {
+		t.Fatalf("CollectStream() error = %v", err)
+	}
+	if v := res.Violations(); len(v) > 0 {
+		t.Fatalf("contract violations: %v", v)
+	}
+	if res.Output() != "Hi" {
+		t.Errorf("Output() = %q, want %q", res.Output(), "Hi")
+	}
+
+	final := res.Final()
+	if final.Model != "local" {
+		t.Errorf("Model = %q, want request model", final.Model)
+	}
+	if final.Reasoning == nil || final.Reasoning.Summary[0] != "hmm" {
+		t.Errorf("Reasoning = %+v, want %q", final.Reasoning, "hmm")
+	}
+}
+
+func TestStreamToolCallsWithoutIndex(t *testing.T) {
+	server := streamServer(t, sseData(
+		`{"choices":[{"delta":{"tool_calls":[{"id":"a","function":{"name":"one","arguments":"{\"x\":"}}]}}]}`,
+		`{"choices":[{"delta":{"tool_calls":[{"function":{"arguments":"1}"}}]}}]}`,
+		`{"choices":[{"delta":{"tool_calls":[{"id":"b","function":{"name":"two","arguments":{"y":2}}}]}}]}`,
+		"[DONE]",
+	), nil)
+	defer server.Close()
+
+	p := New("llamacpp", server.URL, "")
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{Model: "m"})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	res, err := providertest.CollectStream(stream, time.Second)
+	if err != nil {
+		t.Fatalf("CollectStream() error = %v", err)
+	}
+	if res.Err() != nil {
+		t.Fatalf("stream error = %v", res.Err())
+	}
+
+	calls := res.Final().ToolCalls
+	if len(calls) != 2 {
+		t.Fatalf("len(ToolCalls) = %d, want 2", len(calls))
+	}
+	if calls[0].ID != "a" || string(calls[0].Arguments) != `{"x":1}` {
+		t.Errorf("ToolCalls[0] = %+v", calls[0])
+	}
+	if calls[1].ID != "b" || string(calls[1].Arguments) != `{"y":2}` {
+		t.Errorf("ToolCalls[1] = %+v", calls[1])
+	}
+}
+
+func TestStreamErrorEvent(t *testing.T) {
+	server := streamServer(t, sseData(
+		`{"choices":[{"delta":{"content":"partial"}}]}`,
+		`{"error":{"message":"model crashed","type":"server_error"}}`,
+	), nil)
+	defer server.Close()
+
+	p := New("vllm", server.URL, "")
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{Model: "m"})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	res, err := providertest.CollectStream(stream, time.Second)
+	if err != nil {
+		t.Fatalf("CollectStream() error = %v", err)
+	}
+	if v := res.Violations(); len(v) > 0 {
+		t.Fatalf("contract violations: %v", v)
+	}
+
+	var pErr *core.ProviderError
+	if !errors.As(res.Err(), &pErr) {
+		t.Fatalf("expected ProviderError, got %v", res.Err())
+	}
+	if pErr.Message != "model crashed" || pErr.Code != "server_error" {
+		t.Errorf("ProviderError = %+v", pErr)
+	}
+}
+
+func TestStreamUsageOption(t *testing.T) {
+	var got map[string]any
+	server := streamServer(t, sseData(
+		`{"choices":[{"delta":{"content":"Hi"}}]}`,
+		`{"choices":[],"usage":{"prompt_tokens":2,"completion_tokens":1,"total_tokens":3}}`,
+		"[DONE]",
+	), &got)
+	defer server.Close()
+
+	p := New("groq", server.URL, "key", WithStreamUsage())
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{Model: "m"})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	res, err := providertest.CollectStream(stream, time.Second)
+	if err != nil {
+		t.Fatalf("CollectStream() error = %v", err)
+	}
+	if res.Final().Usage.TotalTokens != 3 {
+		t.Errorf("TotalTokens = %d, want 3", res.Final().Usage.TotalTokens)
+	}
+
+	opts, _ := got["stream_options"].(map[string]any)
+	if opts["include_usage"] != true {
+		t.Errorf("stream_options = %v, want include_usage", got["stream_options"])
+	}
+}
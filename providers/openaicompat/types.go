@@ -0,0 +1,135 @@
+package openaicompat
+
+import "encoding/json"
+
+// compatRequest represents a chat completions request.
+type compatRequest struct {
+	Model           string               `json:"model"`
+	Messages        []compatMessage      `json:"messages"`
+	Temperature     *float32             `json:"temperature,omitempty"`
+	MaxTokens       *int                 `json:"max_tokens,omitempty"`
+	ReasoningEffort string               `json:"reasoning_effort,omitempty"`
+	Stream          bool                 `json:"stream"`
+	StreamOptions   *compatStreamOptions `json:"stream_options,omitempty"`
+	Tools           []compatTool         `json:"tools,omitempty"`
+	ToolChoice      string               `json:"tool_choice,omitempty"`
+}
+
+// compatStreamOptions controls optional streaming behavior.
+type compatStreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+// compatMessage represents a message in the request.
+type compatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// compatTool represents a tool definition.
+type compatTool struct {
+	Type     string         `json:"type"`
+	Function compatFunction `json:"function"`
+}
+
+// compatFunction represents a function definition for tools.
+type compatFunction struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Parameters  json.RawMessage `json:"parameters"`
+}
+
+// compatResponse represents a chat completions response.
+// Every field is optional; servers differ in what they populate.
+type compatResponse struct {
+	ID      string         `json:"id"`
+	Model   string         `json:"model"`
+	Choices []compatChoice `json:"choices"`
+	Usage   *compatUsage   `json:"usage,omitempty"`
+}
+
+// compatChoice represents a single choice in a response.
+type compatChoice struct {
+	Index        int           `json:"index"`
+	Message      compatRespMsg `json:"message"`
+	FinishReason string        `json:"finish_reason"`
+}
+
+// compatRespMsg represents the assistant message in a response.
+// Reasoning models served by vLLM, llama.cpp, and others report their
+// thinking in reasoning_content or reasoning.
+type compatRespMsg struct {
+	Role             string           `json:"role"`
+	Content          string           `json:"content"`
+	ReasoningContent string           `json:"reasoning_content,omitempty"`
+	Reasoning        string           `json:"reasoning,omitempty"`
+	ToolCalls        []compatToolCall `json:"tool_calls,omitempty"`
+}
+
+// compatToolCall represents a tool call in a response.
+type compatToolCall struct {
+	ID       string             `json:"id"`
+	Type     string             `json:"type"`
+	Function compatFunctionCall `json:"function"`
+}
+
+// compatFunctionCall represents the function details in a tool call.
+// Arguments is normally a JSON-encoded string, but some servers send an object.
+type compatFunctionCall struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// compatUsage represents token usage.
+type compatUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// Streaming response types.
+
+// compatStreamChunk represents a single SSE chunk.
+type compatStreamChunk struct {
+	ID      string               `json:"id"`
+	Model   string               `json:"model"`
+	Choices []compatStreamChoice `json:"choices"`
+	Usage   *compatUsage         `json:"usage,omitempty"`
+	Error   json.RawMessage      `json:"error,omitempty"`
+}
+
+// compatStreamChoice represents a single choice in a streaming chunk.
+type compatStreamChoice struct {
+	Index        int               `json:"index"`
+	Delta        compatStreamDelta `json:"delta"`
+	FinishReason *string           `json:"finish_reason,omitempty"`
+}
+
+// compatStreamDelta represents the delta content in a streaming chunk.
+type compatStreamDelta struct {
+	Role             string                 `json:"role,omitempty"`
+	Content          string                 `json:"content,omitempty"`
+	ReasoningContent string                 `json:"reasoning_content,omitempty"`
+	Reasoning        string                 `json:"reasoning,omitempty"`
+	ToolCalls        []compatStreamToolCall `json:"tool_calls,omitempty"`
+}
+
+// compatStreamToolCall represents a tool call fragment in a streaming chunk.
+// Index is a pointer because some servers omit it.
+type compatStreamToolCall struct {
+	Index    *int               `json:"index,omitempty"`
+	ID       string             `json:"id,omitempty"`
+	Type     string             `json:"type,omitempty"`
+	Function compatFunctionCall `json:"function,omitempty"`
+}
+
+// compatModelList is the response from GET /models.
+type compatModelList struct {
+	Data []compatModel `json:"data"`
+}
+
+// compatModel is a model entry from GET /models.
+type compatModel struct {
+	ID      string `json:"id"`
+	OwnedBy string `json:"owned_by"`
+}
@@ -5,6 +5,9 @@ import (
 	"net/http"
 
 	"github.com/erikhoward/iris/core"
+
+	// Registers the embedded model catalog as core's default
+	_ "github.com/erikhoward/iris/catalog"
 )
 
 // Perplexity is an LLM provider implementation for the Perplexity Search API.
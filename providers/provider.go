@@ -38,7 +38,13 @@
 //	}
 package providers
 
-import "github.com/erikhoward/iris/core"
+import (
+	"github.com/erikhoward/iris/core"
+
+	// Registers the embedded model catalog as core's default, so clients
+	// of every provider know their models' modalities
+	_ "github.com/erikhoward/iris/catalog"
+)
 
 // Re-export core types for convenience.
 // Provider implementations can import just the providers package.
@@ -0,0 +1,38 @@
+// Package providertest provides a conformance test kit for core.Provider
+// implementations.
+//
+// The kit checks the contracts documented on core.ChatStream and
+// core.ProviderError against a provider wired to a scripted httptest server:
+//
+//   - Ch, Err, and Final are all closed when a stream ends
+//   - Err emits at most one error
+//   - Final emits exactly once on success and never after an error
+//   - Streams terminate promptly when the context is canceled
+//   - HTTP error statuses map to the core sentinel errors
+//   - Tool call arguments are preserved byte-for-byte
+//   - Token usage is reported
+//
+// A provider package describes its wire format with Fixtures and runs the
+// Suite from an ordinary test:
+//
+//	func TestConformance(t *testing.T) {
+//	    suite := providertest.Suite{
+//	        NewProvider: func(baseURL string) core.Provider {
+//	            return New("test-key", WithBaseURL(baseURL))
+//	        },
+//	        Model: "gpt-4o",
+//	        Chat: &providertest.Fixture{
+//	            Body:   `{"id":"1","choices":[{"message":{"content":"Hello"}}]}`,
+//	            Output: "Hello",
+//	        },
+//	        ErrorBody: func(status int) string {
+//	            return `{"error":{"message":"boom"}}`
+//	        },
+//	    }
+//	    suite.Run(t)
+//	}
+//
+// Streams produced without an HTTP server, such as those from Fake or from
+// providers that wrap other providers, can be checked with CollectStream and
+// StreamResult.Violations.
+package providertest
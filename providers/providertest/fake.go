@@ -0,0 +1,152 @@
+package providertest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// Fake is a scripted core.Provider for testing code that consumes providers.
+// Its streams honor the core.ChatStream contract, so anything built on top of
+// a Fake can be checked with CollectStream.
+// Fake is safe for concurrent use.
+type Fake struct {
+	// ProviderID is returned by ID. Defaults to "fake".
+	ProviderID string
+
+	// ModelList is returned by Models.
+	ModelList []core.ModelInfo
+
+	// Features lists the features reported by Supports.
+	Features []core.Feature
+
+	// Response is returned by Chat and sent on Final by StreamChat.
+	// When nil, an empty response for the requested model is used.
+	Response *core.ChatResponse
+
+	// Chunks are the deltas emitted by StreamChat. When empty, the
+	// response output is emitted as a single delta.
+	Chunks []string
+
+	// Err, when set, is returned by Chat and StreamChat.
+	Err error
+
+	// StreamErr, when set, is sent on Err after the chunks instead of a
+	// final response.
+	StreamErr error
+
+	mu       sync.Mutex
+	requests []core.ChatRequest
+}
+
+// ID returns the provider identifier.
+func (f *Fake) ID() string {
+	if f.ProviderID == "" {
+		return "fake"
+	}
+	return f.ProviderID
+}
+
+// Models returns the configured model list.
+func (f *Fake) Models() []core.ModelInfo {
+	return f.ModelList
+}
+
+// Supports reports whether the feature is listed in Features.
+func (f *Fake) Supports(feature core.Feature) bool {
+	for _, ft := range f.Features {
+		if ft == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// Requests returns a copy of every request received so far.
+func (f *Fake) Requests() []core.ChatRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result := make([]core.ChatRequest, len(f.requests))
+	copy(result, f.requests)
+	return result
+}
+
+// Chat records the request and returns the scripted response.
+func (f *Fake) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	f.record(req)
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.response(req), nil
+}
+
+// StreamChat records the request and streams the scripted chunks.
+func (f *Fake) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	f.record(req)
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	resp := f.response(req)
+	chunks := f.Chunks
+	if len(chunks) == 0 && resp.Output != "" {
+		chunks = []string{resp.Output}
+	}
+
+	chunkCh := make(chan core.ChatChunk)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *core.ChatResponse, 1)
+
+	go func() {
+		defer close(chunkCh)
+		defer close(errCh)
+		defer close(finalCh)
+
+		for _, c := range chunks {
+			select {
+			case chunkCh <- core.ChatChunk{Delta: c}:
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+		}
+
+		if f.StreamErr != nil {
+			errCh <- f.StreamErr
+			return
+		}
+		finalCh <- resp
+	}()
+
+	return &core.ChatStream{
+		Ch:    chunkCh,
+		Err:   errCh,
+		Final: finalCh,
+	}, nil
+}
+
+func (f *Fake) record(req *core.ChatRequest) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, *req)
+}
+
+// response returns a copy of the scripted response so callers may mutate it.
+func (f *Fake) response(req *core.ChatRequest) *core.ChatResponse {
+	if f.Response == nil {
+		return &core.ChatResponse{Model: req.Model}
+	}
+	resp := *f.Response
+	if resp.Model == "" {
+		resp.Model = req.Model
+	}
+	return &resp
+}
+
+// Compile-time check that Fake implements Provider.
+var _ core.Provider = (*Fake)(nil)
@@ -0,0 +1,109 @@
+package providertest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestFakeChat(t *testing.T) {
+	f := &Fake{Response: &core.ChatResponse{Output: "hi"}}
+
+	resp, err := f.Chat(context.Background(), &core.ChatRequest{Model: "m1"})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.Output != "hi" {
+		t.Errorf("Output = %q, want %q", resp.Output, "hi")
+	}
+	if resp.Model != "m1" {
+		t.Errorf("Model = %q, want %q", resp.Model, "m1")
+	}
+
+	if got := len(f.Requests()); got != 1 {
+		t.Errorf("len(Requests()) = %d, want 1", got)
+	}
+}
+
+func TestFakeStreamConforms(t *testing.T) {
+	f := &Fake{
+		Response: &core.ChatResponse{Usage: core.TokenUsage{TotalTokens: 3}},
+		Chunks:   []string{"a", "b", "c"},
+	}
+
+	stream, err := f.StreamChat(context.Background(), &core.ChatRequest{Model: "m1"})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	result, err := CollectStream(stream, time.Second)
+	if err != nil {
+		t.Fatalf("CollectStream() error = %v", err)
+	}
+	if v := result.Violations(); len(v) != 0 {
+		t.Errorf("Violations() = %v, want none", v)
+	}
+	if result.Output() != "abc" {
+		t.Errorf("Output() = %q, want %q", result.Output(), "abc")
+	}
+	if result.Final().Usage.TotalTokens != 3 {
+		t.Errorf("Usage.TotalTokens = %d, want 3", result.Final().Usage.TotalTokens)
+	}
+}
+
+func TestFakeStreamError(t *testing.T) {
+	boom := errors.New("boom")
+	f := &Fake{Chunks: []string{"a"}, StreamErr: boom}
+
+	stream, err := f.StreamChat(context.Background(), &core.ChatRequest{Model: "m1"})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	result, err := CollectStream(stream, time.Second)
+	if err != nil {
+		t.Fatalf("CollectStream() error = %v", err)
+	}
+	if v := result.Violations(); len(v) != 0 {
+		t.Errorf("Violations() = %v, want none", v)
+	}
+	if !errors.Is(result.Err(), boom) {
+		t.Errorf("Err() = %v, want %v", result.Err(), boom)
+	}
+}
+
+func TestFakeStreamCancel(t *testing.T) {
+	f := &Fake{Chunks: []string{"a", "b"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := f.StreamChat(ctx, &core.ChatRequest{Model: "m1"})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+	cancel()
+
+	result, err := CollectStream(stream, time.Second)
+	if err != nil {
+		t.Fatalf("CollectStream() error = %v", err)
+	}
+	if v := result.Violations(); len(v) != 0 {
+		t.Errorf("Violations() = %v, want none", v)
+	}
+}
+
+func TestFakeSupports(t *testing.T) {
+	f := &Fake{Features: []core.Feature{core.FeatureChat}}
+
+	if !f.Supports(core.FeatureChat) {
+		t.Error("Supports(FeatureChat) = false, want true")
+	}
+	if f.Supports(core.FeatureToolCalling) {
+		t.Error("Supports(FeatureToolCalling) = true, want false")
+	}
+	if f.ID() != "fake" {
+		t.Errorf("ID() = %q, want %q", f.ID(), "fake")
+	}
+}
@@ -0,0 +1,140 @@
+package providertest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// ErrStreamNotClosed is returned by CollectStream when a stream leaves a
+// channel open past the timeout.
+var ErrStreamNotClosed = errors.New("stream channels not closed")
+
+// StreamResult records everything a ChatStream emitted before it closed.
+type StreamResult struct {
+	// Deltas holds the text of every chunk received on Ch, in order.
+	Deltas []string
+
+	// Errors holds every value received on Err, including nil values.
+	Errors []error
+
+	// Finals holds every value received on Final, including nil values.
+	Finals []*core.ChatResponse
+}
+
+// Output returns the concatenation of all deltas.
+func (r *StreamResult) Output() string {
+	return strings.Join(r.Deltas, "")
+}
+
+// Err returns the first error the stream emitted, or nil.
+func (r *StreamResult) Err() error {
+	for _, err := range r.Errors {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Final returns the first final response the stream emitted, or nil.
+func (r *StreamResult) Final() *core.ChatResponse {
+	if len(r.Finals) == 0 {
+		return nil
+	}
+	return r.Finals[0]
+}
+
+// Violations reports every way the recorded stream broke the core.ChatStream
+// contract. An empty result means the stream conformed.
+func (r *StreamResult) Violations() []string {
+	var v []string
+
+	if len(r.Errors) > 1 {
+		v = append(v, fmt.Sprintf("Err emitted %d values, want at most 1", len(r.Errors)))
+	}
+	for _, err := range r.Errors {
+		if err == nil {
+			v = append(v, "Err emitted a nil error")
+			break
+		}
+	}
+	for _, f := range r.Finals {
+		if f == nil {
+			v = append(v, "Final emitted a nil response")
+			break
+		}
+	}
+
+	if len(r.Errors) > 0 {
+		if len(r.Finals) > 0 {
+			v = append(v, fmt.Sprintf("Final emitted %d values after an error, want 0", len(r.Finals)))
+		}
+	} else if len(r.Finals) != 1 {
+		v = append(v, fmt.Sprintf("Final emitted %d values on success, want exactly 1", len(r.Finals)))
+	}
+
+	return v
+}
+
+// CollectStream drains Ch, Err, and Final concurrently until all three are
+// closed. If any channel is still open when timeout elapses, CollectStream
+// returns the partial result together with an error wrapping
+// ErrStreamNotClosed that names the open channels.
+func CollectStream(s *core.ChatStream, timeout time.Duration) (*StreamResult, error) {
+	if s == nil {
+		return nil, errors.New("nil stream")
+	}
+	if s.Ch == nil || s.Err == nil || s.Final == nil {
+		return nil, fmt.Errorf("%w: stream has a nil channel", ErrStreamNotClosed)
+	}
+
+	result := &StreamResult{}
+	ch, errCh, finalCh := s.Ch, s.Err, s.Final
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for ch != nil || errCh != nil || finalCh != nil {
+		select {
+		case chunk, ok := <-ch:
+			if !ok {
+				ch = nil
+				continue
+			}
+			result.Deltas = append(result.Deltas, chunk.Delta)
+
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil
+				continue
+			}
+			result.Errors = append(result.Errors, err)
+
+		case resp, ok := <-finalCh:
+			if !ok {
+				finalCh = nil
+				continue
+			}
+			result.Finals = append(result.Finals, resp)
+
+		case <-deadline.C:
+			var open []string
+			if ch != nil {
+				open = append(open, "Ch")
+			}
+			if errCh != nil {
+				open = append(open, "Err")
+			}
+			if finalCh != nil {
+				open = append(open, "Final")
+			}
+			return result, fmt.Errorf("%w after %v: %s", ErrStreamNotClosed, timeout, strings.Join(open, ", "))
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,144 @@
+package providertest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// scriptedStream builds a ChatStream that emits the given values and closes.
+func scriptedStream(deltas []string, errs []error, finals []*core.ChatResponse) *core.ChatStream {
+	ch := make(chan core.ChatChunk, len(deltas))
+	errCh := make(chan error, len(errs))
+	finalCh := make(chan *core.ChatResponse, len(finals))
+
+	for _, d := range deltas {
+		ch <- core.ChatChunk{Delta: d}
+	}
+	for _, e := range errs {
+		errCh <- e
+	}
+	for _, f := range finals {
+		finalCh <- f
+	}
+	close(ch)
+	close(errCh)
+	close(finalCh)
+
+	return &core.ChatStream{Ch: ch, Err: errCh, Final: finalCh}
+}
+
+func TestCollectStreamConforming(t *testing.T) {
+	s := scriptedStream([]string{"Hel", "lo"}, nil, []*core.ChatResponse{{ID: "r1"}})
+
+	result, err := CollectStream(s, time.Second)
+	if err != nil {
+		t.Fatalf("CollectStream() error = %v", err)
+	}
+
+	if got := result.Output(); got != "Hello" {
+		t.Errorf("Output() = %q, want %q", got, "Hello")
+	}
+	if result.Final() == nil || result.Final().ID != "r1" {
+		t.Errorf("Final() = %+v, want ID r1", result.Final())
+	}
+	if v := result.Violations(); len(v) != 0 {
+		t.Errorf("Violations() = %v, want none", v)
+	}
+}
+
+func TestCollectStreamErrorOnly(t *testing.T) {
+	boom := errors.New("boom")
+	s := scriptedStream([]string{"partial"}, []error{boom}, nil)
+
+	result, err := CollectStream(s, time.Second)
+	if err != nil {
+		t.Fatalf("CollectStream() error = %v", err)
+	}
+
+	if !errors.Is(result.Err(), boom) {
+		t.Errorf("Err() = %v, want %v", result.Err(), boom)
+	}
+	if v := result.Violations(); len(v) != 0 {
+		t.Errorf("Violations() = %v, want none", v)
+	}
+}
+
+func TestViolations(t *testing.T) {
+	tests := []struct {
+		name   string
+		errs   []error
+		finals []*core.ChatResponse
+		want   string
+	}{
+		{
+			name: "no final on success",
+			want: "want exactly 1",
+		},
+		{
+			name:   "two finals",
+			finals: []*core.ChatResponse{{}, {}},
+			want:   "want exactly 1",
+		},
+		{
+			name: "two errors",
+			errs: []error{errors.New("a"), errors.New("b")},
+			want: "want at most 1",
+		},
+		{
+			name:   "final after error",
+			errs:   []error{errors.New("a")},
+			finals: []*core.ChatResponse{{}},
+			want:   "after an error",
+		},
+		{
+			name: "nil error",
+			errs: []error{nil},
+			want: "nil error",
+		},
+		{
+			name:   "nil final",
+			finals: []*core.ChatResponse{nil},
+			want:   "nil response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CollectStream(scriptedStream(nil, tt.errs, tt.finals), time.Second)
+			if err != nil {
+				t.Fatalf("CollectStream() error = %v", err)
+			}
+
+			v := result.Violations()
+			if !strings.Contains(strings.Join(v, "; "), tt.want) {
+				t.Errorf("Violations() = %v, want one containing %q", v, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectStreamNotClosed(t *testing.T) {
+	ch := make(chan core.ChatChunk)
+	errCh := make(chan error)
+	finalCh := make(chan *core.ChatResponse)
+	close(ch)
+
+	_, err := CollectStream(&core.ChatStream{Ch: ch, Err: errCh, Final: finalCh}, 50*time.Millisecond)
+	if !errors.Is(err, ErrStreamNotClosed) {
+		t.Fatalf("CollectStream() error = %v, want ErrStreamNotClosed", err)
+	}
+	if !strings.Contains(err.Error(), "Err, Final") {
+		t.Errorf("error = %q, want open channels listed", err.Error())
+	}
+}
+
+func TestCollectStreamNilChannel(t *testing.T) {
+	_, err := CollectStream(&core.ChatStream{}, time.Second)
+	if !errors.Is(err, ErrStreamNotClosed) {
+		t.Errorf("CollectStream() error = %v, want ErrStreamNotClosed", err)
+	}
+}
@@ -0,0 +1,396 @@
+package providertest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/tools"
+)
+
+// DefaultTimeout bounds how long the suite waits for a stream to close.
+const DefaultTimeout = 2 * time.Second
+
+// DefaultErrorStatuses maps the HTTP statuses every provider is expected to
+// classify to the sentinel error each one must wrap.
+var DefaultErrorStatuses = map[int]error{
+	http.StatusBadRequest:          core.ErrBadRequest,
+	http.StatusUnauthorized:        core.ErrUnauthorized,
+	http.StatusForbidden:           core.ErrUnauthorized,
+	http.StatusTooManyRequests:     core.ErrRateLimited,
+	http.StatusInternalServerError: core.ErrServer,
+	http.StatusServiceUnavailable:  core.ErrServer,
+}
+
+// Fixture is a scripted server response paired with the result the provider
+// should produce from it.
+type Fixture struct {
+	// Status is the HTTP status code. Defaults to 200.
+	Status int
+
+	// Header holds response headers. Content-Type defaults to
+	// application/json when unset.
+	Header http.Header
+
+	// Body is written verbatim as the response body.
+	Body string
+
+	// Output is the expected response text. For streams it is compared
+	// against the concatenated deltas.
+	Output string
+
+	// Usage is the expected token usage. A zero value is not checked.
+	Usage core.TokenUsage
+
+	// ToolCalls are the expected tool calls. Arguments are compared
+	// byte-for-byte; an empty ID is not checked.
+	ToolCalls []core.ToolCall
+}
+
+// Handler returns an http.Handler that serves the fixture on every path.
+func (f *Fixture) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.writeHead(w)
+		fmt.Fprint(w, f.Body)
+	})
+}
+
+// stallHandler writes the fixture, flushes it, and then holds the connection
+// open until the client goes away.
+func (f *Fixture) stallHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.writeHead(w)
+		fmt.Fprint(w, f.Body)
+		if fl, ok := w.(http.Flusher); ok {
+			fl.Flush()
+		}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+	})
+}
+
+func (f *Fixture) writeHead(w http.ResponseWriter) {
+	for key, values := range f.Header {
+		for _, v := range values {
+			w.Header().Add(key, v)
+		}
+	}
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	status := f.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+}
+
+// Suite runs the conformance checks against one provider.
+// Checks whose fixture is nil are skipped.
+type Suite struct {
+	// NewProvider returns the provider under test configured to send all
+	// requests to baseURL (required).
+	NewProvider func(baseURL string) core.Provider
+
+	// Model is the model ID sent with every request (required).
+	Model core.ModelID
+
+	// Chat is a non-streaming text response.
+	Chat *Fixture
+
+	// Stream is a streaming text response.
+	Stream *Fixture
+
+	// ToolCall is a non-streaming response containing tool calls.
+	ToolCall *Fixture
+
+	// StreamToolCall is a streaming response containing tool calls.
+	StreamToolCall *Fixture
+
+	// Cancel is the beginning of a streaming response. The server writes
+	// it and then stalls so the suite can cancel the request mid-stream.
+	Cancel *Fixture
+
+	// ErrorBody returns the provider's error payload for a status code.
+	// When nil, error mapping is not checked.
+	ErrorBody func(status int) string
+
+	// ErrorStatuses overrides DefaultErrorStatuses.
+	ErrorStatuses map[int]error
+
+	// Timeout overrides DefaultTimeout.
+	Timeout time.Duration
+}
+
+// Run executes every configured check as a subtest of t.
+func (s *Suite) Run(t *testing.T) {
+	t.Helper()
+
+	if s.NewProvider == nil {
+		t.Fatal("providertest: Suite.NewProvider is required")
+	}
+	if s.Model == "" {
+		t.Fatal("providertest: Suite.Model is required")
+	}
+
+	t.Run("Chat", func(t *testing.T) { s.runChat(t, s.Chat, false) })
+	t.Run("Stream", func(t *testing.T) { s.runStream(t, s.Stream, false) })
+	t.Run("ToolCall", func(t *testing.T) { s.runChat(t, s.ToolCall, true) })
+	t.Run("StreamToolCall", func(t *testing.T) { s.runStream(t, s.StreamToolCall, true) })
+	t.Run("Cancel", s.runCancel)
+	t.Run("Errors", s.runErrors)
+}
+
+func (s *Suite) timeout() time.Duration {
+	if s.Timeout > 0 {
+		return s.Timeout
+	}
+	return DefaultTimeout
+}
+
+// request builds the chat request sent by every check.
+func (s *Suite) request(withTools bool) *core.ChatRequest {
+	req := &core.ChatRequest{
+		Model: s.Model,
+		Messages: []core.Message{
+			{Role: core.RoleUser, Content: "Hello"},
+		},
+	}
+	if withTools {
+		req.Tools = []core.Tool{weatherTool{}}
+	}
+	return req
+}
+
+func (s *Suite) runChat(t *testing.T, f *Fixture, withTools bool) {
+	if f == nil {
+		t.Skip("no fixture")
+	}
+
+	server := httptest.NewServer(f.Handler())
+	defer server.Close()
+
+	p := s.NewProvider(server.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout())
+	defer cancel()
+
+	resp, err := p.Chat(ctx, s.request(withTools))
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp == nil {
+		t.Fatal("Chat() returned nil response and nil error")
+	}
+
+	checkResponse(t, f, resp, resp.Output)
+}
+
+func (s *Suite) runStream(t *testing.T, f *Fixture, withTools bool) {
+	if f == nil {
+		t.Skip("no fixture")
+	}
+
+	server := httptest.NewServer(f.Handler())
+	defer server.Close()
+
+	p := s.NewProvider(server.URL)
+	stream, err := p.StreamChat(context.Background(), s.request(withTools))
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	result, err := CollectStream(stream, s.timeout())
+	if err != nil {
+		t.Fatalf("CollectStream() error = %v", err)
+	}
+	for _, v := range result.Violations() {
+		t.Errorf("stream contract: %s", v)
+	}
+	if err := result.Err(); err != nil {
+		t.Fatalf("stream error = %v", err)
+	}
+
+	final := result.Final()
+	if final == nil {
+		t.Fatal("stream emitted no final response")
+	}
+
+	output := final.Output
+	if output == "" {
+		output = result.Output()
+	}
+	checkResponse(t, f, final, output)
+}
+
+func (s *Suite) runCancel(t *testing.T) {
+	if s.Cancel == nil {
+		t.Skip("no fixture")
+	}
+
+	server := httptest.NewServer(s.Cancel.stallHandler())
+	defer server.Close()
+
+	p := s.NewProvider(server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := p.StreamChat(ctx, s.request(false))
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	// Wait for the first delta so cancellation happens mid-stream.
+	var first []string
+	select {
+	case chunk, ok := <-stream.Ch:
+		if ok {
+			first = append(first, chunk.Delta)
+		}
+	case <-time.After(s.timeout()):
+		t.Fatal("no delta received before the server stalled")
+	}
+
+	cancel()
+
+	result, err := CollectStream(stream, s.timeout())
+	if err != nil {
+		t.Fatalf("stream did not terminate after cancellation: %v", err)
+	}
+	result.Deltas = append(first, result.Deltas...)
+
+	if len(result.Errors) > 1 {
+		t.Errorf("stream contract: Err emitted %d values, want at most 1", len(result.Errors))
+	}
+	if len(result.Finals) > 0 && len(result.Errors) == 0 {
+		t.Error("canceled stream reported success on Final, want an error")
+	}
+}
+
+func (s *Suite) runErrors(t *testing.T) {
+	if s.ErrorBody == nil {
+		t.Skip("no ErrorBody")
+	}
+
+	statuses := s.ErrorStatuses
+	if statuses == nil {
+		statuses = DefaultErrorStatuses
+	}
+
+	codes := make([]int, 0, len(statuses))
+	for code := range statuses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	for _, code := range codes {
+		want := statuses[code]
+		f := &Fixture{Status: code, Body: s.ErrorBody(code)}
+
+		t.Run(fmt.Sprintf("Chat/%d", code), func(t *testing.T) {
+			server := httptest.NewServer(f.Handler())
+			defer server.Close()
+
+			p := s.NewProvider(server.URL)
+			_, err := p.Chat(context.Background(), s.request(false))
+			checkError(t, p.ID(), code, want, err)
+		})
+
+		t.Run(fmt.Sprintf("Stream/%d", code), func(t *testing.T) {
+			server := httptest.NewServer(f.Handler())
+			defer server.Close()
+
+			p := s.NewProvider(server.URL)
+			stream, err := p.StreamChat(context.Background(), s.request(false))
+			if err == nil {
+				// The error may also be delivered on the stream itself.
+				result, cerr := CollectStream(stream, s.timeout())
+				if cerr != nil {
+					t.Fatalf("CollectStream() error = %v", cerr)
+				}
+				for _, v := range result.Violations() {
+					t.Errorf("stream contract: %s", v)
+				}
+				err = result.Err()
+			}
+			checkError(t, p.ID(), code, want, err)
+		})
+	}
+}
+
+// checkResponse compares a response against the fixture's expectations.
+func checkResponse(t *testing.T, f *Fixture, resp *core.ChatResponse, output string) {
+	t.Helper()
+
+	if output != f.Output {
+		t.Errorf("Output = %q, want %q", output, f.Output)
+	}
+
+	if f.Usage != (core.TokenUsage{}) && resp.Usage != f.Usage {
+		t.Errorf("Usage = %+v, want %+v", resp.Usage, f.Usage)
+	}
+
+	if len(resp.ToolCalls) != len(f.ToolCalls) {
+		t.Fatalf("len(ToolCalls) = %d, want %d", len(resp.ToolCalls), len(f.ToolCalls))
+	}
+	for i, want := range f.ToolCalls {
+		got := resp.ToolCalls[i]
+		if want.ID != "" && got.ID != want.ID {
+			t.Errorf("ToolCalls[%d].ID = %q, want %q", i, got.ID, want.ID)
+		}
+		if got.Name != want.Name {
+			t.Errorf("ToolCalls[%d].Name = %q, want %q", i, got.Name, want.Name)
+		}
+		if !bytes.Equal(got.Arguments, want.Arguments) {
+			t.Errorf("ToolCalls[%d].Arguments = %s, want %s (byte-for-byte)", i, got.Arguments, want.Arguments)
+		}
+	}
+}
+
+// checkError verifies that err is a ProviderError wrapping the wanted sentinel.
+func checkError(t *testing.T, providerID string, status int, want, err error) {
+	t.Helper()
+
+	if err == nil {
+		t.Fatalf("status %d: error = nil, want %v", status, want)
+	}
+	if !errors.Is(err, want) {
+		t.Errorf("status %d: error = %v, want errors.Is(err, %v)", status, err, want)
+	}
+
+	var pErr *core.ProviderError
+	if !errors.As(err, &pErr) {
+		t.Fatalf("status %d: error type = %T, want *core.ProviderError", status, err)
+	}
+	if pErr.Status != status {
+		t.Errorf("ProviderError.Status = %d, want %d", pErr.Status, status)
+	}
+	if pErr.Provider != providerID {
+		t.Errorf("ProviderError.Provider = %q, want %q", pErr.Provider, providerID)
+	}
+}
+
+// weatherTool is the tool offered to the provider in tool call checks.
+type weatherTool struct{}
+
+func (weatherTool) Name() string        { return "get_weather" }
+func (weatherTool) Description() string { return "Get the current weather for a location" }
+
+func (weatherTool) Schema() tools.ToolSchema {
+	return tools.ToolSchema{
+		JSONSchema: json.RawMessage(`{"type":"object","properties":{"location":{"type":"string"}},"required":["location"]}`),
+	}
+}
+
+func (weatherTool) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	return nil, nil
+}
@@ -0,0 +1,68 @@
+package xai
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+)
+
+const modelsPath = "/models"
+
+// ListModels returns the models available to the API key using GET /models.
+// Models in the static list keep its capabilities; other language models
+// are assumed to support chat, streaming, and tool calling. Results are
+// cached for the configured ModelCacheTTL.
+func (p *Xai) ListModels(ctx context.Context) ([]core.ModelInfo, error) {
+	return p.modelCache.Get(ctx)
+}
+
+// fetchModels queries GET /models and merges the result with the static list.
+func (p *Xai) fetchModels(ctx context.Context) ([]core.ModelInfo, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.BaseURL+modelsPath, nil)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, normalizeError(resp.StatusCode, respBody, resp.Header.Get("x-request-id"))
+	}
+
+	var list xaiModelList
+	if err := json.Unmarshal(respBody, &list); err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	live := make([]core.ModelInfo, len(list.Data))
+	for i, m := range list.Data {
+		capabilities := []core.Feature{core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling}
+		if strings.Contains(m.ID, "image") {
+			capabilities = []core.Feature{core.FeatureImageGeneration}
+		}
+		live[i] = core.ModelInfo{ID: core.ModelID(m.ID), Capabilities: capabilities}
+	}
+	return core.MergeModels(live, models), nil
+}
+
+// Compile-time check that Xai implements ModelLister.
+var _ core.ModelLister = (*Xai)(nil)
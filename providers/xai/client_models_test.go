@@ -0,0 +1,66 @@
+package xai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+func TestListModels(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodGet || r.URL.Path != "/models" {
+			t.Errorf("request = %s %s, want GET /models", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("Authorization = %q", got)
+		}
+		io.WriteString(w, `{"object":"list","data":[
+			{"id":"grok-4","object":"model","owned_by":"xai"},
+			{"id":"grok-5","object":"model","owned_by":"xai"},
+			{"id":"grok-2-image-1212","object":"model","owned_by":"xai"}]}`)
+	}))
+	defer server.Close()
+
+	var provider core.ModelLister = New("test-key", WithBaseURL(server.URL))
+	models, err := provider.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if len(models) != 3 {
+		t.Fatalf("ListModels() returned %d models, want 3", len(models))
+	}
+	if models[0].ID != ModelGrok4 || !models[0].HasCapability(core.FeatureReasoning) {
+		t.Errorf("models[0] = %+v, want static metadata", models[0])
+	}
+	if models[1].ID != "grok-5" || models[1].DisplayName != "grok-5" || !models[1].HasCapability(core.FeatureToolCalling) {
+		t.Errorf("models[1] = %+v", models[1])
+	}
+	if !models[2].HasCapability(core.FeatureImageGeneration) || models[2].HasCapability(core.FeatureChat) {
+		t.Errorf("models[2] = %+v, want image generation only", models[2])
+	}
+
+	provider.ListModels(context.Background())
+	if calls != 1 {
+		t.Errorf("API calls = %d, want 1 (cached)", calls)
+	}
+}
+
+func TestListModelsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		io.WriteString(w, `{"error":{"message":"Incorrect API key","type":"invalid_request_error"}}`)
+	}))
+	defer server.Close()
+
+	_, err := New("test-key", WithBaseURL(server.URL)).ListModels(context.Background())
+	if !errors.Is(err, core.ErrUnauthorized) {
+		t.Errorf("ListModels() error = %v, want ErrUnauthorized", err)
+	}
+}
@@ -21,6 +21,10 @@ type Config struct {
 
 	// Timeout is the optional request timeout.
 	Timeout time.Duration
+
+	// ModelCacheTTL is how long ListModels results are cached.
+	// Defaults to core.DefaultModelCacheTTL; zero or less disables caching.
+	ModelCacheTTL time.Duration
 }
 
 // DefaultBaseURL is the default xAI API base URL.
@@ -59,3 +63,11 @@ func WithTimeout(d time.Duration) Option {
 		c.Timeout = d
 	}
 }
+
+// WithModelCacheTTL sets how long ListModels results are cached.
+// A duration of zero or less disables caching.
+func WithModelCacheTTL(d time.Duration) Option {
+	return func(c *Config) {
+		c.ModelCacheTTL = d
+	}
+}
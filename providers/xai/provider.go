@@ -11,21 +11,26 @@ import (
 // Xai is safe for concurrent use.
 type Xai struct {
 	config Config
+
+	modelCache *core.ModelCache // caches ListModels results
 }
 
 // New creates a new xAI provider with the given API key and options.
 func New(apiKey string, opts ...Option) *Xai {
 	cfg := Config{
-		APIKey:     apiKey,
-		BaseURL:    DefaultBaseURL,
-		HTTPClient: http.DefaultClient,
+		APIKey:        apiKey,
+		BaseURL:       DefaultBaseURL,
+		HTTPClient:    http.DefaultClient,
+		ModelCacheTTL: core.DefaultModelCacheTTL,
 	}
 
 	for _, opt := range opts {
 		opt(&cfg)
 	}
 
-	return &Xai{config: cfg}
+	p := &Xai{config: cfg}
+	p.modelCache = core.NewModelCache(cfg.ModelCacheTTL, p.fetchModels)
+	return p
 }
 
 // ID returns the provider identifier.
@@ -44,7 +49,7 @@ func (p *Xai) Models() []core.ModelInfo {
 // Supports reports whether the provider supports the given feature.
 func (p *Xai) Supports(feature core.Feature) bool {
 	switch feature {
-	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureReasoning:
+	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureReasoning, core.FeatureModelListing:
 		return true
 	default:
 		return false
@@ -117,3 +117,14 @@ type xaiStreamFunction struct {
 	Name      string `json:"name,omitempty"`
 	Arguments string `json:"arguments,omitempty"`
 }
+
+// xaiModelList is the response from GET /models.
+type xaiModelList struct {
+	Data []xaiModel `json:"data"`
+}
+
+// xaiModel is a model entry from GET /models.
+type xaiModel struct {
+	ID      string `json:"id"`
+	OwnedBy string `json:"owned_by"`
+}
@@ -0,0 +1,105 @@
+package textsplit
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Language is a programming language for NewCode.
+type Language string
+
+// Supported languages.
+const (
+	LanguageGo         Language = "go"
+	LanguagePython     Language = "python"
+	LanguageJavaScript Language = "javascript"
+	LanguageTypeScript Language = "typescript"
+	LanguageJava       Language = "java"
+	LanguageRust       Language = "rust"
+	LanguageRuby       Language = "ruby"
+)
+
+// syntax describes where code in a language splits best.
+type syntax struct {
+	// separators begin declarations, coarsest first.
+	separators []string
+
+	// leading are prefixes of the comment, attribute, and decorator lines
+	// that belong to the declaration below them.
+	leading []string
+}
+
+// lineSeparators follow the declaration separators of every language.
+var lineSeparators = []string{"\n\n", "\n", " ", ""}
+
+var languages = map[Language]syntax{
+	LanguageGo: {
+		separators: []string{"\nfunc ", "\ntype ", "\nvar ", "\nconst "},
+		leading:    []string{"//"},
+	},
+	LanguagePython: {
+		separators: []string{"\nclass ", "\ndef ", "\nasync def ", "\n    def ", "\n    async def "},
+		leading:    []string{"#", "@"},
+	},
+	LanguageJavaScript: {
+		separators: []string{"\nfunction ", "\nasync function ", "\nclass ", "\nexport ", "\nconst ", "\nlet ", "\nvar "},
+		leading:    []string{"//", "/*", "*", "@"},
+	},
+	LanguageTypeScript: {
+		separators: []string{"\nfunction ", "\nasync function ", "\nclass ", "\ninterface ", "\ntype ", "\nenum ", "\nexport ", "\nconst ", "\nlet ", "\nvar "},
+		leading:    []string{"//", "/*", "*", "@"},
+	},
+	LanguageJava: {
+		separators: []string{"\nclass ", "\ninterface ", "\nenum ", "\npublic ", "\nprotected ", "\nprivate ", "\n    public ", "\n    protected ", "\n    private ", "\n    static "},
+		leading:    []string{"//", "/*", "*", "@"},
+	},
+	LanguageRust: {
+		separators: []string{"\nfn ", "\npub fn ", "\nstruct ", "\npub struct ", "\nenum ", "\npub enum ", "\ntrait ", "\npub trait ", "\nimpl", "\nmod ", "\npub mod ", "\n    fn ", "\n    pub fn "},
+		leading:    []string{"//", "#["},
+	},
+	LanguageRuby: {
+		separators: []string{"\nclass ", "\nmodule ", "\ndef ", "\n  def "},
+		leading:    []string{"#"},
+	},
+}
+
+var extensions = map[string]Language{
+	".go":   LanguageGo,
+	".py":   LanguagePython,
+	".js":   LanguageJavaScript,
+	".jsx":  LanguageJavaScript,
+	".mjs":  LanguageJavaScript,
+	".cjs":  LanguageJavaScript,
+	".ts":   LanguageTypeScript,
+	".tsx":  LanguageTypeScript,
+	".mts":  LanguageTypeScript,
+	".cts":  LanguageTypeScript,
+	".java": LanguageJava,
+	".rs":   LanguageRust,
+	".rb":   LanguageRuby,
+}
+
+// LanguageForFile returns the language of a source file from its
+// extension, or "" if the language is not supported.
+func LanguageForFile(name string) Language {
+	return extensions[strings.ToLower(filepath.Ext(name))]
+}
+
+// NewCode returns a Splitter for source code in lang. It splits at
+// top-level declarations, such as functions, types, and classes, then at
+// blank lines, lines, and words, keeping the comments, attributes, and
+// decorators directly above a declaration with it. Each chunk's Metadata
+// has lang under "language". Code in an unsupported language is split
+// like NewRecursive.
+func NewCode(lang Language, opts ...Option) Splitter {
+	syn, ok := languages[lang]
+	var separators []string
+	if ok {
+		separators = append(append(separators, syn.separators...), lineSeparators...)
+	}
+	s := &splitter{cfg: newConfig(config{separators: separators}, opts), leading: syn.leading}
+	if lang != "" {
+		s.metadata = map[string]string{"language": string(lang)}
+	}
+	return s
+}
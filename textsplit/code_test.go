@@ -0,0 +1,82 @@
+package textsplit
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+const goSource = `package calc
+
+import "errors"
+
+// ErrDivide is returned for division by zero.
+var ErrDivide = errors.New("division by zero")
+
+// Add returns a + b.
+func Add(a, b int) int {
+	return a + b
+}
+
+// Divide returns a / b.
+//
+// It fails if b is zero.
+func Divide(a, b int) (int, error) {
+	if b == 0 {
+		return 0, ErrDivide
+	}
+	return a / b, nil
+}
+`
+
+func TestCode(t *testing.T) {
+	chunks := NewCode(LanguageGo, WithChunkSize(110)).Split(goSource)
+	checkChunks(t, goSource, chunks, 110, utf8.RuneCountInString)
+	want := []string{
+		"package calc\n\nimport \"errors\"",
+		"// ErrDivide is returned for division by zero.\nvar ErrDivide = errors.New(\"division by zero\")",
+		"// Add returns a + b.\nfunc Add(a, b int) int {\n\treturn a + b\n}",
+		"// Divide returns a / b.\n//\n// It fails if b is zero.\nfunc Divide(a, b int) (int, error) {\n\tif b == 0 {",
+		"return 0, ErrDivide\n\t}\n\treturn a / b, nil\n}",
+	}
+	if got := texts(chunks); !reflect.DeepEqual(got, want) {
+		t.Errorf("Split() = %q", got)
+	}
+	if chunks[0].Metadata["language"] != "go" {
+		t.Errorf("Metadata = %v", chunks[0].Metadata)
+	}
+
+	python := "import os\n\n\n@cache\ndef load(path):\n    return open(path).read()\n\n\nclass Store:\n    pass\n"
+	chunks = NewCode(LanguagePython, WithChunkSize(60)).Split(python)
+	checkChunks(t, python, chunks, 60, utf8.RuneCountInString)
+	want = []string{"import os", "@cache\ndef load(path):\n    return open(path).read()", "class Store:\n    pass"}
+	if got := texts(chunks); !reflect.DeepEqual(got, want) {
+		t.Errorf("Split() of Python = %q", got)
+	}
+
+	// Unsupported languages split like NewRecursive
+	chunks = NewCode("cobol", WithChunkSize(12)).Split("ADD A TO B.\nSTOP RUN.")
+	if got := texts(chunks); !reflect.DeepEqual(got, []string{"ADD A TO B.", "STOP RUN."}) || chunks[0].Metadata["language"] != "cobol" {
+		t.Errorf("Split() of unsupported language = %q, %v", got, chunks[0].Metadata)
+	}
+}
+
+func TestLanguageForFile(t *testing.T) {
+	tests := map[string]Language{
+		"main.go":          LanguageGo,
+		"lib/app.PY":       LanguagePython,
+		"index.tsx":        LanguageTypeScript,
+		"src/lib.rs":       LanguageRust,
+		"README.md":        "",
+		"Makefile":         "",
+		"archive.tar.gz":   "",
+		"component.jsx":    LanguageJavaScript,
+		"Main.java":        LanguageJava,
+		"config/routes.rb": LanguageRuby,
+	}
+	for name, want := range tests {
+		if got := LanguageForFile(name); got != want {
+			t.Errorf("LanguageForFile(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Package textsplit splits documents into chunks for embedding and
+// retrieval.
+//
+// Each Splitter joins consecutive pieces of text into chunks of up to a
+// maximum size, optionally overlapping, and records each chunk's byte
+// offsets in the source:
+//
+//   - NewRecursive splits at paragraphs, then lines, then words, measuring
+//     chunks in characters.
+//   - NewToken splits the same way, measuring chunks in tokens.
+//   - NewMarkdown keeps chunks within sections and records their headers.
+//   - NewCode splits source code at declarations in a given Language.
+//   - NewSentence joins whole sentences.
+//
+// SplitDocuments gives chunks IDs and metadata from their documents, and
+// the chunks plug into embedding requests:
+//
+//	splitter := textsplit.NewMarkdown(textsplit.WithChunkSize(800), textsplit.WithChunkOverlap(100))
+//	docs := textsplit.SplitDocuments(splitter,
+//		textsplit.Document{ID: "guide", Text: guide, Metadata: map[string]string{"source": "guide.md"}},
+//		textsplit.Document{ID: "faq", Text: faq},
+//	)
+//
+//	resp, err := provider.CreateEmbeddings(ctx, &core.EmbeddingRequest{
+//		Model:     "text-embedding-3-small",
+//		Input:     textsplit.EmbeddingInputs(docs...),
+//		InputType: core.InputTypeDocument,
+//	})
+//
+// Providers with contextualized embeddings take the chunks grouped by
+// document:
+//
+//	resp, err := provider.CreateContextualizedEmbeddings(ctx, &core.ContextualizedEmbeddingRequest{
+//		Model:  "voyage-context-3",
+//		Inputs: textsplit.ContextualizedInputs(docs...),
+//	})
+package textsplit
@@ -0,0 +1,129 @@
+package textsplit
+
+import (
+	"strconv"
+	"strings"
+)
+
+// markdown splits Markdown by section.
+type markdown struct {
+	splitter
+}
+
+// NewMarkdown returns a Splitter for Markdown that splits each section,
+// from an ATX header ("## Title") to the next, like NewRecursive, so no
+// chunk spans sections. Each chunk's Metadata holds the headers the chunk
+// is under, keyed "h1" to "h6". Headers in fenced code blocks are ignored.
+func NewMarkdown(opts ...Option) Splitter {
+	return &markdown{splitter{cfg: newConfig(config{}, opts)}}
+}
+
+// Split implements Splitter.
+func (m *markdown) Split(text string) []Chunk {
+	var chunks []Chunk
+	for _, sec := range markdownSections(text) {
+		chunks = m.split(text, sec.start, sec.end, m.cfg.separators, sec.headers, chunks)
+	}
+	return chunks
+}
+
+// section is the Markdown text[start:end] under the given headers.
+type section struct {
+	start, end int
+	headers    map[string]string
+}
+
+// markdownSections splits text before each header.
+func markdownSections(text string) []section {
+	var (
+		sections []section
+		current  section
+		headers  [6]string
+		fence    string
+	)
+	for pos := 0; pos < len(text); {
+		end := len(text)
+		if i := strings.IndexByte(text[pos:], '\n'); i >= 0 {
+			end = pos + i
+		}
+		line := text[pos:end]
+
+		switch {
+		case fence != "":
+			if closesFence(line, fence) {
+				fence = ""
+			}
+		case opensFence(line) != "":
+			fence = opensFence(line)
+		default:
+			if level, title, ok := atxHeader(line); ok {
+				if pos > current.start {
+					current.end = pos
+					sections = append(sections, current)
+				}
+				headers[level-1] = title
+				clear(headers[level:])
+				current = section{start: pos, headers: headerMetadata(headers)}
+			}
+		}
+		pos = end + 1
+	}
+	current.end = len(text)
+	return append(sections, current)
+}
+
+// headerMetadata returns the non-empty headers keyed by level.
+func headerMetadata(headers [6]string) map[string]string {
+	m := make(map[string]string)
+	for i, h := range headers {
+		if h != "" {
+			m["h"+strconv.Itoa(i+1)] = h
+		}
+	}
+	return m
+}
+
+// atxHeader parses a header line such as "## Title ##".
+func atxHeader(line string) (level int, title string, ok bool) {
+	line = strings.TrimRight(line, " \t\r")
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return 0, "", false
+	}
+	level = len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+	if level < 1 || level > 6 {
+		return 0, "", false
+	}
+	rest := trimmed[level:]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return 0, "", false
+	}
+	title = strings.TrimSpace(rest)
+	// Optional closing sequence
+	if closed := strings.TrimRight(title, "#"); closed != title && (closed == "" || strings.HasSuffix(closed, " ") || strings.HasSuffix(closed, "\t")) {
+		title = strings.TrimSpace(closed)
+	}
+	return level, title, true
+}
+
+// opensFence returns the fence that opens a fenced code block on line,
+// or "" if line does not open one.
+func opensFence(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 || len(trimmed) < 3 || (trimmed[0] != '`' && trimmed[0] != '~') {
+		return ""
+	}
+	fence := trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, trimmed[:1]))]
+	if len(fence) < 3 || (fence[0] == '`' && strings.Contains(trimmed[len(fence):], "`")) {
+		return ""
+	}
+	return fence
+}
+
+// closesFence reports whether line closes a code block opened by fence.
+func closesFence(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+	return len(line)-len(strings.TrimLeft(line, " ")) <= 3 &&
+		len(trimmed) >= len(fence) &&
+		strings.Trim(trimmed, fence[:1]) == ""
+}
@@ -0,0 +1,90 @@
+package textsplit
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+const guide = `Intro text.
+
+# Install
+
+Download the binary.
+
+## From source ##
+
+Run:
+
+` + "```sh" + `
+# not a header
+go install ./...
+` + "```" + `
+
+# Usage
+Run it with flags and arguments described in the reference manual.
+`
+
+func TestMarkdown(t *testing.T) {
+	chunks := NewMarkdown(WithChunkSize(40)).Split(guide)
+	checkChunks(t, guide, chunks, 40, utf8.RuneCountInString)
+
+	type want struct {
+		text    string
+		headers map[string]string
+	}
+	wants := []want{
+		{"Intro text.", nil},
+		{"# Install\n\nDownload the binary.", map[string]string{"h1": "Install"}},
+		{"## From source ##\n\nRun:", map[string]string{"h1": "Install", "h2": "From source"}},
+		{"```sh\n# not a header\ngo install ./...", map[string]string{"h1": "Install", "h2": "From source"}},
+		{"```", map[string]string{"h1": "Install", "h2": "From source"}},
+		{"# Usage", map[string]string{"h1": "Usage"}},
+		{"Run it with flags and arguments", map[string]string{"h1": "Usage"}},
+		{"described in the reference manual.", map[string]string{"h1": "Usage"}},
+	}
+	if len(chunks) != len(wants) {
+		t.Fatalf("Split() = %q", texts(chunks))
+	}
+	for i, w := range wants {
+		c := chunks[i]
+		if c.Text != w.text {
+			t.Errorf("chunk %d: Text = %q, want %q", i, c.Text, w.text)
+		}
+		if len(c.Metadata) != 0 || len(w.headers) != 0 {
+			if !reflect.DeepEqual(c.Metadata, w.headers) {
+				t.Errorf("chunk %d: Metadata = %v, want %v", i, c.Metadata, w.headers)
+			}
+		}
+	}
+
+	// Chunks own their metadata
+	chunks[1].Metadata["h1"] = "changed"
+	if chunks[2].Metadata["h1"] != "Install" {
+		t.Error("chunks share metadata")
+	}
+}
+
+func TestATXHeader(t *testing.T) {
+	tests := []struct {
+		line  string
+		level int
+		title string
+		ok    bool
+	}{
+		{"# Title", 1, "Title", true},
+		{"### Title ###", 3, "Title", true},
+		{"   ## Indented", 2, "Indented", true},
+		{"## C# ##\r", 2, "C#", true},
+		{"#", 1, "", true},
+		{"#hashtag", 0, "", false},
+		{"    # Code", 0, "", false},
+		{"####### Seven", 0, "", false},
+	}
+	for _, tt := range tests {
+		level, title, ok := atxHeader(tt.line)
+		if level != tt.level || title != tt.title || ok != tt.ok {
+			t.Errorf("atxHeader(%q) = %d, %q, %v", tt.line, level, title, ok)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package textsplit
+
+import (
+	"maps"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// splitter splits text recursively at separators and joins the parts
+// into chunks. The other splitters build on it.
+type splitter struct {
+	cfg *config
+
+	// leading are line prefixes, such as comments, that stay with the
+	// separator on the line below them.
+	leading []string
+
+	// metadata is set on every chunk.
+	metadata map[string]string
+}
+
+// NewRecursive returns a Splitter that splits text at the coarsest
+// separator that occurs in it: paragraphs, then lines, then words, then
+// characters. Parts still longer than the chunk size are split at the
+// next separator, and consecutive parts are joined into chunks of up to
+// 1000 characters by default.
+func NewRecursive(opts ...Option) Splitter {
+	return &splitter{cfg: newConfig(config{}, opts)}
+}
+
+// NewToken returns a Splitter like NewRecursive whose chunk size and
+// overlap are in tokens, 256 by default. Tokens are counted with
+// EstimateTokens; use WithLengthFunc to count them with a model's
+// tokenizer instead.
+func NewToken(opts ...Option) Splitter {
+	return &splitter{cfg: newConfig(config{chunkSize: 256, length: EstimateTokens}, opts)}
+}
+
+// Split implements Splitter.
+func (s *splitter) Split(text string) []Chunk {
+	return s.split(text, 0, len(text), s.cfg.separators, s.metadata, nil)
+}
+
+// span is the part text[start:end] and its length.
+type span struct {
+	start, end int
+	n          int
+}
+
+// split appends the chunks of text[start:end]. Text longer than the chunk
+// size is split at the first of separators that occurs in it; a
+// separator begins the part after it.
+func (s *splitter) split(text string, start, end int, separators []string, metadata map[string]string, chunks []Chunk) []Chunk {
+	if s.cfg.length(text[start:end]) <= s.cfg.chunkSize {
+		return appendChunk(chunks, text, start, end, metadata)
+	}
+
+	for i, sep := range separators {
+		var points []int
+		if sep == "" {
+			for j := start; j < end; {
+				_, size := utf8.DecodeRuneInString(text[j:end])
+				j += size
+				points = append(points, j)
+			}
+		} else {
+			points = s.splitPoints(text, start, end, sep)
+		}
+		if len(points) > 0 {
+			return s.splitParts(text, start, end, points, separators[i+1:], metadata, chunks)
+		}
+	}
+
+	// Nothing to split at
+	return appendChunk(chunks, text, start, end, metadata)
+}
+
+// splitParts appends the chunks of text[start:end] split at points.
+// Consecutive parts that fit are joined into chunks, and parts that are
+// too long are split with the remaining separators, so a chunk never
+// spans only part of a coarser split.
+func (s *splitter) splitParts(text string, start, end int, points []int, separators []string, metadata map[string]string, chunks []Chunk) []Chunk {
+	var parts []span
+	prev := start
+	for _, p := range append(points, end) {
+		if p <= prev {
+			continue
+		}
+		if n := s.cfg.length(text[prev:p]); n <= s.cfg.chunkSize {
+			parts = append(parts, span{prev, p, n})
+		} else {
+			chunks = s.merge(text, parts, metadata, chunks)
+			parts = nil
+			chunks = s.split(text, prev, p, separators, metadata, chunks)
+		}
+		prev = p
+	}
+	return s.merge(text, parts, metadata, chunks)
+}
+
+// splitPoints returns the offsets in text[start:end] where sep occurs,
+// moved up over any leading lines before it.
+func (s *splitter) splitPoints(text string, start, end int, sep string) []int {
+	var points []int
+	last := start
+	for i := start; i < end; {
+		j := strings.Index(text[i:end], sep)
+		if j < 0 {
+			break
+		}
+		at := i + j
+		i = at + len(sep)
+		if strings.HasPrefix(sep, "\n") && strings.TrimSpace(sep) != "" {
+			at = s.attachLeading(text, last, at)
+		}
+		if at > last {
+			points = append(points, at)
+			last = at
+		}
+	}
+	return points
+}
+
+// attachLeading moves the split point at, which is at the start of a
+// line, up over the leading lines just above it, but not before start.
+func (s *splitter) attachLeading(text string, start, at int) int {
+	for len(s.leading) > 0 && at > start {
+		lineStart := strings.LastIndexByte(text[start:at], '\n')
+		line := strings.TrimSpace(text[start+lineStart+1 : at])
+		if line == "" || !hasAnyPrefix(line, s.leading) {
+			break
+		}
+		if lineStart < 0 {
+			return start
+		}
+		at = start + lineStart
+	}
+	return at
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// merge appends chunks joining consecutive parts up to the chunk size.
+// Each chunk after the first starts with the last parts of the one before
+// it, up to the overlap.
+func (s *splitter) merge(text string, parts []span, metadata map[string]string, chunks []Chunk) []Chunk {
+	var window []span
+	total := 0
+	for _, p := range parts {
+		if len(window) > 0 && total+p.n > s.cfg.chunkSize {
+			chunks = appendChunk(chunks, text, window[0].start, window[len(window)-1].end, metadata)
+			for len(window) > 0 && (total > s.cfg.chunkOverlap || total+p.n > s.cfg.chunkSize) {
+				total -= window[0].n
+				window = window[1:]
+			}
+		}
+		window = append(window, p)
+		total += p.n
+	}
+	if len(window) > 0 {
+		chunks = appendChunk(chunks, text, window[0].start, window[len(window)-1].end, metadata)
+	}
+	return chunks
+}
+
+// appendChunk appends text[start:end] without surrounding whitespace as a
+// chunk, unless it is empty or within the previous chunk.
+func appendChunk(chunks []Chunk, text string, start, end int, metadata map[string]string) []Chunk {
+	s := text[start:end]
+	start += len(s) - len(strings.TrimLeftFunc(s, unicode.IsSpace))
+	end -= len(s) - len(strings.TrimRightFunc(s, unicode.IsSpace))
+	if start >= end {
+		return chunks
+	}
+	if n := len(chunks); n > 0 && start >= chunks[n-1].Start && end <= chunks[n-1].End {
+		return chunks
+	}
+	return append(chunks, Chunk{
+		Index:    len(chunks),
+		Text:     text[start:end],
+		Start:    start,
+		End:      end,
+		Metadata: maps.Clone(metadata),
+	})
+}
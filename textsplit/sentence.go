@@ -0,0 +1,120 @@
+package textsplit
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// sentences joins whole sentences into chunks.
+type sentences struct {
+	splitter
+}
+
+// NewSentence returns a Splitter that joins whole sentences into chunks of
+// up to 1000 characters by default, so chunks end mid-sentence only when a
+// sentence alone exceeds the chunk size; such a sentence is split at
+// words. The overlap also consists of whole sentences.
+func NewSentence(opts ...Option) Splitter {
+	return &sentences{splitter{cfg: newConfig(config{}, opts)}}
+}
+
+// wordSeparators split a sentence that is too long.
+var wordSeparators = []string{" ", ""}
+
+// Split implements Splitter.
+func (s *sentences) Split(text string) []Chunk {
+	return s.splitParts(text, 0, len(text), sentenceEnds(text), wordSeparators, nil, nil)
+}
+
+// Sentences splits text into sentences, one per chunk. A sentence ends at
+// terminal punctuation followed by whitespace and a word that does not
+// start in lowercase, except after common abbreviations and initials, or
+// at a blank line.
+func Sentences(text string) []Chunk {
+	var chunks []Chunk
+	start := 0
+	for _, end := range sentenceEnds(text) {
+		chunks = appendChunk(chunks, text, start, end, nil)
+		start = end
+	}
+	return chunks
+}
+
+// sentenceEnds returns the offsets where the sentences of text end; the
+// last is len(text). Whitespace between sentences begins the next one.
+func sentenceEnds(text string) []int {
+	var ends []int
+	start := 0
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case r == '\n' && blankLineAt(text, i+size):
+			if strings.TrimSpace(text[start:i]) != "" {
+				ends = append(ends, i)
+				start = i
+			}
+		case r == '。' || r == '！' || r == '？':
+			ends = append(ends, i+size)
+			start = i + size
+		case r == '.' || r == '!' || r == '?':
+			end := i + size
+			for end < len(text) {
+				c, n := utf8.DecodeRuneInString(text[end:])
+				if !strings.ContainsRune(".!?\"')]”’»", c) {
+					break
+				}
+				end += n
+			}
+			if end < len(text) && isSentenceBreak(text, start, i, end) {
+				ends = append(ends, end)
+				start = end
+			}
+			i = end
+			continue
+		}
+		i += size
+	}
+	return append(ends, len(text))
+}
+
+// blankLineAt reports whether the line at text[i:] is blank.
+func blankLineAt(text string, i int) bool {
+	rest := text[i:]
+	if j := strings.IndexByte(rest, '\n'); j >= 0 {
+		return strings.TrimSpace(rest[:j]) == ""
+	}
+	return false
+}
+
+// isSentenceBreak reports whether the punctuation at text[dot:end] ends a
+// sentence that began at start.
+func isSentenceBreak(text string, start, dot, end int) bool {
+	next := strings.TrimLeftFunc(text[end:], unicode.IsSpace)
+	if len(next) == len(text[end:]) || next == "" {
+		return false
+	}
+	if r, _ := utf8.DecodeRuneInString(next); unicode.IsLower(r) {
+		return false
+	}
+	if text[dot] != '.' {
+		return true
+	}
+
+	// The word before the period
+	word := text[start:dot]
+	if i := strings.LastIndexFunc(word, func(r rune) bool { return !unicode.IsLetter(r) && r != '.' }); i >= 0 {
+		word = word[i+1:]
+	}
+	if utf8.RuneCountInString(word) == 1 && unicode.IsUpper([]rune(word)[0]) {
+		return false // an initial
+	}
+	return !abbreviations[strings.ToLower(word)]
+}
+
+// abbreviations are words that usually end in a period mid-sentence.
+var abbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true,
+	"sr": true, "jr": true, "st": true, "vs": true, "e.g": true,
+	"i.e": true, "fig": true, "approx": true,
+}
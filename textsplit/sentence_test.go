@@ -0,0 +1,44 @@
+package textsplit
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSentences(t *testing.T) {
+	text := `Dr. Smith arrived at 3.30 p.m. on Monday. He said "Hello!" Then he left, e.g. for lunch? Yes.
+
+A heading without punctuation
+J. R. R. Tolkien wrote it. 日本語です。次の文。`
+	want := []string{
+		"Dr. Smith arrived at 3.30 p.m. on Monday.",
+		`He said "Hello!"`,
+		"Then he left, e.g. for lunch?",
+		"Yes.",
+		"A heading without punctuation\nJ. R. R. Tolkien wrote it.",
+		"日本語です。",
+		"次の文。",
+	}
+	chunks := Sentences(text)
+	checkChunks(t, text, chunks, len(text), utf8.RuneCountInString)
+	if got := texts(chunks); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sentences() = %q", got)
+	}
+}
+
+func TestSentence(t *testing.T) {
+	text := "First sentence here. Second one is here. Third is short. " +
+		"This fourth sentence is much longer than the chunk size allows."
+	chunks := NewSentence(WithChunkSize(45), WithChunkOverlap(20)).Split(text)
+	checkChunks(t, text, chunks, 45, utf8.RuneCountInString)
+	want := []string{
+		"First sentence here. Second one is here.",
+		"Second one is here. Third is short.",
+		"This fourth sentence is much longer than the",
+		"longer than the chunk size allows.",
+	}
+	if got := texts(chunks); !reflect.DeepEqual(got, want) {
+		t.Errorf("Split() = %q", got)
+	}
+}
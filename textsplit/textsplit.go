@@ -0,0 +1,176 @@
+package textsplit
+
+import (
+	"maps"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// Chunk is a piece of a split text.
+type Chunk struct {
+	// ID identifies the chunk. SplitDocuments sets it to the document ID
+	// followed by "#" and Index.
+	ID string
+
+	// Index is the position of the chunk among the text's chunks.
+	Index int
+
+	// Text is the chunk's text, without leading or trailing whitespace.
+	Text string
+
+	// Start and End are the byte offsets of Text in the source text:
+	// Text == source[Start:End]. With overlap, consecutive chunks' ranges
+	// overlap.
+	Start int
+	End   int
+
+	// Metadata describes the chunk, such as the Markdown headers above it
+	// or the programming language of code.
+	Metadata map[string]string
+}
+
+// EmbeddingInput returns the chunk as an input of a core.EmbeddingRequest.
+func (c Chunk) EmbeddingInput() core.EmbeddingInput {
+	return core.EmbeddingInput{Text: c.Text, ID: c.ID, Metadata: c.Metadata}
+}
+
+// Splitter splits text into chunks.
+type Splitter interface {
+	// Split returns the chunks of text, in order. Whitespace-only text
+	// has no chunks.
+	Split(text string) []Chunk
+}
+
+// Document is a text to split, such as a file or web page.
+type Document struct {
+	// ID identifies the document. Chunk IDs are derived from it.
+	ID string
+
+	Text string
+
+	// Metadata is copied to each of the document's chunks.
+	Metadata map[string]string
+}
+
+// SplitDocuments splits each document with s, returning the chunks grouped
+// by document. Each chunk's ID is the document ID followed by "#" and the
+// chunk's index, and its Metadata combines the document's Metadata with
+// the splitter's, which takes precedence.
+func SplitDocuments(s Splitter, docs ...Document) [][]Chunk {
+	out := make([][]Chunk, len(docs))
+	for i, doc := range docs {
+		chunks := s.Split(doc.Text)
+		for j := range chunks {
+			c := &chunks[j]
+			if doc.ID != "" {
+				c.ID = doc.ID + "#" + strconv.Itoa(c.Index)
+			}
+			if len(doc.Metadata) > 0 {
+				metadata := maps.Clone(doc.Metadata)
+				maps.Copy(metadata, c.Metadata)
+				c.Metadata = metadata
+			}
+		}
+		out[i] = chunks
+	}
+	return out
+}
+
+// EmbeddingInputs returns the chunks of one or more documents as the
+// inputs of a core.EmbeddingRequest, in order.
+func EmbeddingInputs(docs ...[]Chunk) []core.EmbeddingInput {
+	var inputs []core.EmbeddingInput
+	for _, chunks := range docs {
+		for _, c := range chunks {
+			inputs = append(inputs, c.EmbeddingInput())
+		}
+	}
+	return inputs
+}
+
+// ContextualizedInputs returns the texts of the chunks grouped by
+// document, for core.ContextualizedEmbeddingRequest.Inputs. The
+// embeddings in the response are grouped the same way, so
+// Embeddings[i][j] belongs to docs[i][j].
+func ContextualizedInputs(docs ...[]Chunk) [][]string {
+	inputs := make([][]string, len(docs))
+	for i, chunks := range docs {
+		texts := make([]string, len(chunks))
+		for j, c := range chunks {
+			texts[j] = c.Text
+		}
+		inputs[i] = texts
+	}
+	return inputs
+}
+
+// config holds the settings of a splitter.
+type config struct {
+	chunkSize    int
+	chunkOverlap int
+	length       func(string) int
+	separators   []string
+}
+
+// Option configures a splitter.
+type Option func(*config)
+
+// WithChunkSize sets the maximum length of a chunk, measured by the
+// splitter's length function: characters by default, or tokens for
+// NewToken. Text that cannot be split at any separator may exceed it.
+func WithChunkSize(n int) Option {
+	return func(c *config) {
+		c.chunkSize = n
+	}
+}
+
+// WithChunkOverlap sets how much of the end of each chunk is repeated at
+// the start of the next, so that context spanning a boundary is not lost.
+// Overlap consists of whole parts of text, so it is at most n. Default: 0.
+func WithChunkOverlap(n int) Option {
+	return func(c *config) {
+		c.chunkOverlap = n
+	}
+}
+
+// WithLengthFunc sets how the length of text is measured for
+// WithChunkSize and WithChunkOverlap, for example with a model's own
+// tokenizer.
+func WithLengthFunc(fn func(string) int) Option {
+	return func(c *config) {
+		c.length = fn
+	}
+}
+
+// WithSeparators sets the separators that NewRecursive, NewToken,
+// NewMarkdown, and NewCode split at, from coarsest to finest. An empty
+// separator splits between characters.
+func WithSeparators(separators ...string) Option {
+	return func(c *config) {
+		c.separators = separators
+	}
+}
+
+// newConfig applies opts to the given defaults.
+func newConfig(c config, opts []Option) *config {
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.chunkSize < 1 {
+		c.chunkSize = 1000
+	}
+	c.chunkOverlap = min(max(c.chunkOverlap, 0), c.chunkSize-1)
+	if c.length == nil {
+		c.length = utf8.RuneCountInString
+	}
+	if len(c.separators) == 0 {
+		c.separators = defaultSeparators
+	}
+	return &c
+}
+
+// defaultSeparators split at paragraphs, then lines, then words, then
+// characters.
+var defaultSeparators = []string{"\n\n", "\n", " ", ""}
@@ -0,0 +1,152 @@
+package textsplit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// checkChunks verifies that chunks are in order, match their offsets in
+// text, and are no longer than size by length.
+func checkChunks(t *testing.T, text string, chunks []Chunk, size int, length func(string) int) {
+	t.Helper()
+	for i, c := range chunks {
+		if c.Index != i {
+			t.Errorf("chunk %d: Index = %d", i, c.Index)
+		}
+		if text[c.Start:c.End] != c.Text {
+			t.Errorf("chunk %d: text[%d:%d] = %q, Text = %q", i, c.Start, c.End, text[c.Start:c.End], c.Text)
+		}
+		if c.Text != strings.TrimSpace(c.Text) || c.Text == "" {
+			t.Errorf("chunk %d: Text = %q, want trimmed and non-empty", i, c.Text)
+		}
+		if n := length(c.Text); n > size {
+			t.Errorf("chunk %d: length %d exceeds %d: %q", i, n, size, c.Text)
+		}
+		if i > 0 && c.Start < chunks[i-1].Start {
+			t.Errorf("chunk %d starts before chunk %d", i, i-1)
+		}
+	}
+}
+
+func texts(chunks []Chunk) []string {
+	out := make([]string, len(chunks))
+	for i, c := range chunks {
+		out[i] = c.Text
+	}
+	return out
+}
+
+const lorem = `Lorem ipsum dolor sit amet, consectetur adipiscing elit. Sed do eiusmod tempor incididunt ut labore.
+
+Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.
+Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur.
+
+Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.`
+
+func TestRecursive(t *testing.T) {
+	chunks := NewRecursive(WithChunkSize(120)).Split(lorem)
+	checkChunks(t, lorem, chunks, 120, utf8.RuneCountInString)
+	want := []string{
+		"Lorem ipsum dolor sit amet, consectetur adipiscing elit. Sed do eiusmod tempor incididunt ut labore.",
+		"Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.",
+		"Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur.",
+		"Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.",
+	}
+	if got := texts(chunks); !reflect.DeepEqual(got, want) {
+		t.Errorf("Split() = %q", got)
+	}
+
+	// Everything fits in one chunk
+	chunks = NewRecursive().Split(lorem)
+	if len(chunks) != 1 || chunks[0].Text != lorem {
+		t.Errorf("Split() with default size = %q", texts(chunks))
+	}
+
+	if chunks := NewRecursive().Split(" \n\n "); len(chunks) != 0 {
+		t.Errorf("Split() of whitespace = %q", texts(chunks))
+	}
+}
+
+func TestRecursiveOverlap(t *testing.T) {
+	text := "one two three four five six seven eight nine ten"
+	chunks := NewRecursive(WithChunkSize(15), WithChunkOverlap(6)).Split(text)
+	checkChunks(t, text, chunks, 15, utf8.RuneCountInString)
+	want := []string{"one two three", "three four", "four five six", "six seven", "seven eight", "eight nine ten"}
+	if got := texts(chunks); !reflect.DeepEqual(got, want) {
+		t.Errorf("Split() = %q, want %q", got, want)
+	}
+}
+
+func TestRecursiveLongWord(t *testing.T) {
+	text := "short ééééééééééé end"
+	chunks := NewRecursive(WithChunkSize(4)).Split(text)
+	checkChunks(t, text, chunks, 4, utf8.RuneCountInString)
+	want := []string{"shor", "t", "ééé", "éééé", "éééé", "end"}
+	if got := texts(chunks); !reflect.DeepEqual(got, want) {
+		t.Errorf("Split() = %q, want %q", got, want)
+	}
+}
+
+func TestToken(t *testing.T) {
+	text := strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 20)
+	chunks := NewToken(WithChunkSize(20), WithChunkOverlap(5)).Split(text)
+	checkChunks(t, text, chunks, 20, EstimateTokens)
+	if len(chunks) < 10 {
+		t.Errorf("Split() returned %d chunks", len(chunks))
+	}
+	for i := 1; i < len(chunks); i++ {
+		if chunks[i].Start >= chunks[i-1].End {
+			t.Errorf("chunk %d does not overlap chunk %d", i, i-1)
+		}
+	}
+
+	words := func(s string) int { return len(strings.Fields(s)) }
+	chunks = NewToken(WithChunkSize(3), WithLengthFunc(words)).Split("a b c d e f g")
+	if got := texts(chunks); !reflect.DeepEqual(got, []string{"a b c", "d e f", "g"}) {
+		t.Errorf("Split() with length func = %q", got)
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	tests := map[string]int{
+		"":                     0,
+		"hello":                2,
+		"Hello, world!":        6,
+		"a b c":                3,
+		"internationalization": 5,
+		"日本語":                  3,
+		"x = 1 + 2;":           6,
+	}
+	for text, want := range tests {
+		if got := EstimateTokens(text); got != want {
+			t.Errorf("EstimateTokens(%q) = %d, want %d", text, got, want)
+		}
+	}
+}
+
+func TestSplitDocuments(t *testing.T) {
+	docs := SplitDocuments(NewMarkdown(WithChunkSize(40)),
+		Document{ID: "guide", Text: "# Setup\n\nInstall the CLI.\n\n# Usage\n\nRun it.", Metadata: map[string]string{"source": "guide.md", "h1": "ignored"}},
+		Document{Text: "No ID here."},
+	)
+	if len(docs) != 2 || len(docs[0]) != 2 || len(docs[1]) != 1 {
+		t.Fatalf("SplitDocuments() = %+v", docs)
+	}
+	if c := docs[0][1]; c.ID != "guide#1" || c.Text != "# Usage\n\nRun it." || !reflect.DeepEqual(c.Metadata, map[string]string{"source": "guide.md", "h1": "Usage"}) {
+		t.Errorf("chunk = %+v", c)
+	}
+	if c := docs[1][0]; c.ID != "" || c.Metadata != nil {
+		t.Errorf("chunk without document ID = %+v", c)
+	}
+
+	inputs := EmbeddingInputs(docs...)
+	if len(inputs) != 3 || inputs[0].ID != "guide#0" || inputs[0].Text != "# Setup\n\nInstall the CLI." || inputs[0].Metadata["h1"] != "Setup" {
+		t.Errorf("EmbeddingInputs() = %+v", inputs)
+	}
+	want := [][]string{{"# Setup\n\nInstall the CLI.", "# Usage\n\nRun it."}, {"No ID here."}}
+	if got := ContextualizedInputs(docs...); !reflect.DeepEqual(got, want) {
+		t.Errorf("ContextualizedInputs() = %q", got)
+	}
+}
@@ -0,0 +1,34 @@
+package textsplit
+
+import "unicode"
+
+// EstimateTokens estimates how many tokens text is for the byte-pair
+// encoding tokenizers of common models, without a model-specific
+// vocabulary: about one token per four letters or digits of a word, one
+// per punctuation mark or symbol, and one per Chinese, Japanese, or Korean
+// character. Estimates are usually slightly high for English, which keeps
+// chunks within a model's limits.
+func EstimateTokens(text string) int {
+	tokens, word := 0, 0
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			tokens += wordTokens(word) + 1
+			word = 0
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			word++
+		case unicode.IsSpace(r):
+			tokens += wordTokens(word)
+			word = 0
+		default:
+			tokens += wordTokens(word) + 1
+			word = 0
+		}
+	}
+	return tokens + wordTokens(word)
+}
+
+// wordTokens estimates the tokens of a word of n letters and digits.
+func wordTokens(n int) int {
+	return (n + 3) / 4
+}
@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// AuditRecord describes one tool call run by an Executor. Arguments are
+// recorded as hashes, so the trail can show which calls were identical
+// without storing their possibly sensitive contents.
+type AuditRecord struct {
+	Time   time.Time `json:"time"`
+	CallID string    `json:"call_id,omitempty"`
+	Tool   string    `json:"tool"`
+
+	// ArgumentsHash is the SHA-256 of the model's arguments, in hex.
+	ArgumentsHash string `json:"arguments_hash"`
+
+	// Decision is the approval hook's decision, empty if the tool did not
+	// require approval or the call was rejected before approval.
+	Decision Decision `json:"decision,omitempty"`
+
+	// ApprovedArgumentsHash is the hash of the arguments the call ran
+	// with, when the approval hook modified them.
+	ApprovedArgumentsHash string `json:"approved_arguments_hash,omitempty"`
+
+	Duration  time.Duration `json:"duration"`
+	Error     string        `json:"error,omitempty"`
+	Truncated bool          `json:"truncated,omitempty"`
+}
+
+// AuditSink receives an Executor's audit records. Record is called
+// synchronously after each call, so slow sinks should buffer.
+type AuditSink interface {
+	Record(ctx context.Context, record AuditRecord)
+}
+
+// AuditFunc adapts a function to an AuditSink.
+type AuditFunc func(ctx context.Context, record AuditRecord)
+
+// Record calls f.
+func (f AuditFunc) Record(ctx context.Context, record AuditRecord) {
+	f(ctx, record)
+}
+
+// JSONAuditSink writes audit records to a writer as JSON lines. It is
+// safe for concurrent use.
+type JSONAuditSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONAuditSink returns a sink writing one JSON object per line to w.
+func NewJSONAuditSink(w io.Writer) *JSONAuditSink {
+	return &JSONAuditSink{w: w}
+}
+
+// Record writes the record. Write errors are ignored so that auditing
+// never fails a tool call.
+func (s *JSONAuditSink) Record(ctx context.Context, record AuditRecord) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.w.Write(append(data, '\n'))
+}
+
+// hashArguments returns the hex SHA-256 of args.
+func hashArguments(args json.RawMessage) string {
+	sum := sha256.Sum256(args)
+	return hex.EncodeToString(sum[:])
+}
+
+// Compile-time check that the sinks implement AuditSink.
+var (
+	_ AuditSink = AuditFunc(nil)
+	_ AuditSink = (*JSONAuditSink)(nil)
+)
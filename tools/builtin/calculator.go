@@ -0,0 +1,359 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/erikhoward/iris/tools"
+)
+
+// Limits on expressions, which come from a model.
+const (
+	maxExpressionLength = 1000
+	maxExpressionDepth  = 50
+)
+
+// CalculatorResult is the result of the calculator tool.
+type CalculatorResult struct {
+	Expression string  `json:"expression"`
+	Result     float64 `json:"result"`
+}
+
+// calculatorArgs are the calculator tool's arguments.
+type calculatorArgs struct {
+	Expression string `json:"expression"`
+}
+
+const calculatorSchema = `{
+	"type": "object",
+	"properties": {
+		"expression": {"type": "string", "description": "The expression, such as (2 + 3) * sqrt(16) / 2. Supports + - * / % ^, parentheses, the constants pi and e, and the functions sqrt, abs, sin, cos, tan, asin, acos, atan, log (base 10), ln, exp, floor, ceil, round, min, max, and pow"}
+	},
+	"required": ["expression"]
+}`
+
+// NewCalculatorTool returns the "calculator" tool, which evaluates
+// arithmetic expressions.
+func NewCalculatorTool() tools.Tool {
+	return &tool{
+		name:        "calculator",
+		description: "Evaluate an arithmetic expression and return the exact numeric result.",
+		schema:      json.RawMessage(calculatorSchema),
+		call: func(ctx context.Context, args json.RawMessage) (any, error) {
+			a, err := decodeArgs[calculatorArgs]("calculator", args)
+			if err != nil {
+				return nil, err
+			}
+			result, err := Evaluate(a.Expression)
+			if err != nil {
+				return nil, fmt.Errorf("builtin: calculator: %w", err)
+			}
+			return &CalculatorResult{Expression: a.Expression, Result: result}, nil
+		},
+	}
+}
+
+// Evaluate evaluates an arithmetic expression as the calculator tool does.
+func Evaluate(expr string) (float64, error) {
+	if strings.TrimSpace(expr) == "" {
+		return 0, errors.New("empty expression")
+	}
+	if len(expr) > maxExpressionLength {
+		return 0, fmt.Errorf("expression longer than %d characters", maxExpressionLength)
+	}
+	p := &exprParser{s: expr}
+	v, err := p.parseExpr()
+	if err != nil {
+		return 0, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return 0, fmt.Errorf("unexpected %q at position %d", p.s[p.pos], p.pos+1)
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, errors.New("result is not a finite number")
+	}
+	return v, nil
+}
+
+// exprParser is a recursive-descent parser that evaluates as it parses.
+//
+//	expr   = term { ("+" | "-") term }
+//	term   = unary { ("*" | "/" | "%") unary }
+//	unary  = ("+" | "-") unary | power
+//	power  = primary [ "^" unary ]
+//	primary = number | name [ "(" expr { "," expr } ")" ] | "(" expr ")"
+type exprParser struct {
+	s     string
+	pos   int
+	depth int
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+// consume skips spaces and then c if it is next.
+func (p *exprParser) consume(c byte) bool {
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *exprParser) parseExpr() (float64, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxExpressionDepth {
+		return 0, errors.New("expression nested too deeply")
+	}
+
+	v, err := p.parseTerm()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		switch {
+		case p.consume('+'):
+			r, err := p.parseTerm()
+			if err != nil {
+				return 0, err
+			}
+			v += r
+		case p.consume('-'):
+			r, err := p.parseTerm()
+			if err != nil {
+				return 0, err
+			}
+			v -= r
+		default:
+			return v, nil
+		}
+	}
+}
+
+func (p *exprParser) parseTerm() (float64, error) {
+	v, err := p.parseUnary()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		var op byte
+		switch {
+		case p.consume('*'):
+			op = '*'
+		case p.consume('/'):
+			op = '/'
+		case p.consume('%'):
+			op = '%'
+		default:
+			return v, nil
+		}
+		r, err := p.parseUnary()
+		if err != nil {
+			return 0, err
+		}
+		switch op {
+		case '*':
+			v *= r
+		case '/':
+			if r == 0 {
+				return 0, errors.New("division by zero")
+			}
+			v /= r
+		case '%':
+			if r == 0 {
+				return 0, errors.New("modulo by zero")
+			}
+			v = math.Mod(v, r)
+		}
+	}
+}
+
+func (p *exprParser) parseUnary() (float64, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxExpressionDepth {
+		return 0, errors.New("expression nested too deeply")
+	}
+
+	switch {
+	case p.consume('-'):
+		v, err := p.parseUnary()
+		return -v, err
+	case p.consume('+'):
+		return p.parseUnary()
+	}
+	return p.parsePower()
+}
+
+func (p *exprParser) parsePower() (float64, error) {
+	v, err := p.parsePrimary()
+	if err != nil {
+		return 0, err
+	}
+	if p.consume('^') {
+		// Right-associative, and binds tighter than a unary minus on
+		// its left: -2^2 is -4
+		r, err := p.parseUnary()
+		if err != nil {
+			return 0, err
+		}
+		v = math.Pow(v, r)
+	}
+	return v, nil
+}
+
+func (p *exprParser) parsePrimary() (float64, error) {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return 0, errors.New("unexpected end of expression")
+	}
+
+	c := p.s[p.pos]
+	switch {
+	case c == '(':
+		p.pos++
+		v, err := p.parseExpr()
+		if err != nil {
+			return 0, err
+		}
+		if !p.consume(')') {
+			return 0, fmt.Errorf("missing ) at position %d", p.pos+1)
+		}
+		return v, nil
+	case isDigit(c) || c == '.':
+		return p.parseNumber()
+	case isLetter(c):
+		return p.parseName()
+	}
+	return 0, fmt.Errorf("unexpected %q at position %d", c, p.pos+1)
+}
+
+func (p *exprParser) parseNumber() (float64, error) {
+	start := p.pos
+	for p.pos < len(p.s) && (isDigit(p.s[p.pos]) || p.s[p.pos] == '.') {
+		p.pos++
+	}
+	// Exponent, as in 1.5e3
+	if p.pos < len(p.s) && (p.s[p.pos] == 'e' || p.s[p.pos] == 'E') {
+		end := p.pos + 1
+		if end < len(p.s) && (p.s[end] == '+' || p.s[end] == '-') {
+			end++
+		}
+		if end < len(p.s) && isDigit(p.s[end]) {
+			for end < len(p.s) && isDigit(p.s[end]) {
+				end++
+			}
+			p.pos = end
+		}
+	}
+	v, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", p.s[start:p.pos])
+	}
+	return v, nil
+}
+
+func (p *exprParser) parseName() (float64, error) {
+	start := p.pos
+	for p.pos < len(p.s) && (isLetter(p.s[p.pos]) || isDigit(p.s[p.pos])) {
+		p.pos++
+	}
+	name := strings.ToLower(p.s[start:p.pos])
+
+	if !p.consume('(') {
+		switch name {
+		case "pi":
+			return math.Pi, nil
+		case "e":
+			return math.E, nil
+		}
+		return 0, fmt.Errorf("unknown constant %q", name)
+	}
+
+	var args []float64
+	if !p.consume(')') {
+		for {
+			v, err := p.parseExpr()
+			if err != nil {
+				return 0, err
+			}
+			args = append(args, v)
+			if p.consume(')') {
+				break
+			}
+			if !p.consume(',') {
+				return 0, fmt.Errorf("missing ) at position %d", p.pos+1)
+			}
+		}
+	}
+	return callFunction(name, args)
+}
+
+// unaryFunctions are the functions of one argument.
+var unaryFunctions = map[string]func(float64) float64{
+	"sqrt":  math.Sqrt,
+	"abs":   math.Abs,
+	"sin":   math.Sin,
+	"cos":   math.Cos,
+	"tan":   math.Tan,
+	"asin":  math.Asin,
+	"acos":  math.Acos,
+	"atan":  math.Atan,
+	"log":   math.Log10,
+	"ln":    math.Log,
+	"exp":   math.Exp,
+	"floor": math.Floor,
+	"ceil":  math.Ceil,
+	"round": math.Round,
+}
+
+// callFunction applies the named function to args.
+func callFunction(name string, args []float64) (float64, error) {
+	if fn, ok := unaryFunctions[name]; ok {
+		if len(args) != 1 {
+			return 0, fmt.Errorf("%s takes 1 argument, got %d", name, len(args))
+		}
+		return fn(args[0]), nil
+	}
+
+	switch name {
+	case "pow":
+		if len(args) != 2 {
+			return 0, fmt.Errorf("pow takes 2 arguments, got %d", len(args))
+		}
+		return math.Pow(args[0], args[1]), nil
+	case "min", "max":
+		if len(args) == 0 {
+			return 0, fmt.Errorf("%s takes at least 1 argument", name)
+		}
+		v := args[0]
+		for _, a := range args[1:] {
+			if name == "min" {
+				v = math.Min(v, a)
+			} else {
+				v = math.Max(v, a)
+			}
+		}
+		return v, nil
+	}
+	return 0, fmt.Errorf("unknown function %q", name)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_'
+}
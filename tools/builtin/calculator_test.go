@@ -0,0 +1,87 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 / 4 - 1", 1.5},
+		{"7 % 3", 1},
+		{"2 ^ 3 ^ 2", 512},
+		{"-2^2", -4},
+		{"--3", 3},
+		{"1.5e3 + .5", 1500.5},
+		{"sqrt(16) + abs(-2)", 6},
+		{"max(1, 5, 3) - min(4, 2)", 3},
+		{"pow(2, 10)", 1024},
+		{"round(PI * 100) / 100", 3.14},
+		{"ln(e)", 1},
+		{"log(1000)", 3},
+		{"floor(2.7) + ceil(2.1)", 5},
+	}
+	for _, tt := range tests {
+		got, err := Evaluate(tt.expr)
+		if err != nil {
+			t.Errorf("Evaluate(%q) error = %v", tt.expr, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Evaluate(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"", "empty expression"},
+		{"1 / 0", "division by zero"},
+		{"5 % (2 - 2)", "modulo by zero"},
+		{"sqrt(-1)", "not a finite number"},
+		{"1 +", "unexpected end"},
+		{"(1 + 2", "missing )"},
+		{"1 2", "unexpected '2'"},
+		{"x + 1", `unknown constant "x"`},
+		{"foo(1)", `unknown function "foo"`},
+		{"sqrt(1, 2)", "sqrt takes 1 argument"},
+		{"min()", "at least 1 argument"},
+		{"1 $ 2", "unexpected '$'"},
+		{strings.Repeat("(", 60) + "1" + strings.Repeat(")", 60), "nested too deeply"},
+		{strings.Repeat("-", 60) + "1", "nested too deeply"},
+		{strings.Repeat("1+", 600) + "1", "longer than"},
+	}
+	for _, tt := range tests {
+		_, err := Evaluate(tt.expr)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Evaluate(%q) error = %v, want %q", tt.expr, err, tt.want)
+		}
+	}
+}
+
+func TestCalculatorTool(t *testing.T) {
+	tool := NewCalculatorTool()
+	result, err := tool.Call(context.Background(), json.RawMessage(`{"expression":"6 * 7"}`))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if r := result.(*CalculatorResult); r.Result != 42 || r.Expression != "6 * 7" {
+		t.Errorf("Call() = %+v", r)
+	}
+
+	_, err = tool.Call(context.Background(), json.RawMessage(`{"expression":"1/0"}`))
+	if err == nil || err.Error() != "builtin: calculator: division by zero" {
+		t.Errorf("Call() error = %v", err)
+	}
+}
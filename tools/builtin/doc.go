@@ -0,0 +1,35 @@
+// Package builtin provides ready-made tools.Tool implementations for
+// common agent tasks, each scoped so a model can use it safely.
+//
+// The tools are:
+//
+//   - http_fetch (NewFetchTool): GET a URL on an allowlisted host, with a
+//     size limit, converting HTML to text.
+//   - read_file, list_directory, and search_files (NewFilesystemTools):
+//     read-only access to the files under a root directory, which paths
+//     and symbolic links cannot escape.
+//   - calculator (NewCalculatorTool): evaluate arithmetic expressions.
+//   - current_time and convert_time (NewTimeTools): the current time in a
+//     time zone, and conversion between time zones.
+//   - run_command (NewShellTool): run an allowlisted command, without a
+//     shell, with a timeout and an output limit.
+//
+// Register the tools you need:
+//
+//	reg := tools.NewRegistry()
+//	reg.Register(builtin.NewCalculatorTool())
+//	reg.Register(builtin.NewFetchTool(builtin.FetchConfig{
+//		AllowedHosts: []string{"go.dev", "*.wikipedia.org"},
+//	}))
+//
+//	fsTools, err := builtin.NewFilesystemTools(builtin.FilesystemConfig{Root: "./docs"})
+//	if err != nil {
+//		return err
+//	}
+//	for _, t := range fsTools {
+//		reg.Register(t)
+//	}
+//
+// Tool errors, such as a host that is not allowed or a file outside the
+// root, are returned from Call with the prefix "builtin: <tool name>:".
+package builtin
@@ -0,0 +1,164 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/erikhoward/iris/tools"
+)
+
+// FetchConfig configures the HTTP fetch tool.
+type FetchConfig struct {
+	// AllowedHosts lists the hosts that may be fetched. An entry
+	// "example.com" allows that host only; "*.example.com" allows its
+	// subdomains. Redirects are followed only to allowed hosts. With no
+	// entries, nothing may be fetched.
+	AllowedHosts []string
+
+	// MaxBytes limits the response body read. Longer bodies are truncated.
+	// Default: 1 MiB.
+	MaxBytes int64
+
+	// Timeout limits each fetch. Default: 30s.
+	Timeout time.Duration
+
+	// HTTPClient sends the requests. Its CheckRedirect is replaced to
+	// enforce the allowlist. Default: a client with no other settings.
+	HTTPClient *http.Client
+}
+
+// FetchResult is the result of the fetch tool.
+type FetchResult struct {
+	URL         string `json:"url"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Content     string `json:"content"`
+	Truncated   bool   `json:"truncated,omitempty"`
+}
+
+// fetchArgs are the fetch tool's arguments.
+type fetchArgs struct {
+	URL string `json:"url"`
+}
+
+// fetchSchema is the schema of the fetch tool's arguments.
+const fetchSchema = `{
+	"type": "object",
+	"properties": {
+		"url": {"type": "string", "description": "The http or https URL to fetch"}
+	},
+	"required": ["url"]
+}`
+
+// NewFetchTool returns the "http_fetch" tool, which GETs a URL on an
+// allowed host and returns its content, with HTML converted to text.
+func NewFetchTool(config FetchConfig) tools.Tool {
+	// Apply defaults
+	if config.MaxBytes <= 0 {
+		config.MaxBytes = 1 << 20
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	client := &http.Client{}
+	if config.HTTPClient != nil {
+		c := *config.HTTPClient
+		client = &c
+	}
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		if !hostAllowed(config.AllowedHosts, req.URL) {
+			return fmt.Errorf("redirect to %s is not allowed", req.URL.Host)
+		}
+		return nil
+	}
+
+	return &tool{
+		name:        "http_fetch",
+		description: "Fetch a web page or API response by URL. HTML is converted to plain text. Only allowed hosts can be fetched.",
+		schema:      json.RawMessage(fetchSchema),
+		call: func(ctx context.Context, args json.RawMessage) (any, error) {
+			a, err := decodeArgs[fetchArgs]("http_fetch", args)
+			if err != nil {
+				return nil, err
+			}
+			return fetch(ctx, client, config, a.URL)
+		},
+	}
+}
+
+// fetch GETs rawURL if its host is allowed.
+func fetch(ctx context.Context, client *http.Client, config FetchConfig, rawURL string) (*FetchResult, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("builtin: http_fetch: invalid URL %q: must be an absolute http or https URL", rawURL)
+	}
+	if !hostAllowed(config.AllowedHosts, u) {
+		return nil, fmt.Errorf("builtin: http_fetch: host %s is not allowed", u.Hostname())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("builtin: http_fetch: %w", err)
+	}
+	req.Header.Set("Accept", "text/html, text/plain, application/json, */*;q=0.5")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("builtin: http_fetch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, config.MaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("builtin: http_fetch: %w", err)
+	}
+	result := &FetchResult{
+		URL:         resp.Request.URL.String(),
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	if int64(len(body)) > config.MaxBytes {
+		body = body[:config.MaxBytes]
+		result.Truncated = true
+	}
+
+	// Truncation may split a character
+	content := strings.ToValidUTF8(string(body), "")
+	mediaType, _, _ := mime.ParseMediaType(result.ContentType)
+	if mediaType == "text/html" || mediaType == "application/xhtml+xml" {
+		content = HTMLToText(content)
+	}
+	result.Content = content
+	return result, nil
+}
+
+// hostAllowed reports whether u's host matches an allowlist entry.
+func hostAllowed(allowed []string, u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	for _, entry := range allowed {
+		entry = strings.ToLower(entry)
+		if suffix, ok := strings.CutPrefix(entry, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+		} else if host == entry {
+			return true
+		}
+	}
+	return false
+}
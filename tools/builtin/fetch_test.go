@@ -0,0 +1,115 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// newFetchServer returns a server with an HTML page, a large text
+// page, and a redirect to redirectTo.
+func newFetchServer(t *testing.T, redirectTo string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, `<html><head><title>Hi</title><script>alert(1)</script></head><body><p>Fish &amp; chips</p></body></html>`)
+	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, strings.Repeat("x", 100))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, redirectTo, http.StatusFound)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetchTool(t *testing.T) {
+	srv := newFetchServer(t, "/page")
+	tool := NewFetchTool(FetchConfig{AllowedHosts: []string{"127.0.0.1"}, MaxBytes: 10})
+	ctx := context.Background()
+
+	call := func(u string) (*FetchResult, error) {
+		t.Helper()
+		args, _ := json.Marshal(fetchArgs{URL: u})
+		result, err := tool.Call(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		return result.(*FetchResult), nil
+	}
+
+	large, err := call(srv.URL + "/large")
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if large.Content != strings.Repeat("x", 10) || !large.Truncated || large.Status != http.StatusOK {
+		t.Errorf("large = %+v", large)
+	}
+
+	unlimited := NewFetchTool(FetchConfig{AllowedHosts: []string{"127.0.0.1"}})
+	args, _ := json.Marshal(fetchArgs{URL: srv.URL + "/redirect"})
+	result, err := unlimited.Call(ctx, args)
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	page := result.(*FetchResult)
+	if page.Content != "Hi\nFish & chips" || page.URL != srv.URL+"/page" || page.Truncated {
+		t.Errorf("page = %+v", page)
+	}
+}
+
+func TestFetchToolErrors(t *testing.T) {
+	other := newFetchServer(t, "")
+	srv := newFetchServer(t, strings.Replace(other.URL, "127.0.0.1", "localhost", 1)+"/page")
+	tool := NewFetchTool(FetchConfig{AllowedHosts: []string{"127.0.0.1"}})
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"host not allowed", strings.Replace(srv.URL, "127.0.0.1", "localhost", 1) + "/page", "host localhost is not allowed"},
+		{"redirect not allowed", srv.URL + "/redirect", "redirect to localhost"},
+		{"relative", "/page", "invalid URL"},
+		{"scheme", "file:///etc/passwd", "invalid URL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, _ := json.Marshal(fetchArgs{URL: tt.url})
+			_, err := tool.Call(context.Background(), args)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Call() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostAllowed(t *testing.T) {
+	allowed := []string{"example.com", "*.Wikipedia.org"}
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://example.com/a", true},
+		{"https://EXAMPLE.com:8443/a", true},
+		{"https://api.example.com/a", false},
+		{"https://en.wikipedia.org/wiki/Go", true},
+		{"https://wikipedia.org/", false},
+		{"https://evilwikipedia.org/", false},
+	}
+	for _, tt := range tests {
+		u, _ := url.Parse(tt.url)
+		if got := hostAllowed(allowed, u); got != tt.want {
+			t.Errorf("hostAllowed(%s) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
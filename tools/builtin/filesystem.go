@@ -0,0 +1,366 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/erikhoward/iris/tools"
+)
+
+// FilesystemConfig configures the filesystem tools.
+type FilesystemConfig struct {
+	// Root is the directory the tools can access. Paths are resolved
+	// relative to it, and neither ".." nor symbolic links can escape it.
+	// Required.
+	Root string
+
+	// MaxFileBytes limits how much of a file read_file returns and how
+	// much of each file search_files scans. Default: 1 MiB.
+	MaxFileBytes int64
+
+	// MaxResults limits the entries list_directory returns and the matches
+	// search_files returns. Default: 100.
+	MaxResults int
+}
+
+// FileContent is the result of the read_file tool.
+type FileContent struct {
+	Path      string `json:"path"`
+	Content   string `json:"content"`
+	Size      int64  `json:"size"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// DirEntry is an entry in the result of the list_directory tool.
+type DirEntry struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Size int64  `json:"size,omitempty"`
+}
+
+// DirListing is the result of the list_directory tool.
+type DirListing struct {
+	Path      string     `json:"path"`
+	Entries   []DirEntry `json:"entries"`
+	Truncated bool       `json:"truncated,omitempty"`
+}
+
+// SearchMatch is a match in the result of the search_files tool. Line
+// and Text are set for content matches.
+type SearchMatch struct {
+	Path string `json:"path"`
+	Line int    `json:"line,omitempty"`
+	Text string `json:"text,omitempty"`
+}
+
+// SearchResult is the result of the search_files tool.
+type SearchResult struct {
+	Matches   []SearchMatch `json:"matches"`
+	Truncated bool          `json:"truncated,omitempty"`
+}
+
+// pathArgs are the read_file and list_directory tools' arguments.
+type pathArgs struct {
+	Path string `json:"path"`
+}
+
+// searchArgs are the search_files tool's arguments.
+type searchArgs struct {
+	Path    string `json:"path"`
+	Pattern string `json:"pattern"`
+	Query   string `json:"query"`
+}
+
+const readFileSchema = `{
+	"type": "object",
+	"properties": {
+		"path": {"type": "string", "description": "The file path, relative to the root directory"}
+	},
+	"required": ["path"]
+}`
+
+const listDirectorySchema = `{
+	"type": "object",
+	"properties": {
+		"path": {"type": "string", "description": "The directory path, relative to the root directory. Default: the root directory"}
+	}
+}`
+
+const searchFilesSchema = `{
+	"type": "object",
+	"properties": {
+		"path": {"type": "string", "description": "The directory to search, relative to the root directory. Default: the root directory"},
+		"pattern": {"type": "string", "description": "A glob matched against file names, such as *.go"},
+		"query": {"type": "string", "description": "Text to find in file contents"}
+	}
+}`
+
+// NewFilesystemTools returns the "read_file", "list_directory", and
+// "search_files" tools, which give read-only access to the files under
+// config.Root.
+func NewFilesystemTools(config FilesystemConfig) ([]tools.Tool, error) {
+	if config.Root == "" {
+		return nil, errors.New("builtin: filesystem root is required")
+	}
+	info, err := os.Stat(config.Root)
+	if err != nil {
+		return nil, fmt.Errorf("builtin: filesystem root: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("builtin: filesystem root %s is not a directory", config.Root)
+	}
+
+	// Apply defaults
+	if config.MaxFileBytes <= 0 {
+		config.MaxFileBytes = 1 << 20
+	}
+	if config.MaxResults <= 0 {
+		config.MaxResults = 100
+	}
+
+	fsys := &rootFS{config: config}
+	return []tools.Tool{
+		&tool{
+			name:        "read_file",
+			description: "Read a text file.",
+			schema:      json.RawMessage(readFileSchema),
+			call: func(ctx context.Context, args json.RawMessage) (any, error) {
+				a, err := decodeArgs[pathArgs]("read_file", args)
+				if err != nil {
+					return nil, err
+				}
+				return fsys.readFile(a.Path)
+			},
+		},
+		&tool{
+			name:        "list_directory",
+			description: "List the files and directories in a directory.",
+			schema:      json.RawMessage(listDirectorySchema),
+			call: func(ctx context.Context, args json.RawMessage) (any, error) {
+				a, err := decodeArgs[pathArgs]("list_directory", args)
+				if err != nil {
+					return nil, err
+				}
+				return fsys.listDirectory(a.Path)
+			},
+		},
+		&tool{
+			name:        "search_files",
+			description: "Find files by name pattern, by content, or both. Content matches include the line number and text.",
+			schema:      json.RawMessage(searchFilesSchema),
+			call: func(ctx context.Context, args json.RawMessage) (any, error) {
+				a, err := decodeArgs[searchArgs]("search_files", args)
+				if err != nil {
+					return nil, err
+				}
+				return fsys.search(ctx, a)
+			},
+		},
+	}, nil
+}
+
+// rootFS implements the filesystem tools. Each call opens the root anew
+// so the tools keep working if the directory is replaced.
+type rootFS struct {
+	config FilesystemConfig
+}
+
+// open opens the root directory as an fs.FS.
+func (r *rootFS) open(name string) (fs.FS, func(), error) {
+	root, err := os.OpenRoot(r.config.Root)
+	if err != nil {
+		return nil, nil, fmt.Errorf("builtin: %s: %w", name, err)
+	}
+	return root.FS(), func() { root.Close() }, nil
+}
+
+// cleanPath converts a path relative to the root, or absolute with the
+// root as "/", to an fs.FS path. The os.Root rejects any escape that
+// remains through symbolic links.
+func cleanPath(p string) string {
+	p = strings.TrimPrefix(path.Clean("/"+p), "/")
+	if p == "" {
+		return "."
+	}
+	return p
+}
+
+func (r *rootFS) readFile(p string) (*FileContent, error) {
+	if p == "" {
+		return nil, errors.New("builtin: read_file: path is required")
+	}
+	fsys, closeRoot, err := r.open("read_file")
+	if err != nil {
+		return nil, err
+	}
+	defer closeRoot()
+
+	name := cleanPath(p)
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("builtin: read_file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("builtin: read_file: %w", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("builtin: read_file: %s is a directory", name)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(f, r.config.MaxFileBytes))
+	if err != nil {
+		return nil, fmt.Errorf("builtin: read_file: %w", err)
+	}
+	if isBinary(data) {
+		return nil, fmt.Errorf("builtin: read_file: %s is not a text file", name)
+	}
+	return &FileContent{
+		Path:      name,
+		Content:   strings.ToValidUTF8(string(data), ""),
+		Size:      info.Size(),
+		Truncated: info.Size() > int64(len(data)),
+	}, nil
+}
+
+func (r *rootFS) listDirectory(p string) (*DirListing, error) {
+	fsys, closeRoot, err := r.open("list_directory")
+	if err != nil {
+		return nil, err
+	}
+	defer closeRoot()
+
+	name := cleanPath(p)
+	entries, err := fs.ReadDir(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("builtin: list_directory: %w", err)
+	}
+
+	listing := &DirListing{Path: name, Entries: []DirEntry{}}
+	for _, entry := range entries {
+		if len(listing.Entries) == r.config.MaxResults {
+			listing.Truncated = true
+			break
+		}
+		e := DirEntry{Name: entry.Name(), Type: entryType(entry.Type())}
+		if entry.Type().IsRegular() {
+			if info, err := entry.Info(); err == nil {
+				e.Size = info.Size()
+			}
+		}
+		listing.Entries = append(listing.Entries, e)
+	}
+	return listing, nil
+}
+
+func (r *rootFS) search(ctx context.Context, args searchArgs) (*SearchResult, error) {
+	if args.Pattern == "" && args.Query == "" {
+		return nil, errors.New("builtin: search_files: pattern or query is required")
+	}
+	if _, err := path.Match(args.Pattern, ""); err != nil {
+		return nil, fmt.Errorf("builtin: search_files: invalid pattern %q: %w", args.Pattern, err)
+	}
+	fsys, closeRoot, err := r.open("search_files")
+	if err != nil {
+		return nil, err
+	}
+	defer closeRoot()
+
+	result := &SearchResult{Matches: []SearchMatch{}}
+	errLimit := errors.New("result limit reached")
+	add := func(m SearchMatch) error {
+		if len(result.Matches) == r.config.MaxResults {
+			result.Truncated = true
+			return errLimit
+		}
+		result.Matches = append(result.Matches, m)
+		return nil
+	}
+
+	err = fs.WalkDir(fsys, cleanPath(args.Path), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Skip unreadable entries rather than failing the search
+			if p == cleanPath(args.Path) {
+				return err
+			}
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if args.Pattern != "" {
+			if ok, _ := path.Match(args.Pattern, d.Name()); !ok {
+				return nil
+			}
+		}
+		if args.Query == "" {
+			return add(SearchMatch{Path: p})
+		}
+		return r.searchFile(fsys, p, args.Query, add)
+	})
+	if err != nil && !errors.Is(err, errLimit) {
+		return nil, fmt.Errorf("builtin: search_files: %w", err)
+	}
+	return result, nil
+}
+
+// searchFile adds a match for each line of the text file p containing
+// query. Binary and unreadable files are skipped.
+func (r *rootFS) searchFile(fsys fs.FS, p, query string, add func(SearchMatch) error) error {
+	f, err := fsys.Open(p)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, r.config.MaxFileBytes))
+	if err != nil || isBinary(data) {
+		return nil
+	}
+
+	for i, text := range strings.Split(string(data), "\n") {
+		if !strings.Contains(text, query) {
+			continue
+		}
+		text = strings.TrimSuffix(text, "\r")
+		if len(text) > 200 {
+			text = strings.ToValidUTF8(text[:200], "") + "..."
+		}
+		if err := add(SearchMatch{Path: p, Line: i + 1, Text: text}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isBinary reports whether data looks like binary content.
+func isBinary(data []byte) bool {
+	return bytes.IndexByte(data, 0) >= 0
+}
+
+// entryType describes a file mode's type.
+func entryType(mode fs.FileMode) string {
+	switch {
+	case mode.IsDir():
+		return "directory"
+	case mode&fs.ModeSymlink != 0:
+		return "symlink"
+	case mode.IsRegular():
+		return "file"
+	default:
+		return "other"
+	}
+}
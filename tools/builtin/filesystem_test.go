@@ -0,0 +1,180 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/tools"
+)
+
+// newFilesystemTools returns the filesystem tools by name, rooted at a
+// directory containing a few files and a symbolic link out of it.
+func newFilesystemTools(t *testing.T, config FilesystemConfig) map[string]tools.Tool {
+	t.Helper()
+	dir := t.TempDir()
+	root := filepath.Join(dir, "root")
+	files := map[string]string{
+		"root/README.md":       "# Project\nSee docs.\n",
+		"root/docs/guide.md":   "Install it.\nThen run it.\n",
+		"root/src/main.go":     "package main\n\n// run it\nfunc main() {}\n",
+		"root/src/image.png":   "\x89PNG\x00\x00run it",
+		"secret.txt":           "password",
+		"root/docs/empty.txt":  "",
+		"root/src/nested/a.go": "package nested\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(dir, "secret.txt"), filepath.Join(root, "link.txt")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+
+	config.Root = root
+	ts, err := NewFilesystemTools(config)
+	if err != nil {
+		t.Fatalf("NewFilesystemTools() error = %v", err)
+	}
+	byName := make(map[string]tools.Tool)
+	for _, tool := range ts {
+		byName[tool.Name()] = tool
+	}
+	return byName
+}
+
+// callTool calls tool with args and returns its JSON-encoded result.
+func callTool(t *testing.T, tool tools.Tool, args string) (string, error) {
+	t.Helper()
+	result, err := tool.Call(context.Background(), json.RawMessage(args))
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	return string(data), nil
+}
+
+func TestReadFile(t *testing.T) {
+	fs := newFilesystemTools(t, FilesystemConfig{MaxFileBytes: 12})
+	read := fs["read_file"]
+
+	got, err := callTool(t, read, `{"path":"/docs/guide.md"}`)
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if want := `{"path":"docs/guide.md","content":"Install it.\n","size":25,"truncated":true}`; got != want {
+		t.Errorf("Call() = %s, want %s", got, want)
+	}
+
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"escape", `{"path":"../secret.txt"}`, "no such file"},
+		{"symlink escape", `{"path":"link.txt"}`, "path escapes"},
+		{"directory", `{"path":"docs"}`, "is a directory"},
+		{"binary", `{"path":"src/image.png"}`, "not a text file"},
+		{"missing path", `{}`, "path is required"},
+		{"invalid arguments", `{"path":1}`, "invalid arguments"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := callTool(t, read, tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Call() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestListDirectory(t *testing.T) {
+	fs := newFilesystemTools(t, FilesystemConfig{})
+
+	got, err := callTool(t, fs["list_directory"], `{}`)
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	want := `{"path":".","entries":[{"name":"README.md","type":"file","size":20},{"name":"docs","type":"directory"},{"name":"link.txt","type":"symlink"},{"name":"src","type":"directory"}]}`
+	if got != want {
+		t.Errorf("Call() = %s, want %s", got, want)
+	}
+
+	limited := newFilesystemTools(t, FilesystemConfig{MaxResults: 1})
+	got, err = callTool(t, limited["list_directory"], `{"path":"src/../src"}`)
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if want := `{"path":"src","entries":[{"name":"image.png","type":"file","size":12}],"truncated":true}`; got != want {
+		t.Errorf("Call() = %s, want %s", got, want)
+	}
+
+	if _, err := callTool(t, fs["list_directory"], `{"path":"README.md"}`); err == nil {
+		t.Error("Call() on a file should fail")
+	}
+}
+
+func TestSearchFiles(t *testing.T) {
+	fs := newFilesystemTools(t, FilesystemConfig{})
+	search := fs["search_files"]
+
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"pattern", `{"pattern":"*.go"}`, `{"matches":[{"path":"src/main.go"},{"path":"src/nested/a.go"}]}`},
+		{"query", `{"query":"run it"}`, `{"matches":[{"path":"docs/guide.md","line":2,"text":"Then run it."},{"path":"src/main.go","line":3,"text":"// run it"}]}`},
+		{"both", `{"path":"src","pattern":"*.go","query":"package"}`, `{"matches":[{"path":"src/main.go","line":1,"text":"package main"},{"path":"src/nested/a.go","line":1,"text":"package nested"}]}`},
+		{"no matches", `{"query":"password"}`, `{"matches":[]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callTool(t, search, tt.args)
+			if err != nil {
+				t.Fatalf("Call() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Call() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	limited := newFilesystemTools(t, FilesystemConfig{MaxResults: 1})
+	got, err := callTool(t, limited["search_files"], `{"pattern":"*"}`)
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if want := `{"matches":[{"path":"README.md"}],"truncated":true}`; got != want {
+		t.Errorf("Call() = %s, want %s", got, want)
+	}
+
+	for _, args := range []string{`{}`, `{"pattern":"["}`, `{"path":"missing","query":"x"}`} {
+		if _, err := callTool(t, search, args); err == nil {
+			t.Errorf("Call(%s) should fail", args)
+		}
+	}
+}
+
+func TestNewFilesystemToolsErrors(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, root := range []string{"", file, filepath.Join(t.TempDir(), "missing")} {
+		if _, err := NewFilesystemTools(FilesystemConfig{Root: root}); err == nil {
+			t.Errorf("NewFilesystemTools(%q) should fail", root)
+		}
+	}
+}
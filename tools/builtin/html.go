@@ -0,0 +1,113 @@
+package builtin
+
+import (
+	"html"
+	"strings"
+)
+
+// skippedElements are elements whose content is not text.
+var skippedElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true, "svg": true,
+}
+
+// blockElements are elements that start a new line.
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "br": true,
+	"dd": true, "div": true, "dl": true, "dt": true, "fieldset": true, "figcaption": true,
+	"figure": true, "footer": true, "form": true, "h1": true, "h2": true, "h3": true,
+	"h4": true, "h5": true, "h6": true, "header": true, "hr": true, "li": true,
+	"main": true, "nav": true, "ol": true, "p": true, "pre": true, "section": true,
+	"table": true, "td": true, "th": true, "title": true, "tr": true, "ul": true,
+}
+
+// HTMLToText extracts the readable text of an HTML document. Scripts,
+// styles, comments, and tags are dropped, entities are decoded, block
+// elements start new lines, and other runs of whitespace are collapsed
+// to a space.
+func HTMLToText(s string) string {
+	var b strings.Builder
+	pre := 0
+	text := func(t string) {
+		t = html.UnescapeString(t)
+		if pre == 0 {
+			// Line breaks in the source are spaces, except in <pre>
+			t = strings.ReplaceAll(t, "\n", " ")
+		}
+		b.WriteString(t)
+	}
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			text(s)
+			break
+		}
+		text(s[:i])
+		s = s[i:]
+
+		if strings.HasPrefix(s, "<!--") {
+			end := strings.Index(s, "-->")
+			if end < 0 {
+				break
+			}
+			s = s[end+len("-->"):]
+			continue
+		}
+
+		end := strings.IndexByte(s, '>')
+		if end < 0 {
+			break
+		}
+		tag := s[1:end]
+		name := tagName(tag)
+		s = s[end+1:]
+
+		opening := !strings.HasPrefix(tag, "/") && !strings.HasSuffix(tag, "/")
+		if opening && skippedElements[name] {
+			// Skip to the closing tag
+			close := strings.Index(strings.ToLower(s), "</"+name)
+			if close < 0 {
+				break
+			}
+			s = s[close:]
+			continue
+		}
+		if name == "pre" {
+			if opening {
+				pre++
+			} else if pre > 0 {
+				pre--
+			}
+		}
+		if blockElements[name] {
+			b.WriteByte('\n')
+		} else if name != "" {
+			b.WriteByte(' ')
+		}
+	}
+	return collapseWhitespace(b.String())
+}
+
+// tagName returns the lowercase name of a tag from its content between
+// '<' and '>', without a leading '/' for closing tags.
+func tagName(tag string) string {
+	tag = strings.TrimPrefix(tag, "/")
+	end := strings.IndexFunc(tag, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '/'
+	})
+	if end >= 0 {
+		tag = tag[:end]
+	}
+	return strings.ToLower(tag)
+}
+
+// collapseWhitespace collapses spaces within lines, trims each line, and
+// drops empty lines.
+func collapseWhitespace(s string) string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,29 @@
+package builtin
+
+import "testing"
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"plain", "hello   world", "hello world"},
+		{"blocks", "<h1>Title</h1><p>One</p><p>Two<br>Three</p>", "Title\nOne\nTwo\nThree"},
+		{"inline", "<p>a <b>bold</b><i>move</i></p>", "a bold move"},
+		{"entities", "<p>&lt;tag&gt; &amp; &quot;q&quot; &#8212;</p>", "<tag> & \"q\" —"},
+		{"skipped", "<style>p{}</style><SCRIPT type=x>if (a < b) {}</SCRIPT>text<noscript>no</noscript>", "text"},
+		{"comments", "a<!-- <p>hidden</p> -->b", "ab"},
+		{"self-closing svg", "a<svg/>b", "a b"},
+		{"source lines", "<p>a\nb</p>\n\n<p>c</p>", "a b\nc"},
+		{"pre", "<pre>x := 1\ny := 2</pre>z\nw", "x := 1\ny := 2\nz w"},
+		{"unterminated", "a <b", "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTMLToText(tt.html); got != tt.want {
+				t.Errorf("HTMLToText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/erikhoward/iris/tools"
+)
+
+// ShellConfig configures the command tool.
+type ShellConfig struct {
+	// AllowedCommands lists the commands that may be run, by name (looked
+	// up in PATH) or by path, exactly as the model must give them.
+	// Required.
+	AllowedCommands []string
+
+	// Dir is the working directory of commands. Default: the current
+	// directory.
+	Dir string
+
+	// Timeout limits each command. A command still running is killed.
+	// Default: 30s.
+	Timeout time.Duration
+
+	// MaxOutputBytes limits the stdout and stderr each returned. Longer
+	// output is truncated. Default: 64 KiB.
+	MaxOutputBytes int
+
+	// Env is the environment of commands. Default: PATH, HOME, and LANG
+	// from the current environment, so secrets in other variables are not
+	// exposed.
+	Env []string
+}
+
+// CommandResult is the result of the command tool. A command that fails
+// or times out is a result rather than an error, so the model can see
+// its output.
+type CommandResult struct {
+	ExitCode  int    `json:"exit_code"`
+	Stdout    string `json:"stdout"`
+	Stderr    string `json:"stderr"`
+	Truncated bool   `json:"truncated,omitempty"`
+	TimedOut  bool   `json:"timed_out,omitempty"`
+}
+
+// commandArgs are the command tool's arguments.
+type commandArgs struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+}
+
+// NewShellTool returns the "run_command" tool, which runs an allowed
+// command with arguments. Commands run directly, not through a shell, so
+// arguments are never interpreted as shell syntax.
+func NewShellTool(config ShellConfig) (tools.Tool, error) {
+	if len(config.AllowedCommands) == 0 {
+		return nil, errors.New("builtin: at least one allowed command is required")
+	}
+
+	// Apply defaults
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+	if config.MaxOutputBytes <= 0 {
+		config.MaxOutputBytes = 64 << 10
+	}
+	if config.Env == nil {
+		// Non-nil, since exec inherits the whole environment for nil
+		config.Env = []string{}
+		for _, key := range []string{"PATH", "HOME", "LANG"} {
+			if v, ok := os.LookupEnv(key); ok {
+				config.Env = append(config.Env, key+"="+v)
+			}
+		}
+	}
+
+	allowed, _ := json.Marshal(config.AllowedCommands)
+	schema := fmt.Sprintf(`{
+	"type": "object",
+	"properties": {
+		"command": {"type": "string", "enum": %s, "description": "The command to run"},
+		"args": {"type": "array", "items": {"type": "string"}, "description": "The command's arguments"}
+	},
+	"required": ["command"]
+}`, allowed)
+
+	return &tool{
+		name:        "run_command",
+		description: fmt.Sprintf("Run a command with arguments and return its exit code and output. Commands run without a shell, so pipes, redirection, and variables are not supported. Commands time out after %s.", config.Timeout),
+		schema:      json.RawMessage(schema),
+		call: func(ctx context.Context, args json.RawMessage) (any, error) {
+			a, err := decodeArgs[commandArgs]("run_command", args)
+			if err != nil {
+				return nil, err
+			}
+			return runCommand(ctx, config, a)
+		},
+	}, nil
+}
+
+// runCommand runs an allowed command.
+func runCommand(ctx context.Context, config ShellConfig, a commandArgs) (*CommandResult, error) {
+	if a.Command == "" {
+		return nil, errors.New("builtin: run_command: command is required")
+	}
+	if !slices.Contains(config.AllowedCommands, a.Command) {
+		return nil, fmt.Errorf("builtin: run_command: command %q is not allowed", a.Command)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	stdout := &limitedBuffer{limit: config.MaxOutputBytes}
+	stderr := &limitedBuffer{limit: config.MaxOutputBytes}
+	cmd := exec.CommandContext(ctx, a.Command, a.Args...)
+	cmd.Dir = config.Dir
+	cmd.Env = config.Env
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// Don't wait on children holding the output pipes after a kill
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	result := &CommandResult{
+		Stdout:    strings.ToValidUTF8(string(stdout.buf), ""),
+		Stderr:    strings.ToValidUTF8(string(stderr.buf), ""),
+		Truncated: stdout.truncated || stderr.truncated,
+	}
+	if err != nil {
+		var exitErr *exec.ExitError
+		switch {
+		case ctx.Err() != nil && errors.Is(ctx.Err(), context.DeadlineExceeded):
+			result.TimedOut = true
+			result.ExitCode = -1
+		case ctx.Err() != nil:
+			return nil, fmt.Errorf("builtin: run_command: %w", ctx.Err())
+		case errors.As(err, &exitErr):
+			result.ExitCode = exitErr.ExitCode()
+		default:
+			return nil, fmt.Errorf("builtin: run_command: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// limitedBuffer keeps the first limit bytes written to it.
+type limitedBuffer struct {
+	buf       []byte
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if n := b.limit - len(b.buf); len(p) > n {
+		b.buf = append(b.buf, p[:n]...)
+		b.truncated = true
+	} else {
+		b.buf = append(b.buf, p...)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,129 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// requireCommands skips the test if any of names is not in PATH.
+func requireCommands(t *testing.T, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		if _, err := exec.LookPath(name); err != nil {
+			t.Skipf("%s not found: %v", name, err)
+		}
+	}
+}
+
+// runShellTool calls the tool with a command and arguments.
+func runShellTool(t *testing.T, config ShellConfig, command string, args ...string) (*CommandResult, error) {
+	t.Helper()
+	tool, err := NewShellTool(config)
+	if err != nil {
+		t.Fatalf("NewShellTool() error = %v", err)
+	}
+	data, _ := json.Marshal(commandArgs{Command: command, Args: args})
+	result, err := tool.Call(context.Background(), data)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*CommandResult), nil
+}
+
+func TestShellTool(t *testing.T) {
+	requireCommands(t, "echo", "sh")
+	config := ShellConfig{AllowedCommands: []string{"echo", "sh"}}
+
+	// Arguments are not shell syntax
+	result, err := runShellTool(t, config, "echo", "hello", "$HOME;", "|", "cat")
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if result.Stdout != "hello $HOME; | cat\n" || result.ExitCode != 0 {
+		t.Errorf("Call() = %+v", result)
+	}
+
+	result, err = runShellTool(t, config, "sh", "-c", "echo oops >&2; exit 3")
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if result.ExitCode != 3 || result.Stderr != "oops\n" {
+		t.Errorf("failing command = %+v", result)
+	}
+
+	config.MaxOutputBytes = 4
+	result, err = runShellTool(t, config, "echo", "truncated")
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if result.Stdout != "trun" || !result.Truncated {
+		t.Errorf("long output = %+v", result)
+	}
+}
+
+func TestShellToolEnv(t *testing.T) {
+	requireCommands(t, "sh")
+	t.Setenv("IRIS_TEST_SECRET", "hunter2")
+	config := ShellConfig{AllowedCommands: []string{"sh"}, Dir: t.TempDir()}
+
+	result, err := runShellTool(t, config, "sh", "-c", `echo "[$IRIS_TEST_SECRET]"; pwd`)
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if !strings.HasPrefix(result.Stdout, "[]\n") || !strings.Contains(result.Stdout, config.Dir) {
+		t.Errorf("Call() = %+v", result)
+	}
+
+	config.Env = []string{"IRIS_TEST_SECRET=shared"}
+	result, err = runShellTool(t, config, "sh", "-c", `echo "[$IRIS_TEST_SECRET]"`)
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if result.Stdout != "[shared]\n" {
+		t.Errorf("Call() with Env = %+v", result)
+	}
+}
+
+func TestShellToolTimeout(t *testing.T) {
+	requireCommands(t, "sleep")
+	config := ShellConfig{AllowedCommands: []string{"sleep"}, Timeout: 50 * time.Millisecond}
+
+	start := time.Now()
+	result, err := runShellTool(t, config, "sleep", "10")
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if !result.TimedOut || result.ExitCode != -1 {
+		t.Errorf("Call() = %+v", result)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Call() took %v", elapsed)
+	}
+}
+
+func TestShellToolErrors(t *testing.T) {
+	if _, err := NewShellTool(ShellConfig{}); err == nil {
+		t.Error("NewShellTool() without allowed commands should fail")
+	}
+
+	config := ShellConfig{AllowedCommands: []string{"echo", "iris-no-such-command"}}
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"rm", `command "rm" is not allowed`},
+		{"/bin/echo", `command "/bin/echo" is not allowed`},
+		{"", "command is required"},
+		{"iris-no-such-command", "executable file not found"},
+	}
+	for _, tt := range tests {
+		_, err := runShellTool(t, config, tt.command)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Call(%q) error = %v, want %q", tt.command, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erikhoward/iris/tools"
+)
+
+// TimeConfig configures the time tools.
+type TimeConfig struct {
+	// Now returns the current time. Default: time.Now.
+	Now func() time.Time
+}
+
+// TimeResult is the result of the time tools.
+type TimeResult struct {
+	Time     string `json:"time"`
+	Timezone string `json:"timezone"`
+	Unix     int64  `json:"unix"`
+	Weekday  string `json:"weekday"`
+}
+
+// currentTimeArgs are the current_time tool's arguments.
+type currentTimeArgs struct {
+	Timezone string `json:"timezone"`
+}
+
+// convertTimeArgs are the convert_time tool's arguments.
+type convertTimeArgs struct {
+	Time         string `json:"time"`
+	FromTimezone string `json:"from_timezone"`
+	ToTimezone   string `json:"to_timezone"`
+}
+
+const currentTimeSchema = `{
+	"type": "object",
+	"properties": {
+		"timezone": {"type": "string", "description": "An IANA time zone such as America/New_York. Default: UTC"}
+	}
+}`
+
+const convertTimeSchema = `{
+	"type": "object",
+	"properties": {
+		"time": {"type": "string", "description": "The time in RFC 3339 format, such as 2024-05-01T09:30:00Z, or as 2024-05-01 09:30 in from_timezone"},
+		"from_timezone": {"type": "string", "description": "The IANA time zone of a time without an offset. Default: UTC"},
+		"to_timezone": {"type": "string", "description": "The IANA time zone to convert to"}
+	},
+	"required": ["time", "to_timezone"]
+}`
+
+// localTimeLayouts are the accepted layouts for times without an offset.
+var localTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// NewTimeTools returns the "current_time" tool, which reports the current
+// time in a time zone, and the "convert_time" tool, which converts a time
+// between time zones.
+func NewTimeTools(config TimeConfig) []tools.Tool {
+	// Apply defaults
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+
+	return []tools.Tool{
+		&tool{
+			name:        "current_time",
+			description: "Get the current date and time in a time zone.",
+			schema:      json.RawMessage(currentTimeSchema),
+			call: func(ctx context.Context, args json.RawMessage) (any, error) {
+				a, err := decodeArgs[currentTimeArgs]("current_time", args)
+				if err != nil {
+					return nil, err
+				}
+				loc, err := loadLocation("current_time", a.Timezone)
+				if err != nil {
+					return nil, err
+				}
+				return timeResult(config.Now().In(loc)), nil
+			},
+		},
+		&tool{
+			name:        "convert_time",
+			description: "Convert a date and time from one time zone to another.",
+			schema:      json.RawMessage(convertTimeSchema),
+			call: func(ctx context.Context, args json.RawMessage) (any, error) {
+				a, err := decodeArgs[convertTimeArgs]("convert_time", args)
+				if err != nil {
+					return nil, err
+				}
+				return convertTime(a)
+			},
+		},
+	}
+}
+
+// convertTime converts a time to another time zone.
+func convertTime(a convertTimeArgs) (*TimeResult, error) {
+	if a.Time == "" || a.ToTimezone == "" {
+		return nil, errors.New("builtin: convert_time: time and to_timezone are required")
+	}
+	from, err := loadLocation("convert_time", a.FromTimezone)
+	if err != nil {
+		return nil, err
+	}
+	to, err := loadLocation("convert_time", a.ToTimezone)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := time.Parse(time.RFC3339, a.Time)
+	if err != nil {
+		var parsed bool
+		for _, layout := range localTimeLayouts {
+			if t, err = time.ParseInLocation(layout, a.Time, from); err == nil {
+				parsed = true
+				break
+			}
+		}
+		if !parsed {
+			return nil, fmt.Errorf("builtin: convert_time: invalid time %q: use RFC 3339 or YYYY-MM-DD HH:MM", a.Time)
+		}
+	}
+	return timeResult(t.In(to)), nil
+}
+
+// loadLocation loads an IANA time zone, defaulting to UTC.
+func loadLocation(name, tz string) (*time.Location, error) {
+	if tz == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("builtin: %s: unknown time zone %q", name, tz)
+	}
+	return loc, nil
+}
+
+// timeResult describes t.
+func timeResult(t time.Time) *TimeResult {
+	return &TimeResult{
+		Time:     t.Format(time.RFC3339),
+		Timezone: t.Location().String(),
+		Unix:     t.Unix(),
+		Weekday:  t.Weekday().String(),
+	}
+}
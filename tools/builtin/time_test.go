@@ -0,0 +1,88 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erikhoward/iris/tools"
+)
+
+// newTimeTools returns the time tools by name, with the current time
+// fixed at 2024-03-10 12:00 UTC.
+func newTimeTools(t *testing.T) map[string]tools.Tool {
+	t.Helper()
+	if _, err := time.LoadLocation("America/New_York"); err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	byName := make(map[string]tools.Tool)
+	for _, tool := range NewTimeTools(TimeConfig{Now: func() time.Time { return now }}) {
+		byName[tool.Name()] = tool
+	}
+	return byName
+}
+
+func TestCurrentTime(t *testing.T) {
+	current := newTimeTools(t)["current_time"]
+
+	tests := []struct {
+		args string
+		want TimeResult
+	}{
+		{`{}`, TimeResult{Time: "2024-03-10T12:00:00Z", Timezone: "UTC", Unix: 1710072000, Weekday: "Sunday"}},
+		{`{"timezone":"Asia/Tokyo"}`, TimeResult{Time: "2024-03-10T21:00:00+09:00", Timezone: "Asia/Tokyo", Unix: 1710072000, Weekday: "Sunday"}},
+	}
+	for _, tt := range tests {
+		result, err := current.Call(context.Background(), json.RawMessage(tt.args))
+		if err != nil {
+			t.Fatalf("Call(%s) error = %v", tt.args, err)
+		}
+		if got := *result.(*TimeResult); got != tt.want {
+			t.Errorf("Call(%s) = %+v, want %+v", tt.args, got, tt.want)
+		}
+	}
+
+	if _, err := current.Call(context.Background(), json.RawMessage(`{"timezone":"Mars/Base"}`)); err == nil || !strings.Contains(err.Error(), "unknown time zone") {
+		t.Errorf("Call() error = %v", err)
+	}
+}
+
+func TestConvertTime(t *testing.T) {
+	convert := newTimeTools(t)["convert_time"]
+
+	tests := []struct {
+		args string
+		want string
+	}{
+		{`{"time":"2024-07-01T09:30:00Z","to_timezone":"America/New_York"}`, "2024-07-01T05:30:00-04:00"},
+		{`{"time":"2024-01-15 09:30","from_timezone":"America/New_York","to_timezone":"Europe/London"}`, "2024-01-15T14:30:00Z"},
+		{`{"time":"2024-01-15","to_timezone":"Asia/Kolkata"}`, "2024-01-15T05:30:00+05:30"},
+	}
+	for _, tt := range tests {
+		result, err := convert.Call(context.Background(), json.RawMessage(tt.args))
+		if err != nil {
+			t.Fatalf("Call(%s) error = %v", tt.args, err)
+		}
+		if got := result.(*TimeResult).Time; got != tt.want {
+			t.Errorf("Call(%s) = %s, want %s", tt.args, got, tt.want)
+		}
+	}
+
+	errTests := []struct {
+		args string
+		want string
+	}{
+		{`{"time":"2024-01-15"}`, "required"},
+		{`{"time":"yesterday","to_timezone":"UTC"}`, "invalid time"},
+		{`{"time":"2024-01-15","from_timezone":"Nowhere","to_timezone":"UTC"}`, "unknown time zone"},
+	}
+	for _, tt := range errTests {
+		_, err := convert.Call(context.Background(), json.RawMessage(tt.args))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Call(%s) error = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+}
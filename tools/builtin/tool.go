@@ -0,0 +1,48 @@
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/erikhoward/iris/tools"
+)
+
+// tool is a tools.Tool backed by a function.
+type tool struct {
+	name        string
+	description string
+	schema      json.RawMessage
+	call        func(ctx context.Context, args json.RawMessage) (any, error)
+}
+
+func (t *tool) Name() string {
+	return t.name
+}
+
+func (t *tool) Description() string {
+	return t.description
+}
+
+func (t *tool) Schema() tools.ToolSchema {
+	return tools.ToolSchema{JSONSchema: t.schema}
+}
+
+func (t *tool) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	return t.call(ctx, args)
+}
+
+// decodeArgs decodes a tool's JSON arguments into T.
+func decodeArgs[T any](name string, args json.RawMessage) (T, error) {
+	var v T
+	if len(args) == 0 || string(args) == "null" {
+		return v, nil
+	}
+	if err := json.Unmarshal(args, &v); err != nil {
+		return v, fmt.Errorf("builtin: %s: invalid arguments: %w", name, err)
+	}
+	return v, nil
+}
+
+// Compile-time check that tool implements tools.Tool.
+var _ tools.Tool = (*tool)(nil)
@@ -1,2 +1,2 @@
-// Package tools provides tool interfaces, registry, and argument parsing for AI tool calling.
+// Package tools provides tool interfaces, registry, argument validation, and argument parsing for AI tool calling.
 package tools
@@ -0,0 +1,360 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// ErrDenied is matched by the error of a Result for a call that an
+// approval hook denied.
+var ErrDenied = errors.New("tool call denied")
+
+// DeniedError is the error of a Result for a call that an approval hook
+// denied. Reason is shown to the model.
+type DeniedError struct {
+	Tool   string
+	Reason string
+}
+
+func (e *DeniedError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("call to %s was denied", e.Tool)
+	}
+	return fmt.Sprintf("call to %s was denied: %s", e.Tool, e.Reason)
+}
+
+// Is reports whether target is ErrDenied.
+func (e *DeniedError) Is(target error) bool {
+	return target == ErrDenied
+}
+
+// Decision is an approval hook's verdict on a tool call.
+type Decision string
+
+const (
+	// DecisionAllow runs the call with the model's arguments.
+	DecisionAllow Decision = "allow"
+	// DecisionDeny rejects the call.
+	DecisionDeny Decision = "deny"
+	// DecisionModify runs the call with Approval.Arguments instead.
+	DecisionModify Decision = "modify"
+)
+
+// Approval is the outcome of an approval hook.
+type Approval struct {
+	Decision Decision
+
+	// Arguments replace the model's arguments for DecisionModify. They are
+	// validated against the tool's schema before the call.
+	Arguments json.RawMessage
+
+	// Reason explains a denial to the model.
+	Reason string
+}
+
+// ApprovalFunc decides whether a tool call may run, typically by asking
+// a person. It is called after the arguments are validated, and may block
+// until a decision is made or ctx is done. An error denies the call.
+type ApprovalFunc func(ctx context.Context, call core.ToolCall) (Approval, error)
+
+// executorConfig holds the settings of an Executor.
+type executorConfig struct {
+	timeout         time.Duration
+	toolTimeouts    map[string]time.Duration
+	maxConcurrent   int
+	toolConcurrency map[string]int
+	approve         ApprovalFunc
+	approvalTools   []string
+	maxResultBytes  int
+	audit           AuditSink
+}
+
+// ExecutorOption configures an Executor.
+type ExecutorOption func(*executorConfig)
+
+// WithTimeout limits how long each tool call may run. The call's context
+// is cancelled at the deadline, and the Result reports
+// context.DeadlineExceeded even if the tool ignores the cancellation.
+func WithTimeout(d time.Duration) ExecutorOption {
+	return func(c *executorConfig) {
+		c.timeout = d
+	}
+}
+
+// WithToolTimeout sets the timeout of the named tool, overriding
+// WithTimeout.
+func WithToolTimeout(name string, d time.Duration) ExecutorOption {
+	return func(c *executorConfig) {
+		c.toolTimeouts[name] = d
+	}
+}
+
+// WithMaxConcurrency limits how many tool calls run at once. Further
+// calls wait for a slot, or until their context is done.
+func WithMaxConcurrency(n int) ExecutorOption {
+	return func(c *executorConfig) {
+		c.maxConcurrent = n
+	}
+}
+
+// WithToolConcurrency limits how many calls of the named tool run at
+// once, in addition to WithMaxConcurrency.
+func WithToolConcurrency(name string, n int) ExecutorOption {
+	return func(c *executorConfig) {
+		c.toolConcurrency[name] = n
+	}
+}
+
+// WithApproval requires approval from fn before the named tools run, or
+// before any tool runs if no names are given.
+func WithApproval(fn ApprovalFunc, toolNames ...string) ExecutorOption {
+	return func(c *executorConfig) {
+		c.approve = fn
+		c.approvalTools = toolNames
+	}
+}
+
+// WithMaxResultBytes limits the size of each result's Content. A longer
+// result is cut to n bytes, followed by a note of the original size, and
+// the Result is marked Truncated.
+func WithMaxResultBytes(n int) ExecutorOption {
+	return func(c *executorConfig) {
+		c.maxResultBytes = n
+	}
+}
+
+// WithAuditSink records an AuditRecord for every call, including calls
+// that are rejected before running.
+func WithAuditSink(sink AuditSink) ExecutorOption {
+	return func(c *executorConfig) {
+		c.audit = sink
+	}
+}
+
+// newExecutorConfig applies opts to the default settings.
+func newExecutorConfig(opts []ExecutorOption) *executorConfig {
+	c := &executorConfig{
+		toolTimeouts:    make(map[string]time.Duration),
+		toolConcurrency: make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Executor runs tool calls from a Registry under an execution policy:
+// timeouts, concurrency limits, approval of sensitive tools, result size
+// limits, and an audit trail. Like Registry.Invoke, it validates
+// arguments first and reports failures in the Result.
+//
+// Executor is safe for concurrent use.
+type Executor struct {
+	registry *Registry
+	cfg      *executorConfig
+	slots    chan struct{}
+	toolSlot map[string]chan struct{}
+}
+
+// NewExecutor returns an Executor for the tools in registry.
+func NewExecutor(registry *Registry, opts ...ExecutorOption) *Executor {
+	cfg := newExecutorConfig(opts)
+	e := &Executor{
+		registry: registry,
+		cfg:      cfg,
+		toolSlot: make(map[string]chan struct{}, len(cfg.toolConcurrency)),
+	}
+	if cfg.maxConcurrent > 0 {
+		e.slots = make(chan struct{}, cfg.maxConcurrent)
+	}
+	for name, n := range cfg.toolConcurrency {
+		if n > 0 {
+			e.toolSlot[name] = make(chan struct{}, n)
+		}
+	}
+	return e
+}
+
+// Invoke runs a tool call from a model: it validates the arguments, asks
+// for approval if the tool requires it, waits for a concurrency slot, and
+// calls the tool within its timeout.
+func (e *Executor) Invoke(ctx context.Context, call core.ToolCall) *Result {
+	start := time.Now()
+	result := &Result{CallID: call.ID, Name: call.Name}
+	record := AuditRecord{
+		Time:          start,
+		CallID:        call.ID,
+		Tool:          call.Name,
+		ArgumentsHash: hashArguments(call.Arguments),
+	}
+	defer func() {
+		if e.cfg.audit == nil {
+			return
+		}
+		record.Duration = time.Since(start)
+		record.Truncated = result.Truncated
+		if result.Err != nil {
+			record.Error = result.Err.Error()
+		}
+		e.cfg.audit.Record(ctx, record)
+	}()
+
+	t, args, err := e.registry.prepare(call)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	if e.requiresApproval(call.Name) {
+		call.Arguments = args
+		approved, decision, err := e.approve(ctx, t, call)
+		record.Decision = decision
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		if decision == DecisionModify {
+			record.ApprovedArgumentsHash = hashArguments(approved)
+		}
+		args = approved
+	}
+
+	result.Value, result.Err = e.call(ctx, t, args)
+	if result.Err == nil {
+		e.truncate(result)
+	}
+	return result
+}
+
+// requiresApproval reports whether calls of the named tool need approval.
+func (e *Executor) requiresApproval(name string) bool {
+	return e.cfg.approve != nil && (len(e.cfg.approvalTools) == 0 || slices.Contains(e.cfg.approvalTools, name))
+}
+
+// approve asks the approval hook about a call, returning the arguments
+// to run it with.
+func (e *Executor) approve(ctx context.Context, t Tool, call core.ToolCall) (json.RawMessage, Decision, error) {
+	approval, err := e.cfg.approve(ctx, call)
+	if err != nil {
+		return nil, DecisionDeny, &DeniedError{Tool: call.Name, Reason: err.Error()}
+	}
+
+	switch approval.Decision {
+	case DecisionAllow:
+		return call.Arguments, DecisionAllow, nil
+	case DecisionModify:
+		args := approval.Arguments
+		if len(bytes.TrimSpace(args)) == 0 {
+			args = json.RawMessage(`{}`)
+		}
+		// Not wrapped: the model cannot correct the approver's arguments
+		if err := t.Schema().Validate(args); err != nil {
+			return nil, DecisionModify, fmt.Errorf("approved arguments for %s do not match its schema: %v", call.Name, err)
+		}
+		return args, DecisionModify, nil
+	case DecisionDeny:
+		return nil, DecisionDeny, &DeniedError{Tool: call.Name, Reason: approval.Reason}
+	default:
+		return nil, DecisionDeny, &DeniedError{Tool: call.Name, Reason: fmt.Sprintf("unknown approval decision %q", approval.Decision)}
+	}
+}
+
+// call runs the tool within its concurrency limits and timeout. The
+// tool's slots are held until it returns, even after a timeout, so a tool
+// that ignores cancellation still counts against the limits.
+func (e *Executor) call(ctx context.Context, t Tool, args json.RawMessage) (any, error) {
+	// Take the tool's slot first, so calls queued behind a busy tool
+	// don't hold shared slots other tools could use
+	var slots []chan struct{}
+	if s, ok := e.toolSlot[t.Name()]; ok {
+		slots = append(slots, s)
+	}
+	if e.slots != nil {
+		slots = append(slots, e.slots)
+	}
+	for i, s := range slots {
+		select {
+		case s <- struct{}{}:
+		case <-ctx.Done():
+			release(slots[:i])
+			return nil, ctx.Err()
+		}
+	}
+
+	timeout := e.cfg.timeout
+	if d, ok := e.cfg.toolTimeouts[t.Name()]; ok {
+		timeout = d
+	}
+	cancel := context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	// Canceled only once the call's outcome is decided, so that a tool
+	// finishing in time is never reported as canceled
+	defer cancel()
+
+	type outcome struct {
+		value any
+		err   error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		defer release(slots)
+		defer func() {
+			if p := recover(); p != nil {
+				done <- outcome{err: fmt.Errorf("tool %s panicked: %v", t.Name(), p)}
+			}
+		}()
+		value, err := t.Call(ctx, args)
+		done <- outcome{value, err}
+	}()
+
+	select {
+	case out := <-done:
+		return out.value, out.err
+	case <-ctx.Done():
+		// The tool may have finished as the context ended
+		select {
+		case out := <-done:
+			return out.value, out.err
+		default:
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("tool %s timed out: %w", t.Name(), ctx.Err())
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// release frees the given concurrency slots.
+func release(slots []chan struct{}) {
+	for _, s := range slots {
+		<-s
+	}
+}
+
+// truncate cuts a result whose content exceeds the size limit.
+func (e *Executor) truncate(result *Result) {
+	if e.cfg.maxResultBytes <= 0 {
+		return
+	}
+	content := result.Content()
+	if len(content) <= e.cfg.maxResultBytes {
+		return
+	}
+	// Don't split a character
+	n := e.cfg.maxResultBytes
+	for n > 0 && !utf8.RuneStart(content[n]) {
+		n--
+	}
+	result.Value = fmt.Sprintf("%s\n[truncated: result was %d bytes, limit %d]", content[:n], len(content), e.cfg.maxResultBytes)
+	result.Truncated = true
+}
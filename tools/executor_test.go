@@ -0,0 +1,345 @@
+package tools_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/tools"
+)
+
+// newFileRegistry returns a registry with a delete_file tool, which
+// records the arguments it is called with, and a read_file tool.
+func newFileRegistry(t *testing.T, deleted *[]string) *tools.Registry {
+	t.Helper()
+	schema := tools.ToolSchema{JSONSchema: json.RawMessage(`{"type":"object","properties":{"path":{"type":"string"}},"required":["path"]}`)}
+	r := tools.NewRegistry()
+	r.Register(&mockTool{
+		name:   "delete_file",
+		schema: schema,
+		callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			var a struct{ Path string }
+			json.Unmarshal(args, &a)
+			*deleted = append(*deleted, a.Path)
+			return "deleted " + a.Path, nil
+		},
+	})
+	r.Register(&mockTool{
+		name:   "read_file",
+		schema: schema,
+		callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			return strings.Repeat("é", 10), nil
+		},
+	})
+	return r
+}
+
+func TestExecutorApproval(t *testing.T) {
+	var deleted []string
+	var asked []string
+	approve := func(ctx context.Context, call core.ToolCall) (tools.Approval, error) {
+		asked = append(asked, string(call.Arguments))
+		switch {
+		case strings.Contains(string(call.Arguments), "/etc"):
+			return tools.Approval{Decision: tools.DecisionDeny, Reason: "system files are off limits"}, nil
+		case strings.Contains(string(call.Arguments), "tmp"):
+			return tools.Approval{Decision: tools.DecisionModify, Arguments: json.RawMessage(`{"path":"/tmp/sandbox/a"}`)}, nil
+		case strings.Contains(string(call.Arguments), "bad"):
+			return tools.Approval{Decision: tools.DecisionModify, Arguments: json.RawMessage(`{"path":1}`)}, nil
+		case strings.Contains(string(call.Arguments), "offline"):
+			return tools.Approval{}, errors.New("no approver available")
+		}
+		return tools.Approval{Decision: tools.DecisionAllow}, nil
+	}
+	e := tools.NewExecutor(newFileRegistry(t, &deleted), tools.WithApproval(approve, "delete_file"))
+	ctx := context.Background()
+	invoke := func(name, args string) *tools.Result {
+		return e.Invoke(ctx, core.ToolCall{ID: "call_1", Name: name, Arguments: json.RawMessage(args)})
+	}
+
+	if result := invoke("delete_file", `{"path":"notes.txt"}`); result.IsError() || result.Value != "deleted notes.txt" {
+		t.Errorf("allowed call = %+v", result)
+	}
+
+	result := invoke("delete_file", `{"path":"/etc/passwd"}`)
+	var denied *tools.DeniedError
+	if !errors.As(result.Err, &denied) || !errors.Is(result.Err, tools.ErrDenied) || denied.Reason != "system files are off limits" {
+		t.Errorf("denied call error = %v", result.Err)
+	}
+	if want := `{"error":"denied","message":"call to delete_file was denied: system files are off limits"}`; result.Content() != want {
+		t.Errorf("denied Content() = %s", result.Content())
+	}
+
+	if result := invoke("delete_file", `{"path":"tmp/a"}`); result.IsError() || result.Value != "deleted /tmp/sandbox/a" {
+		t.Errorf("modified call = %+v", result)
+	}
+
+	result = invoke("delete_file", `{"path":"bad"}`)
+	if result.Err == nil || !strings.Contains(result.Err.Error(), "approved arguments for delete_file do not match its schema") {
+		t.Errorf("invalid modification error = %v", result.Err)
+	}
+
+	result = invoke("delete_file", `{"path":"offline"}`)
+	if !errors.Is(result.Err, tools.ErrDenied) || !strings.Contains(result.Err.Error(), "no approver available") {
+		t.Errorf("hook error = %v", result.Err)
+	}
+
+	// Other tools and invalid arguments don't reach the hook
+	invoke("read_file", `{"path":"/etc/passwd"}`)
+	invoke("delete_file", `{}`)
+
+	if want := []string{"notes.txt", "/tmp/sandbox/a"}; strings.Join(deleted, ",") != strings.Join(want, ",") {
+		t.Errorf("deleted = %v, want %v", deleted, want)
+	}
+	if len(asked) != 5 {
+		t.Errorf("approval hook called %d times, want 5", len(asked))
+	}
+}
+
+func TestExecutorTimeout(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	r := tools.NewRegistry()
+	r.Register(&mockTool{
+		name: "stuck",
+		callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			// Ignores cancellation
+			<-block
+			return "done", nil
+		},
+	})
+	r.Register(&mockTool{
+		name: "slow",
+		callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			select {
+			case <-time.After(50 * time.Millisecond):
+				return "done", nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		},
+	})
+
+	e := tools.NewExecutor(r, tools.WithTimeout(10*time.Millisecond), tools.WithToolTimeout("slow", time.Second))
+
+	start := time.Now()
+	result := e.Invoke(context.Background(), core.ToolCall{Name: "stuck"})
+	if !errors.Is(result.Err, context.DeadlineExceeded) || time.Since(start) > time.Second {
+		t.Errorf("Invoke() error = %v after %v", result.Err, time.Since(start))
+	}
+	if !strings.HasPrefix(result.Content(), `{"error":"timeout"`) {
+		t.Errorf("Content() = %s", result.Content())
+	}
+
+	if result := e.Invoke(context.Background(), core.ToolCall{Name: "slow"}); result.IsError() {
+		t.Errorf("Invoke() with a longer tool timeout error = %v", result.Err)
+	}
+}
+
+func TestExecutorTimeoutFastCalls(t *testing.T) {
+	r := tools.NewRegistry()
+	r.Register(&mockTool{
+		name: "fast",
+		callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			return "done", nil
+		},
+	})
+	e := tools.NewExecutor(r, tools.WithTimeout(time.Minute))
+
+	// A call that finished must not race its own context's cancellation,
+	// which needs more than one processor to show
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	for i := range 50000 {
+		if result := e.Invoke(context.Background(), core.ToolCall{Name: "fast"}); result.IsError() {
+			t.Fatalf("call %d: Invoke() error = %v", i, result.Err)
+		}
+	}
+}
+
+func TestExecutorConcurrency(t *testing.T) {
+	var running, peak, searching atomic.Int32
+	var overlapped atomic.Bool
+	release := make(chan struct{})
+	r := tools.NewRegistry()
+	for _, name := range []string{"search", "fetch"} {
+		r.Register(&mockTool{
+			name: name,
+			callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+				}
+				if name == "search" {
+					if searching.Add(1) > 1 {
+						overlapped.Store(true)
+					}
+					defer searching.Add(-1)
+				}
+				<-release
+				return nil, nil
+			},
+		})
+	}
+	e := tools.NewExecutor(r, tools.WithMaxConcurrency(3), tools.WithToolConcurrency("search", 1))
+
+	var wg sync.WaitGroup
+	for i := range 6 {
+		name := "fetch"
+		if i%2 == 0 {
+			name = "search"
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.Invoke(context.Background(), core.ToolCall{Name: name})
+		}()
+	}
+
+	// Wait until the slots fill, then let everything finish
+	deadline := time.Now().Add(time.Second)
+	for running.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if peak.Load() != 3 {
+		t.Errorf("peak concurrency = %d, want 3", peak.Load())
+	}
+	if overlapped.Load() {
+		t.Error("search ran concurrently despite a limit of 1")
+	}
+}
+
+func TestExecutorConcurrencyCancel(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	r := tools.NewRegistry()
+	started := make(chan struct{}, 1)
+	r.Register(&mockTool{
+		name: "busy",
+		callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			started <- struct{}{}
+			<-release
+			return nil, nil
+		},
+	})
+	e := tools.NewExecutor(r, tools.WithMaxConcurrency(1))
+
+	go e.Invoke(context.Background(), core.ToolCall{Name: "busy"})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if result := e.Invoke(ctx, core.ToolCall{Name: "busy"}); !errors.Is(result.Err, context.DeadlineExceeded) {
+		t.Errorf("Invoke() waiting for a slot error = %v", result.Err)
+	}
+}
+
+func TestExecutorTruncation(t *testing.T) {
+	var deleted []string
+	e := tools.NewExecutor(newFileRegistry(t, &deleted), tools.WithMaxResultBytes(9))
+
+	result := e.Invoke(context.Background(), core.ToolCall{Name: "read_file", Arguments: json.RawMessage(`{"path":"a"}`)})
+	if !result.Truncated {
+		t.Fatalf("Invoke() = %+v, want truncated", result)
+	}
+	// The cut falls inside a two-byte character
+	if want := "éééé\n[truncated: result was 20 bytes, limit 9]"; result.Content() != want {
+		t.Errorf("Content() = %q, want %q", result.Content(), want)
+	}
+
+	result = e.Invoke(context.Background(), core.ToolCall{Name: "delete_file", Arguments: json.RawMessage(`{"path":"a"}`)})
+	if result.Truncated || result.Content() != "deleted a" {
+		t.Errorf("short result = %+v", result)
+	}
+}
+
+func TestExecutorPanic(t *testing.T) {
+	r := tools.NewRegistry()
+	r.Register(&mockTool{
+		name:   "broken",
+		callFn: func(ctx context.Context, args json.RawMessage) (any, error) { panic("oops") },
+	})
+	result := tools.NewExecutor(r).Invoke(context.Background(), core.ToolCall{Name: "broken"})
+	if result.Err == nil || result.Err.Error() != "tool broken panicked: oops" {
+		t.Errorf("Invoke() error = %v", result.Err)
+	}
+}
+
+func TestExecutorAudit(t *testing.T) {
+	var deleted []string
+	var records []tools.AuditRecord
+	sink := tools.AuditFunc(func(ctx context.Context, record tools.AuditRecord) {
+		records = append(records, record)
+	})
+	approve := func(ctx context.Context, call core.ToolCall) (tools.Approval, error) {
+		return tools.Approval{Decision: tools.DecisionModify, Arguments: json.RawMessage(`{"path":"safe"}`)}, nil
+	}
+	e := tools.NewExecutor(newFileRegistry(t, &deleted),
+		tools.WithAuditSink(sink),
+		tools.WithApproval(approve, "delete_file"),
+		tools.WithMaxResultBytes(4),
+	)
+	ctx := context.Background()
+
+	e.Invoke(ctx, core.ToolCall{ID: "1", Name: "delete_file", Arguments: json.RawMessage(`{"path":"x"}`)})
+	e.Invoke(ctx, core.ToolCall{ID: "2", Name: "missing", Arguments: json.RawMessage(`{}`)})
+	e.Invoke(ctx, core.ToolCall{ID: "3", Name: "read_file", Arguments: json.RawMessage(`{"path":"x"}`)})
+
+	if len(records) != 3 {
+		t.Fatalf("got %d audit records, want 3", len(records))
+	}
+	modified := records[0]
+	if modified.CallID != "1" || modified.Tool != "delete_file" || modified.Decision != tools.DecisionModify || modified.Error != "" {
+		t.Errorf("record = %+v", modified)
+	}
+	if want := sha256Hex(`{"path":"x"}`); modified.ArgumentsHash != want {
+		t.Errorf("ArgumentsHash = %s, want %s", modified.ArgumentsHash, want)
+	}
+	if want := sha256Hex(`{"path":"safe"}`); modified.ApprovedArgumentsHash != want {
+		t.Errorf("ApprovedArgumentsHash = %s, want %s", modified.ApprovedArgumentsHash, want)
+	}
+	if records[2].ArgumentsHash != modified.ArgumentsHash {
+		t.Error("identical arguments have different hashes")
+	}
+
+	if missing := records[1]; !strings.Contains(missing.Error, "tool not found") || missing.Decision != "" {
+		t.Errorf("unknown tool record = %+v", missing)
+	}
+	if read := records[2]; !read.Truncated || read.Decision != "" {
+		t.Errorf("truncated record = %+v", read)
+	}
+}
+
+// sha256Hex returns the hex SHA-256 of s.
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestJSONAuditSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := tools.NewJSONAuditSink(&buf)
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	sink.Record(context.Background(), tools.AuditRecord{Time: at, Tool: "a", ArgumentsHash: "h", Duration: time.Second})
+	sink.Record(context.Background(), tools.AuditRecord{Time: at, Tool: "b", ArgumentsHash: "h", Decision: tools.DecisionDeny, Error: "denied"})
+
+	want := `{"time":"2024-01-02T03:04:05Z","tool":"a","arguments_hash":"h","duration":1000000000}
+{"time":"2024-01-02T03:04:05Z","tool":"b","arguments_hash":"h","decision":"deny","duration":0,"error":"denied"}
+`
+	if buf.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
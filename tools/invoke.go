@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// ErrToolNotFound is the error of a Result for a call to a tool that is
+// not registered.
+var ErrToolNotFound = errors.New("tool not found")
+
+// Result is the outcome of invoking a tool call. Exactly one of Value and
+// Err is meaningful: Err is nil when the tool ran and returned Value.
+type Result struct {
+	// CallID and Name identify the tool call.
+	CallID string
+	Name   string
+
+	// Value is the tool's return value. When an Executor truncates a large
+	// result, Value is the truncated text and Truncated is set.
+	Value     any
+	Truncated bool
+
+	// Err is why the call failed: ErrToolNotFound, a *ValidationError for
+	// arguments that do not match the schema, the error the tool
+	// returned, or, from an Executor, a *DeniedError or
+	// context.DeadlineExceeded.
+	Err error
+}
+
+// IsError reports whether the call failed.
+func (r *Result) IsError() bool {
+	return r.Err != nil
+}
+
+// Content returns the result as text to send back to the model. A value
+// that is not a string is encoded as JSON. A failure is a JSON object
+// with "error" and "message" fields, and for invalid arguments a
+// "details" list of the violations, so the model can correct the call.
+func (r *Result) Content() string {
+	if r.Err == nil {
+		switch v := r.Value.(type) {
+		case string:
+			return v
+		case json.RawMessage:
+			return string(v)
+		}
+		data, err := json.Marshal(r.Value)
+		if err != nil {
+			return fmt.Sprint(r.Value)
+		}
+		return string(data)
+	}
+
+	out := struct {
+		Error   string       `json:"error"`
+		Message string       `json:"message"`
+		Details []FieldError `json:"details,omitempty"`
+	}{Error: "tool_error", Message: r.Err.Error()}
+
+	var validationErr *ValidationError
+	switch {
+	case errors.Is(r.Err, ErrToolNotFound):
+		out.Error = "unknown_tool"
+	case errors.Is(r.Err, ErrDenied):
+		out.Error = "denied"
+	case errors.Is(r.Err, context.DeadlineExceeded):
+		out.Error = "timeout"
+	case errors.As(r.Err, &validationErr):
+		out.Error = "invalid_arguments"
+		out.Message = fmt.Sprintf("The arguments for %s do not match its schema. Correct them and call the tool again.", r.Name)
+		out.Details = validationErr.Errors
+	}
+	data, _ := json.Marshal(out)
+	return string(data)
+}
+
+// Invoke runs a tool call from a model. It looks up the tool, validates
+// the arguments against the tool's schema, and calls it only if they are
+// valid. Failures are reported in the Result rather than returned, so a
+// tool loop can send Result.Content back to the model to correct itself.
+func (r *Registry) Invoke(ctx context.Context, call core.ToolCall) *Result {
+	result := &Result{CallID: call.ID, Name: call.Name}
+
+	t, args, err := r.prepare(call)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.Value, result.Err = t.Call(ctx, args)
+	return result
+}
+
+// prepare looks up the tool for a call and validates its arguments,
+// returning them with empty arguments replaced by an empty object.
+func (r *Registry) prepare(call core.ToolCall) (Tool, json.RawMessage, error) {
+	t, ok := r.Get(call.Name)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %q; available tools: %s", ErrToolNotFound, call.Name, strings.Join(r.names(), ", "))
+	}
+
+	args := call.Arguments
+	if len(bytes.TrimSpace(args)) == 0 {
+		args = json.RawMessage(`{}`)
+	}
+	if err := t.Schema().Validate(args); err != nil {
+		return nil, nil, err
+	}
+	return t, args, nil
+}
+
+// names returns the sorted names of the registered tools.
+func (r *Registry) names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.tools))
+	for name := range r.tools {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
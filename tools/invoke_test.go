@@ -0,0 +1,134 @@
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/tools"
+)
+
+// newWeatherRegistry returns a registry with a weather tool that records
+// whether it was called.
+func newWeatherRegistry(t *testing.T, called *bool) *tools.Registry {
+	t.Helper()
+	r := tools.NewRegistry()
+	weather := &mockTool{
+		name:        "get_weather",
+		description: "Get the weather",
+		schema: tools.ToolSchema{JSONSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"location": {"type": "string"},
+				"unit": {"enum": ["celsius", "fahrenheit"]}
+			},
+			"required": ["location"]
+		}`)},
+		callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			*called = true
+			var a struct{ Location string }
+			json.Unmarshal(args, &a)
+			if a.Location == "Atlantis" {
+				return nil, errors.New("location not found")
+			}
+			return map[string]any{"location": a.Location, "temp": 21}, nil
+		},
+	}
+	if err := r.Register(weather); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register(newMockTool("get_time", "Get the time")); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestRegistryInvoke(t *testing.T) {
+	var called bool
+	r := newWeatherRegistry(t, &called)
+
+	result := r.Invoke(context.Background(), core.ToolCall{
+		ID:        "call_1",
+		Name:      "get_weather",
+		Arguments: json.RawMessage(`{"location":"Paris","unit":"celsius"}`),
+	})
+	if result.IsError() || !called {
+		t.Fatalf("Invoke() error = %v, called = %v", result.Err, called)
+	}
+	if result.CallID != "call_1" || result.Name != "get_weather" {
+		t.Errorf("Invoke() call = %q, %q", result.CallID, result.Name)
+	}
+	if got := result.Content(); got != `{"location":"Paris","temp":21}` {
+		t.Errorf("Content() = %s", got)
+	}
+
+	// Empty arguments are an empty object
+	result = r.Invoke(context.Background(), core.ToolCall{Name: "get_time"})
+	if result.IsError() || result.Content() != "null" {
+		t.Errorf("Invoke() without arguments = %+v", result)
+	}
+}
+
+func TestRegistryInvokeInvalidArguments(t *testing.T) {
+	var called bool
+	r := newWeatherRegistry(t, &called)
+
+	result := r.Invoke(context.Background(), core.ToolCall{
+		ID:        "call_1",
+		Name:      "get_weather",
+		Arguments: json.RawMessage(`{"unit":"kelvin"}`),
+	})
+	if called {
+		t.Error("tool was called with invalid arguments")
+	}
+	var validationErr *tools.ValidationError
+	if !errors.As(result.Err, &validationErr) || len(validationErr.Errors) != 2 {
+		t.Fatalf("Invoke() error = %v, want *ValidationError", result.Err)
+	}
+	want := `{"error":"invalid_arguments","message":"The arguments for get_weather do not match its schema. Correct them and call the tool again.",` +
+		`"details":[{"path":"location","message":"is required"},{"path":"unit","message":"must be one of \"celsius\", \"fahrenheit\""}]}`
+	if got := result.Content(); got != want {
+		t.Errorf("Content() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRegistryInvokeErrors(t *testing.T) {
+	var called bool
+	r := newWeatherRegistry(t, &called)
+
+	result := r.Invoke(context.Background(), core.ToolCall{Name: "get_wether", Arguments: json.RawMessage(`{}`)})
+	if !errors.Is(result.Err, tools.ErrToolNotFound) {
+		t.Errorf("Invoke() unknown tool error = %v", result.Err)
+	}
+	if want := `{"error":"unknown_tool","message":"tool not found: \"get_wether\"; available tools: get_time, get_weather"}`; result.Content() != want {
+		t.Errorf("Content() = %s", result.Content())
+	}
+
+	result = r.Invoke(context.Background(), core.ToolCall{Name: "get_weather", Arguments: json.RawMessage(`{"location":"Atlantis"}`)})
+	if !called || result.Err == nil || result.Err.Error() != "location not found" {
+		t.Errorf("Invoke() tool error = %v", result.Err)
+	}
+	if want := `{"error":"tool_error","message":"location not found"}`; result.Content() != want {
+		t.Errorf("Content() = %s", result.Content())
+	}
+}
+
+func TestResultContent(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{"plain text", "plain text"},
+		{json.RawMessage(`{"a":1}`), `{"a":1}`},
+		{[]int{1, 2}, `[1,2]`},
+		{nil, "null"},
+	}
+	for _, tt := range tests {
+		result := &tools.Result{Value: tt.value}
+		if got := result.Content(); got != tt.want {
+			t.Errorf("Content(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,453 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/erikhoward/iris/core"
+	"github.com/erikhoward/iris/tools"
+)
+
+// supportedVersions are the protocol versions the client accepts from a
+// server and the server accepts from a client, newest first.
+var supportedVersions = []string{ProtocolVersion, "2025-03-26", "2024-11-05"}
+
+// config holds the settings of a Client.
+type config struct {
+	clientInfo     Implementation
+	httpClient     *http.Client
+	headers        http.Header
+	toolPrefix     string
+	onToolsChanged func()
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+// WithClientInfo sets the name and version the client reports to servers.
+// The default is "iris".
+func WithClientInfo(name, version string) Option {
+	return func(c *config) {
+		c.clientInfo = Implementation{Name: name, Version: version}
+	}
+}
+
+// WithHTTPClient sets the HTTP client used by ConnectHTTP.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) {
+		c.httpClient = client
+	}
+}
+
+// WithHeader adds a header to every HTTP request, such as an
+// Authorization header.
+func WithHeader(key, value string) Option {
+	return func(c *config) {
+		c.headers.Add(key, value)
+	}
+}
+
+// WithToolPrefix prefixes the names of the server's tools, to keep tools
+// from several servers apart in one registry.
+func WithToolPrefix(prefix string) Option {
+	return func(c *config) {
+		c.toolPrefix = prefix
+	}
+}
+
+// WithToolsChangedHandler sets a function called when the server reports
+// that its tool list changed. The cached list has been dropped by then, so
+// the next call to Tools fetches the new list. fn runs on its own
+// goroutine.
+func WithToolsChangedHandler(fn func()) Option {
+	return func(c *config) {
+		c.onToolsChanged = fn
+	}
+}
+
+func newConfig(opts []Option) config {
+	c := config{
+		clientInfo: Implementation{Name: "iris", Version: "dev"},
+		httpClient: http.DefaultClient,
+		headers:    make(http.Header),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// Client is a connection to an MCP server.
+// Client is safe for concurrent use.
+type Client struct {
+	t      transport
+	cfg    config
+	nextID atomic.Int64
+
+	server       Implementation
+	capabilities ServerCapabilities
+	instructions string
+
+	mu       sync.Mutex
+	pending  map[int64]chan *message
+	tools    []tools.Tool // cached tool list, nil when not loaded
+	toolsGen int          // incremented when the server's tool list changes
+
+	readerDone chan struct{}
+	closeOnce  sync.Once
+}
+
+// ConnectStdio starts cmd as an MCP server and connects to it over its
+// stdin and stdout. The server's stderr is left as configured on cmd.
+// Close stops the server.
+func ConnectStdio(ctx context.Context, cmd *exec.Cmd, opts ...Option) (*Client, error) {
+	t, err := newStdioTransport(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return connect(ctx, t, newConfig(opts))
+}
+
+// ConnectHTTP connects to the MCP server at url over the streamable HTTP
+// transport.
+func ConnectHTTP(ctx context.Context, url string, opts ...Option) (*Client, error) {
+	cfg := newConfig(opts)
+	return connect(ctx, newHTTPTransport(url, cfg.httpClient, cfg.headers), cfg)
+}
+
+// connect starts reading from t and performs the initialize handshake.
+func connect(ctx context.Context, t transport, cfg config) (*Client, error) {
+	c := &Client{
+		t:          t,
+		cfg:        cfg,
+		pending:    make(map[int64]chan *message),
+		readerDone: make(chan struct{}),
+	}
+	go c.read()
+
+	if err := c.initialize(ctx); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// initialize performs the initialize handshake.
+func (c *Client) initialize(ctx context.Context) error {
+	var result initializeResult
+	err := c.call(ctx, methodInitialize, initializeParams{
+		ProtocolVersion: ProtocolVersion,
+		Capabilities:    json.RawMessage(`{}`),
+		ClientInfo:      c.cfg.clientInfo,
+	}, &result)
+	if err != nil {
+		return fmt.Errorf("mcp: initialize: %w", err)
+	}
+	if !slices.Contains(supportedVersions, result.ProtocolVersion) {
+		return fmt.Errorf("mcp: unsupported protocol version %q", result.ProtocolVersion)
+	}
+
+	c.server = result.ServerInfo
+	c.capabilities = result.Capabilities
+	c.instructions = result.Instructions
+
+	if ht, ok := c.t.(*httpTransport); ok {
+		ht.setProtocolVersion(result.ProtocolVersion)
+	}
+	if err := c.notify(ctx, notifyInitialized, nil); err != nil {
+		return fmt.Errorf("mcp: initialize: %w", err)
+	}
+	if ht, ok := c.t.(*httpTransport); ok {
+		go ht.listen()
+	}
+	return nil
+}
+
+// ServerInfo returns the name and version the server reported.
+func (c *Client) ServerInfo() Implementation {
+	return c.server
+}
+
+// Capabilities returns the capabilities the server reported.
+func (c *Client) Capabilities() ServerCapabilities {
+	return c.capabilities
+}
+
+// Instructions returns the usage instructions the server reported, if any.
+func (c *Client) Instructions() string {
+	return c.instructions
+}
+
+// ListTools returns the descriptions of the server's tools, following
+// pagination.
+func (c *Client) ListTools(ctx context.Context) ([]ToolInfo, error) {
+	var all []ToolInfo
+	params := listToolsParams{}
+	for {
+		var page listToolsResult
+		if err := c.call(ctx, methodToolsList, params, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page.Tools...)
+		if page.NextCursor == "" {
+			return all, nil
+		}
+		params.Cursor = page.NextCursor
+	}
+}
+
+// Tools returns the server's tools. The list is cached until the server
+// reports that it changed.
+func (c *Client) Tools(ctx context.Context) ([]tools.Tool, error) {
+	c.mu.Lock()
+	cached, gen := c.tools, c.toolsGen
+	c.mu.Unlock()
+	if cached != nil {
+		return slices.Clone(cached), nil
+	}
+
+	infos, err := c.ListTools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ts := make([]tools.Tool, 0, len(infos))
+	for _, info := range infos {
+		ts = append(ts, &Tool{client: c, info: info})
+	}
+
+	c.mu.Lock()
+	if c.toolsGen == gen {
+		c.tools = ts
+	}
+	c.mu.Unlock()
+	return slices.Clone(ts), nil
+}
+
+// CoreTools returns the server's tools as core.Tool values, for
+// ChatBuilder.Tools.
+func (c *Client) CoreTools(ctx context.Context) ([]core.Tool, error) {
+	ts, err := c.Tools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]core.Tool, len(ts))
+	for i, t := range ts {
+		result[i] = t
+	}
+	return result, nil
+}
+
+// RegisterTools adds the server's tools to r.
+func (c *Client) RegisterTools(ctx context.Context, r *tools.Registry) error {
+	ts, err := c.Tools(ctx)
+	if err != nil {
+		return err
+	}
+	for _, t := range ts {
+		if err := r.Register(t); err != nil {
+			return fmt.Errorf("mcp: register %s: %w", t.Name(), err)
+		}
+	}
+	return nil
+}
+
+// CallTool calls the named tool with JSON arguments and returns its result
+// as sent by the server. A result with IsError set is not an error here.
+func (c *Client) CallTool(ctx context.Context, name string, args json.RawMessage) (*CallToolResult, error) {
+	if len(args) == 0 || string(args) == "null" {
+		args = json.RawMessage(`{}`)
+	}
+	var result CallToolResult
+	if err := c.call(ctx, methodToolsCall, callToolParams{Name: name, Arguments: args}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Ping checks that the server is responsive.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.call(ctx, methodPing, nil, nil)
+}
+
+// Close ends the connection, stopping the server if it was started by
+// ConnectStdio.
+func (c *Client) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		err = c.t.close()
+		<-c.readerDone
+	})
+	return err
+}
+
+// call sends a request and decodes its result into result, which may be
+// nil. If ctx ends first, the server is told to cancel the request.
+func (c *Client) call(ctx context.Context, method string, params, result any) error {
+	id := c.nextID.Add(1)
+	rawID := json.RawMessage(strconv.FormatInt(id, 10))
+
+	msg := message{JSONRPC: jsonrpcVersion, ID: rawID, Method: method}
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return fmt.Errorf("mcp: %w", err)
+		}
+		msg.Params = data
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("mcp: %w", err)
+	}
+
+	ch := make(chan *message, 1)
+	c.mu.Lock()
+	c.pending[id] = ch
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	if err := c.t.send(ctx, data); err != nil {
+		if ctx.Err() != nil {
+			c.cancelRequest(method, rawID, ctx.Err())
+			return ctx.Err()
+		}
+		return err
+	}
+
+	select {
+	case resp := <-ch:
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if result != nil && len(resp.Result) > 0 {
+			if err := json.Unmarshal(resp.Result, result); err != nil {
+				return fmt.Errorf("mcp: decode %s result: %w", method, err)
+			}
+		}
+		return nil
+
+	case <-ctx.Done():
+		c.cancelRequest(method, rawID, ctx.Err())
+		return ctx.Err()
+
+	case <-c.readerDone:
+		return c.connErr()
+	}
+}
+
+// cancelRequest tells the server to stop working on a request the client
+// gave up on. The initialize request cannot be cancelled.
+func (c *Client) cancelRequest(method string, id json.RawMessage, reason error) {
+	if method == methodInitialize {
+		return
+	}
+	go c.notify(context.Background(), notifyCancelled, cancelledParams{RequestID: id, Reason: reason.Error()})
+}
+
+// notify sends a notification.
+func (c *Client) notify(ctx context.Context, method string, params any) error {
+	msg := message{JSONRPC: jsonrpcVersion, Method: method}
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return fmt.Errorf("mcp: %w", err)
+		}
+		msg.Params = data
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("mcp: %w", err)
+	}
+	return c.t.send(ctx, data)
+}
+
+// connErr returns why the connection ended.
+func (c *Client) connErr() error {
+	if err := c.t.err(); err != nil {
+		return err
+	}
+	return ErrClosed
+}
+
+// read handles messages from the server until the connection ends.
+func (c *Client) read() {
+	defer close(c.readerDone)
+	for {
+		select {
+		case data := <-c.t.messages():
+			c.handle(data)
+		case <-c.t.closed():
+			// Handle messages that arrived before the connection ended
+			for {
+				select {
+				case data := <-c.t.messages():
+					c.handle(data)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// handle dispatches one message from the server.
+func (c *Client) handle(data []byte) {
+	var msg message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return
+	}
+
+	switch {
+	case msg.isRequest():
+		go c.reply(&msg)
+
+	case msg.isNotification():
+		if msg.Method == notifyToolsListChanged {
+			c.mu.Lock()
+			c.tools = nil
+			c.toolsGen++
+			c.mu.Unlock()
+			if c.cfg.onToolsChanged != nil {
+				go c.cfg.onToolsChanged()
+			}
+		}
+
+	default:
+		var id int64
+		if err := json.Unmarshal(msg.ID, &id); err != nil {
+			return
+		}
+		c.mu.Lock()
+		ch := c.pending[id]
+		c.mu.Unlock()
+		if ch != nil {
+			select {
+			case ch <- &msg:
+			default:
+			}
+		}
+	}
+}
+
+// reply answers a request from the server. Only ping is supported.
+func (c *Client) reply(req *message) {
+	resp := message{JSONRPC: jsonrpcVersion, ID: req.ID}
+	if req.Method == methodPing {
+		resp.Result = json.RawMessage(`{}`)
+	} else {
+		resp.Error = &Error{Code: CodeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+	if data, err := json.Marshal(resp); err == nil {
+		c.t.send(context.Background(), data)
+	}
+}
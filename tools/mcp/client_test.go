@@ -0,0 +1,421 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/erikhoward/iris/tools"
+)
+
+// TestMain runs the test binary as a fake stdio server when asked to.
+func TestMain(m *testing.M) {
+	if os.Getenv("MCP_FAKE_SERVER") == "1" {
+		runFakeStdioServer()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// fakeServer answers MCP requests for a few fixed tools. Calling the
+// "change" tool adds the "added" tool and notifies the client.
+type fakeServer struct {
+	mu        sync.Mutex
+	added     bool
+	cancelled []string
+	notify    func(msg message)
+}
+
+func (s *fakeServer) handle(msg *message) *message {
+	if !msg.isRequest() {
+		if msg.Method == notifyCancelled {
+			var p cancelledParams
+			json.Unmarshal(msg.Params, &p)
+			s.mu.Lock()
+			s.cancelled = append(s.cancelled, string(p.RequestID))
+			s.mu.Unlock()
+		}
+		return nil
+	}
+
+	result := func(v any) *message {
+		data, _ := json.Marshal(v)
+		return &message{JSONRPC: jsonrpcVersion, ID: msg.ID, Result: data}
+	}
+
+	switch msg.Method {
+	case methodInitialize:
+		return result(initializeResult{
+			ProtocolVersion: ProtocolVersion,
+			Capabilities:    ServerCapabilities{Tools: &ToolsCapability{ListChanged: true}},
+			ServerInfo:      Implementation{Name: "fake", Version: "1.0"},
+			Instructions:    "Use echo to test.",
+		})
+
+	case methodToolsList:
+		var p listToolsParams
+		json.Unmarshal(msg.Params, &p)
+		if p.Cursor == "" {
+			return result(listToolsResult{
+				Tools: []ToolInfo{{
+					Name:        "echo",
+					Description: "Echo the text",
+					InputSchema: json.RawMessage(`{"type":"object","properties":{"text":{"type":"string"}}}`),
+				}},
+				NextCursor: "page2",
+			})
+		}
+		ts := []ToolInfo{
+			{Name: "weather", Title: "Weather", InputSchema: json.RawMessage(`{"type":"object"}`)},
+			{Name: "fail", InputSchema: json.RawMessage(`{"type":"object"}`)},
+			{Name: "change", InputSchema: json.RawMessage(`{"type":"object"}`)},
+		}
+		s.mu.Lock()
+		if s.added {
+			ts = append(ts, ToolInfo{Name: "added", InputSchema: json.RawMessage(`{"type":"object"}`)})
+		}
+		s.mu.Unlock()
+		return result(listToolsResult{Tools: ts})
+
+	case methodToolsCall:
+		var p struct {
+			Name      string         `json:"name"`
+			Arguments map[string]any `json:"arguments"`
+		}
+		json.Unmarshal(msg.Params, &p)
+		switch p.Name {
+		case "echo":
+			return result(CallToolResult{Content: []Content{{Type: "text", Text: fmt.Sprint(p.Arguments["text"])}}})
+		case "weather":
+			return result(CallToolResult{
+				Content:           []Content{{Type: "text", Text: `{"temp_c":18}`}},
+				StructuredContent: json.RawMessage(`{"temp_c":18}`),
+			})
+		case "fail":
+			return result(CallToolResult{Content: []Content{{Type: "text", Text: "city not found"}}, IsError: true})
+		case "change":
+			s.mu.Lock()
+			s.added = true
+			s.mu.Unlock()
+			s.notify(message{JSONRPC: jsonrpcVersion, Method: notifyToolsListChanged})
+			return result(CallToolResult{Content: []Content{}})
+		case "slow":
+			return nil
+		}
+		return &message{JSONRPC: jsonrpcVersion, ID: msg.ID, Error: &Error{Code: CodeInvalidParams, Message: "unknown tool " + p.Name}}
+	}
+	return &message{JSONRPC: jsonrpcVersion, ID: msg.ID, Error: &Error{Code: CodeMethodNotFound, Message: "method not found"}}
+}
+
+// runFakeStdioServer serves the fake server over stdin and stdout.
+func runFakeStdioServer() {
+	var wmu sync.Mutex
+	write := func(msg message) {
+		data, _ := json.Marshal(msg)
+		wmu.Lock()
+		os.Stdout.Write(append(data, '\n'))
+		wmu.Unlock()
+	}
+	s := &fakeServer{notify: write}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var msg message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+		if resp := s.handle(&msg); resp != nil {
+			write(*resp)
+		}
+	}
+}
+
+// newFakeHTTPServer serves the fake server over streamable HTTP. Tool
+// calls are answered as event streams and other requests as JSON;
+// notifications are sent on the GET stream.
+func newFakeHTTPServer(t *testing.T) (*httptest.Server, *fakeServer, *[]http.Header) {
+	events := make(chan message, 10)
+	s := &fakeServer{notify: func(msg message) { events <- msg }}
+
+	var mu sync.Mutex
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		headers = append(headers, r.Header.Clone())
+		mu.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			for {
+				select {
+				case msg := <-events:
+					data, _ := json.Marshal(msg)
+					fmt.Fprintf(w, "data: %s\n\n", data)
+					w.(http.Flusher).Flush()
+				case <-r.Context().Done():
+					return
+				}
+			}
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		var msg message
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if msg.Method == methodInitialize {
+			w.Header().Set("Mcp-Session-Id", "sess-1")
+		}
+
+		resp := s.handle(&msg)
+		if resp == nil {
+			if msg.isRequest() {
+				// Hold slow requests until the client gives up
+				<-r.Context().Done()
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		data, _ := json.Marshal(resp)
+		if msg.Method == methodToolsCall {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server, s, &headers
+}
+
+// connectBoth connects to the fake server over each transport.
+func connectBoth(t *testing.T, opts ...Option) map[string]*Client {
+	t.Helper()
+	ctx := context.Background()
+
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	cmd.Env = append(os.Environ(), "MCP_FAKE_SERVER=1")
+	stdio, err := ConnectStdio(ctx, cmd, opts...)
+	if err != nil {
+		t.Fatalf("ConnectStdio() error = %v", err)
+	}
+	t.Cleanup(func() { stdio.Close() })
+
+	server, _, _ := newFakeHTTPServer(t)
+	remote, err := ConnectHTTP(ctx, server.URL, opts...)
+	if err != nil {
+		t.Fatalf("ConnectHTTP() error = %v", err)
+	}
+	t.Cleanup(func() { remote.Close() })
+
+	return map[string]*Client{"stdio": stdio, "http": remote}
+}
+
+func TestConnect(t *testing.T) {
+	for name, c := range connectBoth(t) {
+		t.Run(name, func(t *testing.T) {
+			if c.ServerInfo().Name != "fake" || c.Instructions() != "Use echo to test." {
+				t.Errorf("ServerInfo() = %+v, Instructions() = %q", c.ServerInfo(), c.Instructions())
+			}
+			if c.Capabilities().Tools == nil || !c.Capabilities().Tools.ListChanged {
+				t.Errorf("Capabilities() = %+v, want tools with listChanged", c.Capabilities())
+			}
+			if err := c.Ping(context.Background()); err == nil {
+				t.Error("Ping() succeeded on a server without ping")
+			}
+		})
+	}
+}
+
+func TestTools(t *testing.T) {
+	for name, c := range connectBoth(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			ts, err := c.Tools(ctx)
+			if err != nil {
+				t.Fatalf("Tools() error = %v", err)
+			}
+			if len(ts) != 4 {
+				t.Fatalf("len(Tools()) = %d, want 4 across both pages", len(ts))
+			}
+
+			echo := ts[0]
+			if echo.Name() != "echo" || echo.Description() != "Echo the text" ||
+				string(echo.Schema().JSONSchema) != `{"type":"object","properties":{"text":{"type":"string"}}}` {
+				t.Errorf("echo = %s %q %s", echo.Name(), echo.Description(), echo.Schema().JSONSchema)
+			}
+			if ts[1].Description() != "Weather" {
+				t.Errorf("Description() = %q, want the title when there is no description", ts[1].Description())
+			}
+
+			got, err := echo.Call(ctx, json.RawMessage(`{"text":"hello"}`))
+			if err != nil || got != "hello" {
+				t.Errorf("echo.Call() = %v, %v, want text", got, err)
+			}
+
+			got, err = ts[1].Call(ctx, nil)
+			if raw, ok := got.(json.RawMessage); err != nil || !ok || string(raw) != `{"temp_c":18}` {
+				t.Errorf("weather.Call() = %v, %v, want structured content", got, err)
+			}
+
+			_, err = ts[2].Call(ctx, json.RawMessage(`{}`))
+			var toolErr *ToolError
+			if !errors.As(err, &toolErr) || !strings.Contains(err.Error(), "city not found") {
+				t.Errorf("fail.Call() error = %v, want ToolError", err)
+			}
+
+			var rpcErr *Error
+			if _, err := c.CallTool(ctx, "missing", nil); !errors.As(err, &rpcErr) || rpcErr.Code != CodeInvalidParams {
+				t.Errorf("CallTool(missing) error = %v, want JSON-RPC error", err)
+			}
+		})
+	}
+}
+
+func TestToolsListChanged(t *testing.T) {
+	changed := make(chan struct{}, 2)
+	clients := connectBoth(t, WithToolsChangedHandler(func() { changed <- struct{}{} }))
+
+	for name, c := range clients {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			ts, err := c.Tools(ctx)
+			if err != nil || len(ts) != 4 {
+				t.Fatalf("Tools() = %d tools, %v", len(ts), err)
+			}
+			if _, err := c.CallTool(ctx, "change", nil); err != nil {
+				t.Fatalf("CallTool(change) error = %v", err)
+			}
+
+			select {
+			case <-changed:
+			case <-time.After(2 * time.Second):
+				t.Fatal("tools changed handler not called")
+			}
+			ts, err = c.Tools(ctx)
+			if err != nil || len(ts) != 5 || ts[4].Name() != "added" {
+				t.Errorf("Tools() after change = %d tools, %v, want the added tool", len(ts), err)
+			}
+		})
+	}
+}
+
+func TestRegisterTools(t *testing.T) {
+	for name, c := range connectBoth(t, WithToolPrefix("fake_")) {
+		t.Run(name, func(t *testing.T) {
+			r := tools.NewRegistry()
+			if err := c.RegisterTools(context.Background(), r); err != nil {
+				t.Fatalf("RegisterTools() error = %v", err)
+			}
+			echo, ok := r.Get("fake_echo")
+			if !ok {
+				t.Fatal("fake_echo not registered")
+			}
+			if got, err := echo.Call(context.Background(), json.RawMessage(`{"text":"hi"}`)); err != nil || got != "hi" {
+				t.Errorf("Call() = %v, %v", got, err)
+			}
+
+			if err := c.RegisterTools(context.Background(), r); !errors.Is(err, tools.ErrDuplicateTool) {
+				t.Errorf("second RegisterTools() error = %v, want ErrDuplicateTool", err)
+			}
+
+			core, err := c.CoreTools(context.Background())
+			if err != nil || len(core) != 4 || core[0].Name() != "fake_echo" {
+				t.Errorf("CoreTools() = %v, %v", core, err)
+			}
+		})
+	}
+}
+
+func TestCallCancelled(t *testing.T) {
+	server, fake, _ := newFakeHTTPServer(t)
+	c, err := ConnectHTTP(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("ConnectHTTP() error = %v", err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.CallTool(ctx, "slow", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("CallTool(slow) error = %v, want DeadlineExceeded", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		fake.mu.Lock()
+		n := len(fake.cancelled)
+		fake.mu.Unlock()
+		if n == 1 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("server did not receive notifications/cancelled")
+}
+
+func TestHTTPSessionAndHeaders(t *testing.T) {
+	server, _, headers := newFakeHTTPServer(t)
+	c, err := ConnectHTTP(context.Background(), server.URL, WithHeader("Authorization", "Bearer token"))
+	if err != nil {
+		t.Fatalf("ConnectHTTP() error = %v", err)
+	}
+	if _, err := c.ListTools(context.Background()); err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	c.Close()
+
+	for i, h := range *headers {
+		if h.Get("Authorization") != "Bearer token" {
+			t.Errorf("request %d Authorization = %q", i, h.Get("Authorization"))
+		}
+		if i == 0 {
+			continue
+		}
+		if h.Get("Mcp-Session-Id") != "sess-1" || h.Get("MCP-Protocol-Version") != ProtocolVersion {
+			t.Errorf("request %d session = %q, version = %q", i, h.Get("Mcp-Session-Id"), h.Get("MCP-Protocol-Version"))
+		}
+	}
+}
+
+func TestConnectHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	if _, err := ConnectHTTP(context.Background(), server.URL); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("ConnectHTTP() error = %v, want 401", err)
+	}
+}
+
+func TestClosedClient(t *testing.T) {
+	clients := connectBoth(t)
+	for name, c := range clients {
+		t.Run(name, func(t *testing.T) {
+			c.Close()
+			if _, err := c.ListTools(context.Background()); !errors.Is(err, ErrClosed) {
+				t.Errorf("ListTools() after Close error = %v, want ErrClosed", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Package mcp connects to Model Context Protocol servers and exposes their
+// tools as tools.Tool values, and serves the tools of a tools.Registry to
+// MCP clients.
+//
+// ConnectStdio spawns a server as a subprocess and talks to it over stdin
+// and stdout; ConnectHTTP talks to a server over the streamable HTTP
+// transport. Both perform the initialize handshake before returning:
+//
+//	client, err := mcp.ConnectStdio(ctx, exec.Command("npx", "-y", "@modelcontextprotocol/server-filesystem", "/tmp"))
+//	if err != nil {
+//	    return err
+//	}
+//	defer client.Close()
+//
+//	ts, err := client.CoreTools(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	resp, err := irisClient.Chat(model).User("List the files in /tmp").Tools(ts...).GetResponse(ctx)
+//
+// RegisterTools adds the tools to a tools.Registry instead, for lookup by
+// name when running the model's tool calls or from petalflow nodes.
+//
+// Each tool's Schema is the server's input schema, and Call performs a
+// tools/call request. Results with structured content are returned as
+// json.RawMessage, results with only text content as a string, and other
+// results as []Content. A result the server marks as an error is returned
+// as a *ToolError.
+//
+// The tool list is cached after the first call to Tools and refreshed when
+// the server reports that it changed; WithToolsChangedHandler is notified
+// when that happens.
+//
+// # Serving tools
+//
+// NewServer publishes a registry's tools. ServeStdio serves one client over
+// stdin and stdout, and the Server is an http.Handler for the streamable
+// HTTP transport:
+//
+//	server := mcp.NewServer(registry, mcp.WithServerInfo("my-tools", "1.0.0"))
+//	err := server.ServeStdio(ctx, os.Stdin, os.Stdout)
+//
+// A string result is sent as text content, and a JSON object as structured
+// content with a text copy. An error from a tool is sent as an error
+// result, except an *Error, which is sent as a JSON-RPC error. Canceled
+// requests cancel the context passed to the tool.
+package mcp
@@ -0,0 +1,171 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ProtocolVersion is the MCP protocol version the client requests and the
+// server prefers.
+const ProtocolVersion = "2025-06-18"
+
+// jsonrpcVersion is the JSON-RPC version of every message.
+const jsonrpcVersion = "2.0"
+
+// JSON-RPC error codes.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// message is a JSON-RPC request, response, or notification. Requests have
+// an ID and a Method, responses an ID and a Result or Error, and
+// notifications only a Method.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// isRequest reports whether the message is a request.
+func (m *message) isRequest() bool {
+	return m.Method != "" && len(m.ID) > 0
+}
+
+// isNotification reports whether the message is a notification.
+func (m *message) isNotification() bool {
+	return m.Method != "" && len(m.ID) == 0
+}
+
+// Error is a JSON-RPC error returned by an MCP server. A tool served by
+// Server may return an *Error to answer with that error instead of a tool
+// result.
+type Error struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("mcp: %s (code %d)", e.Message, e.Code)
+}
+
+// Implementation identifies an MCP client or server.
+type Implementation struct {
+	Name    string `json:"name"`
+	Title   string `json:"title,omitempty"`
+	Version string `json:"version"`
+}
+
+// ServerCapabilities describes the features a server offers.
+type ServerCapabilities struct {
+	Tools     *ToolsCapability `json:"tools,omitempty"`
+	Resources json.RawMessage  `json:"resources,omitempty"`
+	Prompts   json.RawMessage  `json:"prompts,omitempty"`
+	Logging   json.RawMessage  `json:"logging,omitempty"`
+}
+
+// ToolsCapability describes a server's tool support.
+type ToolsCapability struct {
+	// ListChanged reports whether the server notifies clients when its
+	// tool list changes.
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+// initializeParams are the parameters of the initialize request.
+type initializeParams struct {
+	ProtocolVersion string          `json:"protocolVersion"`
+	Capabilities    json.RawMessage `json:"capabilities"`
+	ClientInfo      Implementation  `json:"clientInfo"`
+}
+
+// initializeResult is the result of the initialize request.
+type initializeResult struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ServerCapabilities `json:"capabilities"`
+	ServerInfo      Implementation     `json:"serverInfo"`
+	Instructions    string             `json:"instructions,omitempty"`
+}
+
+// ToolInfo describes a tool offered by a server.
+type ToolInfo struct {
+	Name         string           `json:"name"`
+	Title        string           `json:"title,omitempty"`
+	Description  string           `json:"description,omitempty"`
+	InputSchema  json.RawMessage  `json:"inputSchema"`
+	OutputSchema json.RawMessage  `json:"outputSchema,omitempty"`
+	Annotations  *ToolAnnotations `json:"annotations,omitempty"`
+}
+
+// ToolAnnotations are hints about a tool's behavior. They are reported by
+// the server and not guaranteed.
+type ToolAnnotations struct {
+	Title           string `json:"title,omitempty"`
+	ReadOnlyHint    *bool  `json:"readOnlyHint,omitempty"`
+	DestructiveHint *bool  `json:"destructiveHint,omitempty"`
+	IdempotentHint  *bool  `json:"idempotentHint,omitempty"`
+	OpenWorldHint   *bool  `json:"openWorldHint,omitempty"`
+}
+
+// listToolsParams are the parameters of the tools/list request.
+type listToolsParams struct {
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// listToolsResult is one page of the tools/list result.
+type listToolsResult struct {
+	Tools      []ToolInfo `json:"tools"`
+	NextCursor string     `json:"nextCursor,omitempty"`
+}
+
+// callToolParams are the parameters of the tools/call request.
+type callToolParams struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// CallToolResult is the result of calling a tool.
+type CallToolResult struct {
+	Content           []Content       `json:"content"`
+	StructuredContent json.RawMessage `json:"structuredContent,omitempty"`
+	IsError           bool            `json:"isError,omitempty"`
+}
+
+// Content is an item of a tool result. Type determines which fields are
+// set: "text" uses Text; "image" and "audio" use Data (base64) and
+// MIMEType; "resource_link" uses URI, Name, and MIMEType; "resource" uses
+// Resource.
+type Content struct {
+	Type     string          `json:"type"`
+	Text     string          `json:"text,omitempty"`
+	Data     string          `json:"data,omitempty"`
+	MIMEType string          `json:"mimeType,omitempty"`
+	URI      string          `json:"uri,omitempty"`
+	Name     string          `json:"name,omitempty"`
+	Resource json.RawMessage `json:"resource,omitempty"`
+}
+
+// cancelledParams are the parameters of the notifications/cancelled
+// notification.
+type cancelledParams struct {
+	RequestID json.RawMessage `json:"requestId"`
+	Reason    string          `json:"reason,omitempty"`
+}
+
+// MCP methods and notifications.
+const (
+	methodInitialize       = "initialize"
+	methodPing             = "ping"
+	methodToolsList        = "tools/list"
+	methodToolsCall        = "tools/call"
+	notifyInitialized      = "notifications/initialized"
+	notifyCancelled        = "notifications/cancelled"
+	notifyToolsListChanged = "notifications/tools/list_changed"
+)
@@ -0,0 +1,647 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/erikhoward/iris/tools"
+)
+
+// serverConfig holds the settings of a Server.
+type serverConfig struct {
+	info           Implementation
+	instructions   string
+	sessionTimeout time.Duration
+	maxSessions    int
+	allowedOrigins []string
+}
+
+// ServerOption configures a Server.
+type ServerOption func(*serverConfig)
+
+// WithServerInfo sets the name and version the server reports to clients.
+// The default is "iris".
+func WithServerInfo(name, version string) ServerOption {
+	return func(c *serverConfig) {
+		c.info = Implementation{Name: name, Version: version}
+	}
+}
+
+// WithInstructions sets usage instructions the server reports to clients,
+// which they may add to a model's system prompt.
+func WithInstructions(instructions string) ServerOption {
+	return func(c *serverConfig) {
+		c.instructions = instructions
+	}
+}
+
+// WithSessionTimeout sets how long an HTTP session may sit idle, with no
+// request in progress and no event stream open, before it ends. Expired
+// sessions are ended on a timer, even when no further requests arrive. The
+// default is 30 minutes.
+func WithSessionTimeout(d time.Duration) ServerOption {
+	return func(c *serverConfig) {
+		c.sessionTimeout = d
+	}
+}
+
+// WithMaxSessions limits the number of HTTP sessions open at once. Once
+// the limit is reached, initialize requests fail with 503 Service
+// Unavailable until a session ends. The default is 1000.
+func WithMaxSessions(n int) ServerOption {
+	return func(c *serverConfig) {
+		c.maxSessions = n
+	}
+}
+
+// WithAllowedOrigins sets the origins, such as "https://app.example.com",
+// from which browsers may call the HTTP transport. "*" allows any origin.
+// Requests from the server's own origin and requests without an Origin
+// header are always allowed; others are refused with 403 Forbidden, which
+// guards local servers against DNS rebinding.
+func WithAllowedOrigins(origins ...string) ServerOption {
+	return func(c *serverConfig) {
+		c.allowedOrigins = append(c.allowedOrigins, origins...)
+	}
+}
+
+func newServerConfig(opts []ServerOption) serverConfig {
+	c := serverConfig{
+		info:           Implementation{Name: "iris", Version: "dev"},
+		sessionTimeout: 30 * time.Minute,
+		maxSessions:    1000,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// Server publishes the tools of a tools.Registry to MCP clients. It serves
+// clients over stdio with ServeStdio and over the streamable HTTP transport
+// as an http.Handler.
+//
+// The registry is read on every request, so tools registered after the
+// server starts are offered too; call NotifyToolsChanged to tell connected
+// clients. Server is safe for concurrent use.
+type Server struct {
+	registry *tools.Registry
+	cfg      serverConfig
+
+	mu       sync.Mutex
+	sessions map[string]*session
+	closed   bool
+
+	// sweep ends expired HTTP sessions while any are open
+	sweep *time.Timer
+}
+
+// NewServer creates a server for the tools in registry.
+func NewServer(registry *tools.Registry, opts ...ServerOption) *Server {
+	return &Server{
+		registry: registry,
+		cfg:      newServerConfig(opts),
+		sessions: make(map[string]*session),
+	}
+}
+
+// session is the state of one connected client.
+type session struct {
+	id string
+
+	// ctx is canceled when the session ends, canceling its requests
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// notify sends a message the server initiates, such as a notification
+	notify func(msg []byte)
+
+	// events queues those messages for an HTTP client's event stream
+	events chan []byte
+
+	// active counts the HTTP requests using the session and lastUsed is
+	// when the last one finished; both are guarded by Server.mu
+	active   int
+	lastUsed time.Time
+
+	mu       sync.Mutex
+	inflight map[string]context.CancelFunc
+	requests sync.WaitGroup
+}
+
+// errTooManySessions is returned by newSession when the server has
+// reached its limit of HTTP sessions.
+var errTooManySessions = errors.New("mcp: too many sessions")
+
+// newSession starts a session that sends notifications with notify, or
+// queues them on its events channel if notify is nil. An HTTP session
+// starts in use by the request that created it, which must release it.
+func (s *Server) newSession(notify func([]byte)) (*session, error) {
+	var b [16]byte
+	rand.Read(b[:])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sess := &session{
+		id:       hex.EncodeToString(b[:]),
+		ctx:      ctx,
+		cancel:   cancel,
+		notify:   notify,
+		inflight: make(map[string]context.CancelFunc),
+	}
+	if notify == nil {
+		// Messages are dropped while no event stream is open
+		sess.events = make(chan []byte, 16)
+		sess.notify = func(msg []byte) {
+			select {
+			case sess.events <- msg:
+			default:
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		cancel()
+		return nil, ErrClosed
+	}
+	if sess.events != nil {
+		if s.httpSessions() >= s.cfg.maxSessions {
+			cancel()
+			return nil, errTooManySessions
+		}
+		sess.active = 1
+		if s.sweep == nil {
+			s.sweep = time.AfterFunc(s.sweepInterval(), s.sweepSessions)
+		}
+	}
+	s.sessions[sess.id] = sess
+	return sess, nil
+}
+
+// sweepInterval is how often expired HTTP sessions are ended.
+func (s *Server) sweepInterval() time.Duration {
+	return max(s.cfg.sessionTimeout/2, 10*time.Millisecond)
+}
+
+// sweepSessions ends the expired HTTP sessions, running again while any
+// remain, so that an idle server does not keep them.
+func (s *Server) sweepSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || s.sweep == nil {
+		return
+	}
+	if s.httpSessions() == 0 {
+		s.sweep = nil
+		return
+	}
+	s.sweep.Reset(s.sweepInterval())
+}
+
+// httpSessions ends the expired HTTP sessions and counts the rest. The
+// caller holds s.mu.
+func (s *Server) httpSessions() int {
+	n := 0
+	now := time.Now()
+	for id, sess := range s.sessions {
+		if sess.events == nil {
+			continue
+		}
+		if s.expired(sess, now) {
+			delete(s.sessions, id)
+			sess.cancel()
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// expired reports whether an HTTP session has been idle longer than the
+// session timeout. The caller holds s.mu.
+func (s *Server) expired(sess *session, now time.Time) bool {
+	return sess.active == 0 && now.Sub(sess.lastUsed) > s.cfg.sessionTimeout
+}
+
+// useSession returns the HTTP session with the given ID, marking it in use
+// until the caller releases it. An expired session is ended instead.
+func (s *Server) useSession(id string) (*session, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[id]
+	if !ok || sess.events == nil {
+		return nil, false
+	}
+	if s.expired(sess, time.Now()) {
+		delete(s.sessions, id)
+		sess.cancel()
+		return nil, false
+	}
+	sess.active++
+	return sess, true
+}
+
+// releaseSession marks the end of a request using an HTTP session, which
+// starts its idle time once no request uses it.
+func (s *Server) releaseSession(sess *session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess.active--
+	sess.lastUsed = time.Now()
+}
+
+// endSession cancels the session's requests and forgets it.
+func (s *Server) endSession(sess *session) {
+	s.mu.Lock()
+	delete(s.sessions, sess.id)
+	s.mu.Unlock()
+	sess.cancel()
+}
+
+// NotifyToolsChanged tells every connected client that the tool list
+// changed, so that clients caching it fetch it again.
+func (s *Server) NotifyToolsChanged() {
+	data, err := json.Marshal(message{JSONRPC: jsonrpcVersion, Method: notifyToolsListChanged})
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	sessions := make([]*session, 0, len(s.sessions))
+	for _, sess := range s.sessions {
+		sessions = append(sessions, sess)
+	}
+	s.mu.Unlock()
+
+	for _, sess := range sessions {
+		sess.notify(data)
+	}
+}
+
+// Close ends every session, canceling requests in progress. ServeStdio
+// returns, and the HTTP handler refuses new requests.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	if s.sweep != nil {
+		s.sweep.Stop()
+		s.sweep = nil
+	}
+	sessions := s.sessions
+	s.sessions = make(map[string]*session)
+	s.mu.Unlock()
+
+	for _, sess := range sessions {
+		sess.cancel()
+	}
+	return nil
+}
+
+// ServeStdio serves one client over newline-delimited JSON-RPC messages
+// read from in and written to out, usually os.Stdin and os.Stdout. It
+// returns once in is exhausted and the requests in progress have finished,
+// or when ctx ends or the server is closed.
+func (s *Server) ServeStdio(ctx context.Context, in io.Reader, out io.Writer) error {
+	var wmu sync.Mutex
+	write := func(msg []byte) {
+		wmu.Lock()
+		defer wmu.Unlock()
+		out.Write(append(msg, '\n'))
+	}
+
+	sess, err := s.newSession(write)
+	if err != nil {
+		return err
+	}
+	defer s.endSession(sess)
+	stop := context.AfterFunc(ctx, sess.cancel)
+	defer stop()
+
+	// Reading cannot be interrupted, so it runs on its own goroutine
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		r := bufio.NewReaderSize(in, 64*1024)
+		for {
+			line, err := readLine(r)
+			if len(line) > 0 {
+				select {
+				case lines <- line:
+				case <-sess.ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+loop:
+	for {
+		select {
+		case line := <-lines:
+			s.handle(sess.ctx, sess, line, func(resp []byte) {
+				if resp != nil {
+					write(resp)
+				}
+			})
+		case err = <-readErr:
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			// Let requests in progress finish unless the session ends first
+			finished := make(chan struct{})
+			go func() {
+				sess.requests.Wait()
+				close(finished)
+			}()
+			select {
+			case <-finished:
+			case <-sess.ctx.Done():
+			}
+			break loop
+		case <-sess.ctx.Done():
+			break loop
+		}
+	}
+
+	sess.cancel()
+	sess.requests.Wait()
+	return err
+}
+
+// handle processes a message from the client. It reports whether the
+// message gets a reply; if so, reply is called once with the response, or
+// with nil if the request was canceled. Requests run on their own
+// goroutine with a context derived from ctx, so reply may be called after
+// handle returns.
+func (s *Server) handle(ctx context.Context, sess *session, data []byte, reply func([]byte)) bool {
+	var msg message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		reply(errorResponse(nil, &Error{Code: CodeParseError, Message: "parse error: " + err.Error()}))
+		return true
+	}
+
+	switch {
+	case msg.JSONRPC != jsonrpcVersion:
+		if len(msg.ID) == 0 {
+			return false
+		}
+		reply(errorResponse(msg.ID, &Error{Code: CodeInvalidRequest, Message: `jsonrpc must be "2.0"`}))
+		return true
+
+	case msg.isNotification():
+		s.handleNotification(sess, &msg)
+		return false
+
+	case msg.isRequest():
+		// Register the request before handling later messages, so that a
+		// cancellation that follows it finds it
+		key := string(msg.ID)
+		sess.mu.Lock()
+		if _, dup := sess.inflight[key]; dup {
+			sess.mu.Unlock()
+			reply(errorResponse(msg.ID, &Error{Code: CodeInvalidRequest, Message: "request id " + key + " is already in progress"}))
+			return true
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		stop := context.AfterFunc(sess.ctx, cancel)
+		sess.inflight[key] = cancel
+		sess.mu.Unlock()
+
+		sess.requests.Add(1)
+		go func() {
+			defer sess.requests.Done()
+			defer func() {
+				sess.mu.Lock()
+				delete(sess.inflight, key)
+				sess.mu.Unlock()
+				stop()
+				cancel()
+			}()
+
+			result, err := s.dispatch(ctx, &msg)
+			if ctx.Err() != nil {
+				// Canceled requests get no response
+				reply(nil)
+				return
+			}
+			if err != nil {
+				reply(errorResponse(msg.ID, err))
+				return
+			}
+			reply(resultResponse(msg.ID, result))
+		}()
+		return true
+
+	default:
+		// A response; the server sends no requests that expect one
+		return false
+	}
+}
+
+// handleNotification processes a notification from the client.
+func (s *Server) handleNotification(sess *session, msg *message) {
+	if msg.Method != notifyCancelled {
+		return
+	}
+	var params cancelledParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		return
+	}
+	sess.mu.Lock()
+	cancel := sess.inflight[string(params.RequestID)]
+	sess.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// dispatch runs a request and returns its result.
+func (s *Server) dispatch(ctx context.Context, msg *message) (any, error) {
+	switch msg.Method {
+	case methodInitialize:
+		var params initializeParams
+		if err := decodeParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		// Answer with the client's version if supported, else our own
+		version := ProtocolVersion
+		if slices.Contains(supportedVersions, params.ProtocolVersion) {
+			version = params.ProtocolVersion
+		}
+		return initializeResult{
+			ProtocolVersion: version,
+			Capabilities:    ServerCapabilities{Tools: &ToolsCapability{ListChanged: true}},
+			ServerInfo:      s.cfg.info,
+			Instructions:    s.cfg.instructions,
+		}, nil
+
+	case methodPing:
+		return struct{}{}, nil
+
+	case methodToolsList:
+		return listToolsResult{Tools: s.toolInfos()}, nil
+
+	case methodToolsCall:
+		var params callToolParams
+		if err := decodeParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.callTool(ctx, params)
+
+	default:
+		return nil, &Error{Code: CodeMethodNotFound, Message: "method not found: " + msg.Method}
+	}
+}
+
+// decodeParams decodes request parameters, reporting failure as an
+// invalid params error.
+func decodeParams(data json.RawMessage, v any) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return &Error{Code: CodeInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+	return nil
+}
+
+// toolInfos describes the registry's tools, sorted by name.
+func (s *Server) toolInfos() []ToolInfo {
+	ts := s.registry.List()
+	slices.SortFunc(ts, func(a, b tools.Tool) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	infos := make([]ToolInfo, 0, len(ts))
+	for _, t := range ts {
+		// Tools from another MCP server keep their full description
+		if mt, ok := t.(interface{ Info() ToolInfo }); ok {
+			info := mt.Info()
+			info.Name = t.Name()
+			infos = append(infos, info)
+			continue
+		}
+
+		schema := t.Schema().JSONSchema
+		if len(schema) == 0 {
+			schema = json.RawMessage(`{"type":"object"}`)
+		}
+		infos = append(infos, ToolInfo{
+			Name:        t.Name(),
+			Description: t.Description(),
+			InputSchema: schema,
+		})
+	}
+	return infos
+}
+
+// callTool runs a tool. Failures of the tool itself are reported in the
+// result for the model to see; only an unknown tool, invalid parameters,
+// or an *Error returned by the tool are JSON-RPC errors.
+func (s *Server) callTool(ctx context.Context, params callToolParams) (result *CallToolResult, err error) {
+	if params.Name == "" {
+		return nil, &Error{Code: CodeInvalidParams, Message: "missing tool name"}
+	}
+	t, ok := s.registry.Get(params.Name)
+	if !ok {
+		return nil, &Error{Code: CodeInvalidParams, Message: "unknown tool: " + params.Name}
+	}
+
+	args := params.Arguments
+	if len(args) == 0 || string(args) == "null" {
+		args = json.RawMessage(`{}`)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, &Error{Code: CodeInternalError, Message: fmt.Sprintf("tool %s panicked: %v", params.Name, r)}
+		}
+	}()
+
+	value, err := t.Call(ctx, args)
+	if err != nil {
+		var rpcErr *Error
+		if errors.As(err, &rpcErr) {
+			return nil, rpcErr
+		}
+		var toolErr *ToolError
+		if errors.As(err, &toolErr) {
+			return &CallToolResult{Content: toolErr.Content, IsError: true}, nil
+		}
+		return &CallToolResult{Content: []Content{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+	}
+	return toolResult(value)
+}
+
+// toolResult converts a value returned by a tool to a tool result. Strings
+// become text content; JSON objects, and values that marshal to them,
+// become structured content with a text copy; other JSON values become
+// text. A *CallToolResult or []Content is used as is.
+func toolResult(value any) (*CallToolResult, error) {
+	switch v := value.(type) {
+	case nil:
+		return &CallToolResult{Content: []Content{}}, nil
+	case *CallToolResult:
+		return v, nil
+	case CallToolResult:
+		return &v, nil
+	case []Content:
+		return &CallToolResult{Content: v}, nil
+	case string:
+		return &CallToolResult{Content: []Content{{Type: "text", Text: v}}}, nil
+	}
+
+	data, ok := value.(json.RawMessage)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(value); err != nil {
+			return nil, &Error{Code: CodeInternalError, Message: "encode tool result: " + err.Error()}
+		}
+	}
+
+	result := &CallToolResult{Content: []Content{{Type: "text", Text: string(data)}}}
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "{") {
+		result.StructuredContent = data
+	}
+	return result, nil
+}
+
+// resultResponse returns the response carrying a request's result.
+func resultResponse(id json.RawMessage, result any) []byte {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return errorResponse(id, &Error{Code: CodeInternalError, Message: "encode result: " + err.Error()})
+	}
+	resp, _ := json.Marshal(message{JSONRPC: jsonrpcVersion, ID: id, Result: data})
+	return resp
+}
+
+// errorResponse returns the response carrying a request's error. Errors
+// other than *Error are internal errors.
+func errorResponse(id json.RawMessage, err error) []byte {
+	var rpcErr *Error
+	if !errors.As(err, &rpcErr) {
+		rpcErr = &Error{Code: CodeInternalError, Message: err.Error()}
+	}
+	if len(id) == 0 {
+		// The ID of a message that cannot be parsed is null
+		id = json.RawMessage("null")
+	}
+	resp, _ := json.Marshal(message{JSONRPC: jsonrpcVersion, ID: id, Error: rpcErr})
+	return resp
+}
@@ -0,0 +1,188 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// ServeHTTP serves the streamable HTTP transport. An initialize request
+// starts a session, whose ID the client sends on later requests in the
+// Mcp-Session-Id header. POST carries one message from the client and
+// answers requests with a JSON body; GET opens an event stream for
+// notifications; DELETE ends the session.
+//
+// Sessions idle longer than the session timeout end, and the number open
+// at once is limited; see WithSessionTimeout and WithMaxSessions. Requests
+// from browsers on other origins are refused unless allowed with
+// WithAllowedOrigins.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	closed := s.closed
+	s.mu.Unlock()
+	if closed {
+		http.Error(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	if origin := r.Header.Get("Origin"); origin != "" && !s.allowedOrigin(origin, r.Host) {
+		http.Error(w, "origin not allowed: "+origin, http.StatusForbidden)
+		return
+	}
+
+	if v := r.Header.Get("MCP-Protocol-Version"); v != "" && !slices.Contains(supportedVersions, v) {
+		http.Error(w, "unsupported MCP-Protocol-Version: "+v, http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		s.servePost(w, r)
+	case http.MethodGet:
+		s.serveEvents(w, r)
+	case http.MethodDelete:
+		sess, ok := s.requestSession(w, r)
+		if !ok {
+			return
+		}
+		s.releaseSession(sess)
+		s.endSession(sess)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// servePost handles a message POSTed by the client.
+func (s *Server) servePost(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMessageSize+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxMessageSize {
+		http.Error(w, fmt.Sprintf("message exceeds %d bytes", maxMessageSize), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	var msg message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse(nil, &Error{Code: CodeParseError, Message: "parse error: " + err.Error()}))
+		return
+	}
+
+	var sess *session
+	if msg.Method == methodInitialize && r.Header.Get("Mcp-Session-Id") == "" {
+		var err error
+		if sess, err = s.newSession(nil); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Mcp-Session-Id", sess.id)
+	} else {
+		var ok bool
+		if sess, ok = s.requestSession(w, r); !ok {
+			return
+		}
+	}
+	defer s.releaseSession(sess)
+
+	replies := make(chan []byte, 1)
+	if !s.handle(r.Context(), sess, body, func(resp []byte) { replies <- resp }) {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	resp := <-replies
+	if resp == nil {
+		// The request was canceled
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// serveEvents streams the messages the server sends to the session on its
+// own until the client disconnects or the session ends.
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request) {
+	if !strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		w.Header().Set("Allow", "POST, DELETE")
+		http.Error(w, "GET requires Accept: text/event-stream", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := s.requestSession(w, r)
+	if !ok {
+		return
+	}
+	defer s.releaseSession(sess)
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case msg := <-sess.events:
+			if _, err := fmt.Fprintf(w, "event: message\ndata: %s\n\n", msg); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		case <-sess.ctx.Done():
+			return
+		}
+	}
+}
+
+// requestSession returns the session named by the request's
+// Mcp-Session-Id header, in use until the caller releases it. If there is
+// none, it writes an error response: 400 when the header is missing, and
+// 404 when the session is unknown, ended or expired, which tells the
+// client to initialize a new one.
+func (s *Server) requestSession(w http.ResponseWriter, r *http.Request) (*session, bool) {
+	id := r.Header.Get("Mcp-Session-Id")
+	if id == "" {
+		http.Error(w, "missing Mcp-Session-Id header", http.StatusBadRequest)
+		return nil, false
+	}
+	sess, ok := s.useSession(id)
+	if !ok {
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return nil, false
+	}
+	return sess, true
+}
+
+// allowedOrigin reports whether a browser on origin may call the server,
+// which it may from the server's own host or an origin allowed with
+// WithAllowedOrigins.
+func (s *Server) allowedOrigin(origin, host string) bool {
+	for _, allowed := range s.cfg.allowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, host)
+}
+
+// writeJSON writes a JSON-RPC message as the response body.
+func writeJSON(w http.ResponseWriter, status int, msg []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(msg)
+}
+
+// Compile-time check that Server implements http.Handler.
+var _ http.Handler = (*Server)(nil)
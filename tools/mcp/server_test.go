@@ -0,0 +1,564 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erikhoward/iris/tools"
+)
+
+// funcTool is a tools.Tool backed by a function.
+type funcTool struct {
+	name   string
+	schema string
+	fn     func(ctx context.Context, args json.RawMessage) (any, error)
+}
+
+func (t *funcTool) Name() string        { return t.name }
+func (t *funcTool) Description() string { return "the " + t.name + " tool" }
+
+func (t *funcTool) Schema() tools.ToolSchema {
+	return tools.ToolSchema{JSONSchema: json.RawMessage(t.schema)}
+}
+
+func (t *funcTool) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	return t.fn(ctx, args)
+}
+
+// newTestRegistry returns a registry of tools exercising each kind of
+// result. The "slow" tool reports on started and blocks until canceled,
+// then reports on stopped.
+func newTestRegistry(t *testing.T, started, stopped chan struct{}) *tools.Registry {
+	t.Helper()
+	reg := tools.NewRegistry()
+	for _, tool := range []tools.Tool{
+		&funcTool{name: "echo", schema: `{"type":"object","properties":{"text":{"type":"string"}}}`, fn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			var a struct{ Text string }
+			json.Unmarshal(args, &a)
+			return a.Text, nil
+		}},
+		&funcTool{name: "weather", fn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			return map[string]any{"temp": 21}, nil
+		}},
+		&funcTool{name: "fail", fn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			return nil, errors.New("no such city")
+		}},
+		&funcTool{name: "invalid", fn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			return nil, &Error{Code: CodeInvalidParams, Message: "city required"}
+		}},
+		&funcTool{name: "panic", fn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			panic("boom")
+		}},
+		&funcTool{name: "slow", fn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			close(started)
+			<-ctx.Done()
+			close(stopped)
+			return nil, ctx.Err()
+		}},
+	} {
+		if err := reg.Register(tool); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return reg
+}
+
+// connectServer serves srv over HTTP and connects a client to it.
+func connectServer(t *testing.T, srv *Server, opts ...Option) *Client {
+	t.Helper()
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	client, err := ConnectHTTP(context.Background(), ts.URL, opts...)
+	if err != nil {
+		t.Fatalf("ConnectHTTP() error = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestServerHTTP(t *testing.T) {
+	srv := NewServer(newTestRegistry(t, nil, nil), WithServerInfo("test", "1.0"), WithInstructions("be nice"))
+	defer srv.Close()
+	client := connectServer(t, srv)
+	ctx := context.Background()
+
+	if got := client.ServerInfo(); got.Name != "test" || got.Version != "1.0" {
+		t.Errorf("ServerInfo() = %+v", got)
+	}
+	if client.Instructions() != "be nice" {
+		t.Errorf("Instructions() = %q", client.Instructions())
+	}
+	if caps := client.Capabilities(); caps.Tools == nil || !caps.Tools.ListChanged {
+		t.Errorf("Capabilities() = %+v", caps)
+	}
+
+	infos, err := client.ListTools(ctx)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	var names []string
+	for _, info := range infos {
+		names = append(names, info.Name)
+	}
+	if got := strings.Join(names, ","); got != "echo,fail,invalid,panic,slow,weather" {
+		t.Errorf("tool names = %s", got)
+	}
+	if infos[0].Description != "the echo tool" || !strings.Contains(string(infos[0].InputSchema), `"text"`) {
+		t.Errorf("echo info = %+v", infos[0])
+	}
+	if string(infos[5].InputSchema) != `{"type":"object"}` {
+		t.Errorf("default schema = %s", infos[5].InputSchema)
+	}
+
+	ts, err := client.Tools(ctx)
+	if err != nil {
+		t.Fatalf("Tools() error = %v", err)
+	}
+	byName := make(map[string]tools.Tool)
+	for _, tool := range ts {
+		byName[tool.Name()] = tool
+	}
+
+	got, err := byName["echo"].Call(ctx, json.RawMessage(`{"text":"hi"}`))
+	if err != nil || got != "hi" {
+		t.Errorf("echo = %v, %v", got, err)
+	}
+
+	got, err = byName["weather"].Call(ctx, nil)
+	if raw, ok := got.(json.RawMessage); err != nil || !ok || string(raw) != `{"temp":21}` {
+		t.Errorf("weather = %v, %v", got, err)
+	}
+
+	_, err = byName["fail"].Call(ctx, nil)
+	var toolErr *ToolError
+	if !errors.As(err, &toolErr) || !strings.Contains(err.Error(), "no such city") {
+		t.Errorf("fail error = %v", err)
+	}
+
+	_, err = byName["invalid"].Call(ctx, nil)
+	var rpcErr *Error
+	if !errors.As(err, &rpcErr) || rpcErr.Code != CodeInvalidParams || rpcErr.Message != "city required" {
+		t.Errorf("invalid error = %v", err)
+	}
+
+	_, err = byName["panic"].Call(ctx, nil)
+	if !errors.As(err, &rpcErr) || rpcErr.Code != CodeInternalError || !strings.Contains(rpcErr.Message, "boom") {
+		t.Errorf("panic error = %v", err)
+	}
+
+	_, err = client.CallTool(ctx, "missing", nil)
+	if !errors.As(err, &rpcErr) || rpcErr.Code != CodeInvalidParams {
+		t.Errorf("missing tool error = %v", err)
+	}
+
+	if err := client.Ping(ctx); err != nil {
+		t.Errorf("Ping() error = %v", err)
+	}
+}
+
+func TestServerCancel(t *testing.T) {
+	started, stopped := make(chan struct{}), make(chan struct{})
+	srv := NewServer(newTestRegistry(t, started, stopped))
+	defer srv.Close()
+	client := connectServer(t, srv)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	if _, err := client.CallTool(ctx, "slow", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("CallTool() error = %v, want context.Canceled", err)
+	}
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("tool was not canceled")
+	}
+}
+
+func TestServerNotifyToolsChanged(t *testing.T) {
+	reg := newTestRegistry(t, nil, nil)
+	srv := NewServer(reg)
+	defer srv.Close()
+
+	changed := make(chan struct{}, 1)
+	client := connectServer(t, srv, WithToolsChangedHandler(func() { changed <- struct{}{} }))
+	ctx := context.Background()
+
+	before, err := client.Tools(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reg.Register(&funcTool{name: "added", fn: func(ctx context.Context, args json.RawMessage) (any, error) {
+		return "ok", nil
+	}})
+
+	// The client's event stream opens asynchronously, so notify until the
+	// client hears it
+	deadline := time.After(5 * time.Second)
+	for done := false; !done; {
+		srv.NotifyToolsChanged()
+		select {
+		case <-changed:
+			done = true
+		case <-time.After(50 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("client was not notified")
+		}
+	}
+
+	after, err := client.Tools(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(after) != len(before)+1 {
+		t.Errorf("len(Tools()) = %d, want %d", len(after), len(before)+1)
+	}
+}
+
+const (
+	initializeMessage = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"t","version":"1"}}}`
+	ping              = `{"jsonrpc":"2.0","id":2,"method":"ping"}`
+)
+
+// postMessage POSTs a JSON-RPC message to an MCP server with the given
+// session and extra headers.
+func postMessage(t *testing.T, url, sessionID, body string, header http.Header) *http.Response {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", sessionID)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func TestServerHTTPSessions(t *testing.T) {
+	srv := NewServer(tools.NewRegistry())
+	defer srv.Close()
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	post := func(sessionID, body string) *http.Response {
+		t.Helper()
+		return postMessage(t, ts.URL, sessionID, body, nil)
+	}
+
+	resp := post("", initializeMessage)
+	id := resp.Header.Get("Mcp-Session-Id")
+	if resp.StatusCode != http.StatusOK || id == "" {
+		t.Fatalf("initialize: status %d, session %q", resp.StatusCode, id)
+	}
+
+	if resp := post(id, `{"jsonrpc":"2.0","method":"notifications/initialized"}`); resp.StatusCode != http.StatusAccepted {
+		t.Errorf("notification status = %d, want 202", resp.StatusCode)
+	}
+	if resp := post(id, ping); resp.StatusCode != http.StatusOK {
+		t.Errorf("ping status = %d, want 200", resp.StatusCode)
+	}
+	if resp := post("", ping); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("ping without session status = %d, want 400", resp.StatusCode)
+	}
+	if resp := post("unknown", ping); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("ping with unknown session status = %d, want 404", resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, ts.URL, nil)
+	req.Header.Set("Mcp-Session-Id", id)
+	if resp, err := http.DefaultClient.Do(req); err != nil || resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("DELETE = %v, %v", resp, err)
+	}
+	if resp := post(id, ping); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("ping after DELETE status = %d, want 404", resp.StatusCode)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, ts.URL, nil)
+	if resp, err := http.DefaultClient.Do(req); err != nil || resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET without Accept = %v, %v", resp, err)
+	}
+}
+
+func TestServerHTTPSessionLimits(t *testing.T) {
+	srv := NewServer(tools.NewRegistry(), WithSessionTimeout(50*time.Millisecond), WithMaxSessions(2))
+	defer srv.Close()
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	initialize := func() (string, int) {
+		resp := postMessage(t, ts.URL, "", initializeMessage, nil)
+		return resp.Header.Get("Mcp-Session-Id"), resp.StatusCode
+	}
+
+	first, _ := initialize()
+	second, _ := initialize()
+	if _, status := initialize(); status != http.StatusServiceUnavailable {
+		t.Errorf("initialize over the limit status = %d, want 503", status)
+	}
+
+	// Using a session keeps it alive while the other one expires
+	for range 4 {
+		time.Sleep(20 * time.Millisecond)
+		if resp := postMessage(t, ts.URL, second, ping, nil); resp.StatusCode != http.StatusOK {
+			t.Fatalf("ping status = %d, want 200", resp.StatusCode)
+		}
+	}
+	if resp := postMessage(t, ts.URL, first, ping, nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("ping on idle session status = %d, want 404", resp.StatusCode)
+	}
+	if _, status := initialize(); status != http.StatusOK {
+		t.Errorf("initialize after expiry status = %d, want 200", status)
+	}
+
+	srv.mu.Lock()
+	n := len(srv.sessions)
+	srv.mu.Unlock()
+	if n != 2 {
+		t.Errorf("len(sessions) = %d, want 2", n)
+	}
+}
+
+func TestServerHTTPSessionSweep(t *testing.T) {
+	srv := NewServer(tools.NewRegistry(), WithSessionTimeout(20*time.Millisecond))
+	defer srv.Close()
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	id := postMessage(t, ts.URL, "", initializeMessage, nil).Header.Get("Mcp-Session-Id")
+	srv.mu.Lock()
+	sess, ok := srv.sessions[id]
+	srv.mu.Unlock()
+	if !ok {
+		t.Fatal("session not found after initialize")
+	}
+
+	// No further requests arrive, yet the session ends
+	select {
+	case <-sess.ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("idle session was not ended")
+	}
+	srv.mu.Lock()
+	n, sweeping := len(srv.sessions), srv.sweep != nil
+	srv.mu.Unlock()
+	if n != 0 {
+		t.Errorf("len(sessions) = %d, want 0", n)
+	}
+
+	// The sweep stops once no sessions remain
+	deadline := time.Now().Add(5 * time.Second)
+	for sweeping && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		srv.mu.Lock()
+		sweeping = srv.sweep != nil
+		srv.mu.Unlock()
+	}
+	if sweeping {
+		t.Error("sweep still scheduled with no sessions")
+	}
+}
+
+func TestServerHTTPOrigin(t *testing.T) {
+	srv := NewServer(tools.NewRegistry(), WithAllowedOrigins("https://app.example.com"))
+	defer srv.Close()
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	tests := []struct {
+		origin string
+		want   int
+	}{
+		{"", http.StatusOK},
+		{"https://app.example.com", http.StatusOK},
+		{ts.URL, http.StatusOK},
+		{"https://evil.example.com", http.StatusForbidden},
+		{"http://localhost:1", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		header := http.Header{}
+		if tt.origin != "" {
+			header.Set("Origin", tt.origin)
+		}
+		if resp := postMessage(t, ts.URL, "", initializeMessage, header); resp.StatusCode != tt.want {
+			t.Errorf("Origin %q: status = %d, want %d", tt.origin, resp.StatusCode, tt.want)
+		}
+	}
+
+	srv = NewServer(tools.NewRegistry(), WithAllowedOrigins("*"))
+	defer srv.Close()
+	ts = httptest.NewServer(srv)
+	defer ts.Close()
+	if resp := postMessage(t, ts.URL, "", initializeMessage, http.Header{"Origin": {"https://any.example.com"}}); resp.StatusCode != http.StatusOK {
+		t.Errorf("Origin with * allowed: status = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestServerStdio(t *testing.T) {
+	srv := NewServer(newTestRegistry(t, nil, nil))
+	defer srv.Close()
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.ServeStdio(context.Background(), inR, outW)
+		outW.Close()
+	}()
+
+	out := bufio.NewScanner(outR)
+	roundTrip := func(line string) message {
+		t.Helper()
+		if _, err := io.WriteString(inW, line+"\n"); err != nil {
+			t.Fatal(err)
+		}
+		if !out.Scan() {
+			t.Fatalf("no response to %s", line)
+		}
+		var msg message
+		if err := json.Unmarshal(out.Bytes(), &msg); err != nil {
+			t.Fatalf("decode %s: %v", out.Bytes(), err)
+		}
+		return msg
+	}
+
+	tests := []struct {
+		name     string
+		line     string
+		wantID   string
+		wantCode int
+	}{
+		{"parse error", `{not json`, "null", CodeParseError},
+		{"bad version", `{"jsonrpc":"1.0","id":1,"method":"ping"}`, "1", CodeInvalidRequest},
+		{"unknown method", `{"jsonrpc":"2.0","id":"a","method":"resources/list"}`, `"a"`, CodeMethodNotFound},
+		{"bad params", `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":[1]}`, "3", CodeInvalidParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := roundTrip(tt.line)
+			if string(msg.ID) != tt.wantID {
+				t.Errorf("id = %s, want %s", msg.ID, tt.wantID)
+			}
+			if msg.Error == nil || msg.Error.Code != tt.wantCode {
+				t.Errorf("error = %+v, want code %d", msg.Error, tt.wantCode)
+			}
+		})
+	}
+
+	msg := roundTrip(`{"jsonrpc":"2.0","id":4,"method":"initialize","params":{"protocolVersion":"1999-01-01","capabilities":{},"clientInfo":{"name":"t","version":"1"}}}`)
+	var init initializeResult
+	json.Unmarshal(msg.Result, &init)
+	if init.ProtocolVersion != ProtocolVersion || init.ServerInfo.Name != "iris" {
+		t.Errorf("initialize result = %+v", init)
+	}
+
+	msg = roundTrip(`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"echo","arguments":{"text":"hi"}}}`)
+	var result CallToolResult
+	json.Unmarshal(msg.Result, &result)
+	if len(result.Content) != 1 || result.Content[0].Text != "hi" || result.IsError {
+		t.Errorf("echo result = %s", msg.Result)
+	}
+
+	// Notifications get no response, so the next line answers the ping
+	if msg := roundTrip(`{"jsonrpc":"2.0","method":"notifications/initialized"}` + "\n" + `{"jsonrpc":"2.0","id":6,"method":"ping"}`); string(msg.ID) != "6" {
+		t.Errorf("ping id = %s", msg.ID)
+	}
+
+	inW.Close()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("ServeStdio() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServeStdio() did not return at EOF")
+	}
+}
+
+func TestServerDuplicateRequestID(t *testing.T) {
+	started, stopped := make(chan struct{}), make(chan struct{})
+	srv := NewServer(newTestRegistry(t, started, stopped))
+	defer srv.Close()
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	go func() {
+		srv.ServeStdio(context.Background(), inR, outW)
+		outW.Close()
+	}()
+	defer inW.Close()
+	out := bufio.NewScanner(outR)
+
+	io.WriteString(inW, `{"jsonrpc":"2.0","id":9,"method":"tools/call","params":{"name":"slow"}}`+"\n")
+	<-started
+
+	// Reusing the ID is refused, and the first request keeps running
+	io.WriteString(inW, `{"jsonrpc":"2.0","id":9,"method":"ping"}`+"\n")
+	if !out.Scan() {
+		t.Fatal("no response to the duplicate request")
+	}
+	var msg message
+	json.Unmarshal(out.Bytes(), &msg)
+	if string(msg.ID) != "9" || msg.Error == nil || msg.Error.Code != CodeInvalidRequest {
+		t.Errorf("response = %s, want invalid request error", out.Bytes())
+	}
+
+	// The first request is still registered, so it can be canceled
+	io.WriteString(inW, `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":9}}`+"\n")
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("first request was not canceled")
+	}
+}
+
+func TestToolResult(t *testing.T) {
+	tests := []struct {
+		name           string
+		value          any
+		wantText       string
+		wantStructured string
+	}{
+		{"string", "hello", "hello", ""},
+		{"map", map[string]int{"a": 1}, `{"a":1}`, `{"a":1}`},
+		{"raw object", json.RawMessage(`{"b":2}`), `{"b":2}`, `{"b":2}`},
+		{"number", 42, "42", ""},
+		{"slice", []string{"x"}, `["x"]`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := toolResult(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(result.Content) != 1 || result.Content[0].Text != tt.wantText {
+				t.Errorf("content = %+v, want text %s", result.Content, tt.wantText)
+			}
+			if string(result.StructuredContent) != tt.wantStructured {
+				t.Errorf("structured = %s, want %s", result.StructuredContent, tt.wantStructured)
+			}
+		})
+	}
+
+	if result, _ := toolResult(nil); result.Content == nil || len(result.Content) != 0 {
+		t.Errorf("nil result content = %#v, want empty", result.Content)
+	}
+}
@@ -0,0 +1,98 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/erikhoward/iris/tools"
+)
+
+// Tool is a tool offered by an MCP server.
+type Tool struct {
+	client *Client
+	info   ToolInfo
+}
+
+// Name returns the tool's name, with the client's tool prefix if one is
+// set.
+func (t *Tool) Name() string {
+	return t.client.cfg.toolPrefix + t.info.Name
+}
+
+// Description returns the tool's description, or its title if it has no
+// description.
+func (t *Tool) Description() string {
+	if t.info.Description == "" {
+		return t.info.Title
+	}
+	return t.info.Description
+}
+
+// Schema returns the tool's input schema.
+func (t *Tool) Schema() tools.ToolSchema {
+	return tools.ToolSchema{JSONSchema: t.info.InputSchema}
+}
+
+// Info returns the tool's description as sent by the server.
+func (t *Tool) Info() ToolInfo {
+	return t.info
+}
+
+// Call calls the tool on the server. Structured content is returned as
+// json.RawMessage, text-only content as a string, and any other content as
+// []Content. A result the server marks as an error is returned as a
+// *ToolError.
+func (t *Tool) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	result, err := t.client.CallTool(ctx, t.info.Name, args)
+	if err != nil {
+		return nil, err
+	}
+	if result.IsError {
+		return nil, &ToolError{Tool: t.Name(), Content: result.Content}
+	}
+	return resultValue(result), nil
+}
+
+// resultValue returns the most specific Go value for a tool result.
+func resultValue(result *CallToolResult) any {
+	if len(result.StructuredContent) > 0 && string(result.StructuredContent) != "null" {
+		return result.StructuredContent
+	}
+	if text, ok := textContent(result.Content); ok {
+		return text
+	}
+	return result.Content
+}
+
+// textContent joins the text of content, reporting false if any item is
+// not text.
+func textContent(content []Content) (string, bool) {
+	texts := make([]string, 0, len(content))
+	for _, c := range content {
+		if c.Type != "text" {
+			return "", false
+		}
+		texts = append(texts, c.Text)
+	}
+	return strings.Join(texts, "\n"), true
+}
+
+// ToolError is returned by Tool.Call when the server reports that the tool
+// failed.
+type ToolError struct {
+	Tool    string
+	Content []Content
+}
+
+// Error implements the error interface.
+func (e *ToolError) Error() string {
+	if text, ok := textContent(e.Content); ok && text != "" {
+		return fmt.Sprintf("mcp: tool %s failed: %s", e.Tool, text)
+	}
+	return fmt.Sprintf("mcp: tool %s failed", e.Tool)
+}
+
+// Compile-time check that Tool implements tools.Tool.
+var _ tools.Tool = (*Tool)(nil)
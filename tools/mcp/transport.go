@@ -0,0 +1,405 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned for requests on a closed connection.
+var ErrClosed = errors.New("mcp: connection closed")
+
+// maxMessageSize bounds a single message read from a server.
+const maxMessageSize = 16 << 20
+
+// transport carries JSON-RPC messages between the client and a server.
+type transport interface {
+	// send delivers a message to the server. Replies may arrive on
+	// messages before or after send returns.
+	send(ctx context.Context, msg []byte) error
+
+	// messages returns the messages received from the server.
+	messages() <-chan []byte
+
+	// closed is closed when the connection ends. Messages received before
+	// then may still be queued on messages.
+	closed() <-chan struct{}
+
+	// err returns why the connection ended, once closed is closed.
+	err() error
+
+	// close ends the connection.
+	close() error
+}
+
+// inbox is the receiving side shared by the transports: the queue of
+// received messages, and the reason the connection ended.
+type inbox struct {
+	ch       chan []byte
+	done     chan struct{}
+	once     sync.Once
+	mu       sync.Mutex
+	closeErr error
+}
+
+func newInbox() *inbox {
+	return &inbox{ch: make(chan []byte, 16), done: make(chan struct{})}
+}
+
+// deliver queues a message, dropping it if the connection has ended.
+func (in *inbox) deliver(msg []byte) {
+	select {
+	case in.ch <- msg:
+	case <-in.done:
+	}
+}
+
+// finish ends the connection with err, which may be nil.
+func (in *inbox) finish(err error) {
+	in.once.Do(func() {
+		in.mu.Lock()
+		in.closeErr = err
+		in.mu.Unlock()
+		close(in.done)
+	})
+}
+
+func (in *inbox) messages() <-chan []byte {
+	return in.ch
+}
+
+func (in *inbox) closed() <-chan struct{} {
+	return in.done
+}
+
+func (in *inbox) err() error {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.closeErr
+}
+
+// stdioTransport talks to a subprocess over newline-delimited JSON on its
+// stdin and stdout.
+type stdioTransport struct {
+	*inbox
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	wmu   sync.Mutex
+}
+
+// newStdioTransport starts cmd and reads messages from its stdout.
+func newStdioTransport(cmd *exec.Cmd) (*stdioTransport, error) {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("mcp: start server: %w", err)
+	}
+
+	t := &stdioTransport{inbox: newInbox(), cmd: cmd, stdin: stdin}
+	go t.read(stdout)
+	return t, nil
+}
+
+// read forwards each line of the server's stdout until it closes.
+func (t *stdioTransport) read(stdout io.Reader) {
+	r := bufio.NewReaderSize(stdout, 64*1024)
+	for {
+		line, err := readLine(r)
+		if len(line) > 0 {
+			t.deliver(line)
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = ErrClosed
+			}
+			t.finish(err)
+			return
+		}
+	}
+}
+
+// readLine reads one trimmed line of at most maxMessageSize bytes.
+func readLine(r *bufio.Reader) ([]byte, error) {
+	var line []byte
+	for {
+		chunk, err := r.ReadSlice('\n')
+		line = append(line, chunk...)
+		if len(line) > maxMessageSize {
+			return nil, fmt.Errorf("mcp: message exceeds %d bytes", maxMessageSize)
+		}
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		}
+		return bytes.TrimSpace(line), err
+	}
+}
+
+func (t *stdioTransport) send(ctx context.Context, msg []byte) error {
+	t.wmu.Lock()
+	defer t.wmu.Unlock()
+
+	select {
+	case <-t.done:
+		return ErrClosed
+	default:
+	}
+	_, err := t.stdin.Write(append(msg, '\n'))
+	return err
+}
+
+// close closes the server's stdin and waits for it to exit, killing it if
+// it does not exit promptly.
+func (t *stdioTransport) close() error {
+	t.finish(ErrClosed)
+	t.stdin.Close()
+
+	exited := make(chan error, 1)
+	go func() { exited <- t.cmd.Wait() }()
+	select {
+	case <-exited:
+	case <-time.After(5 * time.Second):
+		t.cmd.Process.Kill()
+		<-exited
+	}
+	return nil
+}
+
+// httpTransport talks to a server over the streamable HTTP transport:
+// each message is POSTed to the endpoint, and the server answers with a
+// JSON body or an event stream. A GET event stream carries messages the
+// server sends on its own, such as notifications.
+type httpTransport struct {
+	*inbox
+	url     string
+	client  *http.Client
+	headers http.Header
+
+	// ctx is canceled on close, ending every open event stream
+	ctx     context.Context
+	cancel  context.CancelFunc
+	streams sync.WaitGroup
+
+	mu              sync.Mutex
+	sessionID       string
+	protocolVersion string
+}
+
+func newHTTPTransport(url string, client *http.Client, headers http.Header) *httpTransport {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &httpTransport{
+		inbox:   newInbox(),
+		url:     url,
+		client:  client,
+		headers: headers,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+func (t *httpTransport) send(ctx context.Context, msg []byte) error {
+	select {
+	case <-t.done:
+		return ErrClosed
+	default:
+	}
+
+	// The request ends with ctx or when the transport closes
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(t.ctx, cancel)
+	release := func() {
+		stop()
+		cancel()
+	}
+
+	req, err := t.newRequest(ctx, http.MethodPost, bytes.NewReader(msg))
+	if err != nil {
+		release()
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		release()
+		return fmt.Errorf("mcp: %w", err)
+	}
+
+	if id := resp.Header.Get("Mcp-Session-Id"); id != "" {
+		t.mu.Lock()
+		t.sessionID = id
+		t.mu.Unlock()
+	}
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") && resp.StatusCode < 300 {
+		t.stream(resp.Body, release)
+		return nil
+	}
+
+	defer release()
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 400:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("mcp: server returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+
+	case resp.StatusCode == http.StatusAccepted:
+		return nil
+
+	default:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxMessageSize))
+		if err != nil {
+			return fmt.Errorf("mcp: %w", err)
+		}
+		if body = bytes.TrimSpace(body); len(body) > 0 {
+			t.deliver(body)
+		}
+		return nil
+	}
+}
+
+// setProtocolVersion sets the version sent on requests after the
+// handshake.
+func (t *httpTransport) setProtocolVersion(version string) {
+	t.mu.Lock()
+	t.protocolVersion = version
+	t.mu.Unlock()
+}
+
+// listen opens the GET event stream for messages the server sends on its
+// own. Servers that do not offer one answer 405, which is not an error.
+func (t *httpTransport) listen() {
+	req, err := t.newRequest(t.ctx, http.MethodGet, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return
+	}
+
+	t.stream(resp.Body, func() {})
+}
+
+// stream reads events from body until it ends or the transport closes,
+// then calls release.
+func (t *httpTransport) stream(body io.ReadCloser, release func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	select {
+	case <-t.done:
+		body.Close()
+		release()
+		return
+	default:
+	}
+
+	t.streams.Add(1)
+	go func() {
+		defer t.streams.Done()
+		defer release()
+		defer body.Close()
+		t.readEvents(body)
+	}()
+}
+
+// newRequest creates a request to the endpoint with the configured
+// headers and the session's headers.
+func (t *httpTransport) newRequest(ctx context.Context, method string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, t.url, body)
+	if err != nil {
+		return nil, fmt.Errorf("mcp: %w", err)
+	}
+	for key, values := range t.headers {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", t.sessionID)
+	}
+	if t.protocolVersion != "" {
+		req.Header.Set("MCP-Protocol-Version", t.protocolVersion)
+	}
+	return req, nil
+}
+
+// readEvents delivers the data of each server-sent event in body.
+func (t *httpTransport) readEvents(body io.Reader) {
+	r := bufio.NewReaderSize(body, 64*1024)
+	var data []byte
+	for {
+		line, err := readLine(r)
+		switch {
+		case len(line) == 0:
+			// A blank line ends the event
+			if len(data) > 0 {
+				t.deliver(data)
+				data = nil
+			}
+		case bytes.HasPrefix(line, []byte("data:")):
+			if len(data) > 0 {
+				data = append(data, '\n')
+			}
+			data = append(data, bytes.TrimSpace(line[len("data:"):])...)
+		}
+		if err != nil {
+			if len(data) > 0 {
+				t.deliver(data)
+			}
+			return
+		}
+	}
+}
+
+// close ends the session. The server is asked to delete the session if it
+// assigned one.
+func (t *httpTransport) close() error {
+	t.mu.Lock()
+	sessionID := t.sessionID
+	t.mu.Unlock()
+
+	if sessionID != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if req, err := t.newRequest(ctx, http.MethodDelete, nil); err == nil {
+			if resp, err := t.client.Do(req); err == nil {
+				resp.Body.Close()
+			}
+		}
+		cancel()
+	}
+
+	// No stream starts once the transport is finished
+	t.mu.Lock()
+	t.finish(ErrClosed)
+	t.mu.Unlock()
+
+	t.cancel()
+	t.streams.Wait()
+	return nil
+}
@@ -0,0 +1,29 @@
+// Package openapi generates tools from OpenAPI 3.x documents, one
+// tools.Tool per operation, so that models can call REST services without
+// a hand-written wrapper per endpoint.
+//
+// Parse reads a document in JSON or YAML, and LoadFile reads one from
+// disk. Local references are resolved; external and recursive references
+// become schemas that accept any value.
+//
+//	doc, err := openapi.LoadFile("petstore.yaml")
+//	if err != nil {
+//	    return err
+//	}
+//	ts, err := doc.Tools(
+//	    openapi.WithBaseURL("https://petstore.internal/v1"),
+//	    openapi.WithBearerToken(token),
+//	    openapi.WithTags("pets"),
+//	)
+//
+// Each tool's schema holds the operation's path, query, header, and cookie
+// parameters by name, leaving out Authorization and the headers set with
+// WithHeader so the model cannot replace the configured credentials. An
+// object request body's properties are merged in
+// when their names do not clash with a parameter; any other body is the
+// "body" argument. Call sends the request and decodes a JSON response;
+// error statuses are returned as a *StatusError.
+//
+// Request bodies are sent as JSON or as URL-encoded forms. Operations with
+// other body types, such as multipart uploads, are skipped.
+package openapi
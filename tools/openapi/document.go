@@ -0,0 +1,416 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is an OpenAPI 3.x document reduced to what is needed to call
+// its operations. References within the document are resolved.
+type Document struct {
+	Title   string
+	Version string
+
+	// Servers are the document's server URLs, with variables replaced by
+	// their defaults.
+	Servers []string
+
+	// Operations are sorted by path, then by method.
+	Operations []*Operation
+}
+
+// Operation is one HTTP operation of a document.
+type Operation struct {
+	// ID is the operationId, or one derived from the method and path.
+	ID          string
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Tags        []string
+	Deprecated  bool
+
+	// Parameters are the path, query, header, and cookie parameters,
+	// including those declared on the path.
+	Parameters []Parameter
+
+	// Body is the request body, or nil if the operation takes none.
+	Body *RequestBody
+}
+
+// Parameter is a parameter of an operation.
+type Parameter struct {
+	Name        string
+	In          string // "path", "query", "header", or "cookie"
+	Description string
+	Required    bool
+	Schema      json.RawMessage
+}
+
+// RequestBody is the request body of an operation.
+type RequestBody struct {
+	ContentType string
+	Description string
+	Required    bool
+	Schema      json.RawMessage
+}
+
+// methods are the operation methods of a path item, in sort order.
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// LoadFile reads the OpenAPI document at path.
+func LoadFile(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return doc, nil
+}
+
+// Parse parses an OpenAPI 3.x document in JSON or YAML.
+func Parse(data []byte) (*Document, error) {
+	var node any
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, &node); err != nil {
+			return nil, fmt.Errorf("openapi: invalid document: %w", err)
+		}
+	} else if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("openapi: invalid document: %w", err)
+	}
+	root, ok := normalize(node).(map[string]any)
+	if !ok {
+		return nil, errors.New("openapi: invalid document: not an object")
+	}
+
+	version, _ := root["openapi"].(string)
+	if !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("openapi: unsupported version %q: only OpenAPI 3.x is supported", version)
+	}
+
+	r := &resolver{root: root}
+	var raw struct {
+		Info struct {
+			Title   string `json:"title"`
+			Version string `json:"version"`
+		} `json:"info"`
+		Servers []rawServer `json:"servers"`
+	}
+	if err := remarshal(root, &raw); err != nil {
+		return nil, fmt.Errorf("openapi: invalid document: %w", err)
+	}
+
+	doc := &Document{Title: raw.Info.Title, Version: raw.Info.Version}
+	for _, s := range raw.Servers {
+		doc.Servers = append(doc.Servers, s.url())
+	}
+
+	paths, _ := root["paths"].(map[string]any)
+	pathNames := make([]string, 0, len(paths))
+	for path := range paths {
+		pathNames = append(pathNames, path)
+	}
+	slices.Sort(pathNames)
+
+	for _, path := range pathNames {
+		item, ok := r.resolve(paths[path]).(map[string]any)
+		if !ok {
+			continue
+		}
+		var shared []rawParameter
+		if err := remarshal(item["parameters"], &shared); err != nil {
+			return nil, fmt.Errorf("openapi: %s: invalid parameters: %w", path, err)
+		}
+		for _, method := range methods {
+			node, ok := item[method]
+			if !ok {
+				continue
+			}
+			op, err := parseOperation(method, path, node, shared)
+			if err != nil {
+				return nil, fmt.Errorf("openapi: %s %s: %w", strings.ToUpper(method), path, err)
+			}
+			doc.Operations = append(doc.Operations, op)
+		}
+	}
+	return doc, nil
+}
+
+// rawServer is a server object.
+type rawServer struct {
+	URL       string `json:"url"`
+	Variables map[string]struct {
+		Default string `json:"default"`
+	} `json:"variables"`
+}
+
+// url returns the server URL with variables replaced by their defaults.
+func (s rawServer) url() string {
+	u := s.URL
+	for name, v := range s.Variables {
+		u = strings.ReplaceAll(u, "{"+name+"}", v.Default)
+	}
+	return u
+}
+
+// rawOperation is an operation object.
+type rawOperation struct {
+	OperationID string          `json:"operationId"`
+	Summary     string          `json:"summary"`
+	Description string          `json:"description"`
+	Tags        []string        `json:"tags"`
+	Deprecated  bool            `json:"deprecated"`
+	Parameters  []rawParameter  `json:"parameters"`
+	RequestBody *rawRequestBody `json:"requestBody"`
+}
+
+// rawParameter is a parameter object.
+type rawParameter struct {
+	Name        string                  `json:"name"`
+	In          string                  `json:"in"`
+	Description string                  `json:"description"`
+	Required    bool                    `json:"required"`
+	Schema      json.RawMessage         `json:"schema"`
+	Content     map[string]rawMediaType `json:"content"`
+}
+
+// rawRequestBody is a request body object.
+type rawRequestBody struct {
+	Description string                  `json:"description"`
+	Required    bool                    `json:"required"`
+	Content     map[string]rawMediaType `json:"content"`
+}
+
+// rawMediaType is a media type object.
+type rawMediaType struct {
+	Schema json.RawMessage `json:"schema"`
+}
+
+// parseOperation parses the operation for method on path. Parameters
+// declared on the operation override those shared by the path.
+func parseOperation(method, path string, node any, shared []rawParameter) (*Operation, error) {
+	var raw rawOperation
+	if err := remarshal(node, &raw); err != nil {
+		return nil, err
+	}
+
+	op := &Operation{
+		ID:          raw.OperationID,
+		Method:      strings.ToUpper(method),
+		Path:        path,
+		Summary:     raw.Summary,
+		Description: raw.Description,
+		Tags:        raw.Tags,
+		Deprecated:  raw.Deprecated,
+	}
+	if op.ID == "" {
+		// For example, "get_pets_petId" for GET /pets/{petId}
+		op.ID = strings.Trim(invalidNameChars.ReplaceAllString(method+path, "_"), "_")
+	}
+
+	params := slices.Clone(shared)
+	for _, p := range raw.Parameters {
+		i := slices.IndexFunc(params, func(q rawParameter) bool { return q.Name == p.Name && q.In == p.In })
+		if i >= 0 {
+			params[i] = p
+		} else {
+			params = append(params, p)
+		}
+	}
+	for _, p := range params {
+		if p.Name == "" || p.In == "" {
+			return nil, errors.New("parameter without name or location")
+		}
+		schema := p.Schema
+		if len(schema) == 0 {
+			// Parameters may describe their schema by media type instead
+			for _, mt := range p.Content {
+				schema = mt.Schema
+				break
+			}
+		}
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:        p.Name,
+			In:          p.In,
+			Description: p.Description,
+			Required:    p.Required || p.In == "path",
+			Schema:      schema,
+		})
+	}
+
+	if raw.RequestBody != nil && len(raw.RequestBody.Content) > 0 {
+		contentType, mt := pickMediaType(raw.RequestBody.Content)
+		op.Body = &RequestBody{
+			ContentType: contentType,
+			Description: raw.RequestBody.Description,
+			Required:    raw.RequestBody.Required,
+			Schema:      mt.Schema,
+		}
+	}
+	return op, nil
+}
+
+// pickMediaType chooses the request body media type to send: JSON if
+// offered, else a form, else the first in sort order.
+func pickMediaType(content map[string]rawMediaType) (string, rawMediaType) {
+	types := make([]string, 0, len(content))
+	for t := range content {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+
+	for _, want := range []func(string) bool{
+		func(t string) bool { return t == "application/json" },
+		isJSON,
+		isForm,
+	} {
+		for _, t := range types {
+			if want(t) {
+				return t, content[t]
+			}
+		}
+	}
+	return types[0], content[types[0]]
+}
+
+// isJSON reports whether a media type is JSON.
+func isJSON(mediaType string) bool {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// isForm reports whether a media type is a URL-encoded form.
+func isForm(mediaType string) bool {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	return strings.TrimSpace(mediaType) == "application/x-www-form-urlencoded"
+}
+
+// resolver replaces references within a document by their targets.
+type resolver struct {
+	root map[string]any
+}
+
+// resolve returns a copy of node with every local reference replaced by
+// its target. A reference that is external, dangling, or recursive
+// becomes an empty schema, which accepts any value.
+func (r *resolver) resolve(node any) any {
+	return r.resolveIn(node, nil)
+}
+
+func (r *resolver) resolveIn(node any, stack []string) any {
+	switch n := node.(type) {
+	case map[string]any:
+		if ref, ok := n["$ref"].(string); ok {
+			if slices.Contains(stack, ref) {
+				return map[string]any{}
+			}
+			target, ok := r.lookup(ref)
+			if !ok {
+				return map[string]any{}
+			}
+			return r.resolveIn(target, append(stack, ref))
+		}
+		out := make(map[string]any, len(n))
+		for k, v := range n {
+			out[k] = r.resolveIn(v, stack)
+		}
+		return out
+	case []any:
+		out := make([]any, len(n))
+		for i, v := range n {
+			out[i] = r.resolveIn(v, stack)
+		}
+		return out
+	default:
+		return node
+	}
+}
+
+// lookup finds the target of a local reference such as
+// "#/components/schemas/Pet".
+func (r *resolver) lookup(ref string) (any, bool) {
+	pointer, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil, false
+	}
+	var node any = r.root
+	for _, token := range strings.Split(pointer, "/") {
+		if unescaped, err := url.PathUnescape(token); err == nil {
+			token = unescaped
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if node, ok = m[token]; !ok {
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+// normalize converts the maps decoded from YAML, which may have
+// non-string keys such as response codes, to map[string]any.
+func normalize(node any) any {
+	switch n := node.(type) {
+	case map[string]any:
+		for k, v := range n {
+			n[k] = normalize(v)
+		}
+		return n
+	case map[any]any:
+		out := make(map[string]any, len(n))
+		for k, v := range n {
+			out[fmt.Sprint(k)] = normalize(v)
+		}
+		return out
+	case []any:
+		for i, v := range n {
+			n[i] = normalize(v)
+		}
+		return n
+	default:
+		return node
+	}
+}
+
+// remarshal decodes a generic node into v through JSON.
+func remarshal(node any, v any) error {
+	if node == nil {
+		return nil
+	}
+	data, err := json.Marshal(node)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// invalidNameChars matches characters not allowed in tool names.
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// toolName returns a tool name for an operation ID: runs of characters
+// other than letters, digits, '_', and '-' become '_', and the name is
+// limited to 64 characters.
+func toolName(id string) string {
+	name := strings.Trim(invalidNameChars.ReplaceAllString(id, "_"), "_")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
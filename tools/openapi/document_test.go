@@ -0,0 +1,247 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// petstore is an OpenAPI document exercising references, shared
+// parameters, and each kind of request body.
+const petstore = `
+openapi: 3.0.3
+info:
+  title: Petstore
+  version: 1.2.0
+servers:
+  - url: https://{env}.example.com/v1
+    variables:
+      env:
+        default: api
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List pets
+      tags: [pets]
+      parameters:
+        - name: limit
+          in: query
+          description: Maximum number of pets
+          schema: {type: integer}
+        - name: tag
+          in: query
+          schema: {type: array, items: {type: string}}
+      responses:
+        200:
+          description: The pets
+    post:
+      operationId: createPet
+      summary: Create a pet
+      description: Adds a pet to the store.
+      tags: [pets]
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Pet'}
+      responses:
+        201:
+          description: Created
+  /pets/{petId}:
+    parameters:
+      - $ref: '#/components/parameters/PetID'
+    get:
+      operationId: showPetById
+      tags: [pets]
+      parameters:
+        - name: X-Trace
+          in: header
+          schema: {type: string}
+      responses:
+        200:
+          description: The pet
+    delete:
+      tags: [admin]
+      responses:
+        204:
+          description: Deleted
+  /pets/{petId}/photo:
+    put:
+      operationId: uploadPhoto
+      parameters:
+        - $ref: '#/components/parameters/PetID'
+      requestBody:
+        content:
+          multipart/form-data:
+            schema: {type: object}
+      responses:
+        204:
+          description: Uploaded
+  /login:
+    post:
+      operationId: login
+      tags: [auth]
+      requestBody:
+        content:
+          application/x-www-form-urlencoded:
+            schema:
+              type: object
+              properties:
+                user: {type: string}
+                password: {type: string}
+      responses:
+        200:
+          description: Logged in
+  /search:
+    post:
+      operationId: search
+      parameters:
+        - name: body
+          in: query
+          schema: {type: string}
+      requestBody:
+        description: Search terms
+        content:
+          application/vnd.search+json:
+            schema: {type: array, items: {type: string}}
+      responses:
+        200:
+          description: Results
+components:
+  parameters:
+    PetID:
+      name: petId
+      in: path
+      description: The pet's ID
+      schema: {type: integer}
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+        parent: {$ref: '#/components/schemas/Pet'}
+`
+
+func TestParse(t *testing.T) {
+	doc, err := Parse([]byte(petstore))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if doc.Title != "Petstore" || doc.Version != "1.2.0" {
+		t.Errorf("Title, Version = %q, %q", doc.Title, doc.Version)
+	}
+	if len(doc.Servers) != 1 || doc.Servers[0] != "https://api.example.com/v1" {
+		t.Errorf("Servers = %v", doc.Servers)
+	}
+
+	var ids []string
+	for _, op := range doc.Operations {
+		ids = append(ids, op.Method+" "+op.ID)
+	}
+	want := "POST login,GET listPets,POST createPet,GET showPetById,DELETE delete_pets_petId,PUT uploadPhoto,POST search"
+	if got := strings.Join(ids, ","); got != want {
+		t.Errorf("operations = %s, want %s", got, want)
+	}
+
+	show := doc.Operations[3]
+	if len(show.Parameters) != 2 {
+		t.Fatalf("showPetById parameters = %+v", show.Parameters)
+	}
+	if p := show.Parameters[0]; p.Name != "petId" || p.In != "path" || !p.Required || string(p.Schema) != `{"type":"integer"}` {
+		t.Errorf("shared parameter = %+v", p)
+	}
+	if p := show.Parameters[1]; p.Name != "X-Trace" || p.In != "header" || p.Required {
+		t.Errorf("header parameter = %+v", p)
+	}
+
+	create := doc.Operations[2]
+	if create.Body == nil || create.Body.ContentType != "application/json" || !create.Body.Required {
+		t.Fatalf("createPet body = %+v", create.Body)
+	}
+	// The recursive reference becomes an empty schema
+	if !strings.Contains(string(create.Body.Schema), `"parent":{}`) {
+		t.Errorf("createPet body schema = %s", create.Body.Schema)
+	}
+
+	if upload := doc.Operations[5]; upload.Body == nil || upload.Body.ContentType != "multipart/form-data" {
+		t.Errorf("uploadPhoto body = %+v", upload.Body)
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	data := `{
+	"openapi": "3.1.0",
+	"info": {"title": "Tiny", "version": "1"},
+	"paths": {
+		"/ping": {"get": {"operationId": "ping", "responses": {"200": {"description": "pong"}}}}
+	}
+}`
+	doc, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(doc.Operations) != 1 || doc.Operations[0].ID != "ping" || doc.Operations[0].Method != "GET" {
+		t.Errorf("Operations = %+v", doc.Operations)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"swagger", "swagger: '2.0'\ninfo: {title: Old}\n", "unsupported version"},
+		{"not an object", "- a\n- b\n", "not an object"},
+		{"invalid yaml", "openapi: [3.0", "invalid document"},
+		{"invalid json", `{"openapi": `, "invalid document"},
+		{"unnamed parameter", "openapi: 3.0.0\npaths:\n  /a:\n    get:\n      parameters:\n        - in: query\n", "parameter without name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "petstore.yaml")
+	if err := os.WriteFile(path, []byte(petstore), 0644); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if doc.Title != "Petstore" {
+		t.Errorf("Title = %q", doc.Title)
+	}
+
+	if _, err := LoadFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("LoadFile() of a missing file should fail")
+	}
+}
+
+func TestToolName(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"listPets", "listPets"},
+		{"delete/pets/{petId}", "delete_pets_petId"},
+		{"users.get-by.id", "users_get-by_id"},
+		{strings.Repeat("a", 70), strings.Repeat("a", 64)},
+	}
+	for _, tt := range tests {
+		if got := toolName(tt.id); got != tt.want {
+			t.Errorf("toolName(%q) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,562 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/erikhoward/iris/tools"
+)
+
+// maxResponseSize bounds the response body read by a tool call.
+const maxResponseSize = 10 << 20
+
+// config holds the settings of generated tools.
+type config struct {
+	baseURL    string
+	httpClient *http.Client
+	headers    http.Header
+	query      url.Values
+	editors    []func(*http.Request) error
+	tags       []string
+	operations []string
+	toolPrefix string
+}
+
+// Option configures the tools generated from a document.
+type Option func(*config)
+
+// WithBaseURL sets the URL the operation paths are relative to. The
+// default is the document's first server URL.
+func WithBaseURL(baseURL string) Option {
+	return func(c *config) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to call operations.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) {
+		c.httpClient = client
+	}
+}
+
+// WithHeader adds a header to every request, such as an API key header.
+// Header parameters of the same name are left out of the tools' schemas,
+// so the model cannot override it.
+func WithHeader(key, value string) Option {
+	return func(c *config) {
+		c.headers.Add(key, value)
+	}
+}
+
+// WithQueryParam adds a query parameter to every request, such as an API
+// key parameter.
+func WithQueryParam(key, value string) Option {
+	return func(c *config) {
+		c.query.Add(key, value)
+	}
+}
+
+// WithBearerToken sends token in the Authorization header of every
+// request.
+func WithBearerToken(token string) Option {
+	return WithHeader("Authorization", "Bearer "+token)
+}
+
+// WithBasicAuth sends HTTP basic authentication with every request.
+func WithBasicAuth(username, password string) Option {
+	return WithRequestEditor(func(req *http.Request) error {
+		req.SetBasicAuth(username, password)
+		return nil
+	})
+}
+
+// WithRequestEditor sets a function called on every request before it is
+// sent, for authentication schemes such as request signing. Editors run in
+// the order given; an error fails the call.
+func WithRequestEditor(fn func(*http.Request) error) Option {
+	return func(c *config) {
+		c.editors = append(c.editors, fn)
+	}
+}
+
+// WithTags limits the tools to operations with at least one of tags.
+func WithTags(tags ...string) Option {
+	return func(c *config) {
+		c.tags = append(c.tags, tags...)
+	}
+}
+
+// WithOperations limits the tools to the operations with the given IDs.
+func WithOperations(ids ...string) Option {
+	return func(c *config) {
+		c.operations = append(c.operations, ids...)
+	}
+}
+
+// WithToolPrefix prefixes the tool names, to keep tools from several
+// documents apart.
+func WithToolPrefix(prefix string) Option {
+	return func(c *config) {
+		c.toolPrefix = prefix
+	}
+}
+
+// ownsHeader reports whether the header is set by the configuration rather
+// than by the model: Authorization, and any header added with WithHeader.
+func (c *config) ownsHeader(name string) bool {
+	key := http.CanonicalHeaderKey(name)
+	_, ok := c.headers[key]
+	return ok || key == "Authorization"
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		httpClient: http.DefaultClient,
+		headers:    make(http.Header),
+		query:      make(url.Values),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Tools returns a tool for each of the document's operations, or for those
+// selected by WithTags and WithOperations. Operations whose request body
+// is neither JSON nor a URL-encoded form are skipped, unless named by
+// WithOperations, which is an error.
+func (d *Document) Tools(opts ...Option) ([]tools.Tool, error) {
+	cfg := newConfig(opts)
+
+	baseURL := cfg.baseURL
+	if baseURL == "" && len(d.Servers) > 0 {
+		baseURL = d.Servers[0]
+	}
+	if u, err := url.Parse(baseURL); err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, errors.New("openapi: no absolute base URL in the document: use WithBaseURL")
+	}
+
+	for _, id := range cfg.operations {
+		if !slices.ContainsFunc(d.Operations, func(op *Operation) bool { return op.ID == id }) {
+			return nil, fmt.Errorf("openapi: unknown operation %q", id)
+		}
+	}
+
+	var result []tools.Tool
+	names := make(map[string]string)
+	for _, op := range d.Operations {
+		if len(cfg.operations) > 0 && !slices.Contains(cfg.operations, op.ID) {
+			continue
+		}
+		if len(cfg.tags) > 0 && !slices.ContainsFunc(op.Tags, func(tag string) bool { return slices.Contains(cfg.tags, tag) }) {
+			continue
+		}
+		if op.Body != nil && !isJSON(op.Body.ContentType) && !isForm(op.Body.ContentType) {
+			if len(cfg.operations) > 0 {
+				return nil, fmt.Errorf("openapi: operation %s: unsupported request body media type %s", op.ID, op.Body.ContentType)
+			}
+			continue
+		}
+
+		t := newTool(op, baseURL, cfg)
+		if other, ok := names[t.name]; ok {
+			return nil, fmt.Errorf("openapi: operations %s and %s both map to tool name %s", other, op.ID, t.name)
+		}
+		names[t.name] = op.ID
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+// RegisterTools adds the document's tools to r.
+func (d *Document) RegisterTools(r *tools.Registry, opts ...Option) error {
+	ts, err := d.Tools(opts...)
+	if err != nil {
+		return err
+	}
+	for _, t := range ts {
+		if err := r.Register(t); err != nil {
+			return fmt.Errorf("openapi: register %s: %w", t.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Tool calls one operation of an OpenAPI document.
+//
+// Its schema is an object holding the operation's parameters by name,
+// except for Authorization and headers set with WithHeader, which are
+// never taken from the model. When the request body is an object whose
+// properties do not clash with the parameters, its properties are merged
+// in; otherwise the body is the "body" argument.
+type Tool struct {
+	op      *Operation
+	cfg     *config
+	baseURL string
+
+	name   string
+	schema json.RawMessage
+
+	// bodyKey is the argument holding the request body, or "" if the
+	// body's properties are merged into the arguments
+	bodyKey string
+}
+
+// newTool creates the tool for op.
+func newTool(op *Operation, baseURL string, cfg *config) *Tool {
+	t := &Tool{op: op, cfg: cfg, baseURL: baseURL, name: toolName(cfg.toolPrefix + op.ID)}
+
+	properties := make(map[string]any)
+	var required []string
+	for _, p := range op.Parameters {
+		if p.In == "header" && cfg.ownsHeader(p.Name) {
+			continue
+		}
+		s := schemaMap(p.Schema)
+		if len(s) == 0 {
+			s["type"] = "string"
+		}
+		if _, ok := s["description"]; !ok && p.Description != "" {
+			s["description"] = p.Description
+		}
+		properties[p.Name] = s
+		if p.Required {
+			required = append(required, p.Name)
+		}
+	}
+
+	if op.Body != nil {
+		body := schemaMap(op.Body.Schema)
+		if bodyProps, ok := mergeableProperties(body, properties); ok {
+			for name, s := range bodyProps {
+				properties[name] = s
+			}
+			if op.Body.Required {
+				if names, ok := body["required"].([]any); ok {
+					for _, name := range names {
+						if s, ok := name.(string); ok {
+							required = append(required, s)
+						}
+					}
+				}
+			}
+		} else {
+			t.bodyKey = "body"
+			if _, clash := properties[t.bodyKey]; clash {
+				t.bodyKey = "request_body"
+			}
+			if _, ok := body["description"]; !ok && op.Body.Description != "" {
+				body["description"] = op.Body.Description
+			}
+			properties[t.bodyKey] = body
+			if op.Body.Required {
+				required = append(required, t.bodyKey)
+			}
+		}
+	}
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	t.schema, _ = json.Marshal(schema)
+	return t
+}
+
+// schemaMap decodes a schema, returning an empty map if there is none.
+func schemaMap(data json.RawMessage) map[string]any {
+	m := make(map[string]any)
+	if len(data) > 0 {
+		json.Unmarshal(data, &m)
+		if m == nil {
+			m = make(map[string]any)
+		}
+	}
+	return m
+}
+
+// mergeableProperties returns the properties of an object body schema,
+// reporting false if the body is not a plain object or a property clashes
+// with a parameter.
+func mergeableProperties(body, params map[string]any) (map[string]any, bool) {
+	if typ, ok := body["type"]; ok && typ != "object" {
+		return nil, false
+	}
+	props, ok := body["properties"].(map[string]any)
+	if !ok || len(props) == 0 {
+		return nil, false
+	}
+	for name := range props {
+		if _, clash := params[name]; clash {
+			return nil, false
+		}
+	}
+	return props, true
+}
+
+// Name returns the tool's name: the operation ID with characters other
+// than letters, digits, '_', and '-' replaced, and the tool prefix if one
+// is set.
+func (t *Tool) Name() string {
+	return t.name
+}
+
+// Description returns the operation's summary and description.
+func (t *Tool) Description() string {
+	switch {
+	case t.op.Summary != "" && t.op.Description != "" && t.op.Summary != t.op.Description:
+		return t.op.Summary + "\n\n" + t.op.Description
+	case t.op.Summary != "":
+		return t.op.Summary
+	case t.op.Description != "":
+		return t.op.Description
+	default:
+		return t.op.Method + " " + t.op.Path
+	}
+}
+
+// Schema returns the schema of the tool's arguments.
+func (t *Tool) Schema() tools.ToolSchema {
+	return tools.ToolSchema{JSONSchema: t.schema}
+}
+
+// Operation returns the operation the tool calls.
+func (t *Tool) Operation() *Operation {
+	return t.op
+}
+
+// Call performs the operation's HTTP request with args. A JSON response is
+// decoded into a map, slice, or other JSON value; any other response is
+// returned as a string, and an empty one as nil. Error statuses are
+// returned as a *StatusError.
+func (t *Tool) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	values := make(map[string]any)
+	if len(args) > 0 && string(args) != "null" {
+		dec := json.NewDecoder(bytes.NewReader(args))
+		dec.UseNumber()
+		if err := dec.Decode(&values); err != nil {
+			return nil, fmt.Errorf("openapi: %s: arguments must be a JSON object: %w", t.name, err)
+		}
+	}
+
+	req, err := t.newRequest(ctx, values)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.cfg.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("openapi: %s: %w", t.name, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("openapi: %s: %w", t.name, err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, &StatusError{Tool: t.name, StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, nil
+	}
+	if isJSON(resp.Header.Get("Content-Type")) {
+		var v any
+		if err := json.Unmarshal(body, &v); err == nil {
+			return v, nil
+		}
+	}
+	return string(body), nil
+}
+
+// newRequest builds the operation's request from the arguments.
+func (t *Tool) newRequest(ctx context.Context, args map[string]any) (*http.Request, error) {
+	path := t.op.Path
+	query := make(url.Values)
+	header := make(http.Header)
+	var cookies []*http.Cookie
+	params := make(map[string]bool)
+
+	for _, p := range t.op.Parameters {
+		params[p.Name] = true
+		if p.In == "header" && t.cfg.ownsHeader(p.Name) {
+			continue
+		}
+		v, ok := args[p.Name]
+		if !ok || v == nil {
+			if p.Required {
+				return nil, fmt.Errorf("openapi: %s: missing required argument %q", t.name, p.Name)
+			}
+			continue
+		}
+		switch p.In {
+		case "path":
+			// Dot segments would move the request to another endpoint
+			s := formatValue(v)
+			if s == "." || s == ".." {
+				return nil, fmt.Errorf("openapi: %s: invalid path argument %q: %q", t.name, p.Name, s)
+			}
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", url.PathEscape(s))
+		case "query":
+			if list, ok := v.([]any); ok {
+				for _, item := range list {
+					query.Add(p.Name, formatValue(item))
+				}
+			} else {
+				query.Set(p.Name, formatValue(v))
+			}
+		case "header":
+			header.Set(p.Name, formatValue(v))
+		case "cookie":
+			cookies = append(cookies, &http.Cookie{Name: p.Name, Value: formatValue(v)})
+		}
+	}
+
+	u, err := url.Parse(strings.TrimSuffix(t.baseURL, "/") + path)
+	if err != nil {
+		return nil, fmt.Errorf("openapi: %s: %w", t.name, err)
+	}
+	q := u.Query()
+	for key, vs := range t.cfg.query {
+		q[key] = append(q[key], vs...)
+	}
+	for key, vs := range query {
+		q[key] = append(q[key], vs...)
+	}
+	u.RawQuery = q.Encode()
+
+	body, contentType, err := t.encodeBody(args, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, t.op.Method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("openapi: %s: %w", t.name, err)
+	}
+	for key, vs := range t.cfg.headers {
+		req.Header[key] = append(req.Header[key], vs...)
+	}
+	for key, vs := range header {
+		req.Header[key] = vs
+	}
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json, */*;q=0.8")
+
+	for _, edit := range t.cfg.editors {
+		if err := edit(req); err != nil {
+			return nil, fmt.Errorf("openapi: %s: %w", t.name, err)
+		}
+	}
+	return req, nil
+}
+
+// encodeBody encodes the request body from the arguments that are not
+// parameters. It returns a nil reader when there is no body to send.
+func (t *Tool) encodeBody(args map[string]any, params map[string]bool) (io.Reader, string, error) {
+	if t.op.Body == nil {
+		return nil, "", nil
+	}
+
+	var body any
+	if t.bodyKey != "" {
+		body = args[t.bodyKey]
+	} else {
+		fields := make(map[string]any)
+		for name, v := range args {
+			if !params[name] {
+				fields[name] = v
+			}
+		}
+		if len(fields) > 0 || t.op.Body.Required {
+			body = fields
+		}
+	}
+	if body == nil {
+		if t.op.Body.Required {
+			return nil, "", fmt.Errorf("openapi: %s: missing required argument %q", t.name, t.bodyKey)
+		}
+		return nil, "", nil
+	}
+
+	if isForm(t.op.Body.ContentType) {
+		fields, ok := body.(map[string]any)
+		if !ok {
+			return nil, "", fmt.Errorf("openapi: %s: form body must be an object", t.name)
+		}
+		form := make(url.Values)
+		for name, v := range fields {
+			if list, ok := v.([]any); ok {
+				for _, item := range list {
+					form.Add(name, formatValue(item))
+				}
+			} else {
+				form.Set(name, formatValue(v))
+			}
+		}
+		return strings.NewReader(form.Encode()), t.op.Body.ContentType, nil
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, "", fmt.Errorf("openapi: %s: %w", t.name, err)
+	}
+	return bytes.NewReader(data), t.op.Body.ContentType, nil
+}
+
+// formatValue formats an argument for a path, query, header, or form.
+// Objects and arrays are formatted as JSON.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// StatusError is returned by Tool.Call when the API responds with an error
+// status.
+type StatusError struct {
+	Tool       string
+	StatusCode int
+	Body       string
+}
+
+// Error implements the error interface.
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("openapi: %s returned status %d", e.Tool, e.StatusCode)
+	}
+	body := e.Body
+	if len(body) > 1000 {
+		body = body[:1000] + "..."
+	}
+	return fmt.Sprintf("openapi: %s returned status %d: %s", e.Tool, e.StatusCode, body)
+}
+
+// Compile-time check that Tool implements tools.Tool.
+var _ tools.Tool = (*Tool)(nil)
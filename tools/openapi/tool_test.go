@@ -0,0 +1,340 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/tools"
+)
+
+// mustParse parses the petstore document.
+func mustParse(t *testing.T) *Document {
+	t.Helper()
+	doc, err := Parse([]byte(petstore))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return doc
+}
+
+// toolsByName returns the tools by name.
+func toolsByName(t *testing.T, doc *Document, opts ...Option) map[string]tools.Tool {
+	t.Helper()
+	ts, err := doc.Tools(opts...)
+	if err != nil {
+		t.Fatalf("Tools() error = %v", err)
+	}
+	byName := make(map[string]tools.Tool)
+	for _, tool := range ts {
+		byName[tool.Name()] = tool
+	}
+	return byName
+}
+
+func TestTools(t *testing.T) {
+	doc := mustParse(t)
+
+	all := toolsByName(t, doc)
+	if len(all) != 6 {
+		t.Errorf("len(Tools()) = %d, want 6 without the multipart upload", len(all))
+	}
+	if _, ok := all["delete_pets_petId"]; !ok {
+		t.Error("missing tool for the operation without an ID")
+	}
+	if got := all["createPet"].Description(); got != "Create a pet\n\nAdds a pet to the store." {
+		t.Errorf("Description() = %q", got)
+	}
+	if got := all["delete_pets_petId"].Description(); got != "DELETE /pets/{petId}" {
+		t.Errorf("Description() without summary = %q", got)
+	}
+
+	pets := toolsByName(t, doc, WithTags("pets", "auth"))
+	if len(pets) != 4 {
+		t.Errorf("tools tagged pets or auth = %d, want 4", len(pets))
+	}
+
+	ops := toolsByName(t, doc, WithOperations("showPetById", "login"), WithToolPrefix("store."))
+	if len(ops) != 2 || ops["store_showPetById"] == nil || ops["store_login"] == nil {
+		t.Errorf("selected tools = %v", ops)
+	}
+}
+
+func TestToolsErrors(t *testing.T) {
+	doc := mustParse(t)
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"unknown operation", []Option{WithOperations("nope")}, `unknown operation "nope"`},
+		{"unsupported body", []Option{WithOperations("uploadPhoto")}, "unsupported request body media type multipart/form-data"},
+		{"relative base URL", []Option{WithBaseURL("/v1")}, "no absolute base URL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := doc.Tools(tt.opts...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Tools() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	doc.Servers = nil
+	if _, err := doc.Tools(); err == nil {
+		t.Error("Tools() without servers or base URL should fail")
+	}
+}
+
+func TestToolSchema(t *testing.T) {
+	all := toolsByName(t, mustParse(t))
+
+	schema := func(name string) map[string]any {
+		t.Helper()
+		var m map[string]any
+		if err := json.Unmarshal(all[name].Schema().JSONSchema, &m); err != nil {
+			t.Fatalf("%s schema: %v", name, err)
+		}
+		return m
+	}
+
+	// Body properties are merged with the parameters
+	create := schema("createPet")
+	props := create["properties"].(map[string]any)
+	if _, ok := props["name"]; !ok {
+		t.Errorf("createPet properties = %v", props)
+	}
+	if req, _ := json.Marshal(create["required"]); string(req) != `["name"]` {
+		t.Errorf("createPet required = %s", req)
+	}
+
+	show := schema("showPetById")
+	props = show["properties"].(map[string]any)
+	if petID := props["petId"].(map[string]any); petID["type"] != "integer" || petID["description"] != "The pet's ID" {
+		t.Errorf("petId schema = %v", petID)
+	}
+	if req, _ := json.Marshal(show["required"]); string(req) != `["petId"]` {
+		t.Errorf("showPetById required = %s", req)
+	}
+
+	// A non-object body, or one clashing with a parameter, is an argument
+	search := schema("search")
+	props = search["properties"].(map[string]any)
+	body, ok := props["request_body"].(map[string]any)
+	if !ok || body["type"] != "array" || body["description"] != "Search terms" {
+		t.Errorf("search properties = %v", props)
+	}
+}
+
+// recordedRequest is a request received by the test server.
+type recordedRequest struct {
+	method, path, query, contentType, body string
+	header                                 http.Header
+}
+
+// newAPIServer returns a server that records each request and answers
+// with status and body.
+func newAPIServer(t *testing.T, status int, contentType, body string) (*httptest.Server, *recordedRequest) {
+	t.Helper()
+	var got recordedRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		got = recordedRequest{
+			method:      r.Method,
+			path:        r.URL.Path,
+			query:       r.URL.RawQuery,
+			contentType: r.Header.Get("Content-Type"),
+			body:        string(data),
+			header:      r.Header.Clone(),
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &got
+}
+
+func TestToolCall(t *testing.T) {
+	srv, got := newAPIServer(t, http.StatusOK, "application/json", `{"id":7,"name":"Rex"}`)
+	all := toolsByName(t, mustParse(t),
+		WithBaseURL(srv.URL+"/v1/"),
+		WithBearerToken("secret"),
+		WithQueryParam("api_key", "k"),
+	)
+	ctx := context.Background()
+
+	result, err := all["showPetById"].Call(ctx, json.RawMessage(`{"petId":7,"X-Trace":"abc"}`))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if m, ok := result.(map[string]any); !ok || m["name"] != "Rex" {
+		t.Errorf("Call() = %v", result)
+	}
+	if got.method != http.MethodGet || got.path != "/v1/pets/7" || got.query != "api_key=k" {
+		t.Errorf("request = %s %s?%s", got.method, got.path, got.query)
+	}
+	if got.header.Get("Authorization") != "Bearer secret" || got.header.Get("X-Trace") != "abc" {
+		t.Errorf("headers = %v", got.header)
+	}
+
+	if _, err := all["listPets"].Call(ctx, json.RawMessage(`{"limit":10,"tag":["a","b"]}`)); err != nil {
+		t.Fatalf("listPets error = %v", err)
+	}
+	if got.query != "api_key=k&limit=10&tag=a&tag=b" {
+		t.Errorf("listPets query = %s", got.query)
+	}
+
+	if _, err := all["createPet"].Call(ctx, json.RawMessage(`{"name":"Rex","parent":{"name":"Max"}}`)); err != nil {
+		t.Fatalf("createPet error = %v", err)
+	}
+	if got.method != http.MethodPost || got.contentType != "application/json" || got.body != `{"name":"Rex","parent":{"name":"Max"}}` {
+		t.Errorf("createPet request = %s %s %s", got.method, got.contentType, got.body)
+	}
+
+	if _, err := all["search"].Call(ctx, json.RawMessage(`{"body":"q","request_body":["x","y"]}`)); err != nil {
+		t.Fatalf("search error = %v", err)
+	}
+	if got.query != "api_key=k&body=q" || got.body != `["x","y"]` || got.contentType != "application/vnd.search+json" {
+		t.Errorf("search request = ?%s %s %s", got.query, got.contentType, got.body)
+	}
+}
+
+func TestToolCallDotPathSegment(t *testing.T) {
+	srv, got := newAPIServer(t, http.StatusOK, "application/json", `{}`)
+	show := toolsByName(t, mustParse(t), WithBaseURL(srv.URL))["showPetById"]
+
+	for _, id := range []string{".", ".."} {
+		got.path = ""
+		_, err := show.Call(context.Background(), json.RawMessage(`{"petId":"`+id+`"}`))
+		if err == nil || !strings.Contains(err.Error(), "invalid path argument") {
+			t.Errorf("Call(petId %q) error = %v, want invalid path argument", id, err)
+		}
+		if got.path != "" {
+			t.Errorf("Call(petId %q) sent a request to %s", id, got.path)
+		}
+	}
+
+	// Dots within a segment are harmless
+	if _, err := show.Call(context.Background(), json.RawMessage(`{"petId":"..a"}`)); err != nil || got.path != "/pets/..a" {
+		t.Errorf("Call(petId ..a) = %v, path %s", err, got.path)
+	}
+}
+
+func TestToolCallConfiguredHeaders(t *testing.T) {
+	doc, err := Parse([]byte(`
+openapi: 3.0.3
+info: {title: Auth, version: "1"}
+paths:
+  /me:
+    get:
+      operationId: me
+      parameters:
+        - {name: authorization, in: header, required: true, schema: {type: string}}
+        - {name: X-Api-Key, in: header, schema: {type: string}}
+        - {name: X-Trace, in: header, schema: {type: string}}
+      responses:
+        200: {description: The user}
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	srv, got := newAPIServer(t, http.StatusOK, "application/json", `{}`)
+	tool := toolsByName(t, doc, WithBaseURL(srv.URL), WithBearerToken("secret"), WithHeader("x-api-key", "k"))["me"]
+
+	var schema struct {
+		Properties map[string]any `json:"properties"`
+		Required   []string       `json:"required"`
+	}
+	json.Unmarshal(tool.Schema().JSONSchema, &schema)
+	if len(schema.Properties) != 1 || schema.Properties["X-Trace"] == nil || len(schema.Required) != 0 {
+		t.Errorf("schema = %s", tool.Schema().JSONSchema)
+	}
+
+	_, err = tool.Call(context.Background(), json.RawMessage(`{"authorization":"Bearer stolen","X-Api-Key":"other","X-Trace":"abc"}`))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if got.header.Get("Authorization") != "Bearer secret" || len(got.header.Values("Authorization")) != 1 {
+		t.Errorf("Authorization = %v", got.header.Values("Authorization"))
+	}
+	if got.header.Get("X-Api-Key") != "k" || len(got.header.Values("X-Api-Key")) != 1 || got.header.Get("X-Trace") != "abc" {
+		t.Errorf("headers = %v", got.header)
+	}
+
+	// Authorization is never taken from the model, even without a token
+	tool = toolsByName(t, doc, WithBaseURL(srv.URL))["me"]
+	if _, err := tool.Call(context.Background(), json.RawMessage(`{"authorization":"Bearer stolen"}`)); err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if auth := got.header.Get("Authorization"); auth != "" {
+		t.Errorf("Authorization = %q, want none", auth)
+	}
+}
+
+func TestToolCallForm(t *testing.T) {
+	srv, got := newAPIServer(t, http.StatusOK, "text/plain", "welcome")
+	all := toolsByName(t, mustParse(t), WithBaseURL(srv.URL), WithBasicAuth("u", "p"))
+
+	result, err := all["login"].Call(context.Background(), json.RawMessage(`{"user":"ada","password":"x y"}`))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if result != "welcome" {
+		t.Errorf("Call() = %v, want text response", result)
+	}
+	if got.contentType != "application/x-www-form-urlencoded" || got.body != "password=x+y&user=ada" {
+		t.Errorf("request = %s %s", got.contentType, got.body)
+	}
+	if user, pass, ok := (&http.Request{Header: got.header}).BasicAuth(); !ok || user != "u" || pass != "p" {
+		t.Errorf("basic auth = %q, %q, %v", user, pass, ok)
+	}
+}
+
+func TestToolCallErrors(t *testing.T) {
+	srv, _ := newAPIServer(t, http.StatusNotFound, "application/json", `{"error":"no such pet"}`)
+	all := toolsByName(t, mustParse(t), WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	_, err := all["showPetById"].Call(ctx, json.RawMessage(`{"petId":1}`))
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound || !strings.Contains(err.Error(), "no such pet") {
+		t.Errorf("Call() error = %v, want *StatusError 404", err)
+	}
+
+	if _, err := all["showPetById"].Call(ctx, json.RawMessage(`{}`)); err == nil || !strings.Contains(err.Error(), `missing required argument "petId"`) {
+		t.Errorf("Call() without petId error = %v", err)
+	}
+	if _, err := all["showPetById"].Call(ctx, json.RawMessage(`[1]`)); err == nil {
+		t.Error("Call() with array arguments should fail")
+	}
+
+	editErr := errors.New("no credentials")
+	failing := toolsByName(t, mustParse(t), WithBaseURL(srv.URL), WithRequestEditor(func(*http.Request) error { return editErr }))
+	if _, err := failing["listPets"].Call(ctx, nil); !errors.Is(err, editErr) {
+		t.Errorf("Call() with failing editor error = %v", err)
+	}
+}
+
+func TestRegisterTools(t *testing.T) {
+	doc := mustParse(t)
+	reg := tools.NewRegistry()
+
+	if err := doc.RegisterTools(reg, WithTags("pets")); err != nil {
+		t.Fatalf("RegisterTools() error = %v", err)
+	}
+	if _, ok := reg.Get("listPets"); !ok || len(reg.List()) != 3 {
+		t.Errorf("registry has %d tools", len(reg.List()))
+	}
+
+	err := doc.RegisterTools(reg, WithOperations("listPets"))
+	if !errors.Is(err, tools.ErrDuplicateTool) {
+		t.Errorf("RegisterTools() twice error = %v, want ErrDuplicateTool", err)
+	}
+}
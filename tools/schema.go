@@ -0,0 +1,530 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxSchemaDepth bounds nesting and $ref resolution in schemas.
+const maxSchemaDepth = 64
+
+// FieldError describes one way the arguments violate the schema. Path
+// locates the offending value, such as "items[2].name"; it is empty for
+// the arguments as a whole.
+type FieldError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when tool arguments do not match the tool's
+// schema. It lists every violation found.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		if fe.Path == "" {
+			msgs[i] = fe.Message
+		} else {
+			msgs[i] = fe.Path + ": " + fe.Message
+		}
+	}
+	return "invalid arguments: " + strings.Join(msgs, "; ")
+}
+
+// Validate checks args against the schema. It returns a *ValidationError
+// listing every violation, or another error if the schema itself is not
+// valid JSON Schema. An empty schema accepts any arguments, and empty
+// args are treated as an empty object.
+//
+// Validate supports the keywords that describe tool arguments: type,
+// enum, const, the numeric, string, array, and object constraints,
+// properties, patternProperties, additionalProperties, items,
+// prefixItems, allOf, anyOf, oneOf, not, and local $ref. Annotations
+// such as format and description are ignored.
+func (s ToolSchema) Validate(args json.RawMessage) error {
+	if len(bytes.TrimSpace(args)) == 0 {
+		args = json.RawMessage(`{}`)
+	}
+	value, err := decodeJSON(args)
+	if err != nil {
+		return &ValidationError{Errors: []FieldError{{Message: "arguments are not valid JSON: " + err.Error()}}}
+	}
+
+	if len(bytes.TrimSpace(s.JSONSchema)) == 0 {
+		return nil
+	}
+	schema, err := decodeJSON(s.JSONSchema)
+	if err != nil {
+		return fmt.Errorf("tools: invalid schema: %w", err)
+	}
+
+	v := &validator{root: schema}
+	v.validate(schema, value, "", 0)
+	if v.schemaErr != nil {
+		return fmt.Errorf("tools: invalid schema: %w", v.schemaErr)
+	}
+	if len(v.errs) > 0 {
+		return &ValidationError{Errors: v.errs}
+	}
+	return nil
+}
+
+// decodeJSON decodes a single JSON value, keeping numbers exact.
+func decodeJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return v, nil
+}
+
+// validator checks a value against a schema, collecting violations.
+type validator struct {
+	root      any
+	errs      []FieldError
+	schemaErr error
+}
+
+func (v *validator) fail(path, format string, args ...any) {
+	v.errs = append(v.errs, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// matches reports whether value is valid against schema, without
+// recording violations.
+func (v *validator) matches(schema, value any, path string, depth int) bool {
+	sub := &validator{root: v.root}
+	sub.validate(schema, value, path, depth)
+	if sub.schemaErr != nil && v.schemaErr == nil {
+		v.schemaErr = sub.schemaErr
+	}
+	return len(sub.errs) == 0
+}
+
+func (v *validator) validate(schema, value any, path string, depth int) {
+	if depth > maxSchemaDepth {
+		v.schemaErr = errors.New("schema nested too deeply or $ref cycle")
+		return
+	}
+	if v.schemaErr != nil {
+		return
+	}
+
+	var s map[string]any
+	switch sch := schema.(type) {
+	case bool:
+		if !sch {
+			v.fail(path, "is not allowed")
+		}
+		return
+	case map[string]any:
+		s = sch
+	default:
+		v.schemaErr = fmt.Errorf("schema at %q is not an object", pathOrRoot(path))
+		return
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		target, err := v.resolve(ref)
+		if err != nil {
+			v.schemaErr = err
+			return
+		}
+		v.validate(target, value, path, depth+1)
+	}
+
+	if !v.validateType(s, value, path) {
+		// Other keywords would only repeat the type mismatch
+		return
+	}
+	v.validateEnum(s, value, path)
+
+	switch val := value.(type) {
+	case json.Number:
+		v.validateNumber(s, val, path)
+	case string:
+		v.validateString(s, val, path)
+	case []any:
+		v.validateArray(s, val, path, depth)
+	case map[string]any:
+		v.validateObject(s, val, path, depth)
+	}
+
+	v.validateComposition(s, value, path, depth)
+}
+
+// validateType checks the type keyword, reporting whether it passed.
+func (v *validator) validateType(s map[string]any, value any, path string) bool {
+	var types []string
+	switch t := s["type"].(type) {
+	case nil:
+		return true
+	case string:
+		types = []string{t}
+	case []any:
+		for _, e := range t {
+			if name, ok := e.(string); ok {
+				types = append(types, name)
+			}
+		}
+	default:
+		v.schemaErr = fmt.Errorf("type at %q must be a string or array", pathOrRoot(path))
+		return false
+	}
+
+	got := jsonType(value)
+	for _, want := range types {
+		if want == got || (want == "number" && got == "integer") {
+			return true
+		}
+	}
+	v.fail(path, "must be of type %s, got %s", strings.Join(types, " or "), got)
+	return false
+}
+
+func (v *validator) validateEnum(s map[string]any, value any, path string) {
+	if enum, ok := s["enum"].([]any); ok {
+		if !slices.ContainsFunc(enum, func(e any) bool { return equalJSON(e, value) }) {
+			v.fail(path, "must be one of %s", joinJSON(enum))
+		}
+	}
+	if c, ok := s["const"]; ok && !equalJSON(c, value) {
+		v.fail(path, "must be %s", encodeJSON(c))
+	}
+}
+
+func (v *validator) validateNumber(s map[string]any, n json.Number, path string) {
+	f, err := n.Float64()
+	if err != nil {
+		v.fail(path, "is not a representable number")
+		return
+	}
+
+	if min, ok := number(s["minimum"]); ok {
+		// Draft 4 expresses an exclusive bound as a boolean
+		if excl, _ := s["exclusiveMinimum"].(bool); excl && f <= min {
+			v.fail(path, "must be greater than %s", formatNumber(min))
+		} else if f < min {
+			v.fail(path, "must be greater than or equal to %s", formatNumber(min))
+		}
+	}
+	if max, ok := number(s["maximum"]); ok {
+		if excl, _ := s["exclusiveMaximum"].(bool); excl && f >= max {
+			v.fail(path, "must be less than %s", formatNumber(max))
+		} else if f > max {
+			v.fail(path, "must be less than or equal to %s", formatNumber(max))
+		}
+	}
+	if min, ok := number(s["exclusiveMinimum"]); ok && f <= min {
+		v.fail(path, "must be greater than %s", formatNumber(min))
+	}
+	if max, ok := number(s["exclusiveMaximum"]); ok && f >= max {
+		v.fail(path, "must be less than %s", formatNumber(max))
+	}
+	if m, ok := number(s["multipleOf"]); ok && m > 0 {
+		if q := f / m; math.Abs(q-math.Round(q)) > 1e-9 {
+			v.fail(path, "must be a multiple of %s", formatNumber(m))
+		}
+	}
+}
+
+func (v *validator) validateString(s map[string]any, str, path string) {
+	length := utf8.RuneCountInString(str)
+	if min, ok := number(s["minLength"]); ok && float64(length) < min {
+		v.fail(path, "must be at least %s characters long", formatNumber(min))
+	}
+	if max, ok := number(s["maxLength"]); ok && float64(length) > max {
+		v.fail(path, "must be at most %s characters long", formatNumber(max))
+	}
+	if pattern, ok := s["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			v.schemaErr = fmt.Errorf("pattern at %q: %w", pathOrRoot(path), err)
+			return
+		}
+		if !re.MatchString(str) {
+			v.fail(path, "must match the pattern %s", pattern)
+		}
+	}
+}
+
+func (v *validator) validateArray(s map[string]any, arr []any, path string, depth int) {
+	if min, ok := number(s["minItems"]); ok && float64(len(arr)) < min {
+		v.fail(path, "must contain at least %s items", formatNumber(min))
+	}
+	if max, ok := number(s["maxItems"]); ok && float64(len(arr)) > max {
+		v.fail(path, "must contain at most %s items", formatNumber(max))
+	}
+	if unique, _ := s["uniqueItems"].(bool); unique {
+	outer:
+		for i := range arr {
+			for j := i + 1; j < len(arr); j++ {
+				if equalJSON(arr[i], arr[j]) {
+					v.fail(path, "must not contain duplicate items (items %d and %d are equal)", i, j)
+					break outer
+				}
+			}
+		}
+	}
+
+	// prefixItems (or items as an array, before draft 2020-12) validate
+	// positions; items validates the rest
+	prefix, _ := s["prefixItems"].([]any)
+	rest := s["items"]
+	if tuple, ok := rest.([]any); ok {
+		prefix, rest = tuple, s["additionalItems"]
+	}
+	for i, item := range arr {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		if i < len(prefix) {
+			v.validate(prefix[i], item, itemPath, depth+1)
+		} else if rest != nil {
+			v.validate(rest, item, itemPath, depth+1)
+		}
+	}
+}
+
+func (v *validator) validateObject(s map[string]any, obj map[string]any, path string, depth int) {
+	if required, ok := s["required"].([]any); ok {
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, present := obj[name]; !present {
+					v.fail(joinPath(path, name), "is required")
+				}
+			}
+		}
+	}
+	if min, ok := number(s["minProperties"]); ok && float64(len(obj)) < min {
+		v.fail(path, "must have at least %s properties", formatNumber(min))
+	}
+	if max, ok := number(s["maxProperties"]); ok && float64(len(obj)) > max {
+		v.fail(path, "must have at most %s properties", formatNumber(max))
+	}
+
+	properties, _ := s["properties"].(map[string]any)
+	patternProperties, _ := s["patternProperties"].(map[string]any)
+	additional, hasAdditional := s["additionalProperties"]
+
+	patterns := make(map[string]*regexp.Regexp, len(patternProperties))
+	for pattern := range patternProperties {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			v.schemaErr = fmt.Errorf("patternProperties at %q: %w", pathOrRoot(path), err)
+			return
+		}
+		patterns[pattern] = re
+	}
+
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		value, propPath := obj[name], joinPath(path, name)
+		matched := false
+		if sub, ok := properties[name]; ok {
+			v.validate(sub, value, propPath, depth+1)
+			matched = true
+		}
+		for pattern, re := range patterns {
+			if re.MatchString(name) {
+				v.validate(patternProperties[pattern], value, propPath, depth+1)
+				matched = true
+			}
+		}
+		if matched || !hasAdditional {
+			continue
+		}
+		if allowed, ok := additional.(bool); ok && !allowed {
+			v.fail(propPath, "is not an allowed property%s", allowedProperties(properties))
+			continue
+		}
+		v.validate(additional, value, propPath, depth+1)
+	}
+}
+
+func (v *validator) validateComposition(s map[string]any, value any, path string, depth int) {
+	if all, ok := s["allOf"].([]any); ok {
+		for _, sub := range all {
+			v.validate(sub, value, path, depth+1)
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]any); ok {
+		if !slices.ContainsFunc(anyOf, func(sub any) bool { return v.matches(sub, value, path, depth+1) }) {
+			v.fail(path, "must match at least one of the allowed schemas")
+		}
+	}
+	if oneOf, ok := s["oneOf"].([]any); ok {
+		n := 0
+		for _, sub := range oneOf {
+			if v.matches(sub, value, path, depth+1) {
+				n++
+			}
+		}
+		if n != 1 {
+			v.fail(path, "must match exactly one of the allowed schemas, matched %d", n)
+		}
+	}
+	if not, ok := s["not"]; ok && v.matches(not, value, path, depth+1) {
+		v.fail(path, "must not match the disallowed schema")
+	}
+}
+
+// resolve resolves a local $ref such as "#/$defs/Item" against the root
+// schema.
+func (v *validator) resolve(ref string) (any, error) {
+	pointer, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, fmt.Errorf("unsupported $ref %q: only local references are supported", ref)
+	}
+	node := v.root
+	if pointer == "" {
+		return node, nil
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch n := node.(type) {
+		case map[string]any:
+			node, ok = n[token]
+		case []any:
+			i, err := strconv.Atoi(token)
+			ok = err == nil && i >= 0 && i < len(n)
+			if ok {
+				node = n[i]
+			}
+		default:
+			ok = false
+		}
+		if !ok {
+			return nil, fmt.Errorf("unresolved $ref %q", ref)
+		}
+	}
+	return node, nil
+}
+
+// jsonType returns the JSON Schema type of a decoded value.
+func jsonType(value any) string {
+	switch val := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if f, err := val.Float64(); err == nil && f == math.Trunc(f) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// equalJSON reports whether two decoded values are equal as JSON, so
+// that 1 and 1.0 are equal.
+func equalJSON(a, b any) bool {
+	switch av := a.(type) {
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		af, errA := av.Float64()
+		bf, errB := bv.Float64()
+		return errA == nil && errB == nil && af == bf
+	case []any:
+		bv, ok := b.([]any)
+		return ok && slices.EqualFunc(av, bv, equalJSON)
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, x := range av {
+			y, ok := bv[k]
+			if !ok || !equalJSON(x, y) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+// number returns a schema keyword's numeric value.
+func number(v any) (float64, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	f, err := n.Float64()
+	return f, err == nil
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func encodeJSON(v any) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// joinJSON formats values as a comma-separated JSON list.
+func joinJSON(values []any) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = encodeJSON(v)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// allowedProperties lists the declared properties, to help the model
+// correct a misspelled name.
+func allowedProperties(properties map[string]any) string {
+	if len(properties) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return " (allowed: " + strings.Join(names, ", ") + ")"
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
@@ -0,0 +1,182 @@
+package tools_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/tools"
+)
+
+// orderSchema exercises each kind of constraint.
+const orderSchema = `{
+	"type": "object",
+	"properties": {
+		"customer": {"type": "string", "minLength": 2, "maxLength": 20, "pattern": "^[a-z]+$"},
+		"priority": {"enum": ["low", "normal", "high"]},
+		"quantity": {"type": "integer", "minimum": 1, "maximum": 10},
+		"discount": {"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 1},
+		"step": {"type": "number", "multipleOf": 0.5},
+		"tags": {"type": "array", "items": {"type": "string"}, "minItems": 1, "maxItems": 3, "uniqueItems": true},
+		"address": {
+			"type": "object",
+			"properties": {
+				"city": {"type": "string"},
+				"zip": {"type": ["string", "null"]}
+			},
+			"required": ["city"],
+			"additionalProperties": false
+		},
+		"items": {"type": "array", "items": {"$ref": "#/$defs/item"}},
+		"gift": {"const": true}
+	},
+	"required": ["customer", "quantity"],
+	"additionalProperties": false,
+	"$defs": {
+		"item": {
+			"type": "object",
+			"properties": {"sku": {"type": "string"}, "count": {"type": "integer"}},
+			"required": ["sku"]
+		}
+	}
+}`
+
+func TestValidateValid(t *testing.T) {
+	schema := tools.ToolSchema{JSONSchema: json.RawMessage(orderSchema)}
+	valid := []string{
+		`{"customer":"ada","quantity":1}`,
+		`{"customer":"ada","quantity":10.0,"priority":"high","discount":0.5,"step":2.5}`,
+		`{"customer":"ada","quantity":3,"tags":["a","b"],"address":{"city":"Paris","zip":null}}`,
+		`{"customer":"ada","quantity":3,"items":[{"sku":"x","count":2},{"sku":"y"}],"gift":true}`,
+	}
+	for _, args := range valid {
+		if err := schema.Validate(json.RawMessage(args)); err != nil {
+			t.Errorf("Validate(%s) error = %v", args, err)
+		}
+	}
+}
+
+func TestValidateInvalid(t *testing.T) {
+	schema := tools.ToolSchema{JSONSchema: json.RawMessage(orderSchema)}
+	tests := []struct {
+		name string
+		args string
+		want []tools.FieldError
+	}{
+		{"not an object", `[1]`, []tools.FieldError{{Path: "", Message: "must be of type object, got array"}}},
+		{"required", `{}`, []tools.FieldError{
+			{Path: "customer", Message: "is required"},
+			{Path: "quantity", Message: "is required"},
+		}},
+		{"types", `{"customer":1,"quantity":"2"}`, []tools.FieldError{
+			{Path: "customer", Message: "must be of type string, got integer"},
+			{Path: "quantity", Message: "must be of type integer, got string"},
+		}},
+		{"integer", `{"customer":"ada","quantity":1.5}`, []tools.FieldError{{Path: "quantity", Message: "must be of type integer, got number"}}},
+		{"enum", `{"customer":"ada","quantity":1,"priority":"urgent"}`, []tools.FieldError{{Path: "priority", Message: `must be one of "low", "normal", "high"`}}},
+		{"ranges", `{"customer":"ada","quantity":11,"discount":1,"step":0.3}`, []tools.FieldError{
+			{Path: "discount", Message: "must be less than 1"},
+			{Path: "quantity", Message: "must be less than or equal to 10"},
+			{Path: "step", Message: "must be a multiple of 0.5"},
+		}},
+		{"strings", `{"customer":"A","quantity":1}`, []tools.FieldError{
+			{Path: "customer", Message: "must be at least 2 characters long"},
+			{Path: "customer", Message: "must match the pattern ^[a-z]+$"},
+		}},
+		{"arrays", `{"customer":"ada","quantity":1,"tags":["a","a",3,"b"]}`, []tools.FieldError{
+			{Path: "tags", Message: "must contain at most 3 items"},
+			{Path: "tags", Message: "must not contain duplicate items (items 0 and 1 are equal)"},
+			{Path: "tags[2]", Message: "must be of type string, got integer"},
+		}},
+		{"nested", `{"customer":"ada","quantity":1,"address":{"zip":5,"country":"FR"},"items":[{"count":1}]}`, []tools.FieldError{
+			{Path: "address.city", Message: "is required"},
+			{Path: "address.country", Message: "is not an allowed property (allowed: city, zip)"},
+			{Path: "address.zip", Message: "must be of type string or null, got integer"},
+			{Path: "items[0].sku", Message: "is required"},
+		}},
+		{"additional", `{"customer":"ada","quantity":1,"colour":"red","gift":false}`, []tools.FieldError{
+			{Path: "colour", Message: "is not an allowed property (allowed: address, customer, discount, gift, items, priority, quantity, step, tags)"},
+			{Path: "gift", Message: "must be true"},
+		}},
+		{"invalid JSON", `{"customer":`, []tools.FieldError{{Message: "arguments are not valid JSON: unexpected EOF"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.Validate(json.RawMessage(tt.args))
+			var validationErr *tools.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Validate() error = %v, want *ValidationError", err)
+			}
+			got, _ := json.Marshal(validationErr.Errors)
+			want, _ := json.Marshal(tt.want)
+			if string(got) != string(want) {
+				t.Errorf("Validate() errors =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestValidateComposition(t *testing.T) {
+	schema := tools.ToolSchema{JSONSchema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"id": {"anyOf": [{"type": "string"}, {"type": "integer", "minimum": 0}]},
+			"shape": {"oneOf": [{"required": ["radius"]}, {"required": ["width"]}]},
+			"name": {"allOf": [{"minLength": 1}, {"not": {"const": "root"}}]},
+			"point": {"type": "array", "prefixItems": [{"type": "number"}, {"type": "number"}], "items": false},
+			"labels": {"type": "object", "patternProperties": {"^x-": {"type": "string"}}, "additionalProperties": {"type": "integer"}}
+		}
+	}`)}
+
+	valid := `{"id":"a","shape":{"radius":1},"name":"leaf","point":[1,2],"labels":{"x-a":"b","count":1}}`
+	if err := schema.Validate(json.RawMessage(valid)); err != nil {
+		t.Errorf("Validate(valid) error = %v", err)
+	}
+
+	invalid := `{"id":-1,"shape":{"radius":1,"width":2},"name":"root","point":[1,2,3],"labels":{"x-a":1,"count":"one"}}`
+	err := schema.Validate(json.RawMessage(invalid))
+	want := "invalid arguments: id: must match at least one of the allowed schemas; " +
+		"labels.count: must be of type integer, got string; labels.x-a: must be of type string, got integer; " +
+		"name: must not match the disallowed schema; point[2]: is not allowed; " +
+		"shape: must match exactly one of the allowed schemas, matched 2"
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() error =\n%v\nwant\n%s", err, want)
+	}
+}
+
+func TestValidateSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{"invalid JSON", `{"type":`, "invalid schema"},
+		{"bad pattern", `{"properties":{"a":{"pattern":"("}}}`, "pattern at \"a\""},
+		{"unresolved ref", `{"$ref":"#/$defs/missing"}`, `unresolved $ref "#/$defs/missing"`},
+		{"remote ref", `{"$ref":"https://example.com/schema.json"}`, "only local references"},
+		{"ref cycle", `{"$ref":"#"}`, "$ref cycle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tools.ToolSchema{JSONSchema: json.RawMessage(tt.schema)}.Validate(json.RawMessage(`{"a":"x"}`))
+			var validationErr *tools.ValidationError
+			if err == nil || errors.As(err, &validationErr) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() error = %v, want schema error %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateEmpty(t *testing.T) {
+	empty := tools.ToolSchema{}
+	if err := empty.Validate(json.RawMessage(`{"anything":[1]}`)); err != nil {
+		t.Errorf("empty schema Validate() error = %v", err)
+	}
+
+	object := tools.ToolSchema{JSONSchema: json.RawMessage(`{"type":"object","required":["a"]}`)}
+	err := object.Validate(nil)
+	if err == nil || err.Error() != "invalid arguments: a: is required" {
+		t.Errorf("Validate(nil) error = %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+// Package vectorstore provides local implementations of core.VectorStore,
+// for retrieval-augmented generation without a hosted vector database.
+//
+// Memory compares a query with every stored vector, giving exact results;
+// it suits up to tens of thousands of vectors. HNSW keeps an approximate
+// nearest neighbor graph, which stays fast for millions of vectors, and
+// Open persists it to a directory:
+//
+//	store, err := vectorstore.Open("./index")
+//	if err != nil {
+//		return err
+//	}
+//	defer store.Close()
+//
+//	resp, err := provider.CreateEmbeddings(ctx, &core.EmbeddingRequest{
+//		Model:     "text-embedding-3-small",
+//		Input:     []core.EmbeddingInput{{ID: "doc-1", Text: text, Metadata: map[string]string{"lang": "en"}}},
+//		InputType: core.InputTypeDocument,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	err = store.Upsert(ctx, resp.Vectors)
+//
+//	matches, err := store.Query(ctx, &core.VectorQuery{
+//		Vector: queryVector,
+//		TopK:   5,
+//		Filter: map[string]string{"lang": "en"},
+//	})
+//
+// Both stores rank by cosine similarity and hold the vectors in memory.
+package vectorstore
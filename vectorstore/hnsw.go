@@ -0,0 +1,467 @@
+package vectorstore
+
+import (
+	"cmp"
+	"container/heap"
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// ErrClosed is returned by the methods of an HNSW store after Close.
+var ErrClosed = errors.New("vectorstore: store is closed")
+
+// config holds the settings of an HNSW store.
+type config struct {
+	m              int
+	efConstruction int
+	efSearch       int
+}
+
+// Option configures an HNSW store.
+type Option func(*config)
+
+// WithM sets the number of neighbors each vector is linked to; level 0
+// links twice as many. Higher values improve recall on high-dimensional
+// data at the cost of memory and insert time. Default: 16.
+func WithM(m int) Option {
+	return func(c *config) {
+		c.m = m
+	}
+}
+
+// WithEfConstruction sets how many candidates are considered when
+// linking a new vector. Higher values build a better graph more slowly.
+// Default: 200.
+func WithEfConstruction(ef int) Option {
+	return func(c *config) {
+		c.efConstruction = ef
+	}
+}
+
+// WithEfSearch sets how many candidates a query considers; at least TopK
+// are always considered. Higher values improve recall at the cost of
+// query time. Default: 64.
+func WithEfSearch(ef int) Option {
+	return func(c *config) {
+		c.efSearch = ef
+	}
+}
+
+// newConfig applies opts to the default settings.
+func newConfig(opts []Option) *config {
+	c := &config{m: 16, efConstruction: 200, efSearch: 64}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.m = max(c.m, 2)
+	c.efConstruction = max(c.efConstruction, c.m)
+	c.efSearch = max(c.efSearch, 1)
+	return c
+}
+
+// node is a vector in the graph. Deleted nodes stay in the graph, so
+// it stays connected, until it is rebuilt.
+type node struct {
+	rec     *record
+	links   [][]int32 // neighbors at each level, from 0
+	deleted bool
+}
+
+// HNSW is a VectorStore with an approximate nearest neighbor index, a
+// Hierarchical Navigable Small World graph. Queries take time roughly
+// logarithmic in the number of vectors, so it suits corpora too large to
+// compare exhaustively; results may occasionally miss a true neighbor.
+//
+// A store made with NewHNSW lives in memory only. A store made with Open
+// is persisted to a directory: changes are appended to a log as they are
+// made, and Compact or Close writes the whole index to a snapshot. In
+// both cases the vectors are held in memory.
+//
+// A filtered query searches the graph first. When that finds fewer than
+// TopK matches, as with a filter few vectors satisfy, the query instead
+// compares every stored vector, taking time linear in the store's size.
+// Queries with selective filters therefore cost as much as on a Memory
+// store.
+//
+// HNSW is safe for concurrent use.
+type HNSW struct {
+	mu       sync.RWMutex
+	cfg      *config
+	dim      int
+	nodes    []*node
+	ids      map[string]int32 // live nodes by ID
+	entry    int32            // -1 when empty
+	maxLevel int
+	rng      *rand.Rand
+
+	// Persistence, for stores made with Open
+	dir    string
+	log    *os.File
+	closed bool
+}
+
+// NewHNSW returns an empty in-memory HNSW store.
+func NewHNSW(opts ...Option) *HNSW {
+	return newHNSW(newConfig(opts))
+}
+
+func newHNSW(cfg *config) *HNSW {
+	return &HNSW{
+		cfg:   cfg,
+		ids:   make(map[string]int32),
+		entry: -1,
+		rng:   rand.New(rand.NewPCG(1, 2)),
+	}
+}
+
+// Upsert adds vectors, replacing any stored with the same ID.
+func (h *HNSW) Upsert(ctx context.Context, vectors []core.EmbeddingVector) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return ErrClosed
+	}
+
+	records, err := prepareVectors(h.dim, vectors)
+	if err != nil {
+		return err
+	}
+	if err := h.appendLog(upsertEntries(records)); err != nil {
+		return err
+	}
+	for _, rec := range records {
+		h.upsert(rec)
+	}
+	h.rebuildIfSparse()
+	return nil
+}
+
+// Delete removes the vectors with the given IDs.
+func (h *HNSW) Delete(ctx context.Context, ids []string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return ErrClosed
+	}
+
+	if err := h.appendLog(deleteEntries(ids)); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		h.remove(id)
+	}
+	h.rebuildIfSparse()
+	return nil
+}
+
+// Query returns the stored vectors most similar to req.Vector. A filtered
+// query may scan every vector; see HNSW.
+func (h *HNSW) Query(ctx context.Context, req *core.VectorQuery) ([]core.VectorMatch, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.closed {
+		return nil, ErrClosed
+	}
+
+	q, k, err := prepareQuery(h.dim, req)
+	if err != nil {
+		return nil, err
+	}
+	if h.entry < 0 {
+		return []core.VectorMatch{}, nil
+	}
+
+	ep := h.entry
+	for level := h.maxLevel; level > 0; level-- {
+		ep = h.greedy(q, ep, level)
+	}
+	top := &topK{k: k}
+	for _, c := range h.searchLayer(q, []int32{ep}, max(h.cfg.efSearch, k), 0) {
+		n := h.nodes[c.id]
+		if !n.deleted && matchesFilter(n.rec.metadata, req.Filter) {
+			top.push(n.rec, 1-c.dist)
+		}
+	}
+
+	// A selective filter or many deleted vectors can leave the graph
+	// search short of matches that exist; find them exhaustively
+	if want := min(k, len(h.ids)); len(top.items) < want {
+		top = &topK{k: k}
+		for _, id := range h.ids {
+			n := h.nodes[id]
+			if matchesFilter(n.rec.metadata, req.Filter) {
+				top.push(n.rec, dot(q, n.rec.vector))
+			}
+		}
+	}
+	return top.matches(req.IncludeVectors), nil
+}
+
+// Len returns the number of stored vectors.
+func (h *HNSW) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.ids)
+}
+
+// upsert inserts rec, replacing a live node with the same ID.
+func (h *HNSW) upsert(rec *record) {
+	h.remove(rec.id)
+	h.insert(rec)
+	h.dim = len(rec.vector)
+}
+
+// remove marks the node with the given ID deleted.
+func (h *HNSW) remove(id string) {
+	if i, ok := h.ids[id]; ok {
+		h.nodes[i].deleted = true
+		delete(h.ids, id)
+	}
+	if len(h.ids) == 0 {
+		// Start afresh, so vectors of other dimensions can be stored
+		h.dim, h.nodes, h.entry, h.maxLevel = 0, nil, -1, 0
+	}
+}
+
+// rebuildIfSparse rebuilds the graph without its deleted nodes once they
+// make up half of it, so that replacing and deleting vectors does not
+// grow the graph without bound.
+func (h *HNSW) rebuildIfSparse() {
+	if deleted := len(h.nodes) - len(h.ids); deleted > 0 && deleted*2 >= len(h.nodes) {
+		h.rebuild()
+	}
+}
+
+// rebuild rebuilds the graph from the live nodes.
+func (h *HNSW) rebuild() {
+	nodes := h.nodes
+	h.nodes, h.ids, h.entry, h.maxLevel = nil, make(map[string]int32, len(h.ids)), -1, 0
+	for _, n := range nodes {
+		if !n.deleted {
+			h.insert(n.rec)
+		}
+	}
+}
+
+// insert adds rec to the graph.
+func (h *HNSW) insert(rec *record) {
+	level := h.randomLevel()
+	n := &node{rec: rec, links: make([][]int32, level+1)}
+	id := int32(len(h.nodes))
+	h.nodes = append(h.nodes, n)
+	h.ids[rec.id] = id
+
+	if h.entry < 0 {
+		h.entry, h.maxLevel = id, level
+		return
+	}
+
+	ep := h.entry
+	for l := h.maxLevel; l > level; l-- {
+		ep = h.greedy(rec.vector, ep, l)
+	}
+	eps := []int32{ep}
+	for l := min(level, h.maxLevel); l >= 0; l-- {
+		candidates := h.searchLayer(rec.vector, eps, h.cfg.efConstruction, l)
+		neighbors := h.selectNeighbors(candidates, h.cfg.m)
+		n.links[l] = make([]int32, len(neighbors))
+		for i, nb := range neighbors {
+			n.links[l][i] = nb.id
+			h.link(nb.id, id, l)
+		}
+		eps = eps[:0]
+		for _, c := range candidates {
+			eps = append(eps, c.id)
+		}
+	}
+	if level > h.maxLevel {
+		h.entry, h.maxLevel = id, level
+	}
+}
+
+// link adds a link from one node to another at a level, pruning the
+// node's links if it has too many.
+func (h *HNSW) link(from, to int32, level int) {
+	n := h.nodes[from]
+	n.links[level] = append(n.links[level], to)
+	maxLinks := h.maxLinks(level)
+	if len(n.links[level]) <= maxLinks {
+		return
+	}
+
+	candidates := make([]candidate, len(n.links[level]))
+	for i, id := range n.links[level] {
+		candidates[i] = candidate{id, h.distance(n.rec.vector, id)}
+	}
+	sortCandidates(candidates)
+	kept := h.selectNeighbors(candidates, maxLinks)
+	n.links[level] = n.links[level][:0]
+	for _, c := range kept {
+		n.links[level] = append(n.links[level], c.id)
+	}
+}
+
+// maxLinks returns the most links a node may have at a level.
+func (h *HNSW) maxLinks(level int) int {
+	if level == 0 {
+		return 2 * h.cfg.m
+	}
+	return h.cfg.m
+}
+
+// randomLevel draws a node's top level from an exponentially decaying
+// distribution.
+func (h *HNSW) randomLevel() int {
+	mult := 1 / math.Log(float64(h.cfg.m))
+	level := int(-math.Log(1-h.rng.Float64()) * mult)
+	return min(level, 16)
+}
+
+// selectNeighbors picks up to m of the candidates, nearest first, using
+// the HNSW heuristic: a candidate is kept only if it is closer to the
+// new node than to any neighbor already kept, which spreads links across
+// clusters. candidates must be sorted by distance.
+func (h *HNSW) selectNeighbors(candidates []candidate, m int) []candidate {
+	if len(candidates) <= m {
+		return candidates
+	}
+	selected := make([]candidate, 0, m)
+	for _, c := range candidates {
+		if len(selected) == m {
+			break
+		}
+		keep := true
+		for _, s := range selected {
+			if h.distance(h.nodes[c.id].rec.vector, s.id) < c.dist {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			selected = append(selected, c)
+		}
+	}
+	return selected
+}
+
+// greedy walks from ep towards q at a level, returning the nearest node
+// found.
+func (h *HNSW) greedy(q []float32, ep int32, level int) int32 {
+	best := h.distance(q, ep)
+	for changed := true; changed; {
+		changed = false
+		for _, nb := range h.nodes[ep].links[level] {
+			if d := h.distance(q, nb); d < best {
+				ep, best, changed = nb, d, true
+			}
+		}
+	}
+	return ep
+}
+
+// searchLayer finds the ef nodes nearest q at a level, starting from
+// eps, sorted by distance.
+func (h *HNSW) searchLayer(q []float32, eps []int32, ef int, level int) []candidate {
+	visited := make([]uint64, (len(h.nodes)+63)/64)
+	visit := func(id int32) bool {
+		word, bit := id/64, uint64(1)<<(id%64)
+		if visited[word]&bit != 0 {
+			return false
+		}
+		visited[word] |= bit
+		return true
+	}
+
+	var pending nearHeap // nearest first
+	var found farHeap    // farthest first
+	for _, ep := range eps {
+		if visit(ep) {
+			c := candidate{ep, h.distance(q, ep)}
+			heap.Push(&pending, c)
+			heap.Push(&found, c)
+		}
+	}
+	for found.Len() > ef {
+		heap.Pop(&found)
+	}
+
+	for pending.Len() > 0 {
+		c := heap.Pop(&pending).(candidate)
+		if found.Len() >= ef && c.dist > found[0].dist {
+			break
+		}
+		for _, nb := range h.nodes[c.id].links[level] {
+			if !visit(nb) {
+				continue
+			}
+			d := h.distance(q, nb)
+			if found.Len() < ef || d < found[0].dist {
+				heap.Push(&pending, candidate{nb, d})
+				heap.Push(&found, candidate{nb, d})
+				if found.Len() > ef {
+					heap.Pop(&found)
+				}
+			}
+		}
+	}
+
+	result := []candidate(found)
+	sortCandidates(result)
+	return result
+}
+
+// distance returns the cosine distance between q and a node.
+func (h *HNSW) distance(q []float32, id int32) float32 {
+	return 1 - dot(q, h.nodes[id].rec.vector)
+}
+
+// candidate is a node with its distance to a query.
+type candidate struct {
+	id   int32
+	dist float32
+}
+
+// sortCandidates sorts candidates by ascending distance.
+func sortCandidates(cs []candidate) {
+	slices.SortFunc(cs, func(a, b candidate) int {
+		return cmp.Compare(a.dist, b.dist)
+	})
+}
+
+// nearHeap is a heap of candidates with the nearest first.
+type nearHeap []candidate
+
+func (h nearHeap) Len() int           { return len(h) }
+func (h nearHeap) Less(i, j int) bool { return h[i].dist < h[j].dist }
+func (h nearHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *nearHeap) Push(x any)        { *h = append(*h, x.(candidate)) }
+func (h *nearHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// farHeap is a heap of candidates with the farthest first.
+type farHeap []candidate
+
+func (h farHeap) Len() int           { return len(h) }
+func (h farHeap) Less(i, j int) bool { return h[i].dist > h[j].dist }
+func (h farHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *farHeap) Push(x any)        { *h = append(*h, x.(candidate)) }
+func (h *farHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// Compile-time check that HNSW implements core.VectorStore.
+var _ core.VectorStore = (*HNSW)(nil)
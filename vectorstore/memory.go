@@ -0,0 +1,84 @@
+package vectorstore
+
+import (
+	"context"
+	"sync"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// Memory is an in-memory VectorStore that compares the query with every
+// stored vector. Results are exact, and it is fast enough for tens of
+// thousands of vectors; use HNSW for larger corpora.
+//
+// Memory is safe for concurrent use.
+type Memory struct {
+	mu      sync.RWMutex
+	dim     int
+	records map[string]*record
+}
+
+// NewMemory returns an empty in-memory store.
+func NewMemory() *Memory {
+	return &Memory{records: make(map[string]*record)}
+}
+
+// Upsert adds vectors, replacing any stored with the same ID.
+func (m *Memory) Upsert(ctx context.Context, vectors []core.EmbeddingVector) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	records, err := prepareVectors(m.dim, vectors)
+	if err != nil {
+		return err
+	}
+	for _, rec := range records {
+		m.records[rec.id] = rec
+	}
+	if len(records) > 0 {
+		m.dim = len(records[0].vector)
+	}
+	return nil
+}
+
+// Delete removes the vectors with the given IDs.
+func (m *Memory) Delete(ctx context.Context, ids []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range ids {
+		delete(m.records, id)
+	}
+	if len(m.records) == 0 {
+		m.dim = 0
+	}
+	return nil
+}
+
+// Query returns the stored vectors most similar to req.Vector.
+func (m *Memory) Query(ctx context.Context, req *core.VectorQuery) ([]core.VectorMatch, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	q, k, err := prepareQuery(m.dim, req)
+	if err != nil {
+		return nil, err
+	}
+	top := &topK{k: k}
+	for _, rec := range m.records {
+		if matchesFilter(rec.metadata, req.Filter) {
+			top.push(rec, dot(q, rec.vector))
+		}
+	}
+	return top.matches(req.IncludeVectors), nil
+}
+
+// Len returns the number of stored vectors.
+func (m *Memory) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.records)
+}
+
+// Compile-time check that Memory implements core.VectorStore.
+var _ core.VectorStore = (*Memory)(nil)
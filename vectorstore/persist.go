@@ -0,0 +1,290 @@
+package vectorstore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Files of a persisted HNSW store.
+const (
+	snapshotFile = "index.gob"
+	logFile      = "log.jsonl"
+)
+
+// snapshotVersion is the version of the snapshot format.
+const snapshotVersion = 1
+
+// snapshot is the persisted form of the whole index.
+type snapshot struct {
+	Version        int
+	Dimensions     int
+	M              int
+	EfConstruction int
+	Entry          int32
+	MaxLevel       int
+	Nodes          []snapshotNode
+}
+
+// snapshotNode is a persisted graph node. Deleted nodes keep their
+// vector and links, which the graph still uses.
+type snapshotNode struct {
+	ID       string
+	Vector   []float32 // normalized
+	Norm     float32
+	Metadata map[string]string
+	Links    [][]int32
+	Deleted  bool
+}
+
+// logEntry is a change recorded in the log since the last snapshot.
+type logEntry struct {
+	Op       string            `json:"op"` // "upsert" or "delete"
+	ID       string            `json:"id"`
+	Vector   []float32         `json:"vector,omitempty"` // normalized
+	Norm     float32           `json:"norm,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+func upsertEntries(records []*record) []logEntry {
+	entries := make([]logEntry, len(records))
+	for i, rec := range records {
+		entries[i] = logEntry{Op: "upsert", ID: rec.id, Vector: rec.vector, Norm: rec.norm, Metadata: rec.metadata}
+	}
+	return entries
+}
+
+func deleteEntries(ids []string) []logEntry {
+	entries := make([]logEntry, len(ids))
+	for i, id := range ids {
+		entries[i] = logEntry{Op: "delete", ID: id}
+	}
+	return entries
+}
+
+// Open opens the HNSW store persisted in dir, creating the directory if
+// needed. It loads the latest snapshot and replays the changes logged
+// since. The graph parameters of an existing index are kept; opts apply
+// to a new index, except WithEfSearch, which always applies.
+//
+// Call Close when done, which writes a snapshot so the next Open need
+// not replay the log.
+func Open(dir string, opts ...Option) (*HNSW, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("vectorstore: %w", err)
+	}
+
+	h := newHNSW(newConfig(opts))
+	h.dir = dir
+	if err := h.loadSnapshot(); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, logFile), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("vectorstore: %w", err)
+	}
+	if err := h.replayLog(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	h.log = f
+	return h, nil
+}
+
+// loadSnapshot loads the snapshot, if there is one.
+func (h *HNSW) loadSnapshot() error {
+	f, err := os.Open(filepath.Join(h.dir, snapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("vectorstore: %w", err)
+	}
+	defer f.Close()
+
+	var snap snapshot
+	if err := gob.NewDecoder(bufio.NewReader(f)).Decode(&snap); err != nil {
+		return fmt.Errorf("vectorstore: reading snapshot: %w", err)
+	}
+	if snap.Version != snapshotVersion {
+		return fmt.Errorf("vectorstore: unsupported snapshot version %d", snap.Version)
+	}
+
+	h.cfg.m, h.cfg.efConstruction = snap.M, snap.EfConstruction
+	h.dim, h.entry, h.maxLevel = snap.Dimensions, snap.Entry, snap.MaxLevel
+	h.nodes = make([]*node, len(snap.Nodes))
+	for i, sn := range snap.Nodes {
+		h.nodes[i] = &node{
+			rec:     &record{id: sn.ID, vector: sn.Vector, norm: sn.Norm, metadata: sn.Metadata},
+			links:   sn.Links,
+			deleted: sn.Deleted,
+		}
+		if !sn.Deleted {
+			h.ids[sn.ID] = int32(i)
+		}
+	}
+	return nil
+}
+
+// replayLog applies the logged changes. A final entry cut short by a
+// crash is discarded.
+func (h *HNSW) replayLog(f *os.File) error {
+	r := bufio.NewReader(f)
+	var offset int64
+	for {
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(line) > 0 {
+				// Incomplete final entry
+				if err := f.Truncate(offset); err != nil {
+					return fmt.Errorf("vectorstore: %w", err)
+				}
+			}
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("vectorstore: reading log: %w", err)
+		}
+
+		var entry logEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return fmt.Errorf("vectorstore: corrupt log entry at offset %d: %w", offset, err)
+		}
+		switch entry.Op {
+		case "upsert":
+			h.upsert(&record{id: entry.ID, vector: entry.Vector, norm: entry.Norm, metadata: entry.Metadata})
+		case "delete":
+			h.remove(entry.ID)
+		default:
+			return fmt.Errorf("vectorstore: unknown log operation %q at offset %d", entry.Op, offset)
+		}
+		h.rebuildIfSparse()
+		offset += int64(len(line))
+	}
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("vectorstore: %w", err)
+	}
+	return nil
+}
+
+// appendLog records changes durably before they are applied. It does
+// nothing for in-memory stores.
+func (h *HNSW) appendLog(entries []logEntry) error {
+	if h.log == nil || len(entries) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("vectorstore: %w", err)
+		}
+	}
+	if _, err := h.log.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("vectorstore: writing log: %w", err)
+	}
+	if err := h.log.Sync(); err != nil {
+		return fmt.Errorf("vectorstore: writing log: %w", err)
+	}
+	return nil
+}
+
+// Compact rebuilds the graph without deleted and replaced vectors if
+// there are many of them, which Upsert and Delete otherwise do only once
+// they make up half the graph. For a persisted store, it then writes the
+// whole index to a snapshot and empties the log.
+func (h *HNSW) Compact() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return ErrClosed
+	}
+	return h.compact()
+}
+
+func (h *HNSW) compact() error {
+	if deleted := len(h.nodes) - len(h.ids); deleted > 0 && deleted*10 >= len(h.nodes) {
+		h.rebuild()
+	}
+	if h.log == nil {
+		return nil
+	}
+
+	snap := snapshot{
+		Version:        snapshotVersion,
+		Dimensions:     h.dim,
+		M:              h.cfg.m,
+		EfConstruction: h.cfg.efConstruction,
+		Entry:          h.entry,
+		MaxLevel:       h.maxLevel,
+		Nodes:          make([]snapshotNode, len(h.nodes)),
+	}
+	for i, n := range h.nodes {
+		sn := snapshotNode{ID: n.rec.id, Vector: n.rec.vector, Norm: n.rec.norm, Links: n.links, Deleted: n.deleted}
+		if !n.deleted {
+			sn.Metadata = n.rec.metadata
+		}
+		snap.Nodes[i] = sn
+	}
+
+	// Write to a temporary file and rename it over the snapshot, so a
+	// crash leaves either the old or the new snapshot
+	tmp, err := os.CreateTemp(h.dir, snapshotFile+".*")
+	if err != nil {
+		return fmt.Errorf("vectorstore: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	w := bufio.NewWriter(tmp)
+	if err := gob.NewEncoder(w).Encode(&snap); err != nil {
+		tmp.Close()
+		return fmt.Errorf("vectorstore: writing snapshot: %w", err)
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("vectorstore: writing snapshot: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("vectorstore: writing snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("vectorstore: writing snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(h.dir, snapshotFile)); err != nil {
+		return fmt.Errorf("vectorstore: %w", err)
+	}
+
+	// The snapshot now holds every logged change
+	if err := h.log.Truncate(0); err != nil {
+		return fmt.Errorf("vectorstore: %w", err)
+	}
+	if _, err := h.log.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("vectorstore: %w", err)
+	}
+	return nil
+}
+
+// Close writes a snapshot and closes a persisted store. It does nothing
+// for in-memory stores.
+func (h *HNSW) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.log == nil || h.closed {
+		return nil
+	}
+
+	err := h.compact()
+	if closeErr := h.log.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("vectorstore: %w", closeErr)
+	}
+	h.closed = true
+	return err
+}
@@ -0,0 +1,233 @@
+package vectorstore
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// queryIDs returns the IDs of the top matches for v.
+func queryIDs(t *testing.T, store core.VectorStore, v []float32, filter map[string]string) []string {
+	t.Helper()
+	matches, err := store.Query(context.Background(), &core.VectorQuery{Vector: v, TopK: 5, Filter: filter})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	return ids(matches)
+}
+
+func TestOpenPersists(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	vectors := randomVectors(rand.New(rand.NewPCG(1, 1)), 300, 16)
+
+	store, err := Open(dir, WithM(8))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if err := store.Upsert(ctx, vectors); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete(ctx, []string{vectors[0].ID}); err != nil {
+		t.Fatal(err)
+	}
+	q := vectors[1].Vector
+	want := queryIDs(t, store, q, nil)
+	stored := func(store *HNSW) []float32 {
+		t.Helper()
+		matches, err := store.Query(ctx, &core.VectorQuery{Vector: q, TopK: 1, IncludeVectors: true})
+		if err != nil || len(matches) != 1 {
+			t.Fatalf("Query() = %v, %v", matches, err)
+		}
+		return matches[0].Vector
+	}
+	wantFiltered := queryIDs(t, store, q, map[string]string{"group": "5"})
+
+	// Without Close, the log is replayed
+	reopened, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open() from log error = %v", err)
+	}
+	if got := queryIDs(t, reopened, q, nil); !equalIDs(got, want) {
+		t.Errorf("from log Query() = %v, want %v", got, want)
+	}
+	for i, x := range stored(reopened) {
+		if math.Abs(float64(x-q[i])) > 1e-5 {
+			t.Fatalf("from log vector = %v, want %v", stored(reopened), q)
+		}
+	}
+	reopened.log.Close()
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if info, err := os.Stat(filepath.Join(dir, logFile)); err != nil || info.Size() != 0 {
+		t.Errorf("log after Close() = %v, %v", info, err)
+	}
+	if _, err := store.Query(ctx, &core.VectorQuery{Vector: q}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Query() after Close() error = %v", err)
+	}
+
+	// From the snapshot
+	store, err = Open(dir)
+	if err != nil {
+		t.Fatalf("Open() from snapshot error = %v", err)
+	}
+	defer store.Close()
+	if store.Len() != 299 || store.cfg.m != 8 {
+		t.Errorf("Len() = %d, M = %d", store.Len(), store.cfg.m)
+	}
+	if got := queryIDs(t, store, q, nil); !equalIDs(got, want) {
+		t.Errorf("from snapshot Query() = %v, want %v", got, want)
+	}
+	if got := queryIDs(t, store, q, map[string]string{"group": "5"}); !equalIDs(got, wantFiltered) {
+		t.Errorf("from snapshot filtered Query() = %v, want %v", got, wantFiltered)
+	}
+	for i, x := range stored(store) {
+		if math.Abs(float64(x-q[i])) > 1e-5 {
+			t.Fatalf("from snapshot vector = %v, want %v", stored(store), q)
+		}
+	}
+
+	// Changes after the snapshot are logged
+	if err := store.Upsert(ctx, []core.EmbeddingVector{{ID: "new", Vector: q}}); err != nil {
+		t.Fatal(err)
+	}
+	store.log.Close()
+	store, err = Open(dir)
+	if err != nil {
+		t.Fatalf("Open() from snapshot and log error = %v", err)
+	}
+	defer store.Close()
+	if got := queryIDs(t, store, q, nil); got[0] != "new" && got[1] != "new" {
+		t.Errorf("Query() = %v, want new among the nearest", got)
+	}
+}
+
+func TestOpenLogRecovery(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Upsert(ctx, fruit); err != nil {
+		t.Fatal(err)
+	}
+	store.log.Close()
+
+	// A crash mid-write leaves a partial entry, which is discarded
+	path := filepath.Join(dir, logFile)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"op":"delete","id":"app`)
+	f.Close()
+
+	store, err = Open(dir)
+	if err != nil {
+		t.Fatalf("Open() with partial entry error = %v", err)
+	}
+	if store.Len() != 4 {
+		t.Errorf("Len() = %d, want 4", store.Len())
+	}
+	if err := store.Delete(ctx, []string{"plum"}); err != nil {
+		t.Fatal(err)
+	}
+	store.log.Close()
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), `"app`+"\n") || !strings.HasSuffix(string(data), `{"op":"delete","id":"plum"}`+"\n") {
+		t.Errorf("log = %s", data)
+	}
+
+	// Corruption before the end is an error
+	os.WriteFile(path, []byte("garbage\n"+string(data)), 0o644)
+	if _, err := Open(dir); err == nil || !strings.Contains(err.Error(), "corrupt log entry at offset 0") {
+		t.Errorf("Open() with corrupt log error = %v", err)
+	}
+}
+
+func TestCompactRebuild(t *testing.T) {
+	ctx := context.Background()
+	vectors := randomVectors(rand.New(rand.NewPCG(2, 2)), 200, 8)
+	for name, store := range map[string]*HNSW{"memory": NewHNSW(), "disk": nil} {
+		t.Run(name, func(t *testing.T) {
+			if store == nil {
+				var err error
+				if store, err = Open(t.TempDir()); err != nil {
+					t.Fatal(err)
+				}
+				defer store.Close()
+			}
+			if err := store.Upsert(ctx, vectors); err != nil {
+				t.Fatal(err)
+			}
+
+			var deleted []string
+			for _, v := range vectors[:40] {
+				deleted = append(deleted, v.ID)
+			}
+			if err := store.Delete(ctx, deleted); err != nil {
+				t.Fatal(err)
+			}
+			if len(store.nodes) != 200 {
+				t.Fatalf("nodes before Compact() = %d", len(store.nodes))
+			}
+			if err := store.Compact(); err != nil {
+				t.Fatalf("Compact() error = %v", err)
+			}
+			if len(store.nodes) != 160 || store.Len() != 160 {
+				t.Errorf("nodes after Compact() = %d, Len() = %d", len(store.nodes), store.Len())
+			}
+			if got := queryIDs(t, store, vectors[150].Vector, nil); got[0] != vectors[150].ID {
+				t.Errorf("Query() after rebuild = %v", got)
+			}
+		})
+	}
+}
+
+func TestHNSWReplacementsStayBounded(t *testing.T) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewPCG(3, 3))
+	store := NewHNSW()
+	for range 50 {
+		if err := store.Upsert(ctx, randomVectors(rng, 20, 8)); err != nil {
+			t.Fatal(err)
+		}
+		if len(store.nodes) > 2*store.Len() {
+			t.Fatalf("%d nodes for %d vectors", len(store.nodes), store.Len())
+		}
+	}
+	if store.Len() != 20 {
+		t.Errorf("Len() = %d, want 20", store.Len())
+	}
+
+	// Replaying a log of replacements stays bounded too
+	dir := t.TempDir()
+	disk, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 50 {
+		if err := disk.Upsert(ctx, randomVectors(rng, 20, 8)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	disk.log.Close()
+	disk, err = Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer disk.Close()
+	if len(disk.nodes) > 2*disk.Len() {
+		t.Errorf("%d nodes after replay for %d vectors", len(disk.nodes), disk.Len())
+	}
+}
@@ -0,0 +1,199 @@
+package vectorstore
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// defaultTopK is the number of matches a query returns without TopK.
+const defaultTopK = 10
+
+// normalize returns a unit-length copy of v, so cosine similarity is a
+// dot product, and the length of v.
+func normalize(v []float32) ([]float32, float32, error) {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 || math.IsNaN(sum) || math.IsInf(sum, 0) {
+		return nil, 0, errors.New("vector must be non-zero and finite")
+	}
+	norm := math.Sqrt(sum)
+	out := make([]float32, len(v))
+	for i, x := range v {
+		out[i] = float32(float64(x) / norm)
+	}
+	return out, float32(norm), nil
+}
+
+// dot returns the dot product of a and b, which have the same length.
+func dot(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// checkDimensions checks that a vector of n values fits a store of dim
+// dimensions, where 0 means the store is empty.
+func checkDimensions(dim, n int) error {
+	if dim != 0 && n != dim {
+		return fmt.Errorf("vectorstore: %w: got %d, want %d", core.ErrDimensionMismatch, n, dim)
+	}
+	return nil
+}
+
+// prepareVectors validates vectors for an upsert into a store of dim
+// dimensions and returns them as records.
+func prepareVectors(dim int, vectors []core.EmbeddingVector) ([]*record, error) {
+	records := make([]*record, len(vectors))
+	for i, v := range vectors {
+		if v.ID == "" {
+			return nil, fmt.Errorf("vectorstore: vector %d has no ID", i)
+		}
+		if len(v.Vector) == 0 {
+			return nil, fmt.Errorf("vectorstore: vector %q has no float values; request embeddings with EncodingFormatFloat", v.ID)
+		}
+		if dim == 0 {
+			dim = len(v.Vector)
+		}
+		if err := checkDimensions(dim, len(v.Vector)); err != nil {
+			return nil, fmt.Errorf("%w (vector %q)", err, v.ID)
+		}
+		normalized, norm, err := normalize(v.Vector)
+		if err != nil {
+			return nil, fmt.Errorf("vectorstore: vector %q: %w", v.ID, err)
+		}
+		records[i] = &record{id: v.ID, vector: normalized, norm: norm, metadata: maps.Clone(v.Metadata)}
+	}
+	return records, nil
+}
+
+// prepareQuery validates a query against a store of dim dimensions and
+// returns the normalized query vector and the number of matches wanted.
+func prepareQuery(dim int, req *core.VectorQuery) ([]float32, int, error) {
+	if req == nil || len(req.Vector) == 0 {
+		return nil, 0, errors.New("vectorstore: query vector is required")
+	}
+	if err := checkDimensions(dim, len(req.Vector)); err != nil {
+		return nil, 0, err
+	}
+	q, _, err := normalize(req.Vector)
+	if err != nil {
+		return nil, 0, fmt.Errorf("vectorstore: query: %w", err)
+	}
+	k := req.TopK
+	if k <= 0 {
+		k = defaultTopK
+	}
+	return q, k, nil
+}
+
+// matchesFilter reports whether metadata has every pair in filter.
+func matchesFilter(metadata, filter map[string]string) bool {
+	for k, v := range filter {
+		if got, ok := metadata[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+// record is a stored vector.
+type record struct {
+	id       string
+	vector   []float32 // normalized
+	norm     float32   // length of the vector as stored
+	metadata map[string]string
+}
+
+// match returns the record as a match with the given score.
+func (r *record) match(score float32, includeVector bool) core.VectorMatch {
+	m := core.VectorMatch{
+		ID:       r.id,
+		Score:    float64(score),
+		Metadata: maps.Clone(r.metadata),
+	}
+	if includeVector {
+		m.Vector = r.original()
+	}
+	return m
+}
+
+// original returns the vector as stored, scaled back from unit length.
+// Records without a known length return the normalized vector.
+func (r *record) original() []float32 {
+	if r.norm == 0 {
+		return slices.Clone(r.vector)
+	}
+	out := make([]float32, len(r.vector))
+	for i, x := range r.vector {
+		out[i] = float32(float64(x) * float64(r.norm))
+	}
+	return out
+}
+
+// scored is a candidate with its similarity to the query.
+type scored struct {
+	rec   *record
+	score float32
+}
+
+// topK keeps the k highest-scoring candidates.
+type topK struct {
+	k     int
+	items minScoreHeap
+}
+
+func (t *topK) push(rec *record, score float32) {
+	if len(t.items) < t.k {
+		heap.Push(&t.items, scored{rec, score})
+	} else if score > t.items[0].score {
+		t.items[0] = scored{rec, score}
+		heap.Fix(&t.items, 0)
+	}
+}
+
+// matches returns the candidates in descending order of score.
+func (t *topK) matches(includeVectors bool) []core.VectorMatch {
+	items := slices.Clone(t.items)
+	slices.SortFunc(items, func(a, b scored) int {
+		if a.score != b.score {
+			if a.score > b.score {
+				return -1
+			}
+			return 1
+		}
+		// Ties by ID, for stable results
+		if a.rec.id < b.rec.id {
+			return -1
+		}
+		return 1
+	})
+	out := make([]core.VectorMatch, len(items))
+	for i, s := range items {
+		out[i] = s.rec.match(s.score, includeVectors)
+	}
+	return out
+}
+
+// minScoreHeap is a heap with the lowest score first.
+type minScoreHeap []scored
+
+func (h minScoreHeap) Len() int           { return len(h) }
+func (h minScoreHeap) Less(i, j int) bool { return h[i].score < h[j].score }
+func (h minScoreHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *minScoreHeap) Push(x any)        { *h = append(*h, x.(scored)) }
+func (h *minScoreHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
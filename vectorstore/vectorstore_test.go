@@ -0,0 +1,249 @@
+package vectorstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/erikhoward/iris/core"
+)
+
+// stores returns a new empty store of each kind.
+func stores(t *testing.T) map[string]core.VectorStore {
+	t.Helper()
+	disk, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { disk.Close() })
+	return map[string]core.VectorStore{
+		"memory": NewMemory(),
+		"hnsw":   NewHNSW(),
+		"disk":   disk,
+	}
+}
+
+// fruit are vectors whose nearest neighbors are easy to tell.
+var fruit = []core.EmbeddingVector{
+	{ID: "apple", Vector: []float32{1, 0, 0}, Metadata: map[string]string{"color": "red"}},
+	{ID: "cherry", Vector: []float32{0.9, 0.1, 0}, Metadata: map[string]string{"color": "red", "size": "small"}},
+	{ID: "lime", Vector: []float32{0, 1, 0}, Metadata: map[string]string{"color": "green"}},
+	{ID: "plum", Vector: []float32{0, 0, 2}, Metadata: map[string]string{"color": "purple"}},
+}
+
+// ids returns the IDs of matches.
+func ids(matches []core.VectorMatch) []string {
+	out := make([]string, len(matches))
+	for i, m := range matches {
+		out[i] = m.ID
+	}
+	return out
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestStores(t *testing.T) {
+	ctx := context.Background()
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			if err := store.Upsert(ctx, fruit); err != nil {
+				t.Fatalf("Upsert() error = %v", err)
+			}
+
+			matches, err := store.Query(ctx, &core.VectorQuery{Vector: []float32{2, 0.1, 0}, TopK: 3})
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			if got := ids(matches); !equalIDs(got, []string{"apple", "cherry", "lime"}) {
+				t.Errorf("Query() = %v", got)
+			}
+			if m := matches[0]; m.Score < 0.99 || m.Metadata["color"] != "red" || m.Vector != nil {
+				t.Errorf("top match = %+v", m)
+			}
+
+			matches, _ = store.Query(ctx, &core.VectorQuery{Vector: []float32{0, 0, 1}, TopK: 1, IncludeVectors: true})
+			if len(matches) != 1 || matches[0].ID != "plum" || matches[0].Score < 0.999 || !slices.Equal(matches[0].Vector, []float32{0, 0, 2}) {
+				t.Errorf("match with vector = %+v, want the stored vector", matches)
+			}
+
+			matches, _ = store.Query(ctx, &core.VectorQuery{Vector: []float32{0, 1, 0}, Filter: map[string]string{"color": "red"}})
+			if got := ids(matches); !equalIDs(got, []string{"cherry", "apple"}) {
+				t.Errorf("filtered Query() = %v", got)
+			}
+			matches, _ = store.Query(ctx, &core.VectorQuery{Vector: []float32{0, 1, 0}, Filter: map[string]string{"color": "red", "size": "large"}})
+			if len(matches) != 0 {
+				t.Errorf("Query() with unmatched filter = %v", ids(matches))
+			}
+
+			// Replace and delete
+			if err := store.Upsert(ctx, []core.EmbeddingVector{{ID: "lime", Vector: []float32{1, 0.05, 0}, Metadata: map[string]string{"color": "green"}}}); err != nil {
+				t.Fatalf("Upsert() error = %v", err)
+			}
+			if err := store.Delete(ctx, []string{"apple", "missing"}); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			matches, _ = store.Query(ctx, &core.VectorQuery{Vector: []float32{1, 0, 0}})
+			if got := ids(matches); !equalIDs(got, []string{"lime", "cherry", "plum"}) {
+				t.Errorf("Query() after changes = %v", got)
+			}
+		})
+	}
+}
+
+func TestStoreErrors(t *testing.T) {
+	ctx := context.Background()
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			if matches, err := store.Query(ctx, &core.VectorQuery{Vector: []float32{1, 2}}); err != nil || len(matches) != 0 {
+				t.Errorf("Query() on empty store = %v, %v", matches, err)
+			}
+
+			invalid := [][]core.EmbeddingVector{
+				{{Vector: []float32{1}}},
+				{{ID: "b64", VectorB64: "AAAA"}},
+				{{ID: "zero", Vector: []float32{0, 0}}},
+				{{ID: "a", Vector: []float32{1, 0}}, {ID: "b", Vector: []float32{1, 0, 0}}},
+			}
+			for _, vectors := range invalid {
+				if err := store.Upsert(ctx, vectors); err == nil {
+					t.Errorf("Upsert(%+v) should fail", vectors)
+				}
+			}
+
+			if err := store.Upsert(ctx, fruit); err != nil {
+				t.Fatal(err)
+			}
+			err := store.Upsert(ctx, []core.EmbeddingVector{{ID: "x", Vector: []float32{1, 2}}})
+			if !errors.Is(err, core.ErrDimensionMismatch) {
+				t.Errorf("Upsert() with other dimensions error = %v", err)
+			}
+			if _, err := store.Query(ctx, &core.VectorQuery{Vector: []float32{1, 2}}); !errors.Is(err, core.ErrDimensionMismatch) {
+				t.Errorf("Query() with other dimensions error = %v", err)
+			}
+			if _, err := store.Query(ctx, &core.VectorQuery{}); err == nil {
+				t.Error("Query() without a vector should fail")
+			}
+
+			// An emptied store accepts other dimensions
+			if err := store.Delete(ctx, []string{"apple", "cherry", "lime", "plum"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Upsert(ctx, []core.EmbeddingVector{{ID: "x", Vector: []float32{1, 2}}}); err != nil {
+				t.Errorf("Upsert() into emptied store error = %v", err)
+			}
+		})
+	}
+}
+
+// randomVectors returns n random vectors of dim dimensions, tagged into
+// ten groups.
+func randomVectors(rng *rand.Rand, n, dim int) []core.EmbeddingVector {
+	vectors := make([]core.EmbeddingVector, n)
+	for i := range vectors {
+		v := make([]float32, dim)
+		for j := range v {
+			v[j] = float32(rng.NormFloat64())
+		}
+		vectors[i] = core.EmbeddingVector{
+			ID:       fmt.Sprintf("v%d", i),
+			Vector:   v,
+			Metadata: map[string]string{"group": strconv.Itoa(i % 10)},
+		}
+	}
+	return vectors
+}
+
+func TestHNSWRecall(t *testing.T) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewPCG(7, 7))
+	vectors := randomVectors(rng, 2000, 32)
+
+	exact := NewMemory()
+	approx := NewHNSW()
+	if err := exact.Upsert(ctx, vectors); err != nil {
+		t.Fatal(err)
+	}
+	if err := approx.Upsert(ctx, vectors); err != nil {
+		t.Fatal(err)
+	}
+	if approx.Len() != 2000 {
+		t.Fatalf("Len() = %d", approx.Len())
+	}
+
+	const queries, k = 50, 10
+	var hits, total int
+	for _, q := range randomVectors(rng, queries, 32) {
+		for _, filter := range []map[string]string{nil, {"group": "3"}} {
+			req := &core.VectorQuery{Vector: q.Vector, TopK: k, Filter: filter}
+			want, _ := exact.Query(ctx, req)
+			got, err := approx.Query(ctx, req)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			if len(got) != k {
+				t.Fatalf("Query() returned %d matches, want %d", len(got), k)
+			}
+			found := make(map[string]bool)
+			for _, m := range got {
+				found[m.ID] = true
+			}
+			for _, m := range want {
+				if found[m.ID] {
+					hits++
+				}
+			}
+			total += k
+		}
+	}
+	if recall := float64(hits) / float64(total); recall < 0.9 {
+		t.Errorf("recall@%d = %.3f, want at least 0.9", k, recall)
+	}
+}
+
+func TestHNSWFilterFallback(t *testing.T) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewPCG(3, 3))
+	vectors := randomVectors(rng, 1000, 16)
+	// Only a few vectors pass the filter, too few for the graph search to
+	// find TopK of them
+	for i := range vectors {
+		vectors[i].Metadata = map[string]string{"rare": strconv.FormatBool(i%400 == 0)}
+	}
+
+	exact := NewMemory()
+	approx := NewHNSW(WithEfSearch(1))
+	if err := exact.Upsert(ctx, vectors); err != nil {
+		t.Fatal(err)
+	}
+	if err := approx.Upsert(ctx, vectors); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, q := range randomVectors(rng, 10, 16) {
+		for _, k := range []int{2, 5} {
+			req := &core.VectorQuery{Vector: q.Vector, TopK: k, Filter: map[string]string{"rare": "true"}}
+			want, _ := exact.Query(ctx, req)
+			got, err := approx.Query(ctx, req)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			if !equalIDs(ids(got), ids(want)) {
+				t.Errorf("Query(TopK %d) = %v, want %v", k, ids(got), ids(want))
+			}
+		}
+	}
+}